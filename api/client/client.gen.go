@@ -0,0 +1,14023 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BearerAuthScopes  = "bearerAuth.Scopes"
+	KioskCookieScopes = "kioskCookie.Scopes"
+)
+
+// Defines values for AttendanceStatus.
+const (
+	AttendanceStatusAbsent  AttendanceStatus = "absent"
+	AttendanceStatusExcused AttendanceStatus = "excused"
+	AttendanceStatusLate    AttendanceStatus = "late"
+	AttendanceStatusPresent AttendanceStatus = "present"
+)
+
+// Defines values for ScanResultStatus.
+const (
+	ScanResultStatusDebounced ScanResultStatus = "debounced"
+	ScanResultStatusDuplicate ScanResultStatus = "duplicate"
+	ScanResultStatusFailed    ScanResultStatus = "failed"
+	ScanResultStatusInvalid   ScanResultStatus = "invalid"
+	ScanResultStatusNotFound  ScanResultStatus = "not_found"
+	ScanResultStatusTracked   ScanResultStatus = "tracked"
+)
+
+// Defines values for TrackRequestDirection.
+const (
+	TrackRequestDirectionEmpty TrackRequestDirection = ""
+	TrackRequestDirectionIn    TrackRequestDirection = "in"
+	TrackRequestDirectionOut   TrackRequestDirection = "out"
+)
+
+// Defines values for GetTardySlipParamsFormat.
+const (
+	GetTardySlipParamsFormatEscpos GetTardySlipParamsFormat = "escpos"
+	GetTardySlipParamsFormatHtml   GetTardySlipParamsFormat = "html"
+	GetTardySlipParamsFormatPdf    GetTardySlipParamsFormat = "pdf"
+)
+
+// AddKeyRequest defines model for AddKeyRequest.
+type AddKeyRequest struct {
+	VisitorId  int32  `json:"visitor_id"`
+	VisitorKey string `json:"visitor_key"`
+}
+
+// AdjustRequest defines model for AdjustRequest.
+type AdjustRequest struct {
+	Date        *openapi_types.Date `json:"date,omitempty"`
+	MinutesLate *int                `json:"minutes_late,omitempty"`
+	Note        *string             `json:"note,omitempty"`
+	Status      AttendanceStatus    `json:"status"`
+	VisitorId   int32               `json:"visitor_id"`
+}
+
+// ArrivalResponse defines model for ArrivalResponse.
+type ArrivalResponse struct {
+	Arrival *BusArrival `json:"arrival,omitempty"`
+
+	// Excusal Excusal of the late riders, null when the bus was on time.
+	Excusal *Excusal `json:"excusal"`
+}
+
+// AssignDetentionRequest The visitor, or a pending detention consequence.
+type AssignDetentionRequest struct {
+	ConsequenceId *int64 `json:"consequence_id,omitempty"`
+	VisitorId     *int32 `json:"visitor_id,omitempty"`
+}
+
+// AttendanceScanRequest defines model for AttendanceScanRequest.
+type AttendanceScanRequest struct {
+	DeviceId string `json:"device_id"`
+	VisitKey string `json:"visit_key"`
+}
+
+// AttendanceScanResponse defines model for AttendanceScanResponse.
+type AttendanceScanResponse struct {
+	Date        *openapi_types.Date `json:"date,omitempty"`
+	Lesson      *Lesson             `json:"lesson,omitempty"`
+	MinutesLate *int                `json:"minutes_late,omitempty"`
+	Status      *AttendanceStatus   `json:"status,omitempty"`
+	Visitor     *Visitor            `json:"visitor,omitempty"`
+}
+
+// AttendanceStatus defines model for AttendanceStatus.
+type AttendanceStatus string
+
+// BatchRequest defines model for BatchRequest.
+type BatchRequest struct {
+	Scans []Scan `json:"scans"`
+}
+
+// BatchResponse defines model for BatchResponse.
+type BatchResponse struct {
+	Results *[]ScanResult `json:"results,omitempty"`
+}
+
+// Branding defines model for Branding.
+type Branding struct {
+	LogoUrl *string `json:"logo_url,omitempty"`
+	Name    *string `json:"name,omitempty"`
+}
+
+// BusArrival defines model for BusArrival.
+type BusArrival struct {
+	ArrivedAt *time.Time `json:"arrived_at,omitempty"`
+	DeviceId  *string    `json:"device_id,omitempty"`
+	ExcusalId *int64     `json:"excusal_id,omitempty"`
+	Id        *int64     `json:"id,omitempty"`
+	RouteId   *int64     `json:"route_id,omitempty"`
+}
+
+// BusRider defines model for BusRider.
+type BusRider struct {
+	RouteId  *int64   `json:"route_id,omitempty"`
+	StopId   *int64   `json:"stop_id,omitempty"`
+	StopName *string  `json:"stop_name,omitempty"`
+	Visitor  *Visitor `json:"visitor,omitempty"`
+}
+
+// BusRoute defines model for BusRoute.
+type BusRoute struct {
+	DeviceId *string    `json:"device_id,omitempty"`
+	Id       *int64     `json:"id,omitempty"`
+	Name     *string    `json:"name,omitempty"`
+	Riders   *int       `json:"riders,omitempty"`
+	Stops    *[]BusStop `json:"stops,omitempty"`
+}
+
+// BusRouteRequest defines model for BusRouteRequest.
+type BusRouteRequest struct {
+	DeviceId *string `json:"device_id,omitempty"`
+	Name     string  `json:"name"`
+	Stops    *[]struct {
+		Name     string  `json:"name"`
+		PickupAt *string `json:"pickup_at,omitempty"`
+	} `json:"stops,omitempty"`
+}
+
+// BusStop defines model for BusStop.
+type BusStop struct {
+	Id       *int64  `json:"id,omitempty"`
+	Name     *string `json:"name,omitempty"`
+	PickupAt *string `json:"pickup_at,omitempty"`
+	Position *int    `json:"position,omitempty"`
+	RouteId  *int64  `json:"route_id,omitempty"`
+}
+
+// CheckRequest defines model for CheckRequest.
+type CheckRequest struct {
+	AssemblyPoint *string `json:"assembly_point,omitempty"`
+	VisitKey      *string `json:"visit_key,omitempty"`
+	VisitorId     *int32  `json:"visitor_id,omitempty"`
+}
+
+// ClassMembers defines model for ClassMembers.
+type ClassMembers struct {
+	Class   *SchoolClass `json:"class,omitempty"`
+	Members *[]Visitor   `json:"members,omitempty"`
+}
+
+// ClassRequest defines model for ClassRequest.
+type ClassRequest struct {
+	Name      string `json:"name"`
+	TeacherId *int32 `json:"teacher_id,omitempty"`
+}
+
+// ClosedDay defines model for ClosedDay.
+type ClosedDay struct {
+	Date   *openapi_types.Date `json:"date,omitempty"`
+	Reason *string             `json:"reason,omitempty"`
+	SiteId *int64              `json:"site_id,omitempty"`
+}
+
+// ClosedDayRequest defines model for ClosedDayRequest.
+type ClosedDayRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// Consequence defines model for Consequence.
+type Consequence struct {
+	Action     *string    `json:"action,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	Id         *int64     `json:"id,omitempty"`
+	LateCount  *int       `json:"late_count,omitempty"`
+	Note       *string    `json:"note,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	Rule       *string    `json:"rule,omitempty"`
+	Status     *string    `json:"status,omitempty"`
+	Visitor    *Visitor   `json:"visitor,omitempty"`
+	VisitorId  *int32     `json:"visitor_id,omitempty"`
+}
+
+// ConsequenceRule defines model for ConsequenceRule.
+type ConsequenceRule struct {
+	Action *string `json:"action,omitempty"`
+
+	// Grades Grades the rule applies to, empty for every grade.
+	Grades     *[]int  `json:"grades"`
+	Id         *int64  `json:"id,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Recipient  *string `json:"recipient,omitempty"`
+	Source     *string `json:"source,omitempty"`
+	Threshold  *int    `json:"threshold,omitempty"`
+	WebhookUrl *string `json:"webhook_url,omitempty"`
+	WindowDays *int    `json:"window_days,omitempty"`
+}
+
+// DetentionAssignment defines model for DetentionAssignment.
+type DetentionAssignment struct {
+	AttendedAt    *time.Time `json:"attended_at"`
+	ConsequenceId *int64     `json:"consequence_id,omitempty"`
+	Id            *int64     `json:"id,omitempty"`
+	SessionId     *int64     `json:"session_id,omitempty"`
+	Status        *string    `json:"status,omitempty"`
+	Visitor       *Visitor   `json:"visitor,omitempty"`
+	VisitorId     *int32     `json:"visitor_id,omitempty"`
+}
+
+// DetentionScanRequest defines model for DetentionScanRequest.
+type DetentionScanRequest struct {
+	DeviceId string `json:"device_id"`
+	VisitKey string `json:"visit_key"`
+}
+
+// DetentionSession defines model for DetentionSession.
+type DetentionSession struct {
+	Assigned     *int       `json:"assigned,omitempty"`
+	Capacity     *int       `json:"capacity,omitempty"`
+	ClosedAt     *time.Time `json:"closed_at"`
+	EndsAt       *time.Time `json:"ends_at,omitempty"`
+	Id           *int64     `json:"id,omitempty"`
+	RoomId       *int64     `json:"room_id,omitempty"`
+	StartsAt     *time.Time `json:"starts_at,omitempty"`
+	SupervisorId *int32     `json:"supervisor_id,omitempty"`
+}
+
+// DetentionSessionDetails defines model for DetentionSessionDetails.
+type DetentionSessionDetails struct {
+	Assignments *[]DetentionAssignment `json:"assignments,omitempty"`
+	Session     *DetentionSession      `json:"session,omitempty"`
+}
+
+// DetentionSessionRequest defines model for DetentionSessionRequest.
+type DetentionSessionRequest struct {
+	Capacity     *int      `json:"capacity,omitempty"`
+	EndsAt       time.Time `json:"ends_at"`
+	RoomId       int64     `json:"room_id"`
+	StartsAt     time.Time `json:"starts_at"`
+	SupervisorId int32     `json:"supervisor_id"`
+}
+
+// Device defines model for Device.
+type Device struct {
+	Id     *string `json:"id,omitempty"`
+	Name   *string `json:"name,omitempty"`
+	RoomId *int64  `json:"room_id,omitempty"`
+}
+
+// DeviceArrivalRequest defines model for DeviceArrivalRequest.
+type DeviceArrivalRequest struct {
+	DeviceId string `json:"device_id"`
+}
+
+// DeviceRequest defines model for DeviceRequest.
+type DeviceRequest struct {
+	Name   *string `json:"name,omitempty"`
+	RoomId *int64  `json:"room_id,omitempty"`
+}
+
+// Dismissal defines model for Dismissal.
+type Dismissal struct {
+	CollectorName  *string       `json:"collector_name,omitempty"`
+	CreatedAt      *time.Time    `json:"created_at,omitempty"`
+	Id             *int64        `json:"id,omitempty"`
+	PickupPerson   *PickupPerson `json:"pickup_person,omitempty"`
+	PickupPersonId *int64        `json:"pickup_person_id,omitempty"`
+	Reason         *string       `json:"reason,omitempty"`
+	Status         *string       `json:"status,omitempty"`
+	Visitor        *Visitor      `json:"visitor,omitempty"`
+	VisitorId      *int32        `json:"visitor_id,omitempty"`
+}
+
+// DismissalRequest defines model for DismissalRequest.
+type DismissalRequest struct {
+	CollectorName  *string `json:"collector_name,omitempty"`
+	PickupKey      *string `json:"pickup_key,omitempty"`
+	PickupPersonId *int64  `json:"pickup_person_id,omitempty"`
+	Reason         *string `json:"reason,omitempty"`
+	VisitKey       *string `json:"visit_key,omitempty"`
+	VisitorId      *int32  `json:"visitor_id,omitempty"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	// Code Machine-readable code of the error. `validation`, `not_found`,
+	// `conflict`, `unauthorized`, `forbidden`, `internal_error`,
+	// `timeout` and `external_service_error` are those of the kind of
+	// error, some
+	// errors carry a code of their own: `anti_passback`,
+	// `pickup_not_authorized`, `detention_full`, `bus_already_arrived`,
+	// `hall_pass_limit`, `hall_pass_open`, `evacuation_active`,
+	// `idempotency_key_reused`, `idempotency_key_in_progress` and
+	// `not_available`.
+	Code string `json:"code"`
+
+	// Error Message of the error for people.
+	Error string `json:"error"`
+}
+
+// EvacuationEntry defines model for EvacuationEntry.
+type EvacuationEntry struct {
+	AccountedAt   *time.Time `json:"accounted_at"`
+	AssemblyPoint *string    `json:"assembly_point,omitempty"`
+	SessionId     *int64     `json:"session_id,omitempty"`
+	Visitor       *Visitor   `json:"visitor,omitempty"`
+}
+
+// EvacuationReport defines model for EvacuationReport.
+type EvacuationReport struct {
+	Accounted   *int               `json:"accounted,omitempty"`
+	Entries     *[]EvacuationEntry `json:"entries,omitempty"`
+	Session     *EvacuationSession `json:"session,omitempty"`
+	Total       *int               `json:"total,omitempty"`
+	Unaccounted *[]Visitor         `json:"unaccounted,omitempty"`
+}
+
+// EvacuationSession defines model for EvacuationSession.
+type EvacuationSession struct {
+	EndedAt   *time.Time `json:"ended_at"`
+	Id        *int64     `json:"id,omitempty"`
+	Note      *string    `json:"note,omitempty"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+}
+
+// Excusal defines model for Excusal.
+type Excusal struct {
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	Excused    *int       `json:"excused,omitempty"`
+	From       *time.Time `json:"from,omitempty"`
+	Grades     *[]int     `json:"grades,omitempty"`
+	Id         *int64     `json:"id,omitempty"`
+	Reason     *string    `json:"reason,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	RouteId    *int64     `json:"route_id,omitempty"`
+	To         *time.Time `json:"to,omitempty"`
+	VisitorIds *[]int32   `json:"visitor_ids,omitempty"`
+}
+
+// ExcusalRequest defines model for ExcusalRequest.
+type ExcusalRequest struct {
+	From       time.Time `json:"from"`
+	Grades     *[]int    `json:"grades,omitempty"`
+	Reason     string    `json:"reason"`
+	RouteId    *int64    `json:"route_id,omitempty"`
+	To         time.Time `json:"to"`
+	VisitorIds *[]int32  `json:"visitor_ids,omitempty"`
+}
+
+// ExcusalResponse defines model for ExcusalResponse.
+type ExcusalResponse struct {
+	Excusal *Excusal `json:"excusal,omitempty"`
+	Tardies *[]Tardy `json:"tardies,omitempty"`
+}
+
+// GuestRequest defines model for GuestRequest.
+type GuestRequest struct {
+	Name    string  `json:"name"`
+	Surname *string `json:"surname,omitempty"`
+}
+
+// HallPass defines model for HallPass.
+type HallPass struct {
+	ClosedAt    *time.Time `json:"closed_at"`
+	Destination *string    `json:"destination,omitempty"`
+	Id          *int64     `json:"id,omitempty"`
+	IssuedAt    *time.Time `json:"issued_at,omitempty"`
+	MaxMinutes  *int       `json:"max_minutes,omitempty"`
+	Overdue     *bool      `json:"overdue,omitempty"`
+	StartedAt   *time.Time `json:"started_at"`
+	TeacherId   *int32     `json:"teacher_id,omitempty"`
+	Visitor     *Visitor   `json:"visitor,omitempty"`
+	VisitorId   *int32     `json:"visitor_id,omitempty"`
+}
+
+// HallPassReport defines model for HallPassReport.
+type HallPassReport struct {
+	Destinations *map[string]int     `json:"destinations,omitempty"`
+	From         *openapi_types.Date `json:"from,omitempty"`
+	Overdue      *int                `json:"overdue,omitempty"`
+	Passes       *int                `json:"passes,omitempty"`
+	Students     *[]HallPassUsage    `json:"students,omitempty"`
+	To           *openapi_types.Date `json:"to,omitempty"`
+}
+
+// HallPassScanRequest defines model for HallPassScanRequest.
+type HallPassScanRequest struct {
+	VisitKey string `json:"visit_key"`
+}
+
+// HallPassUsage defines model for HallPassUsage.
+type HallPassUsage struct {
+	Destinations *map[string]int `json:"destinations,omitempty"`
+	Overdue      *int            `json:"overdue,omitempty"`
+	Passes       *int            `json:"passes,omitempty"`
+	TotalMinutes *int            `json:"total_minutes,omitempty"`
+	Visitor      *Visitor        `json:"visitor,omitempty"`
+}
+
+// IssueHallPassRequest defines model for IssueHallPassRequest.
+type IssueHallPassRequest struct {
+	Destination string `json:"destination"`
+	MaxMinutes  *int   `json:"max_minutes,omitempty"`
+
+	// StartOnScan The pass starts when the visitor scans out instead of now.
+	StartOnScan *bool `json:"start_on_scan,omitempty"`
+	TeacherId   int32 `json:"teacher_id"`
+	VisitorId   int32 `json:"visitor_id"`
+}
+
+// Lateness defines model for Lateness.
+type Lateness struct {
+	Excused     *int     `json:"excused,omitempty"`
+	Late        *int     `json:"late,omitempty"`
+	MinutesLate *int     `json:"minutes_late,omitempty"`
+	Visitor     *Visitor `json:"visitor,omitempty"`
+}
+
+// Lesson defines model for Lesson.
+type Lesson struct {
+	Class    *SchoolClass `json:"class,omitempty"`
+	ClassId  *int64       `json:"class_id,omitempty"`
+	Id       *int64       `json:"id,omitempty"`
+	Period   *Period      `json:"period,omitempty"`
+	PeriodId *int64       `json:"period_id,omitempty"`
+	Room     *Room        `json:"room,omitempty"`
+	RoomId   *int64       `json:"room_id,omitempty"`
+
+	// Weekday 0 is Sunday.
+	Weekday *int `json:"weekday,omitempty"`
+}
+
+// LessonRequest defines model for LessonRequest.
+type LessonRequest struct {
+	ClassId  int64 `json:"class_id"`
+	PeriodId int64 `json:"period_id"`
+	RoomId   int64 `json:"room_id"`
+	Weekday  *int  `json:"weekday,omitempty"`
+}
+
+// ManualTrack defines model for ManualTrack.
+type ManualTrack struct {
+	// Cr When the track was made, in the zone of the school.
+	Cr *string `json:"cr,omitempty"`
+
+	// Id The visitor.
+	Id      *int32  `json:"id,omitempty"`
+	Message *string `json:"message,omitempty"`
+
+	// Tr Whether the visitor signed in.
+	Tr *bool `json:"tr,omitempty"`
+
+	// Vk The visit key.
+	Vk *string `json:"vk,omitempty"`
+}
+
+// MarkDetentionRequest defines model for MarkDetentionRequest.
+type MarkDetentionRequest struct {
+	Status string `json:"status"`
+}
+
+// MembersRequest defines model for MembersRequest.
+type MembersRequest struct {
+	VisitorIds *[]int32 `json:"visitor_ids,omitempty"`
+}
+
+// Message defines model for Message.
+type Message struct {
+	Message string `json:"message"`
+}
+
+// Period defines model for Period.
+type Period struct {
+	EndsAt   *string `json:"ends_at,omitempty"`
+	Id       *int64  `json:"id,omitempty"`
+	Name     *string `json:"name,omitempty"`
+	StartsAt *string `json:"starts_at,omitempty"`
+}
+
+// PeriodRequest defines model for PeriodRequest.
+type PeriodRequest struct {
+	EndsAt   string `json:"ends_at"`
+	Name     string `json:"name"`
+	StartsAt string `json:"starts_at"`
+}
+
+// PickupPerson defines model for PickupPerson.
+type PickupPerson struct {
+	Id           *int64  `json:"id,omitempty"`
+	Image        *string `json:"image,omitempty"`
+	Key          *string `json:"key,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Relationship *string `json:"relationship,omitempty"`
+	VisitorId    *int32  `json:"visitor_id,omitempty"`
+}
+
+// PickupPersonRequest defines model for PickupPersonRequest.
+type PickupPersonRequest struct {
+	Image        *string `json:"image,omitempty"`
+	Key          *string `json:"key,omitempty"`
+	Name         string  `json:"name"`
+	Relationship *string `json:"relationship,omitempty"`
+}
+
+// ProvisionResponse defines model for ProvisionResponse.
+type ProvisionResponse struct {
+	ApiToken *string `json:"api_token,omitempty"`
+	Site     *Site   `json:"site,omitempty"`
+	Tenant   *Tenant `json:"tenant,omitempty"`
+}
+
+// ReasonRequest defines model for ReasonRequest.
+type ReasonRequest struct {
+	Note   *string `json:"note,omitempty"`
+	Reason string  `json:"reason"`
+}
+
+// ReasonResponse defines model for ReasonResponse.
+type ReasonResponse struct {
+	Printed *bool   `json:"printed,omitempty"`
+	SlipUrl *string `json:"slip_url,omitempty"`
+	Tardy   *Tardy  `json:"tardy,omitempty"`
+}
+
+// Register defines model for Register.
+type Register struct {
+	Date    *openapi_types.Date `json:"date,omitempty"`
+	Entries *[]RegisterEntry    `json:"entries,omitempty"`
+	Lesson  *Lesson             `json:"lesson,omitempty"`
+	Totals  *map[string]int     `json:"totals,omitempty"`
+}
+
+// RegisterEntry defines model for RegisterEntry.
+type RegisterEntry struct {
+	MinutesLate *int       `json:"minutes_late,omitempty"`
+	Note        *string    `json:"note,omitempty"`
+	ScannedAt   *time.Time `json:"scanned_at"`
+	Status      *string    `json:"status,omitempty"`
+	Visitor     *Visitor   `json:"visitor,omitempty"`
+}
+
+// ResolveRequest defines model for ResolveRequest.
+type ResolveRequest struct {
+	Note   *string `json:"note,omitempty"`
+	Status string  `json:"status"`
+}
+
+// RiderRequest defines model for RiderRequest.
+type RiderRequest struct {
+	StopId    *int64 `json:"stop_id,omitempty"`
+	VisitorId int32  `json:"visitor_id"`
+}
+
+// Room defines model for Room.
+type Room struct {
+	Id   *int64  `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+// RoomRequest defines model for RoomRequest.
+type RoomRequest struct {
+	Name string `json:"name"`
+}
+
+// RosterResponse defines model for RosterResponse.
+type RosterResponse struct {
+	Visitors *[]VisitDetails `json:"visitors,omitempty"`
+}
+
+// Scan defines model for Scan.
+type Scan struct {
+	// Id Id the kiosk gave the scan, syncing it again is a duplicate.
+	Id        string    `json:"id"`
+	ScannedAt time.Time `json:"scanned_at"`
+	VisitKey  string    `json:"visit_key"`
+}
+
+// ScanResult defines model for ScanResult.
+type ScanResult struct {
+	Error  *string           `json:"error,omitempty"`
+	Id     *string           `json:"id,omitempty"`
+	Status *ScanResultStatus `json:"status,omitempty"`
+	Track  *TrackResponse    `json:"track,omitempty"`
+}
+
+// ScanResultStatus defines model for ScanResult.Status.
+type ScanResultStatus string
+
+// SchoolClass defines model for SchoolClass.
+type SchoolClass struct {
+	Id        *int64  `json:"id,omitempty"`
+	Name      *string `json:"name,omitempty"`
+	TeacherId *int32  `json:"teacher_id,omitempty"`
+}
+
+// Site defines model for Site.
+type Site struct {
+	Code              *string `json:"code,omitempty"`
+	Id                *int64  `json:"id,omitempty"`
+	LateGraceMinutes  *int    `json:"late_grace_minutes,omitempty"`
+	Name              *string `json:"name,omitempty"`
+	SchoolDayStartsAt *string `json:"school_day_starts_at,omitempty"`
+}
+
+// SiteRequest defines model for SiteRequest.
+type SiteRequest struct {
+	Code              string  `json:"code"`
+	LateGraceMinutes  *int    `json:"late_grace_minutes"`
+	Name              string  `json:"name"`
+	SchoolDayStartsAt *string `json:"school_day_starts_at,omitempty"`
+}
+
+// StartEvacuationRequest defines model for StartEvacuationRequest.
+type StartEvacuationRequest struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// Tardy defines model for Tardy.
+type Tardy struct {
+	ExcusalId     *int64     `json:"excusal_id,omitempty"`
+	ExcusalReason *string    `json:"excusal_reason,omitempty"`
+	Id            *int64     `json:"id,omitempty"`
+	MinutesLate   *int       `json:"minutes_late,omitempty"`
+	Note          *string    `json:"note,omitempty"`
+	Reason        *string    `json:"reason,omitempty"`
+	TrackId       *int64     `json:"track_id,omitempty"`
+	TrackedAt     *time.Time `json:"tracked_at,omitempty"`
+	Visitor       *Visitor   `json:"visitor,omitempty"`
+	VisitorId     *int32     `json:"visitor_id,omitempty"`
+}
+
+// TardyReport defines model for TardyReport.
+type TardyReport struct {
+	Excused  *int                `json:"excused,omitempty"`
+	From     *openapi_types.Date `json:"from,omitempty"`
+	Late     *int                `json:"late,omitempty"`
+	Reasons  *map[string]int     `json:"reasons,omitempty"`
+	Students *[]Lateness         `json:"students,omitempty"`
+	Tardies  *[]Tardy            `json:"tardies,omitempty"`
+	To       *openapi_types.Date `json:"to,omitempty"`
+}
+
+// Tenant defines model for Tenant.
+type Tenant struct {
+	Code     *string `json:"code,omitempty"`
+	Hostname *string `json:"hostname,omitempty"`
+	Id       *int64  `json:"id,omitempty"`
+	Logo     *string `json:"logo,omitempty"`
+	Name     *string `json:"name,omitempty"`
+}
+
+// TenantRequest defines model for TenantRequest.
+type TenantRequest struct {
+	Code     string  `json:"code"`
+	Hostname *string `json:"hostname,omitempty"`
+	Name     string  `json:"name"`
+}
+
+// Timesheet defines model for Timesheet.
+type Timesheet struct {
+	Days     *[]TimesheetDay  `json:"days,omitempty"`
+	Hours    *float32         `json:"hours,omitempty"`
+	Overtime *float32         `json:"overtime,omitempty"`
+	Visitor  *Visitor         `json:"visitor,omitempty"`
+	Weeks    *[]TimesheetWeek `json:"weeks,omitempty"`
+}
+
+// TimesheetDay defines model for TimesheetDay.
+type TimesheetDay struct {
+	Date      *openapi_types.Date  `json:"date,omitempty"`
+	Flags     *[]string            `json:"flags,omitempty"`
+	Hours     *float32             `json:"hours,omitempty"`
+	Intervals *[]TimesheetInterval `json:"intervals,omitempty"`
+	Overtime  *float32             `json:"overtime,omitempty"`
+}
+
+// TimesheetInterval A sign-in and the sign-out after it, either is null when missing and flagged missing_sign_in or missing_sign_out.
+type TimesheetInterval struct {
+	Flags   *[]string  `json:"flags,omitempty"`
+	Hours   *float32   `json:"hours,omitempty"`
+	SignIn  *time.Time `json:"sign_in"`
+	SignOut *time.Time `json:"sign_out"`
+}
+
+// TimesheetResponse defines model for TimesheetResponse.
+type TimesheetResponse struct {
+	From       *openapi_types.Date `json:"from,omitempty"`
+	Timesheets *[]Timesheet        `json:"timesheets,omitempty"`
+	To         *openapi_types.Date `json:"to,omitempty"`
+}
+
+// TimesheetWeek defines model for TimesheetWeek.
+type TimesheetWeek struct {
+	Hours    *float32 `json:"hours,omitempty"`
+	Overtime *float32 `json:"overtime,omitempty"`
+	Week     *int     `json:"week,omitempty"`
+	Year     *int     `json:"year,omitempty"`
+}
+
+// TrackRequest defines model for TrackRequest.
+type TrackRequest struct {
+	// ClientId Id the kiosk gave the scan, the id of the scan if it is queued and synced with /scans/batch. A scan whose id is tracked already is answered with the stored track.
+	ClientId *string `json:"client_id,omitempty"`
+
+	// Direction in for entrance readers, out for exits, empty when the reader is used for both.
+	Direction *TrackRequestDirection `json:"direction,omitempty"`
+	SignedIn  *bool                  `json:"signed_in,omitempty"`
+
+	// VisitKey The scanned key, see /find-and-track.
+	VisitKey *string `json:"visit_key,omitempty"`
+
+	// VisitorId Visitor of a manual track, see /track.
+	VisitorId *int32 `json:"visitor_id,omitempty"`
+}
+
+// TrackRequestDirection in for entrance readers, out for exits, empty when the reader is used for both.
+type TrackRequestDirection string
+
+// TrackResponse defines model for TrackResponse.
+type TrackResponse struct {
+	// Excused The late sign-in is covered by a bus arrival.
+	Excused     *bool     `json:"excused,omitempty"`
+	Late        *bool     `json:"late,omitempty"`
+	LateReasons *[]string `json:"late_reasons,omitempty"`
+	MinutesLate *int      `json:"minutes_late,omitempty"`
+
+	// Status attention on a safeguarding watchlist match.
+	Status *string `json:"status,omitempty"`
+
+	// TrackDate When the track was made, in the zone of the school.
+	TrackDate *string `json:"track_date,omitempty"`
+	TrackId   *int64  `json:"track_id,omitempty"`
+
+	// TrackType sign-in or sign-out, the track of a duplicate scan only has its id and date.
+	TrackType *string  `json:"track_type,omitempty"`
+	Visitor   *Visitor `json:"visitor"`
+}
+
+// VisitDetails defines model for VisitDetails.
+type VisitDetails struct {
+	Key     *string  `json:"key,omitempty"`
+	Visitor *Visitor `json:"visitor,omitempty"`
+}
+
+// Visitor defines model for Visitor.
+type Visitor struct {
+	Grade   *int    `json:"grade,omitempty"`
+	Id      *int32  `json:"id,omitempty"`
+	Image   *string `json:"image,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	SiteId  *int64  `json:"site_id,omitempty"`
+	Surname *string `json:"surname,omitempty"`
+}
+
+// WatchlistEntry defines model for WatchlistEntry.
+type WatchlistEntry struct {
+	Active    *bool      `json:"active,omitempty"`
+	Category  *string    `json:"category,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Id        *int64     `json:"id,omitempty"`
+	Name      *string    `json:"name,omitempty"`
+	Notes     *string    `json:"notes,omitempty"`
+	Surname   *string    `json:"surname,omitempty"`
+	VisitorId *int32     `json:"visitor_id,omitempty"`
+}
+
+// WatchlistEntryRequest defines model for WatchlistEntryRequest.
+type WatchlistEntryRequest struct {
+	Category  string  `json:"category"`
+	Name      *string `json:"name,omitempty"`
+	Notes     *string `json:"notes,omitempty"`
+	Surname   *string `json:"surname,omitempty"`
+	VisitorId *int32  `json:"visitor_id,omitempty"`
+}
+
+// WatchlistHit defines model for WatchlistHit.
+type WatchlistHit struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Details   *string    `json:"details,omitempty"`
+	EntryId   *int64     `json:"entry_id,omitempty"`
+	Id        *int64     `json:"id,omitempty"`
+	Source    *string    `json:"source,omitempty"`
+	VisitorId *int32     `json:"visitor_id,omitempty"`
+}
+
+// Date defines model for Date.
+type Date = openapi_types.Date
+
+// From defines model for From.
+type From = openapi_types.Date
+
+// Id defines model for Id.
+type Id = int64
+
+// To defines model for To.
+type To = openapi_types.Date
+
+// VisitorId defines model for VisitorId.
+type VisitorId = int32
+
+// BusArrivalResponse defines model for BusArrivalResponse.
+type BusArrivalResponse = ArrivalResponse
+
+// BusRidersResponse defines model for BusRidersResponse.
+type BusRidersResponse = []BusRider
+
+// BusRouteResponse defines model for BusRouteResponse.
+type BusRouteResponse = BusRoute
+
+// ClassMembersResponse defines model for ClassMembersResponse.
+type ClassMembersResponse = ClassMembers
+
+// DetentionAssignmentResponse defines model for DetentionAssignmentResponse.
+type DetentionAssignmentResponse = DetentionAssignment
+
+// DetentionSessionResponse defines model for DetentionSessionResponse.
+type DetentionSessionResponse = DetentionSessionDetails
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse = Error
+
+// EvacuationReportResponse defines model for EvacuationReportResponse.
+type EvacuationReportResponse = EvacuationReport
+
+// HallPassResponse defines model for HallPassResponse.
+type HallPassResponse = HallPass
+
+// NotFoundResponse defines model for NotFoundResponse.
+type NotFoundResponse = Error
+
+// RegisterResponse defines model for RegisterResponse.
+type RegisterResponse = Register
+
+// SiteResponse defines model for SiteResponse.
+type SiteResponse = Site
+
+// UploadTenantLogoMultipartBody defines parameters for UploadTenantLogo.
+type UploadTenantLogoMultipartBody struct {
+	Logo openapi_types.File `json:"logo"`
+}
+
+// GetRegisterParams defines parameters for GetRegister.
+type GetRegisterParams struct {
+	// Date Day in the zone of the school, today by default.
+	Date *Date `form:"date,omitempty" json:"date,omitempty"`
+}
+
+// GetTeacherLessonsParams defines parameters for GetTeacherLessons.
+type GetTeacherLessonsParams struct {
+	// Date Day in the zone of the school, today by default.
+	Date *Date `form:"date,omitempty" json:"date,omitempty"`
+}
+
+// ListConsequencesParams defines parameters for ListConsequences.
+type ListConsequencesParams struct {
+	Status *string `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// ListDetentionSessionsParams defines parameters for ListDetentionSessions.
+type ListDetentionSessionsParams struct {
+	// From First day of the period.
+	From *From `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day of the period, inclusive.
+	To *To `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListDismissalsParams defines parameters for ListDismissals.
+type ListDismissalsParams struct {
+	// Date Day in the zone of the school, today by default.
+	Date *Date `form:"date,omitempty" json:"date,omitempty"`
+}
+
+// ListExcusalsParams defines parameters for ListExcusals.
+type ListExcusalsParams struct {
+	// From First day of the period.
+	From *From `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day of the period, inclusive.
+	To *To `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListActiveHallPassesParams defines parameters for ListActiveHallPasses.
+type ListActiveHallPassesParams struct {
+	// Overdue true keeps only the overdue passes.
+	Overdue *bool `form:"overdue,omitempty" json:"overdue,omitempty"`
+}
+
+// GetHallPassReportParams defines parameters for GetHallPassReport.
+type GetHallPassReportParams struct {
+	// From First day of the period.
+	From *From `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day of the period, inclusive.
+	To *To `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetRosterParams defines parameters for GetRoster.
+type GetRosterParams struct {
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// ListClosedDaysParams defines parameters for ListClosedDays.
+type ListClosedDaysParams struct {
+	// From First day of the period.
+	From *From `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day of the period, inclusive.
+	To *To `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetTardyReportParams defines parameters for GetTardyReport.
+type GetTardyReportParams struct {
+	// From First day of the period.
+	From *From `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day of the period, inclusive.
+	To *To `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetTimesheetsParams defines parameters for GetTimesheets.
+type GetTimesheetsParams struct {
+	// From First day of the period.
+	From *From `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day of the period, inclusive.
+	To *To `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ExportTimesheetsParams defines parameters for ExportTimesheets.
+type ExportTimesheetsParams struct {
+	// From First day of the period.
+	From *From `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day of the period, inclusive.
+	To *To `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetTardySlipParams defines parameters for GetTardySlip.
+type GetTardySlipParams struct {
+	Format *GetTardySlipParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetTardySlipParamsFormat defines parameters for GetTardySlip.
+type GetTardySlipParamsFormat string
+
+// ListWatchlistHitsParams defines parameters for ListWatchlistHits.
+type ListWatchlistHitsParams struct {
+	// Date Day in the zone of the school, today by default.
+	Date *Date `form:"date,omitempty" json:"date,omitempty"`
+}
+
+// AddKeyJSONRequestBody defines body for AddKey for application/json ContentType.
+type AddKeyJSONRequestBody = AddKeyRequest
+
+// CreateTenantJSONRequestBody defines body for CreateTenant for application/json ContentType.
+type CreateTenantJSONRequestBody = TenantRequest
+
+// UpdateTenantJSONRequestBody defines body for UpdateTenant for application/json ContentType.
+type UpdateTenantJSONRequestBody = TenantRequest
+
+// UploadTenantLogoMultipartRequestBody defines body for UploadTenantLogo for multipart/form-data ContentType.
+type UploadTenantLogoMultipartRequestBody UploadTenantLogoMultipartBody
+
+// AdjustAttendanceJSONRequestBody defines body for AdjustAttendance for application/json ContentType.
+type AdjustAttendanceJSONRequestBody = AdjustRequest
+
+// ScanAttendanceJSONRequestBody defines body for ScanAttendance for application/json ContentType.
+type ScanAttendanceJSONRequestBody = AttendanceScanRequest
+
+// CreateBusRouteJSONRequestBody defines body for CreateBusRoute for application/json ContentType.
+type CreateBusRouteJSONRequestBody = BusRouteRequest
+
+// BusArrivedAtDeviceJSONRequestBody defines body for BusArrivedAtDevice for application/json ContentType.
+type BusArrivedAtDeviceJSONRequestBody = DeviceArrivalRequest
+
+// AssignBusRiderJSONRequestBody defines body for AssignBusRider for application/json ContentType.
+type AssignBusRiderJSONRequestBody = RiderRequest
+
+// CreateClassJSONRequestBody defines body for CreateClass for application/json ContentType.
+type CreateClassJSONRequestBody = ClassRequest
+
+// SetClassMembersJSONRequestBody defines body for SetClassMembers for application/json ContentType.
+type SetClassMembersJSONRequestBody = MembersRequest
+
+// CreateConsequenceRuleJSONRequestBody defines body for CreateConsequenceRule for application/json ContentType.
+type CreateConsequenceRuleJSONRequestBody = ConsequenceRule
+
+// ResolveConsequenceJSONRequestBody defines body for ResolveConsequence for application/json ContentType.
+type ResolveConsequenceJSONRequestBody = ResolveRequest
+
+// ScanDetentionJSONRequestBody defines body for ScanDetention for application/json ContentType.
+type ScanDetentionJSONRequestBody = DetentionScanRequest
+
+// CreateDetentionSessionJSONRequestBody defines body for CreateDetentionSession for application/json ContentType.
+type CreateDetentionSessionJSONRequestBody = DetentionSessionRequest
+
+// AssignDetentionJSONRequestBody defines body for AssignDetention for application/json ContentType.
+type AssignDetentionJSONRequestBody = AssignDetentionRequest
+
+// MarkDetentionJSONRequestBody defines body for MarkDetention for application/json ContentType.
+type MarkDetentionJSONRequestBody = MarkDetentionRequest
+
+// SaveDeviceJSONRequestBody defines body for SaveDevice for application/json ContentType.
+type SaveDeviceJSONRequestBody = DeviceRequest
+
+// DismissJSONRequestBody defines body for Dismiss for application/json ContentType.
+type DismissJSONRequestBody = DismissalRequest
+
+// StartEvacuationJSONRequestBody defines body for StartEvacuation for application/json ContentType.
+type StartEvacuationJSONRequestBody = StartEvacuationRequest
+
+// CheckEvacueeJSONRequestBody defines body for CheckEvacuee for application/json ContentType.
+type CheckEvacueeJSONRequestBody = CheckRequest
+
+// CreateExcusalJSONRequestBody defines body for CreateExcusal for application/json ContentType.
+type CreateExcusalJSONRequestBody = ExcusalRequest
+
+// FindAndTrackJSONRequestBody defines body for FindAndTrack for application/json ContentType.
+type FindAndTrackJSONRequestBody = TrackRequest
+
+// ScreenGuestJSONRequestBody defines body for ScreenGuest for application/json ContentType.
+type ScreenGuestJSONRequestBody = GuestRequest
+
+// IssueHallPassJSONRequestBody defines body for IssueHallPass for application/json ContentType.
+type IssueHallPassJSONRequestBody = IssueHallPassRequest
+
+// ScanHallPassJSONRequestBody defines body for ScanHallPass for application/json ContentType.
+type ScanHallPassJSONRequestBody = HallPassScanRequest
+
+// CreateLessonJSONRequestBody defines body for CreateLesson for application/json ContentType.
+type CreateLessonJSONRequestBody = LessonRequest
+
+// CreatePeriodJSONRequestBody defines body for CreatePeriod for application/json ContentType.
+type CreatePeriodJSONRequestBody = PeriodRequest
+
+// CreateRoomJSONRequestBody defines body for CreateRoom for application/json ContentType.
+type CreateRoomJSONRequestBody = RoomRequest
+
+// BatchScansJSONRequestBody defines body for BatchScans for application/json ContentType.
+type BatchScansJSONRequestBody = BatchRequest
+
+// CreateSiteJSONRequestBody defines body for CreateSite for application/json ContentType.
+type CreateSiteJSONRequestBody = SiteRequest
+
+// UpdateSiteJSONRequestBody defines body for UpdateSite for application/json ContentType.
+type UpdateSiteJSONRequestBody = SiteRequest
+
+// AddClosedDayJSONRequestBody defines body for AddClosedDay for application/json ContentType.
+type AddClosedDayJSONRequestBody = ClosedDayRequest
+
+// ManualTrackJSONRequestBody defines body for ManualTrack for application/json ContentType.
+type ManualTrackJSONRequestBody = TrackRequest
+
+// SetLateReasonJSONRequestBody defines body for SetLateReason for application/json ContentType.
+type SetLateReasonJSONRequestBody = ReasonRequest
+
+// CreatePickupPersonJSONRequestBody defines body for CreatePickupPerson for application/json ContentType.
+type CreatePickupPersonJSONRequestBody = PickupPersonRequest
+
+// CreateWatchlistEntryJSONRequestBody defines body for CreateWatchlistEntry for application/json ContentType.
+type CreateWatchlistEntryJSONRequestBody = WatchlistEntryRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ReaderPage request
+	ReaderPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddKeyPage request
+	AddKeyPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DocsPage request
+	DocsPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOpenAPI request
+	GetOpenAPI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddKeyWithBody request with any body
+	AddKeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddKey(ctx context.Context, body AddKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListTenants request
+	ListTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateTenantWithBody request with any body
+	CreateTenantWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateTenant(ctx context.Context, body CreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateTenantWithBody request with any body
+	UpdateTenantWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateTenant(ctx context.Context, id Id, body UpdateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UploadTenantLogoWithBody request with any body
+	UploadTenantLogoWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RotateTenantToken request
+	RotateTenantToken(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRegister request
+	GetRegister(ctx context.Context, id Id, params *GetRegisterParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdjustAttendanceWithBody request with any body
+	AdjustAttendanceWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AdjustAttendance(ctx context.Context, id Id, body AdjustAttendanceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ScanAttendanceWithBody request with any body
+	ScanAttendanceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ScanAttendance(ctx context.Context, body ScanAttendanceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTeacherLessons request
+	GetTeacherLessons(ctx context.Context, id Id, params *GetTeacherLessonsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBranding request
+	GetBranding(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLogo request
+	GetLogo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListBusRoutes request
+	ListBusRoutes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateBusRouteWithBody request with any body
+	CreateBusRouteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateBusRoute(ctx context.Context, body CreateBusRouteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BusArrivedAtDeviceWithBody request with any body
+	BusArrivedAtDeviceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BusArrivedAtDevice(ctx context.Context, body BusArrivedAtDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteBusRoute request
+	DeleteBusRoute(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBusRoute request
+	GetBusRoute(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BusArrived request
+	BusArrived(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListMissingBusRiders request
+	ListMissingBusRiders(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListBusRiders request
+	ListBusRiders(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AssignBusRiderWithBody request with any body
+	AssignBusRiderWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AssignBusRider(ctx context.Context, id Id, body AssignBusRiderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveBusRider request
+	RemoveBusRider(ctx context.Context, id Id, visitorId VisitorId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateClassWithBody request with any body
+	CreateClassWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateClass(ctx context.Context, body CreateClassJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetClassMembers request
+	GetClassMembers(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetClassMembersWithBody request with any body
+	SetClassMembersWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetClassMembers(ctx context.Context, id Id, body SetClassMembersJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListConsequenceRules request
+	ListConsequenceRules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateConsequenceRuleWithBody request with any body
+	CreateConsequenceRuleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateConsequenceRule(ctx context.Context, body CreateConsequenceRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteConsequenceRule request
+	DeleteConsequenceRule(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListConsequences request
+	ListConsequences(ctx context.Context, params *ListConsequencesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResolveConsequenceWithBody request with any body
+	ResolveConsequenceWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ResolveConsequence(ctx context.Context, id Id, body ResolveConsequenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ScanDetentionWithBody request with any body
+	ScanDetentionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ScanDetention(ctx context.Context, body ScanDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListDetentionSessions request
+	ListDetentionSessions(ctx context.Context, params *ListDetentionSessionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateDetentionSessionWithBody request with any body
+	CreateDetentionSessionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateDetentionSession(ctx context.Context, body CreateDetentionSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDetentionSession request
+	GetDetentionSession(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AssignDetentionWithBody request with any body
+	AssignDetentionWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AssignDetention(ctx context.Context, id Id, body AssignDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// MarkDetentionWithBody request with any body
+	MarkDetentionWithBody(ctx context.Context, id Id, visitorId VisitorId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	MarkDetention(ctx context.Context, id Id, visitorId VisitorId, body MarkDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CloseDetentionSession request
+	CloseDetentionSession(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// FillDetentionSession request
+	FillDetentionSession(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListDevices request
+	ListDevices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SaveDeviceWithBody request with any body
+	SaveDeviceWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SaveDevice(ctx context.Context, id string, body SaveDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListDismissals request
+	ListDismissals(ctx context.Context, params *ListDismissalsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DismissWithBody request with any body
+	DismissWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Dismiss(ctx context.Context, body DismissJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StartEvacuationWithBody request with any body
+	StartEvacuationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	StartEvacuation(ctx context.Context, body StartEvacuationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetActiveEvacuation request
+	GetActiveEvacuation(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEvacuation request
+	GetEvacuation(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CheckEvacueeWithBody request with any body
+	CheckEvacueeWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CheckEvacuee(ctx context.Context, id Id, body CheckEvacueeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EndEvacuation request
+	EndEvacuation(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportEvacuation request
+	ExportEvacuation(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListExcusals request
+	ListExcusals(ctx context.Context, params *ListExcusalsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateExcusalWithBody request with any body
+	CreateExcusalWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateExcusal(ctx context.Context, body CreateExcusalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeExcusal request
+	RevokeExcusal(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// FindAndTrackWithBody request with any body
+	FindAndTrackWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	FindAndTrack(ctx context.Context, body FindAndTrackJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ScreenGuestWithBody request with any body
+	ScreenGuestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ScreenGuest(ctx context.Context, body ScreenGuestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// IssueHallPassWithBody request with any body
+	IssueHallPassWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	IssueHallPass(ctx context.Context, body IssueHallPassJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListActiveHallPasses request
+	ListActiveHallPasses(ctx context.Context, params *ListActiveHallPassesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHallPassReport request
+	GetHallPassReport(ctx context.Context, params *GetHallPassReportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ScanHallPassWithBody request with any body
+	ScanHallPassWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ScanHallPass(ctx context.Context, body ScanHallPassJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CloseHallPass request
+	CloseHallPass(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnenrollKiosk request
+	UnenrollKiosk(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EnrollKiosk request
+	EnrollKiosk(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListLateReasons request
+	ListLateReasons(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateLessonWithBody request with any body
+	CreateLessonWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateLesson(ctx context.Context, body CreateLessonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPeriods request
+	ListPeriods(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreatePeriodWithBody request with any body
+	CreatePeriodWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreatePeriod(ctx context.Context, body CreatePeriodJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeletePickupPerson request
+	DeletePickupPerson(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListRooms request
+	ListRooms(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateRoomWithBody request with any body
+	CreateRoomWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateRoom(ctx context.Context, body CreateRoomJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRoster request
+	GetRoster(ctx context.Context, params *GetRosterParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BatchScansWithBody request with any body
+	BatchScansWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BatchScans(ctx context.Context, body BatchScansJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SearchVisitor request
+	SearchVisitor(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCurrentSite request
+	GetCurrentSite(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSites request
+	ListSites(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateSiteWithBody request with any body
+	CreateSiteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateSite(ctx context.Context, body CreateSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateSiteWithBody request with any body
+	UpdateSiteWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateSite(ctx context.Context, id Id, body UpdateSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListClosedDays request
+	ListClosedDays(ctx context.Context, id Id, params *ListClosedDaysParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveClosedDay request
+	RemoveClosedDay(ctx context.Context, id Id, date openapi_types.Date, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddClosedDayWithBody request with any body
+	AddClosedDayWithBody(ctx context.Context, id Id, date openapi_types.Date, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddClosedDay(ctx context.Context, id Id, date openapi_types.Date, body AddClosedDayJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTardyReport request
+	GetTardyReport(ctx context.Context, params *GetTardyReportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTimesheets request
+	GetTimesheets(ctx context.Context, params *GetTimesheetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportTimesheets request
+	ExportTimesheets(ctx context.Context, params *ExportTimesheetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ManualTrackWithBody request with any body
+	ManualTrackWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ManualTrack(ctx context.Context, body ManualTrackJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetLateReasonWithBody request with any body
+	SetLateReasonWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetLateReason(ctx context.Context, id Id, body SetLateReasonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTardySlip request
+	GetTardySlip(ctx context.Context, id Id, params *GetTardySlipParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PrintTardySlip request
+	PrintTardySlip(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPickupPersons request
+	ListPickupPersons(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreatePickupPersonWithBody request with any body
+	CreatePickupPersonWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreatePickupPerson(ctx context.Context, id Id, body CreatePickupPersonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListWatchlist request
+	ListWatchlist(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateWatchlistEntryWithBody request with any body
+	CreateWatchlistEntryWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateWatchlistEntry(ctx context.Context, body CreateWatchlistEntryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListWatchlistHits request
+	ListWatchlistHits(ctx context.Context, params *ListWatchlistHitsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteWatchlistEntry request
+	DeleteWatchlistEntry(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DismissalPage request
+	DismissalPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EvacuationPage request
+	EvacuationPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// KioskPage request
+	KioskPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ManualPage request
+	ManualPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Ping request
+	Ping(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ReaderPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReaderPageRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddKeyPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddKeyPageRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DocsPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDocsPageRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOpenAPI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOpenAPIRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddKeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddKeyRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddKey(ctx context.Context, body AddKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddKeyRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTenantsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTenantWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTenantRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTenant(ctx context.Context, body CreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTenantRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTenantWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTenantRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTenant(ctx context.Context, id Id, body UpdateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTenantRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UploadTenantLogoWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUploadTenantLogoRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RotateTenantToken(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRotateTenantTokenRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRegister(ctx context.Context, id Id, params *GetRegisterParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRegisterRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdjustAttendanceWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdjustAttendanceRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdjustAttendance(ctx context.Context, id Id, body AdjustAttendanceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdjustAttendanceRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScanAttendanceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScanAttendanceRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScanAttendance(ctx context.Context, body ScanAttendanceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScanAttendanceRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTeacherLessons(ctx context.Context, id Id, params *GetTeacherLessonsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTeacherLessonsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBranding(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBrandingRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLogo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLogoRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListBusRoutes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListBusRoutesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateBusRouteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBusRouteRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateBusRoute(ctx context.Context, body CreateBusRouteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBusRouteRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BusArrivedAtDeviceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBusArrivedAtDeviceRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BusArrivedAtDevice(ctx context.Context, body BusArrivedAtDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBusArrivedAtDeviceRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteBusRoute(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteBusRouteRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBusRoute(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBusRouteRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BusArrived(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBusArrivedRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListMissingBusRiders(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListMissingBusRidersRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListBusRiders(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListBusRidersRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AssignBusRiderWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAssignBusRiderRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AssignBusRider(ctx context.Context, id Id, body AssignBusRiderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAssignBusRiderRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveBusRider(ctx context.Context, id Id, visitorId VisitorId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveBusRiderRequest(c.Server, id, visitorId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateClassWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateClassRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateClass(ctx context.Context, body CreateClassJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateClassRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetClassMembers(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetClassMembersRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetClassMembersWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetClassMembersRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetClassMembers(ctx context.Context, id Id, body SetClassMembersJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetClassMembersRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListConsequenceRules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListConsequenceRulesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateConsequenceRuleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateConsequenceRuleRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateConsequenceRule(ctx context.Context, body CreateConsequenceRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateConsequenceRuleRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteConsequenceRule(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteConsequenceRuleRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListConsequences(ctx context.Context, params *ListConsequencesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListConsequencesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResolveConsequenceWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResolveConsequenceRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResolveConsequence(ctx context.Context, id Id, body ResolveConsequenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResolveConsequenceRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScanDetentionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScanDetentionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScanDetention(ctx context.Context, body ScanDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScanDetentionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListDetentionSessions(ctx context.Context, params *ListDetentionSessionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListDetentionSessionsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateDetentionSessionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateDetentionSessionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateDetentionSession(ctx context.Context, body CreateDetentionSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateDetentionSessionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDetentionSession(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDetentionSessionRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AssignDetentionWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAssignDetentionRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AssignDetention(ctx context.Context, id Id, body AssignDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAssignDetentionRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) MarkDetentionWithBody(ctx context.Context, id Id, visitorId VisitorId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMarkDetentionRequestWithBody(c.Server, id, visitorId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) MarkDetention(ctx context.Context, id Id, visitorId VisitorId, body MarkDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMarkDetentionRequest(c.Server, id, visitorId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CloseDetentionSession(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCloseDetentionSessionRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FillDetentionSession(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFillDetentionSessionRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListDevices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListDevicesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SaveDeviceWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSaveDeviceRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SaveDevice(ctx context.Context, id string, body SaveDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSaveDeviceRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListDismissals(ctx context.Context, params *ListDismissalsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListDismissalsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DismissWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDismissRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Dismiss(ctx context.Context, body DismissJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDismissRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartEvacuationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartEvacuationRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartEvacuation(ctx context.Context, body StartEvacuationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartEvacuationRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetActiveEvacuation(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetActiveEvacuationRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEvacuation(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEvacuationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CheckEvacueeWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCheckEvacueeRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CheckEvacuee(ctx context.Context, id Id, body CheckEvacueeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCheckEvacueeRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EndEvacuation(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEndEvacuationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportEvacuation(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportEvacuationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListExcusals(ctx context.Context, params *ListExcusalsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListExcusalsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateExcusalWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateExcusalRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateExcusal(ctx context.Context, body CreateExcusalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateExcusalRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeExcusal(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeExcusalRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FindAndTrackWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFindAndTrackRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FindAndTrack(ctx context.Context, body FindAndTrackJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFindAndTrackRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScreenGuestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScreenGuestRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScreenGuest(ctx context.Context, body ScreenGuestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScreenGuestRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) IssueHallPassWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewIssueHallPassRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) IssueHallPass(ctx context.Context, body IssueHallPassJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewIssueHallPassRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListActiveHallPasses(ctx context.Context, params *ListActiveHallPassesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListActiveHallPassesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHallPassReport(ctx context.Context, params *GetHallPassReportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHallPassReportRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScanHallPassWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScanHallPassRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScanHallPass(ctx context.Context, body ScanHallPassJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScanHallPassRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CloseHallPass(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCloseHallPassRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnenrollKiosk(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnenrollKioskRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EnrollKiosk(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEnrollKioskRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListLateReasons(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListLateReasonsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateLessonWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateLessonRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateLesson(ctx context.Context, body CreateLessonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateLessonRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListPeriods(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPeriodsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePeriodWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePeriodRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePeriod(ctx context.Context, body CreatePeriodJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePeriodRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeletePickupPerson(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeletePickupPersonRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListRooms(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListRoomsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateRoomWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateRoomRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateRoom(ctx context.Context, body CreateRoomJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateRoomRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRoster(ctx context.Context, params *GetRosterParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRosterRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BatchScansWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBatchScansRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BatchScans(ctx context.Context, body BatchScansJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBatchScansRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SearchVisitor(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSearchVisitorRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCurrentSite(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCurrentSiteRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListSites(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSitesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSiteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSiteRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSite(ctx context.Context, body CreateSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSiteRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateSiteWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateSiteRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateSite(ctx context.Context, id Id, body UpdateSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateSiteRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListClosedDays(ctx context.Context, id Id, params *ListClosedDaysParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListClosedDaysRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveClosedDay(ctx context.Context, id Id, date openapi_types.Date, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveClosedDayRequest(c.Server, id, date)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddClosedDayWithBody(ctx context.Context, id Id, date openapi_types.Date, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddClosedDayRequestWithBody(c.Server, id, date, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddClosedDay(ctx context.Context, id Id, date openapi_types.Date, body AddClosedDayJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddClosedDayRequest(c.Server, id, date, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTardyReport(ctx context.Context, params *GetTardyReportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTardyReportRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTimesheets(ctx context.Context, params *GetTimesheetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTimesheetsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportTimesheets(ctx context.Context, params *ExportTimesheetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportTimesheetsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ManualTrackWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewManualTrackRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ManualTrack(ctx context.Context, body ManualTrackJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewManualTrackRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetLateReasonWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetLateReasonRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetLateReason(ctx context.Context, id Id, body SetLateReasonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetLateReasonRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTardySlip(ctx context.Context, id Id, params *GetTardySlipParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTardySlipRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PrintTardySlip(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPrintTardySlipRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListPickupPersons(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPickupPersonsRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePickupPersonWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePickupPersonRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePickupPerson(ctx context.Context, id Id, body CreatePickupPersonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePickupPersonRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListWatchlist(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListWatchlistRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateWatchlistEntryWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateWatchlistEntryRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateWatchlistEntry(ctx context.Context, body CreateWatchlistEntryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateWatchlistEntryRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListWatchlistHits(ctx context.Context, params *ListWatchlistHitsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListWatchlistHitsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteWatchlistEntry(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteWatchlistEntryRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DismissalPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDismissalPageRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EvacuationPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEvacuationPageRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) KioskPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewKioskPageRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ManualPage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewManualPageRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Ping(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPingRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewReaderPageRequest generates requests for ReaderPage
+func NewReaderPageRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddKeyPageRequest generates requests for AddKeyPage
+func NewAddKeyPageRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/add")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDocsPageRequest generates requests for DocsPage
+func NewDocsPageRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/docs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOpenAPIRequest generates requests for GetOpenAPI
+func NewGetOpenAPIRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/openapi.yaml")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddKeyRequest calls the generic AddKey builder with application/json body
+func NewAddKeyRequest(server string, body AddKeyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddKeyRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddKeyRequestWithBody generates requests for AddKey with any type of body
+func NewAddKeyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/add-key")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListTenantsRequest generates requests for ListTenants
+func NewListTenantsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/admin/tenants")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateTenantRequest calls the generic CreateTenant builder with application/json body
+func NewCreateTenantRequest(server string, body CreateTenantJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateTenantRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateTenantRequestWithBody generates requests for CreateTenant with any type of body
+func NewCreateTenantRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/admin/tenants")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUpdateTenantRequest calls the generic UpdateTenant builder with application/json body
+func NewUpdateTenantRequest(server string, id Id, body UpdateTenantJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateTenantRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateTenantRequestWithBody generates requests for UpdateTenant with any type of body
+func NewUpdateTenantRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/admin/tenants/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUploadTenantLogoRequestWithBody generates requests for UploadTenantLogo with any type of body
+func NewUploadTenantLogoRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/admin/tenants/%s/logo", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRotateTenantTokenRequest generates requests for RotateTenantToken
+func NewRotateTenantTokenRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/admin/tenants/%s/token", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRegisterRequest generates requests for GetRegister
+func NewGetRegisterRequest(server string, id Id, params *GetRegisterParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/attendance/lessons/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Date != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, *params.Date); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdjustAttendanceRequest calls the generic AdjustAttendance builder with application/json body
+func NewAdjustAttendanceRequest(server string, id Id, body AdjustAttendanceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAdjustAttendanceRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewAdjustAttendanceRequestWithBody generates requests for AdjustAttendance with any type of body
+func NewAdjustAttendanceRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/attendance/lessons/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewScanAttendanceRequest calls the generic ScanAttendance builder with application/json body
+func NewScanAttendanceRequest(server string, body ScanAttendanceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewScanAttendanceRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewScanAttendanceRequestWithBody generates requests for ScanAttendance with any type of body
+func NewScanAttendanceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/attendance/scan")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTeacherLessonsRequest generates requests for GetTeacherLessons
+func NewGetTeacherLessonsRequest(server string, id Id, params *GetTeacherLessonsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/attendance/teachers/%s/lessons", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Date != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, *params.Date); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBrandingRequest generates requests for GetBranding
+func NewGetBrandingRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/branding")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLogoRequest generates requests for GetLogo
+func NewGetLogoRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/branding/logo")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListBusRoutesRequest generates requests for ListBusRoutes
+func NewListBusRoutesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/bus-routes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateBusRouteRequest calls the generic CreateBusRoute builder with application/json body
+func NewCreateBusRouteRequest(server string, body CreateBusRouteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateBusRouteRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateBusRouteRequestWithBody generates requests for CreateBusRoute with any type of body
+func NewCreateBusRouteRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/bus-routes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewBusArrivedAtDeviceRequest calls the generic BusArrivedAtDevice builder with application/json body
+func NewBusArrivedAtDeviceRequest(server string, body BusArrivedAtDeviceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewBusArrivedAtDeviceRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewBusArrivedAtDeviceRequestWithBody generates requests for BusArrivedAtDevice with any type of body
+func NewBusArrivedAtDeviceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/bus-routes/arrived")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteBusRouteRequest generates requests for DeleteBusRoute
+func NewDeleteBusRouteRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/bus-routes/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBusRouteRequest generates requests for GetBusRoute
+func NewGetBusRouteRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/bus-routes/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewBusArrivedRequest generates requests for BusArrived
+func NewBusArrivedRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/bus-routes/%s/arrived", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListMissingBusRidersRequest generates requests for ListMissingBusRiders
+func NewListMissingBusRidersRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/bus-routes/%s/missing", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListBusRidersRequest generates requests for ListBusRiders
+func NewListBusRidersRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/bus-routes/%s/riders", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAssignBusRiderRequest calls the generic AssignBusRider builder with application/json body
+func NewAssignBusRiderRequest(server string, id Id, body AssignBusRiderJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAssignBusRiderRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewAssignBusRiderRequestWithBody generates requests for AssignBusRider with any type of body
+func NewAssignBusRiderRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/bus-routes/%s/riders", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRemoveBusRiderRequest generates requests for RemoveBusRider
+func NewRemoveBusRiderRequest(server string, id Id, visitorId VisitorId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "visitorId", runtime.ParamLocationPath, visitorId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/bus-routes/%s/riders/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateClassRequest calls the generic CreateClass builder with application/json body
+func NewCreateClassRequest(server string, body CreateClassJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateClassRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateClassRequestWithBody generates requests for CreateClass with any type of body
+func NewCreateClassRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/classes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetClassMembersRequest generates requests for GetClassMembers
+func NewGetClassMembersRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/classes/%s/members", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetClassMembersRequest calls the generic SetClassMembers builder with application/json body
+func NewSetClassMembersRequest(server string, id Id, body SetClassMembersJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetClassMembersRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewSetClassMembersRequestWithBody generates requests for SetClassMembers with any type of body
+func NewSetClassMembersRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/classes/%s/members", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListConsequenceRulesRequest generates requests for ListConsequenceRules
+func NewListConsequenceRulesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/consequence-rules")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateConsequenceRuleRequest calls the generic CreateConsequenceRule builder with application/json body
+func NewCreateConsequenceRuleRequest(server string, body CreateConsequenceRuleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateConsequenceRuleRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateConsequenceRuleRequestWithBody generates requests for CreateConsequenceRule with any type of body
+func NewCreateConsequenceRuleRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/consequence-rules")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteConsequenceRuleRequest generates requests for DeleteConsequenceRule
+func NewDeleteConsequenceRuleRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/consequence-rules/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListConsequencesRequest generates requests for ListConsequences
+func NewListConsequencesRequest(server string, params *ListConsequencesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/consequences")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewResolveConsequenceRequest calls the generic ResolveConsequence builder with application/json body
+func NewResolveConsequenceRequest(server string, id Id, body ResolveConsequenceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewResolveConsequenceRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewResolveConsequenceRequestWithBody generates requests for ResolveConsequence with any type of body
+func NewResolveConsequenceRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/consequences/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewScanDetentionRequest calls the generic ScanDetention builder with application/json body
+func NewScanDetentionRequest(server string, body ScanDetentionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewScanDetentionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewScanDetentionRequestWithBody generates requests for ScanDetention with any type of body
+func NewScanDetentionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/detentions/scan")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListDetentionSessionsRequest generates requests for ListDetentionSessions
+func NewListDetentionSessionsRequest(server string, params *ListDetentionSessionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/detentions/sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateDetentionSessionRequest calls the generic CreateDetentionSession builder with application/json body
+func NewCreateDetentionSessionRequest(server string, body CreateDetentionSessionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateDetentionSessionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateDetentionSessionRequestWithBody generates requests for CreateDetentionSession with any type of body
+func NewCreateDetentionSessionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/detentions/sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetDetentionSessionRequest generates requests for GetDetentionSession
+func NewGetDetentionSessionRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/detentions/sessions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAssignDetentionRequest calls the generic AssignDetention builder with application/json body
+func NewAssignDetentionRequest(server string, id Id, body AssignDetentionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAssignDetentionRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewAssignDetentionRequestWithBody generates requests for AssignDetention with any type of body
+func NewAssignDetentionRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/detentions/sessions/%s/assignments", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewMarkDetentionRequest calls the generic MarkDetention builder with application/json body
+func NewMarkDetentionRequest(server string, id Id, visitorId VisitorId, body MarkDetentionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewMarkDetentionRequestWithBody(server, id, visitorId, "application/json", bodyReader)
+}
+
+// NewMarkDetentionRequestWithBody generates requests for MarkDetention with any type of body
+func NewMarkDetentionRequestWithBody(server string, id Id, visitorId VisitorId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "visitorId", runtime.ParamLocationPath, visitorId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/detentions/sessions/%s/assignments/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCloseDetentionSessionRequest generates requests for CloseDetentionSession
+func NewCloseDetentionSessionRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/detentions/sessions/%s/close", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewFillDetentionSessionRequest generates requests for FillDetentionSession
+func NewFillDetentionSessionRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/detentions/sessions/%s/fill", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListDevicesRequest generates requests for ListDevices
+func NewListDevicesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/devices")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSaveDeviceRequest calls the generic SaveDevice builder with application/json body
+func NewSaveDeviceRequest(server string, id string, body SaveDeviceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSaveDeviceRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewSaveDeviceRequestWithBody generates requests for SaveDevice with any type of body
+func NewSaveDeviceRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/devices/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListDismissalsRequest generates requests for ListDismissals
+func NewListDismissalsRequest(server string, params *ListDismissalsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/dismissals")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Date != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, *params.Date); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDismissRequest calls the generic Dismiss builder with application/json body
+func NewDismissRequest(server string, body DismissJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDismissRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewDismissRequestWithBody generates requests for Dismiss with any type of body
+func NewDismissRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/dismissals")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewStartEvacuationRequest calls the generic StartEvacuation builder with application/json body
+func NewStartEvacuationRequest(server string, body StartEvacuationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewStartEvacuationRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewStartEvacuationRequestWithBody generates requests for StartEvacuation with any type of body
+func NewStartEvacuationRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/evacuation")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetActiveEvacuationRequest generates requests for GetActiveEvacuation
+func NewGetActiveEvacuationRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/evacuation/active")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEvacuationRequest generates requests for GetEvacuation
+func NewGetEvacuationRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/evacuation/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCheckEvacueeRequest calls the generic CheckEvacuee builder with application/json body
+func NewCheckEvacueeRequest(server string, id Id, body CheckEvacueeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCheckEvacueeRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewCheckEvacueeRequestWithBody generates requests for CheckEvacuee with any type of body
+func NewCheckEvacueeRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/evacuation/%s/check", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewEndEvacuationRequest generates requests for EndEvacuation
+func NewEndEvacuationRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/evacuation/%s/end", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportEvacuationRequest generates requests for ExportEvacuation
+func NewExportEvacuationRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/evacuation/%s/export", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListExcusalsRequest generates requests for ListExcusals
+func NewListExcusalsRequest(server string, params *ListExcusalsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/excusals")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateExcusalRequest calls the generic CreateExcusal builder with application/json body
+func NewCreateExcusalRequest(server string, body CreateExcusalJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateExcusalRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateExcusalRequestWithBody generates requests for CreateExcusal with any type of body
+func NewCreateExcusalRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/excusals")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRevokeExcusalRequest generates requests for RevokeExcusal
+func NewRevokeExcusalRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/excusals/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewFindAndTrackRequest calls the generic FindAndTrack builder with application/json body
+func NewFindAndTrackRequest(server string, body FindAndTrackJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewFindAndTrackRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewFindAndTrackRequestWithBody generates requests for FindAndTrack with any type of body
+func NewFindAndTrackRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/find-and-track")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewScreenGuestRequest calls the generic ScreenGuest builder with application/json body
+func NewScreenGuestRequest(server string, body ScreenGuestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewScreenGuestRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewScreenGuestRequestWithBody generates requests for ScreenGuest with any type of body
+func NewScreenGuestRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/guests/screen")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewIssueHallPassRequest calls the generic IssueHallPass builder with application/json body
+func NewIssueHallPassRequest(server string, body IssueHallPassJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewIssueHallPassRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewIssueHallPassRequestWithBody generates requests for IssueHallPass with any type of body
+func NewIssueHallPassRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/hall-passes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListActiveHallPassesRequest generates requests for ListActiveHallPasses
+func NewListActiveHallPassesRequest(server string, params *ListActiveHallPassesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/hall-passes/active")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Overdue != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "overdue", runtime.ParamLocationQuery, *params.Overdue); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHallPassReportRequest generates requests for GetHallPassReport
+func NewGetHallPassReportRequest(server string, params *GetHallPassReportParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/hall-passes/report")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewScanHallPassRequest calls the generic ScanHallPass builder with application/json body
+func NewScanHallPassRequest(server string, body ScanHallPassJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewScanHallPassRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewScanHallPassRequestWithBody generates requests for ScanHallPass with any type of body
+func NewScanHallPassRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/hall-passes/scan")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCloseHallPassRequest generates requests for CloseHallPass
+func NewCloseHallPassRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/hall-passes/%s/close", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUnenrollKioskRequest generates requests for UnenrollKiosk
+func NewUnenrollKioskRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/kiosk")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewEnrollKioskRequest generates requests for EnrollKiosk
+func NewEnrollKioskRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/kiosk")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListLateReasonsRequest generates requests for ListLateReasons
+func NewListLateReasonsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/late-reasons")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateLessonRequest calls the generic CreateLesson builder with application/json body
+func NewCreateLessonRequest(server string, body CreateLessonJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateLessonRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateLessonRequestWithBody generates requests for CreateLesson with any type of body
+func NewCreateLessonRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/lessons")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListPeriodsRequest generates requests for ListPeriods
+func NewListPeriodsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/periods")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreatePeriodRequest calls the generic CreatePeriod builder with application/json body
+func NewCreatePeriodRequest(server string, body CreatePeriodJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreatePeriodRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreatePeriodRequestWithBody generates requests for CreatePeriod with any type of body
+func NewCreatePeriodRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/periods")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeletePickupPersonRequest generates requests for DeletePickupPerson
+func NewDeletePickupPersonRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/pickup-persons/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListRoomsRequest generates requests for ListRooms
+func NewListRoomsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/rooms")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateRoomRequest calls the generic CreateRoom builder with application/json body
+func NewCreateRoomRequest(server string, body CreateRoomJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateRoomRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateRoomRequestWithBody generates requests for CreateRoom with any type of body
+func NewCreateRoomRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/rooms")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetRosterRequest generates requests for GetRoster
+func NewGetRosterRequest(server string, params *GetRosterParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/roster")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewBatchScansRequest calls the generic BatchScans builder with application/json body
+func NewBatchScansRequest(server string, body BatchScansJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewBatchScansRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewBatchScansRequestWithBody generates requests for BatchScans with any type of body
+func NewBatchScansRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scans/batch")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSearchVisitorRequest generates requests for SearchVisitor
+func NewSearchVisitorRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/search/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCurrentSiteRequest generates requests for GetCurrentSite
+func NewGetCurrentSiteRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/site")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListSitesRequest generates requests for ListSites
+func NewListSitesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sites")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateSiteRequest calls the generic CreateSite builder with application/json body
+func NewCreateSiteRequest(server string, body CreateSiteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateSiteRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateSiteRequestWithBody generates requests for CreateSite with any type of body
+func NewCreateSiteRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sites")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUpdateSiteRequest calls the generic UpdateSite builder with application/json body
+func NewUpdateSiteRequest(server string, id Id, body UpdateSiteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateSiteRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateSiteRequestWithBody generates requests for UpdateSite with any type of body
+func NewUpdateSiteRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sites/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListClosedDaysRequest generates requests for ListClosedDays
+func NewListClosedDaysRequest(server string, id Id, params *ListClosedDaysParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sites/%s/closed-days", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRemoveClosedDayRequest generates requests for RemoveClosedDay
+func NewRemoveClosedDayRequest(server string, id Id, date openapi_types.Date) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "date", runtime.ParamLocationPath, date)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sites/%s/closed-days/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddClosedDayRequest calls the generic AddClosedDay builder with application/json body
+func NewAddClosedDayRequest(server string, id Id, date openapi_types.Date, body AddClosedDayJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddClosedDayRequestWithBody(server, id, date, "application/json", bodyReader)
+}
+
+// NewAddClosedDayRequestWithBody generates requests for AddClosedDay with any type of body
+func NewAddClosedDayRequestWithBody(server string, id Id, date openapi_types.Date, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "date", runtime.ParamLocationPath, date)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sites/%s/closed-days/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTardyReportRequest generates requests for GetTardyReport
+func NewGetTardyReportRequest(server string, params *GetTardyReportParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/tardies")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTimesheetsRequest generates requests for GetTimesheets
+func NewGetTimesheetsRequest(server string, params *GetTimesheetsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/timesheets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportTimesheetsRequest generates requests for ExportTimesheets
+func NewExportTimesheetsRequest(server string, params *ExportTimesheetsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/timesheets/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewManualTrackRequest calls the generic ManualTrack builder with application/json body
+func NewManualTrackRequest(server string, body ManualTrackJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewManualTrackRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewManualTrackRequestWithBody generates requests for ManualTrack with any type of body
+func NewManualTrackRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/track")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSetLateReasonRequest calls the generic SetLateReason builder with application/json body
+func NewSetLateReasonRequest(server string, id Id, body SetLateReasonJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetLateReasonRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewSetLateReasonRequestWithBody generates requests for SetLateReason with any type of body
+func NewSetLateReasonRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/tracks/%s/late-reason", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTardySlipRequest generates requests for GetTardySlip
+func NewGetTardySlipRequest(server string, id Id, params *GetTardySlipParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/tracks/%s/tardy-slip", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPrintTardySlipRequest generates requests for PrintTardySlip
+func NewPrintTardySlipRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/tracks/%s/tardy-slip/print", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListPickupPersonsRequest generates requests for ListPickupPersons
+func NewListPickupPersonsRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/visitors/%s/pickup-persons", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreatePickupPersonRequest calls the generic CreatePickupPerson builder with application/json body
+func NewCreatePickupPersonRequest(server string, id Id, body CreatePickupPersonJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreatePickupPersonRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewCreatePickupPersonRequestWithBody generates requests for CreatePickupPerson with any type of body
+func NewCreatePickupPersonRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/visitors/%s/pickup-persons", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListWatchlistRequest generates requests for ListWatchlist
+func NewListWatchlistRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/watchlist")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateWatchlistEntryRequest calls the generic CreateWatchlistEntry builder with application/json body
+func NewCreateWatchlistEntryRequest(server string, body CreateWatchlistEntryJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateWatchlistEntryRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateWatchlistEntryRequestWithBody generates requests for CreateWatchlistEntry with any type of body
+func NewCreateWatchlistEntryRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/watchlist")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListWatchlistHitsRequest generates requests for ListWatchlistHits
+func NewListWatchlistHitsRequest(server string, params *ListWatchlistHitsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/watchlist/hits")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Date != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, *params.Date); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteWatchlistEntryRequest generates requests for DeleteWatchlistEntry
+func NewDeleteWatchlistEntryRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/watchlist/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDismissalPageRequest generates requests for DismissalPage
+func NewDismissalPageRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/dismissal")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewEvacuationPageRequest generates requests for EvacuationPage
+func NewEvacuationPageRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/evacuation")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewKioskPageRequest generates requests for KioskPage
+func NewKioskPageRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/kiosk")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewManualPageRequest generates requests for ManualPage
+func NewManualPageRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/manual")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPingRequest generates requests for Ping
+func NewPingRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ping")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ReaderPageWithResponse request
+	ReaderPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReaderPageResponse, error)
+
+	// AddKeyPageWithResponse request
+	AddKeyPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*AddKeyPageResponse, error)
+
+	// DocsPageWithResponse request
+	DocsPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DocsPageResponse, error)
+
+	// GetOpenAPIWithResponse request
+	GetOpenAPIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPIResponse, error)
+
+	// AddKeyWithBodyWithResponse request with any body
+	AddKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddKeyResponse, error)
+
+	AddKeyWithResponse(ctx context.Context, body AddKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*AddKeyResponse, error)
+
+	// ListTenantsWithResponse request
+	ListTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTenantsResponse, error)
+
+	// CreateTenantWithBodyWithResponse request with any body
+	CreateTenantWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTenantResponse, error)
+
+	CreateTenantWithResponse(ctx context.Context, body CreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTenantResponse, error)
+
+	// UpdateTenantWithBodyWithResponse request with any body
+	UpdateTenantWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTenantResponse, error)
+
+	UpdateTenantWithResponse(ctx context.Context, id Id, body UpdateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTenantResponse, error)
+
+	// UploadTenantLogoWithBodyWithResponse request with any body
+	UploadTenantLogoWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadTenantLogoResponse, error)
+
+	// RotateTenantTokenWithResponse request
+	RotateTenantTokenWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RotateTenantTokenResponse, error)
+
+	// GetRegisterWithResponse request
+	GetRegisterWithResponse(ctx context.Context, id Id, params *GetRegisterParams, reqEditors ...RequestEditorFn) (*GetRegisterResponse, error)
+
+	// AdjustAttendanceWithBodyWithResponse request with any body
+	AdjustAttendanceWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AdjustAttendanceResponse, error)
+
+	AdjustAttendanceWithResponse(ctx context.Context, id Id, body AdjustAttendanceJSONRequestBody, reqEditors ...RequestEditorFn) (*AdjustAttendanceResponse, error)
+
+	// ScanAttendanceWithBodyWithResponse request with any body
+	ScanAttendanceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScanAttendanceResponse, error)
+
+	ScanAttendanceWithResponse(ctx context.Context, body ScanAttendanceJSONRequestBody, reqEditors ...RequestEditorFn) (*ScanAttendanceResponse, error)
+
+	// GetTeacherLessonsWithResponse request
+	GetTeacherLessonsWithResponse(ctx context.Context, id Id, params *GetTeacherLessonsParams, reqEditors ...RequestEditorFn) (*GetTeacherLessonsResponse, error)
+
+	// GetBrandingWithResponse request
+	GetBrandingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetBrandingResponse, error)
+
+	// GetLogoWithResponse request
+	GetLogoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogoResponse, error)
+
+	// ListBusRoutesWithResponse request
+	ListBusRoutesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListBusRoutesResponse, error)
+
+	// CreateBusRouteWithBodyWithResponse request with any body
+	CreateBusRouteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBusRouteResponse, error)
+
+	CreateBusRouteWithResponse(ctx context.Context, body CreateBusRouteJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBusRouteResponse, error)
+
+	// BusArrivedAtDeviceWithBodyWithResponse request with any body
+	BusArrivedAtDeviceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BusArrivedAtDeviceResponse, error)
+
+	BusArrivedAtDeviceWithResponse(ctx context.Context, body BusArrivedAtDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*BusArrivedAtDeviceResponse, error)
+
+	// DeleteBusRouteWithResponse request
+	DeleteBusRouteWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeleteBusRouteResponse, error)
+
+	// GetBusRouteWithResponse request
+	GetBusRouteWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetBusRouteResponse, error)
+
+	// BusArrivedWithResponse request
+	BusArrivedWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*BusArrivedResponse, error)
+
+	// ListMissingBusRidersWithResponse request
+	ListMissingBusRidersWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ListMissingBusRidersResponse, error)
+
+	// ListBusRidersWithResponse request
+	ListBusRidersWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ListBusRidersResponse, error)
+
+	// AssignBusRiderWithBodyWithResponse request with any body
+	AssignBusRiderWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AssignBusRiderResponse, error)
+
+	AssignBusRiderWithResponse(ctx context.Context, id Id, body AssignBusRiderJSONRequestBody, reqEditors ...RequestEditorFn) (*AssignBusRiderResponse, error)
+
+	// RemoveBusRiderWithResponse request
+	RemoveBusRiderWithResponse(ctx context.Context, id Id, visitorId VisitorId, reqEditors ...RequestEditorFn) (*RemoveBusRiderResponse, error)
+
+	// CreateClassWithBodyWithResponse request with any body
+	CreateClassWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateClassResponse, error)
+
+	CreateClassWithResponse(ctx context.Context, body CreateClassJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateClassResponse, error)
+
+	// GetClassMembersWithResponse request
+	GetClassMembersWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetClassMembersResponse, error)
+
+	// SetClassMembersWithBodyWithResponse request with any body
+	SetClassMembersWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetClassMembersResponse, error)
+
+	SetClassMembersWithResponse(ctx context.Context, id Id, body SetClassMembersJSONRequestBody, reqEditors ...RequestEditorFn) (*SetClassMembersResponse, error)
+
+	// ListConsequenceRulesWithResponse request
+	ListConsequenceRulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListConsequenceRulesResponse, error)
+
+	// CreateConsequenceRuleWithBodyWithResponse request with any body
+	CreateConsequenceRuleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateConsequenceRuleResponse, error)
+
+	CreateConsequenceRuleWithResponse(ctx context.Context, body CreateConsequenceRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateConsequenceRuleResponse, error)
+
+	// DeleteConsequenceRuleWithResponse request
+	DeleteConsequenceRuleWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeleteConsequenceRuleResponse, error)
+
+	// ListConsequencesWithResponse request
+	ListConsequencesWithResponse(ctx context.Context, params *ListConsequencesParams, reqEditors ...RequestEditorFn) (*ListConsequencesResponse, error)
+
+	// ResolveConsequenceWithBodyWithResponse request with any body
+	ResolveConsequenceWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ResolveConsequenceResponse, error)
+
+	ResolveConsequenceWithResponse(ctx context.Context, id Id, body ResolveConsequenceJSONRequestBody, reqEditors ...RequestEditorFn) (*ResolveConsequenceResponse, error)
+
+	// ScanDetentionWithBodyWithResponse request with any body
+	ScanDetentionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScanDetentionResponse, error)
+
+	ScanDetentionWithResponse(ctx context.Context, body ScanDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*ScanDetentionResponse, error)
+
+	// ListDetentionSessionsWithResponse request
+	ListDetentionSessionsWithResponse(ctx context.Context, params *ListDetentionSessionsParams, reqEditors ...RequestEditorFn) (*ListDetentionSessionsResponse, error)
+
+	// CreateDetentionSessionWithBodyWithResponse request with any body
+	CreateDetentionSessionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateDetentionSessionResponse, error)
+
+	CreateDetentionSessionWithResponse(ctx context.Context, body CreateDetentionSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateDetentionSessionResponse, error)
+
+	// GetDetentionSessionWithResponse request
+	GetDetentionSessionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetDetentionSessionResponse, error)
+
+	// AssignDetentionWithBodyWithResponse request with any body
+	AssignDetentionWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AssignDetentionResponse, error)
+
+	AssignDetentionWithResponse(ctx context.Context, id Id, body AssignDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*AssignDetentionResponse, error)
+
+	// MarkDetentionWithBodyWithResponse request with any body
+	MarkDetentionWithBodyWithResponse(ctx context.Context, id Id, visitorId VisitorId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MarkDetentionResponse, error)
+
+	MarkDetentionWithResponse(ctx context.Context, id Id, visitorId VisitorId, body MarkDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*MarkDetentionResponse, error)
+
+	// CloseDetentionSessionWithResponse request
+	CloseDetentionSessionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*CloseDetentionSessionResponse, error)
+
+	// FillDetentionSessionWithResponse request
+	FillDetentionSessionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*FillDetentionSessionResponse, error)
+
+	// ListDevicesWithResponse request
+	ListDevicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDevicesResponse, error)
+
+	// SaveDeviceWithBodyWithResponse request with any body
+	SaveDeviceWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SaveDeviceResponse, error)
+
+	SaveDeviceWithResponse(ctx context.Context, id string, body SaveDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*SaveDeviceResponse, error)
+
+	// ListDismissalsWithResponse request
+	ListDismissalsWithResponse(ctx context.Context, params *ListDismissalsParams, reqEditors ...RequestEditorFn) (*ListDismissalsResponse, error)
+
+	// DismissWithBodyWithResponse request with any body
+	DismissWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DismissResponse, error)
+
+	DismissWithResponse(ctx context.Context, body DismissJSONRequestBody, reqEditors ...RequestEditorFn) (*DismissResponse, error)
+
+	// StartEvacuationWithBodyWithResponse request with any body
+	StartEvacuationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartEvacuationResponse, error)
+
+	StartEvacuationWithResponse(ctx context.Context, body StartEvacuationJSONRequestBody, reqEditors ...RequestEditorFn) (*StartEvacuationResponse, error)
+
+	// GetActiveEvacuationWithResponse request
+	GetActiveEvacuationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetActiveEvacuationResponse, error)
+
+	// GetEvacuationWithResponse request
+	GetEvacuationWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetEvacuationResponse, error)
+
+	// CheckEvacueeWithBodyWithResponse request with any body
+	CheckEvacueeWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CheckEvacueeResponse, error)
+
+	CheckEvacueeWithResponse(ctx context.Context, id Id, body CheckEvacueeJSONRequestBody, reqEditors ...RequestEditorFn) (*CheckEvacueeResponse, error)
+
+	// EndEvacuationWithResponse request
+	EndEvacuationWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*EndEvacuationResponse, error)
+
+	// ExportEvacuationWithResponse request
+	ExportEvacuationWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ExportEvacuationResponse, error)
+
+	// ListExcusalsWithResponse request
+	ListExcusalsWithResponse(ctx context.Context, params *ListExcusalsParams, reqEditors ...RequestEditorFn) (*ListExcusalsResponse, error)
+
+	// CreateExcusalWithBodyWithResponse request with any body
+	CreateExcusalWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateExcusalResponse, error)
+
+	CreateExcusalWithResponse(ctx context.Context, body CreateExcusalJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateExcusalResponse, error)
+
+	// RevokeExcusalWithResponse request
+	RevokeExcusalWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RevokeExcusalResponse, error)
+
+	// FindAndTrackWithBodyWithResponse request with any body
+	FindAndTrackWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*FindAndTrackResponse, error)
+
+	FindAndTrackWithResponse(ctx context.Context, body FindAndTrackJSONRequestBody, reqEditors ...RequestEditorFn) (*FindAndTrackResponse, error)
+
+	// ScreenGuestWithBodyWithResponse request with any body
+	ScreenGuestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScreenGuestResponse, error)
+
+	ScreenGuestWithResponse(ctx context.Context, body ScreenGuestJSONRequestBody, reqEditors ...RequestEditorFn) (*ScreenGuestResponse, error)
+
+	// IssueHallPassWithBodyWithResponse request with any body
+	IssueHallPassWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueHallPassResponse, error)
+
+	IssueHallPassWithResponse(ctx context.Context, body IssueHallPassJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueHallPassResponse, error)
+
+	// ListActiveHallPassesWithResponse request
+	ListActiveHallPassesWithResponse(ctx context.Context, params *ListActiveHallPassesParams, reqEditors ...RequestEditorFn) (*ListActiveHallPassesResponse, error)
+
+	// GetHallPassReportWithResponse request
+	GetHallPassReportWithResponse(ctx context.Context, params *GetHallPassReportParams, reqEditors ...RequestEditorFn) (*GetHallPassReportResponse, error)
+
+	// ScanHallPassWithBodyWithResponse request with any body
+	ScanHallPassWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScanHallPassResponse, error)
+
+	ScanHallPassWithResponse(ctx context.Context, body ScanHallPassJSONRequestBody, reqEditors ...RequestEditorFn) (*ScanHallPassResponse, error)
+
+	// CloseHallPassWithResponse request
+	CloseHallPassWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*CloseHallPassResponse, error)
+
+	// UnenrollKioskWithResponse request
+	UnenrollKioskWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*UnenrollKioskResponse, error)
+
+	// EnrollKioskWithResponse request
+	EnrollKioskWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*EnrollKioskResponse, error)
+
+	// ListLateReasonsWithResponse request
+	ListLateReasonsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListLateReasonsResponse, error)
+
+	// CreateLessonWithBodyWithResponse request with any body
+	CreateLessonWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateLessonResponse, error)
+
+	CreateLessonWithResponse(ctx context.Context, body CreateLessonJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateLessonResponse, error)
+
+	// ListPeriodsWithResponse request
+	ListPeriodsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPeriodsResponse, error)
+
+	// CreatePeriodWithBodyWithResponse request with any body
+	CreatePeriodWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePeriodResponse, error)
+
+	CreatePeriodWithResponse(ctx context.Context, body CreatePeriodJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePeriodResponse, error)
+
+	// DeletePickupPersonWithResponse request
+	DeletePickupPersonWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeletePickupPersonResponse, error)
+
+	// ListRoomsWithResponse request
+	ListRoomsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListRoomsResponse, error)
+
+	// CreateRoomWithBodyWithResponse request with any body
+	CreateRoomWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateRoomResponse, error)
+
+	CreateRoomWithResponse(ctx context.Context, body CreateRoomJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateRoomResponse, error)
+
+	// GetRosterWithResponse request
+	GetRosterWithResponse(ctx context.Context, params *GetRosterParams, reqEditors ...RequestEditorFn) (*GetRosterResponse, error)
+
+	// BatchScansWithBodyWithResponse request with any body
+	BatchScansWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BatchScansResponse, error)
+
+	BatchScansWithResponse(ctx context.Context, body BatchScansJSONRequestBody, reqEditors ...RequestEditorFn) (*BatchScansResponse, error)
+
+	// SearchVisitorWithResponse request
+	SearchVisitorWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*SearchVisitorResponse, error)
+
+	// GetCurrentSiteWithResponse request
+	GetCurrentSiteWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCurrentSiteResponse, error)
+
+	// ListSitesWithResponse request
+	ListSitesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSitesResponse, error)
+
+	// CreateSiteWithBodyWithResponse request with any body
+	CreateSiteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSiteResponse, error)
+
+	CreateSiteWithResponse(ctx context.Context, body CreateSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSiteResponse, error)
+
+	// UpdateSiteWithBodyWithResponse request with any body
+	UpdateSiteWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateSiteResponse, error)
+
+	UpdateSiteWithResponse(ctx context.Context, id Id, body UpdateSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateSiteResponse, error)
+
+	// ListClosedDaysWithResponse request
+	ListClosedDaysWithResponse(ctx context.Context, id Id, params *ListClosedDaysParams, reqEditors ...RequestEditorFn) (*ListClosedDaysResponse, error)
+
+	// RemoveClosedDayWithResponse request
+	RemoveClosedDayWithResponse(ctx context.Context, id Id, date openapi_types.Date, reqEditors ...RequestEditorFn) (*RemoveClosedDayResponse, error)
+
+	// AddClosedDayWithBodyWithResponse request with any body
+	AddClosedDayWithBodyWithResponse(ctx context.Context, id Id, date openapi_types.Date, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddClosedDayResponse, error)
+
+	AddClosedDayWithResponse(ctx context.Context, id Id, date openapi_types.Date, body AddClosedDayJSONRequestBody, reqEditors ...RequestEditorFn) (*AddClosedDayResponse, error)
+
+	// GetTardyReportWithResponse request
+	GetTardyReportWithResponse(ctx context.Context, params *GetTardyReportParams, reqEditors ...RequestEditorFn) (*GetTardyReportResponse, error)
+
+	// GetTimesheetsWithResponse request
+	GetTimesheetsWithResponse(ctx context.Context, params *GetTimesheetsParams, reqEditors ...RequestEditorFn) (*GetTimesheetsResponse, error)
+
+	// ExportTimesheetsWithResponse request
+	ExportTimesheetsWithResponse(ctx context.Context, params *ExportTimesheetsParams, reqEditors ...RequestEditorFn) (*ExportTimesheetsResponse, error)
+
+	// ManualTrackWithBodyWithResponse request with any body
+	ManualTrackWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ManualTrackResponse, error)
+
+	ManualTrackWithResponse(ctx context.Context, body ManualTrackJSONRequestBody, reqEditors ...RequestEditorFn) (*ManualTrackResponse, error)
+
+	// SetLateReasonWithBodyWithResponse request with any body
+	SetLateReasonWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLateReasonResponse, error)
+
+	SetLateReasonWithResponse(ctx context.Context, id Id, body SetLateReasonJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLateReasonResponse, error)
+
+	// GetTardySlipWithResponse request
+	GetTardySlipWithResponse(ctx context.Context, id Id, params *GetTardySlipParams, reqEditors ...RequestEditorFn) (*GetTardySlipResponse, error)
+
+	// PrintTardySlipWithResponse request
+	PrintTardySlipWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*PrintTardySlipResponse, error)
+
+	// ListPickupPersonsWithResponse request
+	ListPickupPersonsWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ListPickupPersonsResponse, error)
+
+	// CreatePickupPersonWithBodyWithResponse request with any body
+	CreatePickupPersonWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePickupPersonResponse, error)
+
+	CreatePickupPersonWithResponse(ctx context.Context, id Id, body CreatePickupPersonJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePickupPersonResponse, error)
+
+	// ListWatchlistWithResponse request
+	ListWatchlistWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListWatchlistResponse, error)
+
+	// CreateWatchlistEntryWithBodyWithResponse request with any body
+	CreateWatchlistEntryWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateWatchlistEntryResponse, error)
+
+	CreateWatchlistEntryWithResponse(ctx context.Context, body CreateWatchlistEntryJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateWatchlistEntryResponse, error)
+
+	// ListWatchlistHitsWithResponse request
+	ListWatchlistHitsWithResponse(ctx context.Context, params *ListWatchlistHitsParams, reqEditors ...RequestEditorFn) (*ListWatchlistHitsResponse, error)
+
+	// DeleteWatchlistEntryWithResponse request
+	DeleteWatchlistEntryWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeleteWatchlistEntryResponse, error)
+
+	// DismissalPageWithResponse request
+	DismissalPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DismissalPageResponse, error)
+
+	// EvacuationPageWithResponse request
+	EvacuationPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*EvacuationPageResponse, error)
+
+	// KioskPageWithResponse request
+	KioskPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*KioskPageResponse, error)
+
+	// ManualPageWithResponse request
+	ManualPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ManualPageResponse, error)
+
+	// PingWithResponse request
+	PingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PingResponse, error)
+}
+
+type ReaderPageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ReaderPageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReaderPageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddKeyPageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r AddKeyPageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddKeyPageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DocsPageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DocsPageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DocsPageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOpenAPIResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	YAML200      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOpenAPIResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOpenAPIResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Message
+	JSON404      *NotFoundResponse
+	JSON409      *Error
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AddKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTenantsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Tenant
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTenantsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTenantsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateTenantResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ProvisionResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateTenantResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateTenantResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateTenantResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Tenant
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateTenantResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateTenantResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UploadTenantLogoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Tenant
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UploadTenantLogoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UploadTenantLogoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RotateTenantTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		ApiToken string `json:"api_token"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r RotateTenantTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RotateTenantTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRegisterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RegisterResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRegisterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRegisterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdjustAttendanceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RegisterResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AdjustAttendanceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdjustAttendanceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ScanAttendanceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AttendanceScanResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ScanAttendanceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ScanAttendanceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTeacherLessonsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Lesson
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTeacherLessonsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTeacherLessonsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBrandingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Branding
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBrandingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBrandingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLogoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLogoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLogoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListBusRoutesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]BusRoute
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListBusRoutesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListBusRoutesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateBusRouteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *BusRouteResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateBusRouteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateBusRouteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BusArrivedAtDeviceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *BusArrivalResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r BusArrivedAtDeviceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BusArrivedAtDeviceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteBusRouteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Message
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteBusRouteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteBusRouteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBusRouteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BusRouteResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBusRouteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBusRouteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BusArrivedResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *BusArrivalResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r BusArrivedResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BusArrivedResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListMissingBusRidersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BusRidersResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListMissingBusRidersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListMissingBusRidersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListBusRidersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BusRidersResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListBusRidersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListBusRidersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AssignBusRiderResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BusRider
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AssignBusRiderResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AssignBusRiderResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveBusRiderResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Message
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveBusRiderResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveBusRiderResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateClassResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *SchoolClass
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateClassResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateClassResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetClassMembersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ClassMembersResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetClassMembersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetClassMembersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetClassMembersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ClassMembersResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SetClassMembersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetClassMembersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListConsequenceRulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ConsequenceRule
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListConsequenceRulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListConsequenceRulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateConsequenceRuleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ConsequenceRule
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateConsequenceRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateConsequenceRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteConsequenceRuleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Message
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteConsequenceRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteConsequenceRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListConsequencesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Consequence
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListConsequencesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListConsequencesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResolveConsequenceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Consequence
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ResolveConsequenceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResolveConsequenceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ScanDetentionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DetentionAssignmentResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ScanDetentionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ScanDetentionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListDetentionSessionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]DetentionSession
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListDetentionSessionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListDetentionSessionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateDetentionSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *DetentionSession
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateDetentionSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateDetentionSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDetentionSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DetentionSessionResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDetentionSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDetentionSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AssignDetentionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *DetentionAssignmentResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AssignDetentionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AssignDetentionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type MarkDetentionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DetentionAssignmentResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r MarkDetentionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r MarkDetentionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CloseDetentionSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DetentionSessionResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CloseDetentionSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CloseDetentionSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type FillDetentionSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]DetentionAssignment
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r FillDetentionSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r FillDetentionSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListDevicesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Device
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListDevicesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListDevicesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SaveDeviceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Device
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SaveDeviceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SaveDeviceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListDismissalsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Dismissal
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListDismissalsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListDismissalsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DismissResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Dismissal
+	JSON403      *Error
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DismissResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DismissResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StartEvacuationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *EvacuationReportResponse
+	JSON409      *Error
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r StartEvacuationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StartEvacuationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetActiveEvacuationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EvacuationReportResponse
+	JSON404      *NotFoundResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetActiveEvacuationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetActiveEvacuationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEvacuationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EvacuationReportResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEvacuationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEvacuationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CheckEvacueeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EvacuationReportResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CheckEvacueeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CheckEvacueeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EndEvacuationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EvacuationReportResponse
+	JSON409      *Error
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r EndEvacuationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EndEvacuationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportEvacuationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportEvacuationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportEvacuationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListExcusalsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Excusal
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListExcusalsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListExcusalsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateExcusalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ExcusalResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateExcusalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateExcusalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeExcusalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Excusal
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeExcusalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeExcusalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type FindAndTrackResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TrackResponse
+	JSON404      *NotFoundResponse
+	JSON409      *Error
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r FindAndTrackResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r FindAndTrackResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ScreenGuestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Status string `json:"status"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ScreenGuestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ScreenGuestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type IssueHallPassResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *HallPassResponse
+	JSON409      *Error
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r IssueHallPassResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r IssueHallPassResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListActiveHallPassesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]HallPass
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListActiveHallPassesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListActiveHallPassesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHallPassReportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HallPassReport
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHallPassReportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHallPassReportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ScanHallPassResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HallPassResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ScanHallPassResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ScanHallPassResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CloseHallPassResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HallPassResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CloseHallPassResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CloseHallPassResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnenrollKioskResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UnenrollKioskResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnenrollKioskResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EnrollKioskResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r EnrollKioskResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EnrollKioskResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListLateReasonsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]string
+}
+
+// Status returns HTTPResponse.Status
+func (r ListLateReasonsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListLateReasonsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateLessonResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Lesson
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateLessonResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateLessonResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListPeriodsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Period
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPeriodsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPeriodsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreatePeriodResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Period
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreatePeriodResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreatePeriodResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeletePickupPersonResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeletePickupPersonResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeletePickupPersonResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListRoomsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Room
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListRoomsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListRoomsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateRoomResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Room
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateRoomResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateRoomResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRosterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RosterResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRosterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRosterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BatchScansResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BatchResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r BatchScansResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BatchScansResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SearchVisitorResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *VisitDetails
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SearchVisitorResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SearchVisitorResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCurrentSiteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SiteResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCurrentSiteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCurrentSiteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSitesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Site
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSitesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSitesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateSiteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *SiteResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateSiteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateSiteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateSiteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SiteResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateSiteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateSiteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListClosedDaysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ClosedDay
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListClosedDaysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListClosedDaysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveClosedDayResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveClosedDayResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveClosedDayResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddClosedDayResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ClosedDay
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AddClosedDayResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddClosedDayResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTardyReportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TardyReport
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTardyReportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTardyReportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTimesheetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TimesheetResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTimesheetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTimesheetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportTimesheetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportTimesheetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportTimesheetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ManualTrackResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ManualTrack
+	JSON404      *NotFoundResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ManualTrackResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ManualTrackResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetLateReasonResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReasonResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SetLateReasonResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetLateReasonResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTardySlipResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTardySlipResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTardySlipResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PrintTardySlipResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Message
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PrintTardySlipResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PrintTardySlipResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListPickupPersonsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]PickupPerson
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPickupPersonsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPickupPersonsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreatePickupPersonResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *PickupPerson
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreatePickupPersonResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreatePickupPersonResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListWatchlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]WatchlistEntry
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListWatchlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListWatchlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateWatchlistEntryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *WatchlistEntry
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateWatchlistEntryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateWatchlistEntryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListWatchlistHitsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]WatchlistHit
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListWatchlistHitsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListWatchlistHitsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteWatchlistEntryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Message
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteWatchlistEntryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteWatchlistEntryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DismissalPageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DismissalPageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DismissalPageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EvacuationPageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r EvacuationPageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EvacuationPageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type KioskPageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r KioskPageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r KioskPageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ManualPageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ManualPageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ManualPageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Message
+}
+
+// Status returns HTTPResponse.Status
+func (r PingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ReaderPageWithResponse request returning *ReaderPageResponse
+func (c *ClientWithResponses) ReaderPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReaderPageResponse, error) {
+	rsp, err := c.ReaderPage(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReaderPageResponse(rsp)
+}
+
+// AddKeyPageWithResponse request returning *AddKeyPageResponse
+func (c *ClientWithResponses) AddKeyPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*AddKeyPageResponse, error) {
+	rsp, err := c.AddKeyPage(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddKeyPageResponse(rsp)
+}
+
+// DocsPageWithResponse request returning *DocsPageResponse
+func (c *ClientWithResponses) DocsPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DocsPageResponse, error) {
+	rsp, err := c.DocsPage(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDocsPageResponse(rsp)
+}
+
+// GetOpenAPIWithResponse request returning *GetOpenAPIResponse
+func (c *ClientWithResponses) GetOpenAPIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPIResponse, error) {
+	rsp, err := c.GetOpenAPI(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOpenAPIResponse(rsp)
+}
+
+// AddKeyWithBodyWithResponse request with arbitrary body returning *AddKeyResponse
+func (c *ClientWithResponses) AddKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddKeyResponse, error) {
+	rsp, err := c.AddKeyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddKeyResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddKeyWithResponse(ctx context.Context, body AddKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*AddKeyResponse, error) {
+	rsp, err := c.AddKey(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddKeyResponse(rsp)
+}
+
+// ListTenantsWithResponse request returning *ListTenantsResponse
+func (c *ClientWithResponses) ListTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTenantsResponse, error) {
+	rsp, err := c.ListTenants(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTenantsResponse(rsp)
+}
+
+// CreateTenantWithBodyWithResponse request with arbitrary body returning *CreateTenantResponse
+func (c *ClientWithResponses) CreateTenantWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTenantResponse, error) {
+	rsp, err := c.CreateTenantWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTenantResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateTenantWithResponse(ctx context.Context, body CreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTenantResponse, error) {
+	rsp, err := c.CreateTenant(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTenantResponse(rsp)
+}
+
+// UpdateTenantWithBodyWithResponse request with arbitrary body returning *UpdateTenantResponse
+func (c *ClientWithResponses) UpdateTenantWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTenantResponse, error) {
+	rsp, err := c.UpdateTenantWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTenantResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateTenantWithResponse(ctx context.Context, id Id, body UpdateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTenantResponse, error) {
+	rsp, err := c.UpdateTenant(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTenantResponse(rsp)
+}
+
+// UploadTenantLogoWithBodyWithResponse request with arbitrary body returning *UploadTenantLogoResponse
+func (c *ClientWithResponses) UploadTenantLogoWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadTenantLogoResponse, error) {
+	rsp, err := c.UploadTenantLogoWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUploadTenantLogoResponse(rsp)
+}
+
+// RotateTenantTokenWithResponse request returning *RotateTenantTokenResponse
+func (c *ClientWithResponses) RotateTenantTokenWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RotateTenantTokenResponse, error) {
+	rsp, err := c.RotateTenantToken(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRotateTenantTokenResponse(rsp)
+}
+
+// GetRegisterWithResponse request returning *GetRegisterResponse
+func (c *ClientWithResponses) GetRegisterWithResponse(ctx context.Context, id Id, params *GetRegisterParams, reqEditors ...RequestEditorFn) (*GetRegisterResponse, error) {
+	rsp, err := c.GetRegister(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRegisterResponse(rsp)
+}
+
+// AdjustAttendanceWithBodyWithResponse request with arbitrary body returning *AdjustAttendanceResponse
+func (c *ClientWithResponses) AdjustAttendanceWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AdjustAttendanceResponse, error) {
+	rsp, err := c.AdjustAttendanceWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdjustAttendanceResponse(rsp)
+}
+
+func (c *ClientWithResponses) AdjustAttendanceWithResponse(ctx context.Context, id Id, body AdjustAttendanceJSONRequestBody, reqEditors ...RequestEditorFn) (*AdjustAttendanceResponse, error) {
+	rsp, err := c.AdjustAttendance(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdjustAttendanceResponse(rsp)
+}
+
+// ScanAttendanceWithBodyWithResponse request with arbitrary body returning *ScanAttendanceResponse
+func (c *ClientWithResponses) ScanAttendanceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScanAttendanceResponse, error) {
+	rsp, err := c.ScanAttendanceWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScanAttendanceResponse(rsp)
+}
+
+func (c *ClientWithResponses) ScanAttendanceWithResponse(ctx context.Context, body ScanAttendanceJSONRequestBody, reqEditors ...RequestEditorFn) (*ScanAttendanceResponse, error) {
+	rsp, err := c.ScanAttendance(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScanAttendanceResponse(rsp)
+}
+
+// GetTeacherLessonsWithResponse request returning *GetTeacherLessonsResponse
+func (c *ClientWithResponses) GetTeacherLessonsWithResponse(ctx context.Context, id Id, params *GetTeacherLessonsParams, reqEditors ...RequestEditorFn) (*GetTeacherLessonsResponse, error) {
+	rsp, err := c.GetTeacherLessons(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTeacherLessonsResponse(rsp)
+}
+
+// GetBrandingWithResponse request returning *GetBrandingResponse
+func (c *ClientWithResponses) GetBrandingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetBrandingResponse, error) {
+	rsp, err := c.GetBranding(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBrandingResponse(rsp)
+}
+
+// GetLogoWithResponse request returning *GetLogoResponse
+func (c *ClientWithResponses) GetLogoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogoResponse, error) {
+	rsp, err := c.GetLogo(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLogoResponse(rsp)
+}
+
+// ListBusRoutesWithResponse request returning *ListBusRoutesResponse
+func (c *ClientWithResponses) ListBusRoutesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListBusRoutesResponse, error) {
+	rsp, err := c.ListBusRoutes(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListBusRoutesResponse(rsp)
+}
+
+// CreateBusRouteWithBodyWithResponse request with arbitrary body returning *CreateBusRouteResponse
+func (c *ClientWithResponses) CreateBusRouteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBusRouteResponse, error) {
+	rsp, err := c.CreateBusRouteWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBusRouteResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateBusRouteWithResponse(ctx context.Context, body CreateBusRouteJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBusRouteResponse, error) {
+	rsp, err := c.CreateBusRoute(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBusRouteResponse(rsp)
+}
+
+// BusArrivedAtDeviceWithBodyWithResponse request with arbitrary body returning *BusArrivedAtDeviceResponse
+func (c *ClientWithResponses) BusArrivedAtDeviceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BusArrivedAtDeviceResponse, error) {
+	rsp, err := c.BusArrivedAtDeviceWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBusArrivedAtDeviceResponse(rsp)
+}
+
+func (c *ClientWithResponses) BusArrivedAtDeviceWithResponse(ctx context.Context, body BusArrivedAtDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*BusArrivedAtDeviceResponse, error) {
+	rsp, err := c.BusArrivedAtDevice(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBusArrivedAtDeviceResponse(rsp)
+}
+
+// DeleteBusRouteWithResponse request returning *DeleteBusRouteResponse
+func (c *ClientWithResponses) DeleteBusRouteWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeleteBusRouteResponse, error) {
+	rsp, err := c.DeleteBusRoute(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteBusRouteResponse(rsp)
+}
+
+// GetBusRouteWithResponse request returning *GetBusRouteResponse
+func (c *ClientWithResponses) GetBusRouteWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetBusRouteResponse, error) {
+	rsp, err := c.GetBusRoute(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBusRouteResponse(rsp)
+}
+
+// BusArrivedWithResponse request returning *BusArrivedResponse
+func (c *ClientWithResponses) BusArrivedWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*BusArrivedResponse, error) {
+	rsp, err := c.BusArrived(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBusArrivedResponse(rsp)
+}
+
+// ListMissingBusRidersWithResponse request returning *ListMissingBusRidersResponse
+func (c *ClientWithResponses) ListMissingBusRidersWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ListMissingBusRidersResponse, error) {
+	rsp, err := c.ListMissingBusRiders(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListMissingBusRidersResponse(rsp)
+}
+
+// ListBusRidersWithResponse request returning *ListBusRidersResponse
+func (c *ClientWithResponses) ListBusRidersWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ListBusRidersResponse, error) {
+	rsp, err := c.ListBusRiders(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListBusRidersResponse(rsp)
+}
+
+// AssignBusRiderWithBodyWithResponse request with arbitrary body returning *AssignBusRiderResponse
+func (c *ClientWithResponses) AssignBusRiderWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AssignBusRiderResponse, error) {
+	rsp, err := c.AssignBusRiderWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAssignBusRiderResponse(rsp)
+}
+
+func (c *ClientWithResponses) AssignBusRiderWithResponse(ctx context.Context, id Id, body AssignBusRiderJSONRequestBody, reqEditors ...RequestEditorFn) (*AssignBusRiderResponse, error) {
+	rsp, err := c.AssignBusRider(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAssignBusRiderResponse(rsp)
+}
+
+// RemoveBusRiderWithResponse request returning *RemoveBusRiderResponse
+func (c *ClientWithResponses) RemoveBusRiderWithResponse(ctx context.Context, id Id, visitorId VisitorId, reqEditors ...RequestEditorFn) (*RemoveBusRiderResponse, error) {
+	rsp, err := c.RemoveBusRider(ctx, id, visitorId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveBusRiderResponse(rsp)
+}
+
+// CreateClassWithBodyWithResponse request with arbitrary body returning *CreateClassResponse
+func (c *ClientWithResponses) CreateClassWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateClassResponse, error) {
+	rsp, err := c.CreateClassWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateClassResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateClassWithResponse(ctx context.Context, body CreateClassJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateClassResponse, error) {
+	rsp, err := c.CreateClass(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateClassResponse(rsp)
+}
+
+// GetClassMembersWithResponse request returning *GetClassMembersResponse
+func (c *ClientWithResponses) GetClassMembersWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetClassMembersResponse, error) {
+	rsp, err := c.GetClassMembers(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetClassMembersResponse(rsp)
+}
+
+// SetClassMembersWithBodyWithResponse request with arbitrary body returning *SetClassMembersResponse
+func (c *ClientWithResponses) SetClassMembersWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetClassMembersResponse, error) {
+	rsp, err := c.SetClassMembersWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetClassMembersResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetClassMembersWithResponse(ctx context.Context, id Id, body SetClassMembersJSONRequestBody, reqEditors ...RequestEditorFn) (*SetClassMembersResponse, error) {
+	rsp, err := c.SetClassMembers(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetClassMembersResponse(rsp)
+}
+
+// ListConsequenceRulesWithResponse request returning *ListConsequenceRulesResponse
+func (c *ClientWithResponses) ListConsequenceRulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListConsequenceRulesResponse, error) {
+	rsp, err := c.ListConsequenceRules(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListConsequenceRulesResponse(rsp)
+}
+
+// CreateConsequenceRuleWithBodyWithResponse request with arbitrary body returning *CreateConsequenceRuleResponse
+func (c *ClientWithResponses) CreateConsequenceRuleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateConsequenceRuleResponse, error) {
+	rsp, err := c.CreateConsequenceRuleWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateConsequenceRuleResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateConsequenceRuleWithResponse(ctx context.Context, body CreateConsequenceRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateConsequenceRuleResponse, error) {
+	rsp, err := c.CreateConsequenceRule(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateConsequenceRuleResponse(rsp)
+}
+
+// DeleteConsequenceRuleWithResponse request returning *DeleteConsequenceRuleResponse
+func (c *ClientWithResponses) DeleteConsequenceRuleWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeleteConsequenceRuleResponse, error) {
+	rsp, err := c.DeleteConsequenceRule(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteConsequenceRuleResponse(rsp)
+}
+
+// ListConsequencesWithResponse request returning *ListConsequencesResponse
+func (c *ClientWithResponses) ListConsequencesWithResponse(ctx context.Context, params *ListConsequencesParams, reqEditors ...RequestEditorFn) (*ListConsequencesResponse, error) {
+	rsp, err := c.ListConsequences(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListConsequencesResponse(rsp)
+}
+
+// ResolveConsequenceWithBodyWithResponse request with arbitrary body returning *ResolveConsequenceResponse
+func (c *ClientWithResponses) ResolveConsequenceWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ResolveConsequenceResponse, error) {
+	rsp, err := c.ResolveConsequenceWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResolveConsequenceResponse(rsp)
+}
+
+func (c *ClientWithResponses) ResolveConsequenceWithResponse(ctx context.Context, id Id, body ResolveConsequenceJSONRequestBody, reqEditors ...RequestEditorFn) (*ResolveConsequenceResponse, error) {
+	rsp, err := c.ResolveConsequence(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResolveConsequenceResponse(rsp)
+}
+
+// ScanDetentionWithBodyWithResponse request with arbitrary body returning *ScanDetentionResponse
+func (c *ClientWithResponses) ScanDetentionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScanDetentionResponse, error) {
+	rsp, err := c.ScanDetentionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScanDetentionResponse(rsp)
+}
+
+func (c *ClientWithResponses) ScanDetentionWithResponse(ctx context.Context, body ScanDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*ScanDetentionResponse, error) {
+	rsp, err := c.ScanDetention(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScanDetentionResponse(rsp)
+}
+
+// ListDetentionSessionsWithResponse request returning *ListDetentionSessionsResponse
+func (c *ClientWithResponses) ListDetentionSessionsWithResponse(ctx context.Context, params *ListDetentionSessionsParams, reqEditors ...RequestEditorFn) (*ListDetentionSessionsResponse, error) {
+	rsp, err := c.ListDetentionSessions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListDetentionSessionsResponse(rsp)
+}
+
+// CreateDetentionSessionWithBodyWithResponse request with arbitrary body returning *CreateDetentionSessionResponse
+func (c *ClientWithResponses) CreateDetentionSessionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateDetentionSessionResponse, error) {
+	rsp, err := c.CreateDetentionSessionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateDetentionSessionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateDetentionSessionWithResponse(ctx context.Context, body CreateDetentionSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateDetentionSessionResponse, error) {
+	rsp, err := c.CreateDetentionSession(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateDetentionSessionResponse(rsp)
+}
+
+// GetDetentionSessionWithResponse request returning *GetDetentionSessionResponse
+func (c *ClientWithResponses) GetDetentionSessionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetDetentionSessionResponse, error) {
+	rsp, err := c.GetDetentionSession(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDetentionSessionResponse(rsp)
+}
+
+// AssignDetentionWithBodyWithResponse request with arbitrary body returning *AssignDetentionResponse
+func (c *ClientWithResponses) AssignDetentionWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AssignDetentionResponse, error) {
+	rsp, err := c.AssignDetentionWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAssignDetentionResponse(rsp)
+}
+
+func (c *ClientWithResponses) AssignDetentionWithResponse(ctx context.Context, id Id, body AssignDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*AssignDetentionResponse, error) {
+	rsp, err := c.AssignDetention(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAssignDetentionResponse(rsp)
+}
+
+// MarkDetentionWithBodyWithResponse request with arbitrary body returning *MarkDetentionResponse
+func (c *ClientWithResponses) MarkDetentionWithBodyWithResponse(ctx context.Context, id Id, visitorId VisitorId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MarkDetentionResponse, error) {
+	rsp, err := c.MarkDetentionWithBody(ctx, id, visitorId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMarkDetentionResponse(rsp)
+}
+
+func (c *ClientWithResponses) MarkDetentionWithResponse(ctx context.Context, id Id, visitorId VisitorId, body MarkDetentionJSONRequestBody, reqEditors ...RequestEditorFn) (*MarkDetentionResponse, error) {
+	rsp, err := c.MarkDetention(ctx, id, visitorId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMarkDetentionResponse(rsp)
+}
+
+// CloseDetentionSessionWithResponse request returning *CloseDetentionSessionResponse
+func (c *ClientWithResponses) CloseDetentionSessionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*CloseDetentionSessionResponse, error) {
+	rsp, err := c.CloseDetentionSession(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCloseDetentionSessionResponse(rsp)
+}
+
+// FillDetentionSessionWithResponse request returning *FillDetentionSessionResponse
+func (c *ClientWithResponses) FillDetentionSessionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*FillDetentionSessionResponse, error) {
+	rsp, err := c.FillDetentionSession(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFillDetentionSessionResponse(rsp)
+}
+
+// ListDevicesWithResponse request returning *ListDevicesResponse
+func (c *ClientWithResponses) ListDevicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDevicesResponse, error) {
+	rsp, err := c.ListDevices(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListDevicesResponse(rsp)
+}
+
+// SaveDeviceWithBodyWithResponse request with arbitrary body returning *SaveDeviceResponse
+func (c *ClientWithResponses) SaveDeviceWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SaveDeviceResponse, error) {
+	rsp, err := c.SaveDeviceWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSaveDeviceResponse(rsp)
+}
+
+func (c *ClientWithResponses) SaveDeviceWithResponse(ctx context.Context, id string, body SaveDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*SaveDeviceResponse, error) {
+	rsp, err := c.SaveDevice(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSaveDeviceResponse(rsp)
+}
+
+// ListDismissalsWithResponse request returning *ListDismissalsResponse
+func (c *ClientWithResponses) ListDismissalsWithResponse(ctx context.Context, params *ListDismissalsParams, reqEditors ...RequestEditorFn) (*ListDismissalsResponse, error) {
+	rsp, err := c.ListDismissals(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListDismissalsResponse(rsp)
+}
+
+// DismissWithBodyWithResponse request with arbitrary body returning *DismissResponse
+func (c *ClientWithResponses) DismissWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DismissResponse, error) {
+	rsp, err := c.DismissWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDismissResponse(rsp)
+}
+
+func (c *ClientWithResponses) DismissWithResponse(ctx context.Context, body DismissJSONRequestBody, reqEditors ...RequestEditorFn) (*DismissResponse, error) {
+	rsp, err := c.Dismiss(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDismissResponse(rsp)
+}
+
+// StartEvacuationWithBodyWithResponse request with arbitrary body returning *StartEvacuationResponse
+func (c *ClientWithResponses) StartEvacuationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartEvacuationResponse, error) {
+	rsp, err := c.StartEvacuationWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartEvacuationResponse(rsp)
+}
+
+func (c *ClientWithResponses) StartEvacuationWithResponse(ctx context.Context, body StartEvacuationJSONRequestBody, reqEditors ...RequestEditorFn) (*StartEvacuationResponse, error) {
+	rsp, err := c.StartEvacuation(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartEvacuationResponse(rsp)
+}
+
+// GetActiveEvacuationWithResponse request returning *GetActiveEvacuationResponse
+func (c *ClientWithResponses) GetActiveEvacuationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetActiveEvacuationResponse, error) {
+	rsp, err := c.GetActiveEvacuation(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetActiveEvacuationResponse(rsp)
+}
+
+// GetEvacuationWithResponse request returning *GetEvacuationResponse
+func (c *ClientWithResponses) GetEvacuationWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetEvacuationResponse, error) {
+	rsp, err := c.GetEvacuation(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEvacuationResponse(rsp)
+}
+
+// CheckEvacueeWithBodyWithResponse request with arbitrary body returning *CheckEvacueeResponse
+func (c *ClientWithResponses) CheckEvacueeWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CheckEvacueeResponse, error) {
+	rsp, err := c.CheckEvacueeWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCheckEvacueeResponse(rsp)
+}
+
+func (c *ClientWithResponses) CheckEvacueeWithResponse(ctx context.Context, id Id, body CheckEvacueeJSONRequestBody, reqEditors ...RequestEditorFn) (*CheckEvacueeResponse, error) {
+	rsp, err := c.CheckEvacuee(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCheckEvacueeResponse(rsp)
+}
+
+// EndEvacuationWithResponse request returning *EndEvacuationResponse
+func (c *ClientWithResponses) EndEvacuationWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*EndEvacuationResponse, error) {
+	rsp, err := c.EndEvacuation(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEndEvacuationResponse(rsp)
+}
+
+// ExportEvacuationWithResponse request returning *ExportEvacuationResponse
+func (c *ClientWithResponses) ExportEvacuationWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ExportEvacuationResponse, error) {
+	rsp, err := c.ExportEvacuation(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportEvacuationResponse(rsp)
+}
+
+// ListExcusalsWithResponse request returning *ListExcusalsResponse
+func (c *ClientWithResponses) ListExcusalsWithResponse(ctx context.Context, params *ListExcusalsParams, reqEditors ...RequestEditorFn) (*ListExcusalsResponse, error) {
+	rsp, err := c.ListExcusals(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListExcusalsResponse(rsp)
+}
+
+// CreateExcusalWithBodyWithResponse request with arbitrary body returning *CreateExcusalResponse
+func (c *ClientWithResponses) CreateExcusalWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateExcusalResponse, error) {
+	rsp, err := c.CreateExcusalWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateExcusalResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateExcusalWithResponse(ctx context.Context, body CreateExcusalJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateExcusalResponse, error) {
+	rsp, err := c.CreateExcusal(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateExcusalResponse(rsp)
+}
+
+// RevokeExcusalWithResponse request returning *RevokeExcusalResponse
+func (c *ClientWithResponses) RevokeExcusalWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RevokeExcusalResponse, error) {
+	rsp, err := c.RevokeExcusal(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeExcusalResponse(rsp)
+}
+
+// FindAndTrackWithBodyWithResponse request with arbitrary body returning *FindAndTrackResponse
+func (c *ClientWithResponses) FindAndTrackWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*FindAndTrackResponse, error) {
+	rsp, err := c.FindAndTrackWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFindAndTrackResponse(rsp)
+}
+
+func (c *ClientWithResponses) FindAndTrackWithResponse(ctx context.Context, body FindAndTrackJSONRequestBody, reqEditors ...RequestEditorFn) (*FindAndTrackResponse, error) {
+	rsp, err := c.FindAndTrack(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFindAndTrackResponse(rsp)
+}
+
+// ScreenGuestWithBodyWithResponse request with arbitrary body returning *ScreenGuestResponse
+func (c *ClientWithResponses) ScreenGuestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScreenGuestResponse, error) {
+	rsp, err := c.ScreenGuestWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScreenGuestResponse(rsp)
+}
+
+func (c *ClientWithResponses) ScreenGuestWithResponse(ctx context.Context, body ScreenGuestJSONRequestBody, reqEditors ...RequestEditorFn) (*ScreenGuestResponse, error) {
+	rsp, err := c.ScreenGuest(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScreenGuestResponse(rsp)
+}
+
+// IssueHallPassWithBodyWithResponse request with arbitrary body returning *IssueHallPassResponse
+func (c *ClientWithResponses) IssueHallPassWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueHallPassResponse, error) {
+	rsp, err := c.IssueHallPassWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssueHallPassResponse(rsp)
+}
+
+func (c *ClientWithResponses) IssueHallPassWithResponse(ctx context.Context, body IssueHallPassJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueHallPassResponse, error) {
+	rsp, err := c.IssueHallPass(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssueHallPassResponse(rsp)
+}
+
+// ListActiveHallPassesWithResponse request returning *ListActiveHallPassesResponse
+func (c *ClientWithResponses) ListActiveHallPassesWithResponse(ctx context.Context, params *ListActiveHallPassesParams, reqEditors ...RequestEditorFn) (*ListActiveHallPassesResponse, error) {
+	rsp, err := c.ListActiveHallPasses(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListActiveHallPassesResponse(rsp)
+}
+
+// GetHallPassReportWithResponse request returning *GetHallPassReportResponse
+func (c *ClientWithResponses) GetHallPassReportWithResponse(ctx context.Context, params *GetHallPassReportParams, reqEditors ...RequestEditorFn) (*GetHallPassReportResponse, error) {
+	rsp, err := c.GetHallPassReport(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHallPassReportResponse(rsp)
+}
+
+// ScanHallPassWithBodyWithResponse request with arbitrary body returning *ScanHallPassResponse
+func (c *ClientWithResponses) ScanHallPassWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScanHallPassResponse, error) {
+	rsp, err := c.ScanHallPassWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScanHallPassResponse(rsp)
+}
+
+func (c *ClientWithResponses) ScanHallPassWithResponse(ctx context.Context, body ScanHallPassJSONRequestBody, reqEditors ...RequestEditorFn) (*ScanHallPassResponse, error) {
+	rsp, err := c.ScanHallPass(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScanHallPassResponse(rsp)
+}
+
+// CloseHallPassWithResponse request returning *CloseHallPassResponse
+func (c *ClientWithResponses) CloseHallPassWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*CloseHallPassResponse, error) {
+	rsp, err := c.CloseHallPass(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCloseHallPassResponse(rsp)
+}
+
+// UnenrollKioskWithResponse request returning *UnenrollKioskResponse
+func (c *ClientWithResponses) UnenrollKioskWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*UnenrollKioskResponse, error) {
+	rsp, err := c.UnenrollKiosk(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnenrollKioskResponse(rsp)
+}
+
+// EnrollKioskWithResponse request returning *EnrollKioskResponse
+func (c *ClientWithResponses) EnrollKioskWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*EnrollKioskResponse, error) {
+	rsp, err := c.EnrollKiosk(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEnrollKioskResponse(rsp)
+}
+
+// ListLateReasonsWithResponse request returning *ListLateReasonsResponse
+func (c *ClientWithResponses) ListLateReasonsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListLateReasonsResponse, error) {
+	rsp, err := c.ListLateReasons(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListLateReasonsResponse(rsp)
+}
+
+// CreateLessonWithBodyWithResponse request with arbitrary body returning *CreateLessonResponse
+func (c *ClientWithResponses) CreateLessonWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateLessonResponse, error) {
+	rsp, err := c.CreateLessonWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateLessonResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateLessonWithResponse(ctx context.Context, body CreateLessonJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateLessonResponse, error) {
+	rsp, err := c.CreateLesson(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateLessonResponse(rsp)
+}
+
+// ListPeriodsWithResponse request returning *ListPeriodsResponse
+func (c *ClientWithResponses) ListPeriodsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPeriodsResponse, error) {
+	rsp, err := c.ListPeriods(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPeriodsResponse(rsp)
+}
+
+// CreatePeriodWithBodyWithResponse request with arbitrary body returning *CreatePeriodResponse
+func (c *ClientWithResponses) CreatePeriodWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePeriodResponse, error) {
+	rsp, err := c.CreatePeriodWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePeriodResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreatePeriodWithResponse(ctx context.Context, body CreatePeriodJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePeriodResponse, error) {
+	rsp, err := c.CreatePeriod(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePeriodResponse(rsp)
+}
+
+// DeletePickupPersonWithResponse request returning *DeletePickupPersonResponse
+func (c *ClientWithResponses) DeletePickupPersonWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeletePickupPersonResponse, error) {
+	rsp, err := c.DeletePickupPerson(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeletePickupPersonResponse(rsp)
+}
+
+// ListRoomsWithResponse request returning *ListRoomsResponse
+func (c *ClientWithResponses) ListRoomsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListRoomsResponse, error) {
+	rsp, err := c.ListRooms(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListRoomsResponse(rsp)
+}
+
+// CreateRoomWithBodyWithResponse request with arbitrary body returning *CreateRoomResponse
+func (c *ClientWithResponses) CreateRoomWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateRoomResponse, error) {
+	rsp, err := c.CreateRoomWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateRoomResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateRoomWithResponse(ctx context.Context, body CreateRoomJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateRoomResponse, error) {
+	rsp, err := c.CreateRoom(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateRoomResponse(rsp)
+}
+
+// GetRosterWithResponse request returning *GetRosterResponse
+func (c *ClientWithResponses) GetRosterWithResponse(ctx context.Context, params *GetRosterParams, reqEditors ...RequestEditorFn) (*GetRosterResponse, error) {
+	rsp, err := c.GetRoster(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRosterResponse(rsp)
+}
+
+// BatchScansWithBodyWithResponse request with arbitrary body returning *BatchScansResponse
+func (c *ClientWithResponses) BatchScansWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BatchScansResponse, error) {
+	rsp, err := c.BatchScansWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBatchScansResponse(rsp)
+}
+
+func (c *ClientWithResponses) BatchScansWithResponse(ctx context.Context, body BatchScansJSONRequestBody, reqEditors ...RequestEditorFn) (*BatchScansResponse, error) {
+	rsp, err := c.BatchScans(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBatchScansResponse(rsp)
+}
+
+// SearchVisitorWithResponse request returning *SearchVisitorResponse
+func (c *ClientWithResponses) SearchVisitorWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*SearchVisitorResponse, error) {
+	rsp, err := c.SearchVisitor(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchVisitorResponse(rsp)
+}
+
+// GetCurrentSiteWithResponse request returning *GetCurrentSiteResponse
+func (c *ClientWithResponses) GetCurrentSiteWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCurrentSiteResponse, error) {
+	rsp, err := c.GetCurrentSite(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCurrentSiteResponse(rsp)
+}
+
+// ListSitesWithResponse request returning *ListSitesResponse
+func (c *ClientWithResponses) ListSitesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSitesResponse, error) {
+	rsp, err := c.ListSites(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSitesResponse(rsp)
+}
+
+// CreateSiteWithBodyWithResponse request with arbitrary body returning *CreateSiteResponse
+func (c *ClientWithResponses) CreateSiteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSiteResponse, error) {
+	rsp, err := c.CreateSiteWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSiteResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateSiteWithResponse(ctx context.Context, body CreateSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSiteResponse, error) {
+	rsp, err := c.CreateSite(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSiteResponse(rsp)
+}
+
+// UpdateSiteWithBodyWithResponse request with arbitrary body returning *UpdateSiteResponse
+func (c *ClientWithResponses) UpdateSiteWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateSiteResponse, error) {
+	rsp, err := c.UpdateSiteWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateSiteResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateSiteWithResponse(ctx context.Context, id Id, body UpdateSiteJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateSiteResponse, error) {
+	rsp, err := c.UpdateSite(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateSiteResponse(rsp)
+}
+
+// ListClosedDaysWithResponse request returning *ListClosedDaysResponse
+func (c *ClientWithResponses) ListClosedDaysWithResponse(ctx context.Context, id Id, params *ListClosedDaysParams, reqEditors ...RequestEditorFn) (*ListClosedDaysResponse, error) {
+	rsp, err := c.ListClosedDays(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListClosedDaysResponse(rsp)
+}
+
+// RemoveClosedDayWithResponse request returning *RemoveClosedDayResponse
+func (c *ClientWithResponses) RemoveClosedDayWithResponse(ctx context.Context, id Id, date openapi_types.Date, reqEditors ...RequestEditorFn) (*RemoveClosedDayResponse, error) {
+	rsp, err := c.RemoveClosedDay(ctx, id, date, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveClosedDayResponse(rsp)
+}
+
+// AddClosedDayWithBodyWithResponse request with arbitrary body returning *AddClosedDayResponse
+func (c *ClientWithResponses) AddClosedDayWithBodyWithResponse(ctx context.Context, id Id, date openapi_types.Date, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddClosedDayResponse, error) {
+	rsp, err := c.AddClosedDayWithBody(ctx, id, date, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddClosedDayResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddClosedDayWithResponse(ctx context.Context, id Id, date openapi_types.Date, body AddClosedDayJSONRequestBody, reqEditors ...RequestEditorFn) (*AddClosedDayResponse, error) {
+	rsp, err := c.AddClosedDay(ctx, id, date, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddClosedDayResponse(rsp)
+}
+
+// GetTardyReportWithResponse request returning *GetTardyReportResponse
+func (c *ClientWithResponses) GetTardyReportWithResponse(ctx context.Context, params *GetTardyReportParams, reqEditors ...RequestEditorFn) (*GetTardyReportResponse, error) {
+	rsp, err := c.GetTardyReport(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTardyReportResponse(rsp)
+}
+
+// GetTimesheetsWithResponse request returning *GetTimesheetsResponse
+func (c *ClientWithResponses) GetTimesheetsWithResponse(ctx context.Context, params *GetTimesheetsParams, reqEditors ...RequestEditorFn) (*GetTimesheetsResponse, error) {
+	rsp, err := c.GetTimesheets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTimesheetsResponse(rsp)
+}
+
+// ExportTimesheetsWithResponse request returning *ExportTimesheetsResponse
+func (c *ClientWithResponses) ExportTimesheetsWithResponse(ctx context.Context, params *ExportTimesheetsParams, reqEditors ...RequestEditorFn) (*ExportTimesheetsResponse, error) {
+	rsp, err := c.ExportTimesheets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportTimesheetsResponse(rsp)
+}
+
+// ManualTrackWithBodyWithResponse request with arbitrary body returning *ManualTrackResponse
+func (c *ClientWithResponses) ManualTrackWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ManualTrackResponse, error) {
+	rsp, err := c.ManualTrackWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseManualTrackResponse(rsp)
+}
+
+func (c *ClientWithResponses) ManualTrackWithResponse(ctx context.Context, body ManualTrackJSONRequestBody, reqEditors ...RequestEditorFn) (*ManualTrackResponse, error) {
+	rsp, err := c.ManualTrack(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseManualTrackResponse(rsp)
+}
+
+// SetLateReasonWithBodyWithResponse request with arbitrary body returning *SetLateReasonResponse
+func (c *ClientWithResponses) SetLateReasonWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLateReasonResponse, error) {
+	rsp, err := c.SetLateReasonWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLateReasonResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetLateReasonWithResponse(ctx context.Context, id Id, body SetLateReasonJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLateReasonResponse, error) {
+	rsp, err := c.SetLateReason(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLateReasonResponse(rsp)
+}
+
+// GetTardySlipWithResponse request returning *GetTardySlipResponse
+func (c *ClientWithResponses) GetTardySlipWithResponse(ctx context.Context, id Id, params *GetTardySlipParams, reqEditors ...RequestEditorFn) (*GetTardySlipResponse, error) {
+	rsp, err := c.GetTardySlip(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTardySlipResponse(rsp)
+}
+
+// PrintTardySlipWithResponse request returning *PrintTardySlipResponse
+func (c *ClientWithResponses) PrintTardySlipWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*PrintTardySlipResponse, error) {
+	rsp, err := c.PrintTardySlip(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrintTardySlipResponse(rsp)
+}
+
+// ListPickupPersonsWithResponse request returning *ListPickupPersonsResponse
+func (c *ClientWithResponses) ListPickupPersonsWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ListPickupPersonsResponse, error) {
+	rsp, err := c.ListPickupPersons(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPickupPersonsResponse(rsp)
+}
+
+// CreatePickupPersonWithBodyWithResponse request with arbitrary body returning *CreatePickupPersonResponse
+func (c *ClientWithResponses) CreatePickupPersonWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePickupPersonResponse, error) {
+	rsp, err := c.CreatePickupPersonWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePickupPersonResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreatePickupPersonWithResponse(ctx context.Context, id Id, body CreatePickupPersonJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePickupPersonResponse, error) {
+	rsp, err := c.CreatePickupPerson(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePickupPersonResponse(rsp)
+}
+
+// ListWatchlistWithResponse request returning *ListWatchlistResponse
+func (c *ClientWithResponses) ListWatchlistWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListWatchlistResponse, error) {
+	rsp, err := c.ListWatchlist(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListWatchlistResponse(rsp)
+}
+
+// CreateWatchlistEntryWithBodyWithResponse request with arbitrary body returning *CreateWatchlistEntryResponse
+func (c *ClientWithResponses) CreateWatchlistEntryWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateWatchlistEntryResponse, error) {
+	rsp, err := c.CreateWatchlistEntryWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateWatchlistEntryResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateWatchlistEntryWithResponse(ctx context.Context, body CreateWatchlistEntryJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateWatchlistEntryResponse, error) {
+	rsp, err := c.CreateWatchlistEntry(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateWatchlistEntryResponse(rsp)
+}
+
+// ListWatchlistHitsWithResponse request returning *ListWatchlistHitsResponse
+func (c *ClientWithResponses) ListWatchlistHitsWithResponse(ctx context.Context, params *ListWatchlistHitsParams, reqEditors ...RequestEditorFn) (*ListWatchlistHitsResponse, error) {
+	rsp, err := c.ListWatchlistHits(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListWatchlistHitsResponse(rsp)
+}
+
+// DeleteWatchlistEntryWithResponse request returning *DeleteWatchlistEntryResponse
+func (c *ClientWithResponses) DeleteWatchlistEntryWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeleteWatchlistEntryResponse, error) {
+	rsp, err := c.DeleteWatchlistEntry(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteWatchlistEntryResponse(rsp)
+}
+
+// DismissalPageWithResponse request returning *DismissalPageResponse
+func (c *ClientWithResponses) DismissalPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DismissalPageResponse, error) {
+	rsp, err := c.DismissalPage(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDismissalPageResponse(rsp)
+}
+
+// EvacuationPageWithResponse request returning *EvacuationPageResponse
+func (c *ClientWithResponses) EvacuationPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*EvacuationPageResponse, error) {
+	rsp, err := c.EvacuationPage(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEvacuationPageResponse(rsp)
+}
+
+// KioskPageWithResponse request returning *KioskPageResponse
+func (c *ClientWithResponses) KioskPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*KioskPageResponse, error) {
+	rsp, err := c.KioskPage(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKioskPageResponse(rsp)
+}
+
+// ManualPageWithResponse request returning *ManualPageResponse
+func (c *ClientWithResponses) ManualPageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ManualPageResponse, error) {
+	rsp, err := c.ManualPage(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseManualPageResponse(rsp)
+}
+
+// PingWithResponse request returning *PingResponse
+func (c *ClientWithResponses) PingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PingResponse, error) {
+	rsp, err := c.Ping(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePingResponse(rsp)
+}
+
+// ParseReaderPageResponse parses an HTTP response from a ReaderPageWithResponse call
+func ParseReaderPageResponse(rsp *http.Response) (*ReaderPageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReaderPageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseAddKeyPageResponse parses an HTTP response from a AddKeyPageWithResponse call
+func ParseAddKeyPageResponse(rsp *http.Response) (*AddKeyPageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddKeyPageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseDocsPageResponse parses an HTTP response from a DocsPageWithResponse call
+func ParseDocsPageResponse(rsp *http.Response) (*DocsPageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DocsPageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetOpenAPIResponse parses an HTTP response from a GetOpenAPIWithResponse call
+func ParseGetOpenAPIResponse(rsp *http.Response) (*GetOpenAPIResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOpenAPIResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "yaml") && rsp.StatusCode == 200:
+		var dest string
+		if err := yaml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.YAML200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddKeyResponse parses an HTTP response from a AddKeyWithResponse call
+func ParseAddKeyResponse(rsp *http.Response) (*AddKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFoundResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTenantsResponse parses an HTTP response from a ListTenantsWithResponse call
+func ParseListTenantsResponse(rsp *http.Response) (*ListTenantsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTenantsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Tenant
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateTenantResponse parses an HTTP response from a CreateTenantWithResponse call
+func ParseCreateTenantResponse(rsp *http.Response) (*CreateTenantResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateTenantResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ProvisionResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateTenantResponse parses an HTTP response from a UpdateTenantWithResponse call
+func ParseUpdateTenantResponse(rsp *http.Response) (*UpdateTenantResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateTenantResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Tenant
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUploadTenantLogoResponse parses an HTTP response from a UploadTenantLogoWithResponse call
+func ParseUploadTenantLogoResponse(rsp *http.Response) (*UploadTenantLogoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UploadTenantLogoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Tenant
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRotateTenantTokenResponse parses an HTTP response from a RotateTenantTokenWithResponse call
+func ParseRotateTenantTokenResponse(rsp *http.Response) (*RotateTenantTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RotateTenantTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			ApiToken string `json:"api_token"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRegisterResponse parses an HTTP response from a GetRegisterWithResponse call
+func ParseGetRegisterResponse(rsp *http.Response) (*GetRegisterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRegisterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RegisterResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdjustAttendanceResponse parses an HTTP response from a AdjustAttendanceWithResponse call
+func ParseAdjustAttendanceResponse(rsp *http.Response) (*AdjustAttendanceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdjustAttendanceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RegisterResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseScanAttendanceResponse parses an HTTP response from a ScanAttendanceWithResponse call
+func ParseScanAttendanceResponse(rsp *http.Response) (*ScanAttendanceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ScanAttendanceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AttendanceScanResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTeacherLessonsResponse parses an HTTP response from a GetTeacherLessonsWithResponse call
+func ParseGetTeacherLessonsResponse(rsp *http.Response) (*GetTeacherLessonsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTeacherLessonsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Lesson
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBrandingResponse parses an HTTP response from a GetBrandingWithResponse call
+func ParseGetBrandingResponse(rsp *http.Response) (*GetBrandingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBrandingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Branding
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLogoResponse parses an HTTP response from a GetLogoWithResponse call
+func ParseGetLogoResponse(rsp *http.Response) (*GetLogoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLogoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseListBusRoutesResponse parses an HTTP response from a ListBusRoutesWithResponse call
+func ParseListBusRoutesResponse(rsp *http.Response) (*ListBusRoutesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListBusRoutesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []BusRoute
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateBusRouteResponse parses an HTTP response from a CreateBusRouteWithResponse call
+func ParseCreateBusRouteResponse(rsp *http.Response) (*CreateBusRouteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateBusRouteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest BusRouteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseBusArrivedAtDeviceResponse parses an HTTP response from a BusArrivedAtDeviceWithResponse call
+func ParseBusArrivedAtDeviceResponse(rsp *http.Response) (*BusArrivedAtDeviceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BusArrivedAtDeviceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest BusArrivalResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteBusRouteResponse parses an HTTP response from a DeleteBusRouteWithResponse call
+func ParseDeleteBusRouteResponse(rsp *http.Response) (*DeleteBusRouteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteBusRouteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBusRouteResponse parses an HTTP response from a GetBusRouteWithResponse call
+func ParseGetBusRouteResponse(rsp *http.Response) (*GetBusRouteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBusRouteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BusRouteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseBusArrivedResponse parses an HTTP response from a BusArrivedWithResponse call
+func ParseBusArrivedResponse(rsp *http.Response) (*BusArrivedResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BusArrivedResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest BusArrivalResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListMissingBusRidersResponse parses an HTTP response from a ListMissingBusRidersWithResponse call
+func ParseListMissingBusRidersResponse(rsp *http.Response) (*ListMissingBusRidersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListMissingBusRidersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BusRidersResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListBusRidersResponse parses an HTTP response from a ListBusRidersWithResponse call
+func ParseListBusRidersResponse(rsp *http.Response) (*ListBusRidersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListBusRidersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BusRidersResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAssignBusRiderResponse parses an HTTP response from a AssignBusRiderWithResponse call
+func ParseAssignBusRiderResponse(rsp *http.Response) (*AssignBusRiderResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AssignBusRiderResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BusRider
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveBusRiderResponse parses an HTTP response from a RemoveBusRiderWithResponse call
+func ParseRemoveBusRiderResponse(rsp *http.Response) (*RemoveBusRiderResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveBusRiderResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateClassResponse parses an HTTP response from a CreateClassWithResponse call
+func ParseCreateClassResponse(rsp *http.Response) (*CreateClassResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateClassResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest SchoolClass
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetClassMembersResponse parses an HTTP response from a GetClassMembersWithResponse call
+func ParseGetClassMembersResponse(rsp *http.Response) (*GetClassMembersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetClassMembersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ClassMembersResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetClassMembersResponse parses an HTTP response from a SetClassMembersWithResponse call
+func ParseSetClassMembersResponse(rsp *http.Response) (*SetClassMembersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetClassMembersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ClassMembersResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListConsequenceRulesResponse parses an HTTP response from a ListConsequenceRulesWithResponse call
+func ParseListConsequenceRulesResponse(rsp *http.Response) (*ListConsequenceRulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListConsequenceRulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ConsequenceRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateConsequenceRuleResponse parses an HTTP response from a CreateConsequenceRuleWithResponse call
+func ParseCreateConsequenceRuleResponse(rsp *http.Response) (*CreateConsequenceRuleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateConsequenceRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ConsequenceRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteConsequenceRuleResponse parses an HTTP response from a DeleteConsequenceRuleWithResponse call
+func ParseDeleteConsequenceRuleResponse(rsp *http.Response) (*DeleteConsequenceRuleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteConsequenceRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListConsequencesResponse parses an HTTP response from a ListConsequencesWithResponse call
+func ParseListConsequencesResponse(rsp *http.Response) (*ListConsequencesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListConsequencesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Consequence
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseResolveConsequenceResponse parses an HTTP response from a ResolveConsequenceWithResponse call
+func ParseResolveConsequenceResponse(rsp *http.Response) (*ResolveConsequenceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResolveConsequenceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Consequence
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseScanDetentionResponse parses an HTTP response from a ScanDetentionWithResponse call
+func ParseScanDetentionResponse(rsp *http.Response) (*ScanDetentionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ScanDetentionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DetentionAssignmentResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListDetentionSessionsResponse parses an HTTP response from a ListDetentionSessionsWithResponse call
+func ParseListDetentionSessionsResponse(rsp *http.Response) (*ListDetentionSessionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListDetentionSessionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []DetentionSession
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateDetentionSessionResponse parses an HTTP response from a CreateDetentionSessionWithResponse call
+func ParseCreateDetentionSessionResponse(rsp *http.Response) (*CreateDetentionSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateDetentionSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest DetentionSession
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDetentionSessionResponse parses an HTTP response from a GetDetentionSessionWithResponse call
+func ParseGetDetentionSessionResponse(rsp *http.Response) (*GetDetentionSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDetentionSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DetentionSessionResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAssignDetentionResponse parses an HTTP response from a AssignDetentionWithResponse call
+func ParseAssignDetentionResponse(rsp *http.Response) (*AssignDetentionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AssignDetentionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest DetentionAssignmentResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseMarkDetentionResponse parses an HTTP response from a MarkDetentionWithResponse call
+func ParseMarkDetentionResponse(rsp *http.Response) (*MarkDetentionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MarkDetentionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DetentionAssignmentResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCloseDetentionSessionResponse parses an HTTP response from a CloseDetentionSessionWithResponse call
+func ParseCloseDetentionSessionResponse(rsp *http.Response) (*CloseDetentionSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CloseDetentionSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DetentionSessionResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseFillDetentionSessionResponse parses an HTTP response from a FillDetentionSessionWithResponse call
+func ParseFillDetentionSessionResponse(rsp *http.Response) (*FillDetentionSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FillDetentionSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []DetentionAssignment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListDevicesResponse parses an HTTP response from a ListDevicesWithResponse call
+func ParseListDevicesResponse(rsp *http.Response) (*ListDevicesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListDevicesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Device
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSaveDeviceResponse parses an HTTP response from a SaveDeviceWithResponse call
+func ParseSaveDeviceResponse(rsp *http.Response) (*SaveDeviceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SaveDeviceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Device
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListDismissalsResponse parses an HTTP response from a ListDismissalsWithResponse call
+func ParseListDismissalsResponse(rsp *http.Response) (*ListDismissalsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListDismissalsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Dismissal
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDismissResponse parses an HTTP response from a DismissWithResponse call
+func ParseDismissResponse(rsp *http.Response) (*DismissResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DismissResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Dismissal
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStartEvacuationResponse parses an HTTP response from a StartEvacuationWithResponse call
+func ParseStartEvacuationResponse(rsp *http.Response) (*StartEvacuationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StartEvacuationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest EvacuationReportResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetActiveEvacuationResponse parses an HTTP response from a GetActiveEvacuationWithResponse call
+func ParseGetActiveEvacuationResponse(rsp *http.Response) (*GetActiveEvacuationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetActiveEvacuationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EvacuationReportResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFoundResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEvacuationResponse parses an HTTP response from a GetEvacuationWithResponse call
+func ParseGetEvacuationResponse(rsp *http.Response) (*GetEvacuationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEvacuationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EvacuationReportResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCheckEvacueeResponse parses an HTTP response from a CheckEvacueeWithResponse call
+func ParseCheckEvacueeResponse(rsp *http.Response) (*CheckEvacueeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CheckEvacueeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EvacuationReportResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseEndEvacuationResponse parses an HTTP response from a EndEvacuationWithResponse call
+func ParseEndEvacuationResponse(rsp *http.Response) (*EndEvacuationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EndEvacuationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EvacuationReportResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportEvacuationResponse parses an HTTP response from a ExportEvacuationWithResponse call
+func ParseExportEvacuationResponse(rsp *http.Response) (*ExportEvacuationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportEvacuationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListExcusalsResponse parses an HTTP response from a ListExcusalsWithResponse call
+func ParseListExcusalsResponse(rsp *http.Response) (*ListExcusalsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListExcusalsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Excusal
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateExcusalResponse parses an HTTP response from a CreateExcusalWithResponse call
+func ParseCreateExcusalResponse(rsp *http.Response) (*CreateExcusalResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateExcusalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ExcusalResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRevokeExcusalResponse parses an HTTP response from a RevokeExcusalWithResponse call
+func ParseRevokeExcusalResponse(rsp *http.Response) (*RevokeExcusalResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevokeExcusalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Excusal
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseFindAndTrackResponse parses an HTTP response from a FindAndTrackWithResponse call
+func ParseFindAndTrackResponse(rsp *http.Response) (*FindAndTrackResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FindAndTrackResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TrackResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFoundResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseScreenGuestResponse parses an HTTP response from a ScreenGuestWithResponse call
+func ParseScreenGuestResponse(rsp *http.Response) (*ScreenGuestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ScreenGuestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Status string `json:"status"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseIssueHallPassResponse parses an HTTP response from a IssueHallPassWithResponse call
+func ParseIssueHallPassResponse(rsp *http.Response) (*IssueHallPassResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &IssueHallPassResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest HallPassResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListActiveHallPassesResponse parses an HTTP response from a ListActiveHallPassesWithResponse call
+func ParseListActiveHallPassesResponse(rsp *http.Response) (*ListActiveHallPassesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListActiveHallPassesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []HallPass
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHallPassReportResponse parses an HTTP response from a GetHallPassReportWithResponse call
+func ParseGetHallPassReportResponse(rsp *http.Response) (*GetHallPassReportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHallPassReportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HallPassReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseScanHallPassResponse parses an HTTP response from a ScanHallPassWithResponse call
+func ParseScanHallPassResponse(rsp *http.Response) (*ScanHallPassResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ScanHallPassResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HallPassResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCloseHallPassResponse parses an HTTP response from a CloseHallPassWithResponse call
+func ParseCloseHallPassResponse(rsp *http.Response) (*CloseHallPassResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CloseHallPassResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HallPassResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUnenrollKioskResponse parses an HTTP response from a UnenrollKioskWithResponse call
+func ParseUnenrollKioskResponse(rsp *http.Response) (*UnenrollKioskResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnenrollKioskResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseEnrollKioskResponse parses an HTTP response from a EnrollKioskWithResponse call
+func ParseEnrollKioskResponse(rsp *http.Response) (*EnrollKioskResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EnrollKioskResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListLateReasonsResponse parses an HTTP response from a ListLateReasonsWithResponse call
+func ParseListLateReasonsResponse(rsp *http.Response) (*ListLateReasonsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListLateReasonsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateLessonResponse parses an HTTP response from a CreateLessonWithResponse call
+func ParseCreateLessonResponse(rsp *http.Response) (*CreateLessonResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateLessonResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Lesson
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListPeriodsResponse parses an HTTP response from a ListPeriodsWithResponse call
+func ParseListPeriodsResponse(rsp *http.Response) (*ListPeriodsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPeriodsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Period
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreatePeriodResponse parses an HTTP response from a CreatePeriodWithResponse call
+func ParseCreatePeriodResponse(rsp *http.Response) (*CreatePeriodResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreatePeriodResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Period
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeletePickupPersonResponse parses an HTTP response from a DeletePickupPersonWithResponse call
+func ParseDeletePickupPersonResponse(rsp *http.Response) (*DeletePickupPersonResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeletePickupPersonResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListRoomsResponse parses an HTTP response from a ListRoomsWithResponse call
+func ParseListRoomsResponse(rsp *http.Response) (*ListRoomsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListRoomsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Room
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateRoomResponse parses an HTTP response from a CreateRoomWithResponse call
+func ParseCreateRoomResponse(rsp *http.Response) (*CreateRoomResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateRoomResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Room
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRosterResponse parses an HTTP response from a GetRosterWithResponse call
+func ParseGetRosterResponse(rsp *http.Response) (*GetRosterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRosterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RosterResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseBatchScansResponse parses an HTTP response from a BatchScansWithResponse call
+func ParseBatchScansResponse(rsp *http.Response) (*BatchScansResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BatchScansResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BatchResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSearchVisitorResponse parses an HTTP response from a SearchVisitorWithResponse call
+func ParseSearchVisitorResponse(rsp *http.Response) (*SearchVisitorResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SearchVisitorResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VisitDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCurrentSiteResponse parses an HTTP response from a GetCurrentSiteWithResponse call
+func ParseGetCurrentSiteResponse(rsp *http.Response) (*GetCurrentSiteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCurrentSiteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SiteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListSitesResponse parses an HTTP response from a ListSitesWithResponse call
+func ParseListSitesResponse(rsp *http.Response) (*ListSitesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListSitesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Site
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateSiteResponse parses an HTTP response from a CreateSiteWithResponse call
+func ParseCreateSiteResponse(rsp *http.Response) (*CreateSiteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateSiteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest SiteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateSiteResponse parses an HTTP response from a UpdateSiteWithResponse call
+func ParseUpdateSiteResponse(rsp *http.Response) (*UpdateSiteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateSiteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SiteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListClosedDaysResponse parses an HTTP response from a ListClosedDaysWithResponse call
+func ParseListClosedDaysResponse(rsp *http.Response) (*ListClosedDaysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListClosedDaysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ClosedDay
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveClosedDayResponse parses an HTTP response from a RemoveClosedDayWithResponse call
+func ParseRemoveClosedDayResponse(rsp *http.Response) (*RemoveClosedDayResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveClosedDayResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddClosedDayResponse parses an HTTP response from a AddClosedDayWithResponse call
+func ParseAddClosedDayResponse(rsp *http.Response) (*AddClosedDayResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddClosedDayResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ClosedDay
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTardyReportResponse parses an HTTP response from a GetTardyReportWithResponse call
+func ParseGetTardyReportResponse(rsp *http.Response) (*GetTardyReportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTardyReportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TardyReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTimesheetsResponse parses an HTTP response from a GetTimesheetsWithResponse call
+func ParseGetTimesheetsResponse(rsp *http.Response) (*GetTimesheetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTimesheetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TimesheetResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportTimesheetsResponse parses an HTTP response from a ExportTimesheetsWithResponse call
+func ParseExportTimesheetsResponse(rsp *http.Response) (*ExportTimesheetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportTimesheetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseManualTrackResponse parses an HTTP response from a ManualTrackWithResponse call
+func ParseManualTrackResponse(rsp *http.Response) (*ManualTrackResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ManualTrackResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ManualTrack
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFoundResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetLateReasonResponse parses an HTTP response from a SetLateReasonWithResponse call
+func ParseSetLateReasonResponse(rsp *http.Response) (*SetLateReasonResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetLateReasonResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReasonResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTardySlipResponse parses an HTTP response from a GetTardySlipWithResponse call
+func ParseGetTardySlipResponse(rsp *http.Response) (*GetTardySlipResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTardySlipResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePrintTardySlipResponse parses an HTTP response from a PrintTardySlipWithResponse call
+func ParsePrintTardySlipResponse(rsp *http.Response) (*PrintTardySlipResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PrintTardySlipResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListPickupPersonsResponse parses an HTTP response from a ListPickupPersonsWithResponse call
+func ParseListPickupPersonsResponse(rsp *http.Response) (*ListPickupPersonsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPickupPersonsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []PickupPerson
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreatePickupPersonResponse parses an HTTP response from a CreatePickupPersonWithResponse call
+func ParseCreatePickupPersonResponse(rsp *http.Response) (*CreatePickupPersonResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreatePickupPersonResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest PickupPerson
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListWatchlistResponse parses an HTTP response from a ListWatchlistWithResponse call
+func ParseListWatchlistResponse(rsp *http.Response) (*ListWatchlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListWatchlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []WatchlistEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateWatchlistEntryResponse parses an HTTP response from a CreateWatchlistEntryWithResponse call
+func ParseCreateWatchlistEntryResponse(rsp *http.Response) (*CreateWatchlistEntryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateWatchlistEntryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest WatchlistEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListWatchlistHitsResponse parses an HTTP response from a ListWatchlistHitsWithResponse call
+func ParseListWatchlistHitsResponse(rsp *http.Response) (*ListWatchlistHitsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListWatchlistHitsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []WatchlistHit
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteWatchlistEntryResponse parses an HTTP response from a DeleteWatchlistEntryWithResponse call
+func ParseDeleteWatchlistEntryResponse(rsp *http.Response) (*DeleteWatchlistEntryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteWatchlistEntryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDismissalPageResponse parses an HTTP response from a DismissalPageWithResponse call
+func ParseDismissalPageResponse(rsp *http.Response) (*DismissalPageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DismissalPageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseEvacuationPageResponse parses an HTTP response from a EvacuationPageWithResponse call
+func ParseEvacuationPageResponse(rsp *http.Response) (*EvacuationPageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EvacuationPageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseKioskPageResponse parses an HTTP response from a KioskPageWithResponse call
+func ParseKioskPageResponse(rsp *http.Response) (*KioskPageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &KioskPageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseManualPageResponse parses an HTTP response from a ManualPageWithResponse call
+func ParseManualPageResponse(rsp *http.Response) (*ManualPageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ManualPageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParsePingResponse parses an HTTP response from a PingWithResponse call
+func ParsePingResponse(rsp *http.Response) (*PingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// The client of api/openapi.yaml is generated into client.gen.go, the
+// options below name the tenant and site of its requests:
+//
+//	c, err := client.NewClientWithResponses("https://imlate.example.com",
+//		client.WithToken(token), client.WithSite("north"))
+//	response, err := c.FindAndTrackWithResponse(ctx, client.TrackRequest{VisitKey: &key})
+
+// WithToken sends the API token of the tenant with every request, requests
+// without one go to the default tenant and are rejected on the hostname of
+// another tenant.
+func WithToken(token string) ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// WithSite names the site of every request by code or id, requests without
+// one go to the default site.
+func WithSite(site string) ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("X-Site", site)
+		return nil
+	})
+}
+
+// IdempotencyKey makes a write safe to retry, the server replays the
+// response of the first request with the key.
+func IdempotencyKey(key string) RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Idempotency-Key", key)
+		return nil
+	}
+}
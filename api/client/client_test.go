@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAndTrackWillSendScopeAndDecodeTrack(t *testing.T) {
+	// Setup
+	var received *http.Request
+	var body TrackRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"track_id":9,"visitor":{"id":7,"name":"Jane"},"track_type":"sign-in","late":true,"minutes_late":10}`))
+	}))
+	defer server.Close()
+	c, err := NewClientWithResponses(server.URL, WithToken("oak-token"), WithSite("north"))
+	assert.NoError(t, err)
+	key := "KEY123"
+
+	// Execute
+	response, err := c.FindAndTrackWithResponse(context.Background(), TrackRequest{VisitKey: &key}, IdempotencyKey("scan-1"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "/api/v1/find-and-track", received.URL.Path)
+	assert.Equal(t, "Bearer oak-token", received.Header.Get("Authorization"))
+	assert.Equal(t, "north", received.Header.Get("X-Site"))
+	assert.Equal(t, "scan-1", received.Header.Get("Idempotency-Key"))
+	assert.Equal(t, "KEY123", *body.VisitKey)
+	if assert.NotNil(t, response.JSON200) {
+		assert.Equal(t, int64(9), *response.JSON200.TrackId)
+		assert.Equal(t, "Jane", *response.JSON200.Visitor.Name)
+		assert.Equal(t, 10, *response.JSON200.MinutesLate)
+	}
+}
+
+func TestFindAndTrackWillDecodeAntiPassback(t *testing.T) {
+	// Setup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"code":"anti_passback","error":"Visitor already signed in"}`))
+	}))
+	defer server.Close()
+	c, _ := NewClientWithResponses(server.URL)
+	key := "KEY123"
+	direction := TrackRequestDirectionIn
+
+	// Execute
+	response, err := c.FindAndTrackWithResponse(context.Background(), TrackRequest{VisitKey: &key, Direction: &direction})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, response.StatusCode())
+	if assert.NotNil(t, response.JSON409) {
+		assert.Equal(t, "anti_passback", response.JSON409.Code)
+	}
+}
@@ -0,0 +1,573 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: imlate/v1/tracking.proto
+
+package imlatev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Visitor struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name    string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Surname string                 `protobuf:"bytes,3,opt,name=surname,proto3" json:"surname,omitempty"`
+	Grade   int32                  `protobuf:"varint,4,opt,name=grade,proto3" json:"grade,omitempty"`
+	Image   string                 `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	// Home site of the visitor.
+	SiteId        int64 `protobuf:"varint,6,opt,name=site_id,json=siteId,proto3" json:"site_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Visitor) Reset() {
+	*x = Visitor{}
+	mi := &file_imlate_v1_tracking_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Visitor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Visitor) ProtoMessage() {}
+
+func (x *Visitor) ProtoReflect() protoreflect.Message {
+	mi := &file_imlate_v1_tracking_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Visitor.ProtoReflect.Descriptor instead.
+func (*Visitor) Descriptor() ([]byte, []int) {
+	return file_imlate_v1_tracking_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Visitor) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Visitor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Visitor) GetSurname() string {
+	if x != nil {
+		return x.Surname
+	}
+	return ""
+}
+
+func (x *Visitor) GetGrade() int32 {
+	if x != nil {
+		return x.Grade
+	}
+	return 0
+}
+
+func (x *Visitor) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Visitor) GetSiteId() int64 {
+	if x != nil {
+		return x.SiteId
+	}
+	return 0
+}
+
+type LookupVisitorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VisitKey      string                 `protobuf:"bytes,1,opt,name=visit_key,json=visitKey,proto3" json:"visit_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupVisitorRequest) Reset() {
+	*x = LookupVisitorRequest{}
+	mi := &file_imlate_v1_tracking_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupVisitorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupVisitorRequest) ProtoMessage() {}
+
+func (x *LookupVisitorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_imlate_v1_tracking_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupVisitorRequest.ProtoReflect.Descriptor instead.
+func (*LookupVisitorRequest) Descriptor() ([]byte, []int) {
+	return file_imlate_v1_tracking_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LookupVisitorRequest) GetVisitKey() string {
+	if x != nil {
+		return x.VisitKey
+	}
+	return ""
+}
+
+type LookupVisitorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Visitor       *Visitor               `protobuf:"bytes,1,opt,name=visitor,proto3" json:"visitor,omitempty"`
+	VisitKey      string                 `protobuf:"bytes,2,opt,name=visit_key,json=visitKey,proto3" json:"visit_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupVisitorResponse) Reset() {
+	*x = LookupVisitorResponse{}
+	mi := &file_imlate_v1_tracking_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupVisitorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupVisitorResponse) ProtoMessage() {}
+
+func (x *LookupVisitorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_imlate_v1_tracking_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupVisitorResponse.ProtoReflect.Descriptor instead.
+func (*LookupVisitorResponse) Descriptor() ([]byte, []int) {
+	return file_imlate_v1_tracking_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LookupVisitorResponse) GetVisitor() *Visitor {
+	if x != nil {
+		return x.Visitor
+	}
+	return nil
+}
+
+func (x *LookupVisitorResponse) GetVisitKey() string {
+	if x != nil {
+		return x.VisitKey
+	}
+	return ""
+}
+
+type TrackRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	VisitKey string                 `protobuf:"bytes,1,opt,name=visit_key,json=visitKey,proto3" json:"visit_key,omitempty"`
+	// Direction of the reader: "in" for entrances, "out" for exits, empty
+	// when the reader is used for both.
+	Direction string `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	// Id the caller gave the scan, at most 64 characters. A call retried with
+	// the id of a tracked scan is answered with the stored track.
+	ClientId      string `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackRequest) Reset() {
+	*x = TrackRequest{}
+	mi := &file_imlate_v1_tracking_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackRequest) ProtoMessage() {}
+
+func (x *TrackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_imlate_v1_tracking_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackRequest.ProtoReflect.Descriptor instead.
+func (*TrackRequest) Descriptor() ([]byte, []int) {
+	return file_imlate_v1_tracking_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TrackRequest) GetVisitKey() string {
+	if x != nil {
+		return x.VisitKey
+	}
+	return ""
+}
+
+func (x *TrackRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *TrackRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type TrackResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TrackId int64                  `protobuf:"varint,1,opt,name=track_id,json=trackId,proto3" json:"track_id,omitempty"`
+	Visitor *Visitor               `protobuf:"bytes,2,opt,name=visitor,proto3" json:"visitor,omitempty"`
+	// "sign-in" or "sign-out".
+	TrackType string `protobuf:"bytes,3,opt,name=track_type,json=trackType,proto3" json:"track_type,omitempty"`
+	// Time of the track in the school timezone, "2006-01-02 15:04:05".
+	TrackDate string `protobuf:"bytes,4,opt,name=track_date,json=trackDate,proto3" json:"track_date,omitempty"`
+	// "attention" on a safeguarding watchlist match.
+	Status      string   `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Late        bool     `protobuf:"varint,6,opt,name=late,proto3" json:"late,omitempty"`
+	MinutesLate int32    `protobuf:"varint,7,opt,name=minutes_late,json=minutesLate,proto3" json:"minutes_late,omitempty"`
+	LateReasons []string `protobuf:"bytes,8,rep,name=late_reasons,json=lateReasons,proto3" json:"late_reasons,omitempty"`
+	// Set when the late sign-in is covered by a bus arrival.
+	Excused       bool `protobuf:"varint,9,opt,name=excused,proto3" json:"excused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackResponse) Reset() {
+	*x = TrackResponse{}
+	mi := &file_imlate_v1_tracking_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackResponse) ProtoMessage() {}
+
+func (x *TrackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_imlate_v1_tracking_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackResponse.ProtoReflect.Descriptor instead.
+func (*TrackResponse) Descriptor() ([]byte, []int) {
+	return file_imlate_v1_tracking_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TrackResponse) GetTrackId() int64 {
+	if x != nil {
+		return x.TrackId
+	}
+	return 0
+}
+
+func (x *TrackResponse) GetVisitor() *Visitor {
+	if x != nil {
+		return x.Visitor
+	}
+	return nil
+}
+
+func (x *TrackResponse) GetTrackType() string {
+	if x != nil {
+		return x.TrackType
+	}
+	return ""
+}
+
+func (x *TrackResponse) GetTrackDate() string {
+	if x != nil {
+		return x.TrackDate
+	}
+	return ""
+}
+
+func (x *TrackResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TrackResponse) GetLate() bool {
+	if x != nil {
+		return x.Late
+	}
+	return false
+}
+
+func (x *TrackResponse) GetMinutesLate() int32 {
+	if x != nil {
+		return x.MinutesLate
+	}
+	return 0
+}
+
+func (x *TrackResponse) GetLateReasons() []string {
+	if x != nil {
+		return x.LateReasons
+	}
+	return nil
+}
+
+func (x *TrackResponse) GetExcused() bool {
+	if x != nil {
+		return x.Excused
+	}
+	return false
+}
+
+type SubscribeTracksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeTracksRequest) Reset() {
+	*x = SubscribeTracksRequest{}
+	mi := &file_imlate_v1_tracking_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeTracksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeTracksRequest) ProtoMessage() {}
+
+func (x *SubscribeTracksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_imlate_v1_tracking_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeTracksRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeTracksRequest) Descriptor() ([]byte, []int) {
+	return file_imlate_v1_tracking_proto_rawDescGZIP(), []int{5}
+}
+
+type TrackEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VisitKey      string                 `protobuf:"bytes,1,opt,name=visit_key,json=visitKey,proto3" json:"visit_key,omitempty"`
+	Track         *TrackResponse         `protobuf:"bytes,2,opt,name=track,proto3" json:"track,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackEvent) Reset() {
+	*x = TrackEvent{}
+	mi := &file_imlate_v1_tracking_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackEvent) ProtoMessage() {}
+
+func (x *TrackEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_imlate_v1_tracking_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackEvent.ProtoReflect.Descriptor instead.
+func (*TrackEvent) Descriptor() ([]byte, []int) {
+	return file_imlate_v1_tracking_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TrackEvent) GetVisitKey() string {
+	if x != nil {
+		return x.VisitKey
+	}
+	return ""
+}
+
+func (x *TrackEvent) GetTrack() *TrackResponse {
+	if x != nil {
+		return x.Track
+	}
+	return nil
+}
+
+var File_imlate_v1_tracking_proto protoreflect.FileDescriptor
+
+const file_imlate_v1_tracking_proto_rawDesc = "" +
+	"\n" +
+	"\x18imlate/v1/tracking.proto\x12\timlate.v1\"\x8c\x01\n" +
+	"\aVisitor\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\asurname\x18\x03 \x01(\tR\asurname\x12\x14\n" +
+	"\x05grade\x18\x04 \x01(\x05R\x05grade\x12\x14\n" +
+	"\x05image\x18\x05 \x01(\tR\x05image\x12\x17\n" +
+	"\asite_id\x18\x06 \x01(\x03R\x06siteId\"3\n" +
+	"\x14LookupVisitorRequest\x12\x1b\n" +
+	"\tvisit_key\x18\x01 \x01(\tR\bvisitKey\"b\n" +
+	"\x15LookupVisitorResponse\x12,\n" +
+	"\avisitor\x18\x01 \x01(\v2\x12.imlate.v1.VisitorR\avisitor\x12\x1b\n" +
+	"\tvisit_key\x18\x02 \x01(\tR\bvisitKey\"f\n" +
+	"\fTrackRequest\x12\x1b\n" +
+	"\tvisit_key\x18\x01 \x01(\tR\bvisitKey\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\"\xa2\x02\n" +
+	"\rTrackResponse\x12\x19\n" +
+	"\btrack_id\x18\x01 \x01(\x03R\atrackId\x12,\n" +
+	"\avisitor\x18\x02 \x01(\v2\x12.imlate.v1.VisitorR\avisitor\x12\x1d\n" +
+	"\n" +
+	"track_type\x18\x03 \x01(\tR\ttrackType\x12\x1d\n" +
+	"\n" +
+	"track_date\x18\x04 \x01(\tR\ttrackDate\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x12\n" +
+	"\x04late\x18\x06 \x01(\bR\x04late\x12!\n" +
+	"\fminutes_late\x18\a \x01(\x05R\vminutesLate\x12!\n" +
+	"\flate_reasons\x18\b \x03(\tR\vlateReasons\x12\x18\n" +
+	"\aexcused\x18\t \x01(\bR\aexcused\"\x18\n" +
+	"\x16SubscribeTracksRequest\"Y\n" +
+	"\n" +
+	"TrackEvent\x12\x1b\n" +
+	"\tvisit_key\x18\x01 \x01(\tR\bvisitKey\x12.\n" +
+	"\x05track\x18\x02 \x01(\v2\x18.imlate.v1.TrackResponseR\x05track2\xe9\x01\n" +
+	"\bTracking\x12R\n" +
+	"\rLookupVisitor\x12\x1f.imlate.v1.LookupVisitorRequest\x1a .imlate.v1.LookupVisitorResponse\x12:\n" +
+	"\x05Track\x12\x17.imlate.v1.TrackRequest\x1a\x18.imlate.v1.TrackResponse\x12M\n" +
+	"\x0fSubscribeTracks\x12!.imlate.v1.SubscribeTracksRequest\x1a\x15.imlate.v1.TrackEvent0\x01B1Z/github.com/buzyka/imlate/api/imlate/v1;imlatev1b\x06proto3"
+
+var (
+	file_imlate_v1_tracking_proto_rawDescOnce sync.Once
+	file_imlate_v1_tracking_proto_rawDescData []byte
+)
+
+func file_imlate_v1_tracking_proto_rawDescGZIP() []byte {
+	file_imlate_v1_tracking_proto_rawDescOnce.Do(func() {
+		file_imlate_v1_tracking_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_imlate_v1_tracking_proto_rawDesc), len(file_imlate_v1_tracking_proto_rawDesc)))
+	})
+	return file_imlate_v1_tracking_proto_rawDescData
+}
+
+var file_imlate_v1_tracking_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_imlate_v1_tracking_proto_goTypes = []any{
+	(*Visitor)(nil),                // 0: imlate.v1.Visitor
+	(*LookupVisitorRequest)(nil),   // 1: imlate.v1.LookupVisitorRequest
+	(*LookupVisitorResponse)(nil),  // 2: imlate.v1.LookupVisitorResponse
+	(*TrackRequest)(nil),           // 3: imlate.v1.TrackRequest
+	(*TrackResponse)(nil),          // 4: imlate.v1.TrackResponse
+	(*SubscribeTracksRequest)(nil), // 5: imlate.v1.SubscribeTracksRequest
+	(*TrackEvent)(nil),             // 6: imlate.v1.TrackEvent
+}
+var file_imlate_v1_tracking_proto_depIdxs = []int32{
+	0, // 0: imlate.v1.LookupVisitorResponse.visitor:type_name -> imlate.v1.Visitor
+	0, // 1: imlate.v1.TrackResponse.visitor:type_name -> imlate.v1.Visitor
+	4, // 2: imlate.v1.TrackEvent.track:type_name -> imlate.v1.TrackResponse
+	1, // 3: imlate.v1.Tracking.LookupVisitor:input_type -> imlate.v1.LookupVisitorRequest
+	3, // 4: imlate.v1.Tracking.Track:input_type -> imlate.v1.TrackRequest
+	5, // 5: imlate.v1.Tracking.SubscribeTracks:input_type -> imlate.v1.SubscribeTracksRequest
+	2, // 6: imlate.v1.Tracking.LookupVisitor:output_type -> imlate.v1.LookupVisitorResponse
+	4, // 7: imlate.v1.Tracking.Track:output_type -> imlate.v1.TrackResponse
+	6, // 8: imlate.v1.Tracking.SubscribeTracks:output_type -> imlate.v1.TrackEvent
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_imlate_v1_tracking_proto_init() }
+func file_imlate_v1_tracking_proto_init() {
+	if File_imlate_v1_tracking_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_imlate_v1_tracking_proto_rawDesc), len(file_imlate_v1_tracking_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_imlate_v1_tracking_proto_goTypes,
+		DependencyIndexes: file_imlate_v1_tracking_proto_depIdxs,
+		MessageInfos:      file_imlate_v1_tracking_proto_msgTypes,
+	}.Build()
+	File_imlate_v1_tracking_proto = out.File
+	file_imlate_v1_tracking_proto_goTypes = nil
+	file_imlate_v1_tracking_proto_depIdxs = nil
+}
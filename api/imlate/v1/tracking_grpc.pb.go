@@ -0,0 +1,223 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: imlate/v1/tracking.proto
+
+package imlatev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Tracking_LookupVisitor_FullMethodName   = "/imlate.v1.Tracking/LookupVisitor"
+	Tracking_Track_FullMethodName           = "/imlate.v1.Tracking/Track"
+	Tracking_SubscribeTracks_FullMethodName = "/imlate.v1.Tracking/SubscribeTracks"
+)
+
+// TrackingClient is the client API for Tracking service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Tracking is the typed counterpart of the kiosk API for integrators and
+// reader agents. Calls name their tenant and site the way HTTP requests do:
+// the tenant API token as "authorization: Bearer <token>" metadata and the
+// site code or id as "x-site" metadata, the defaults serve calls without.
+type TrackingClient interface {
+	// LookupVisitor returns the visitor a card key belongs to.
+	LookupVisitor(ctx context.Context, in *LookupVisitorRequest, opts ...grpc.CallOption) (*LookupVisitorResponse, error)
+	// Track tracks a scan the way POST /find-and-track does. Unknown keys fail
+	// with NOT_FOUND, sign-ins rejected by anti-passback with
+	// FAILED_PRECONDITION.
+	Track(ctx context.Context, in *TrackRequest, opts ...grpc.CallOption) (*TrackResponse, error)
+	// SubscribeTracks streams the tracks of the site as they are made, from
+	// the kiosk, the reader agents, networked readers and synced batches.
+	SubscribeTracks(ctx context.Context, in *SubscribeTracksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrackEvent], error)
+}
+
+type trackingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTrackingClient(cc grpc.ClientConnInterface) TrackingClient {
+	return &trackingClient{cc}
+}
+
+func (c *trackingClient) LookupVisitor(ctx context.Context, in *LookupVisitorRequest, opts ...grpc.CallOption) (*LookupVisitorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupVisitorResponse)
+	err := c.cc.Invoke(ctx, Tracking_LookupVisitor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackingClient) Track(ctx context.Context, in *TrackRequest, opts ...grpc.CallOption) (*TrackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrackResponse)
+	err := c.cc.Invoke(ctx, Tracking_Track_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackingClient) SubscribeTracks(ctx context.Context, in *SubscribeTracksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrackEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tracking_ServiceDesc.Streams[0], Tracking_SubscribeTracks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeTracksRequest, TrackEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tracking_SubscribeTracksClient = grpc.ServerStreamingClient[TrackEvent]
+
+// TrackingServer is the server API for Tracking service.
+// All implementations must embed UnimplementedTrackingServer
+// for forward compatibility.
+//
+// Tracking is the typed counterpart of the kiosk API for integrators and
+// reader agents. Calls name their tenant and site the way HTTP requests do:
+// the tenant API token as "authorization: Bearer <token>" metadata and the
+// site code or id as "x-site" metadata, the defaults serve calls without.
+type TrackingServer interface {
+	// LookupVisitor returns the visitor a card key belongs to.
+	LookupVisitor(context.Context, *LookupVisitorRequest) (*LookupVisitorResponse, error)
+	// Track tracks a scan the way POST /find-and-track does. Unknown keys fail
+	// with NOT_FOUND, sign-ins rejected by anti-passback with
+	// FAILED_PRECONDITION.
+	Track(context.Context, *TrackRequest) (*TrackResponse, error)
+	// SubscribeTracks streams the tracks of the site as they are made, from
+	// the kiosk, the reader agents, networked readers and synced batches.
+	SubscribeTracks(*SubscribeTracksRequest, grpc.ServerStreamingServer[TrackEvent]) error
+	mustEmbedUnimplementedTrackingServer()
+}
+
+// UnimplementedTrackingServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTrackingServer struct{}
+
+func (UnimplementedTrackingServer) LookupVisitor(context.Context, *LookupVisitorRequest) (*LookupVisitorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupVisitor not implemented")
+}
+func (UnimplementedTrackingServer) Track(context.Context, *TrackRequest) (*TrackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Track not implemented")
+}
+func (UnimplementedTrackingServer) SubscribeTracks(*SubscribeTracksRequest, grpc.ServerStreamingServer[TrackEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTracks not implemented")
+}
+func (UnimplementedTrackingServer) mustEmbedUnimplementedTrackingServer() {}
+func (UnimplementedTrackingServer) testEmbeddedByValue()                  {}
+
+// UnsafeTrackingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TrackingServer will
+// result in compilation errors.
+type UnsafeTrackingServer interface {
+	mustEmbedUnimplementedTrackingServer()
+}
+
+func RegisterTrackingServer(s grpc.ServiceRegistrar, srv TrackingServer) {
+	// If the following call pancis, it indicates UnimplementedTrackingServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Tracking_ServiceDesc, srv)
+}
+
+func _Tracking_LookupVisitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupVisitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackingServer).LookupVisitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tracking_LookupVisitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackingServer).LookupVisitor(ctx, req.(*LookupVisitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tracking_Track_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackingServer).Track(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tracking_Track_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackingServer).Track(ctx, req.(*TrackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tracking_SubscribeTracks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTracksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrackingServer).SubscribeTracks(m, &grpc.GenericServerStream[SubscribeTracksRequest, TrackEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tracking_SubscribeTracksServer = grpc.ServerStreamingServer[TrackEvent]
+
+// Tracking_ServiceDesc is the grpc.ServiceDesc for Tracking service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Tracking_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "imlate.v1.Tracking",
+	HandlerType: (*TrackingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LookupVisitor",
+			Handler:    _Tracking_LookupVisitor_Handler,
+		},
+		{
+			MethodName: "Track",
+			Handler:    _Tracking_Track_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTracks",
+			Handler:       _Tracking_SubscribeTracks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "imlate/v1/tracking.proto",
+}
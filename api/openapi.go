@@ -0,0 +1,13 @@
+// Package api holds the contracts of the server: the OpenAPI document of the
+// HTTP routes and the protobuf definitions of the gRPC services.
+package api
+
+import (
+	_ "embed"
+)
+
+// OpenAPI is the OpenAPI 3 document of every HTTP route, served at
+// /api/openapi.yaml.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
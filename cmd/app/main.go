@@ -2,14 +2,13 @@ package main
 
 import (
 	"fmt"
-	"net/http"
+	"net"
+	_ "time/tzdata"
 
 	"github.com/buzyka/imlate/internal/config"
 	"github.com/buzyka/imlate/internal/infrastructure/gocontainer"
 	"github.com/buzyka/imlate/internal/infrastructure/util"
-	"github.com/buzyka/imlate/internal/isb/search"
-	"github.com/buzyka/imlate/internal/isb/tracker"
-	"github.com/buzyka/imlate/internal/isb/visitor"
+	"github.com/buzyka/imlate/internal/isb/tenant"
 	"github.com/gin-gonic/gin"
 	"github.com/golobby/container/v3"
 	"github.com/subosito/gotenv"
@@ -30,42 +29,35 @@ func main() {
 	}
 	
 	gocontainer.Build(&cfg)
+	readers, err := gocontainer.ReaderSubscriber(&cfg)
+	if err != nil {
+		panic(fmt.Sprintf("Error connecting networked readers: %v\n", err))
+	}
+	if readers != nil {
+		if err := readers.Start(); err != nil {
+			panic(fmt.Sprintf("Error subscribing to reader scans: %v\n", err))
+		}
+		defer readers.Broker.Close()
+	}
+	if cfg.GrpcListenAddress != "" {
+		listener, err := net.Listen("tcp", cfg.GrpcListenAddress)
+		if err != nil {
+			panic(fmt.Sprintf("Error listening for gRPC: %v\n", err))
+		}
+		grpcServer := gocontainer.GrpcServer()
+		go grpcServer.Serve(listener)
+		defer grpcServer.GracefulStop()
+	}
+	var tenantResolver *tenant.Resolver
+	container.MustResolve(container.Global, &tenantResolver)
 	r := gin.Default()
 
 	r.Static("/assets", "./website/assets")
 	r.Static("/output", "./output")
 
-	// Define gita simple GET route
-	r.GET("/ping", func(ctx *gin.Context) {
-		ctx.JSON(http.StatusOK, gin.H{
-			"message": "pong",
-		})
-	})
-
-	r.GET("/", func(ctx *gin.Context) {
-		ctx.File("website/reader.html")
-	})
-
-	r.GET("/manual", func(ctx *gin.Context) {
-		ctx.File("website/index.html")
-	})
-
-	r.GET("/add", func(ctx *gin.Context) {
-		ctx.File("website/add-key.html")
-	})
-
-	searchController := &search.SearchController{}
-	container.MustFill(container.Global, searchController)
-	r.GET("/search/:id", searchController.SearchHandler())
-	
-	apiRouteGroup := r.Group("/api")
-	trackerController := &tracker.TrackerController{}
-	container.MustFill(container.Global, trackerController)	
-	r.POST("/track", trackerController.TrackHandler())
-	r.POST("/find-and-track", trackerController.FindAndTrackHandler())
-	visitorController := &visitor.VisitorController{}
-	container.MustFill(container.Global, visitorController)
-	apiRouteGroup.PATCH("/add-key", visitorController.AddKeyHandler())
+	tenantController := &tenant.TenantController{}
+	container.MustFill(container.Global, tenantController)
+	routes(r, &cfg, tenantResolver, tenantController)
 
 	// Start the server on port 8080
 	r.Run("0.0.0.0:8080")
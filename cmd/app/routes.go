@@ -0,0 +1,236 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/buzyka/imlate/api"
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/gocontainer"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/attendance"
+	"github.com/buzyka/imlate/internal/isb/consequence"
+	"github.com/buzyka/imlate/internal/isb/detention"
+	"github.com/buzyka/imlate/internal/isb/device"
+	"github.com/buzyka/imlate/internal/isb/dismissal"
+	"github.com/buzyka/imlate/internal/isb/evacuation"
+	"github.com/buzyka/imlate/internal/isb/excusal"
+	"github.com/buzyka/imlate/internal/isb/hallpass"
+	"github.com/buzyka/imlate/internal/isb/idempotency"
+	"github.com/buzyka/imlate/internal/isb/search"
+	"github.com/buzyka/imlate/internal/isb/site"
+	"github.com/buzyka/imlate/internal/isb/tardy"
+	"github.com/buzyka/imlate/internal/isb/tenant"
+	"github.com/buzyka/imlate/internal/isb/timesheet"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/buzyka/imlate/internal/isb/transport"
+	"github.com/buzyka/imlate/internal/isb/visitor"
+	"github.com/buzyka/imlate/internal/isb/watchlist"
+	"github.com/gin-gonic/gin"
+)
+
+// routes registers every route of the server, each of them is documented in
+// api/openapi.yaml. The endpoints of the API are served under /api/v1.
+func routes(r *gin.Engine, cfg *config.Config, tenantResolver *tenant.Resolver, tenantController *tenant.TenantController) {
+	// Define gita simple GET route
+	r.GET("/ping", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "pong",
+		})
+	})
+
+	r.GET("/", func(ctx *gin.Context) {
+		ctx.File("website/reader.html")
+	})
+
+	r.GET("/manual", func(ctx *gin.Context) {
+		ctx.File("website/index.html")
+	})
+
+	r.GET("/add", func(ctx *gin.Context) {
+		ctx.File("website/add-key.html")
+	})
+
+	r.GET("/evacuation", func(ctx *gin.Context) {
+		ctx.File("website/evacuation.html")
+	})
+
+	r.GET("/dismissal", func(ctx *gin.Context) {
+		ctx.File("website/dismissal.html")
+	})
+
+	r.GET("/kiosk", func(ctx *gin.Context) {
+		ctx.File("website/kiosk.html")
+	})
+
+	r.GET("/api/docs", func(ctx *gin.Context) {
+		ctx.File("website/api-docs.html")
+	})
+
+	r.GET("/api/openapi.yaml", func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "application/yaml", api.OpenAPI)
+	})
+
+	// Requests of no route get the error envelope of the API.
+	r.NoRoute(func(ctx *gin.Context) {
+		ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Route not found"))))
+	})
+
+	v1 := r.Group("/api/v1")
+	adminRoutes(v1.Group("/admin", tenant.SuperAdmin(cfg.SuperAdminToken)), tenantController)
+	// A kiosk whose cookie stopped naming its tenant is still unenrolled.
+	v1.DELETE("/kiosk", tenant.UnenrollHandler())
+	scoped := v1.Group("", scope(tenantResolver)...)
+	apiRoutes(scoped, scoped)
+
+	// The paths served before /api/v1 are deprecated aliases of the ones of
+	// /api/v1, see deprecated.
+	legacy := r.Group("", deprecated)
+	adminRoutes(legacy.Group("/api/admin", tenant.SuperAdmin(cfg.SuperAdminToken)), tenantController)
+	legacyScoped := legacy.Group("", scope(tenantResolver)...)
+	apiRoutes(legacyScoped, legacyScoped.Group("/api"))
+}
+
+// adminRoutes registers tenant provisioning for the operator of the
+// instance, see tenant.SuperAdmin.
+func adminRoutes(adminRouteGroup *gin.RouterGroup, tenantController *tenant.TenantController) {
+	adminRouteGroup.GET("/tenants", tenantController.ListHandler())
+	adminRouteGroup.POST("/tenants", tenantController.CreateHandler())
+	adminRouteGroup.PUT("/tenants/:id", tenantController.UpdateHandler())
+	adminRouteGroup.POST("/tenants/:id/token", tenantController.RotateTokenHandler())
+	adminRouteGroup.PUT("/tenants/:id/logo", tenantController.LogoHandler())
+}
+
+// scope serves the tenant and site named by the request, see tenant.Resolver
+// and site.Header. Writes sent with an Idempotency-Key header are safe to
+// retry.
+func scope(tenantResolver *tenant.Resolver) []gin.HandlerFunc {
+	return []gin.HandlerFunc{
+		tenantResolver.Middleware(),
+		gocontainer.SiteMiddleware(),
+		gocontainer.Handle((*idempotency.Guard).Middleware),
+	}
+}
+
+// deprecated marks the response of a path served before /api/v1 as
+// deprecated and links the path of /api/v1 which replaces it.
+func deprecated(ctx *gin.Context) {
+	successor := "/api/v1" + strings.TrimPrefix(ctx.Request.URL.Path, "/api")
+	ctx.Header("Deprecation", "true")
+	ctx.Header("Link", "<"+successor+">; rel=\"successor-version\"")
+	ctx.Next()
+}
+
+// apiRoutes registers the endpoints of the API, the ones of the tracking
+// kiosk on root and the others on apiRouteGroup. Both are the same group
+// under /api/v1.
+func apiRoutes(root *gin.RouterGroup, apiRouteGroup *gin.RouterGroup) {
+	root.GET("/branding/logo", gocontainer.Handle((*tenant.BrandingController).LogoHandler))
+	root.GET("/search/:id", gocontainer.Handle((*search.SearchController).SearchHandler))
+	root.POST("/track", gocontainer.Handle((*tracker.TrackerController).TrackHandler))
+	root.POST("/find-and-track", gocontainer.Handle((*tracker.TrackerController).FindAndTrackHandler))
+	apiRouteGroup.PATCH("/add-key", gocontainer.Handle((*visitor.VisitorController).AddKeyHandler))
+	apiRouteGroup.POST("/scans/batch", gocontainer.Handle((*tracker.TrackerController).BatchHandler))
+	apiRouteGroup.GET("/roster", gocontainer.Handle((*visitor.VisitorController).RosterHandler))
+
+	// The memory engine keeps visitors and tracks only, the endpoints below
+	// need a database.
+	database := apiRouteGroup.Group("", gocontainer.DatabaseMiddleware())
+
+	evacuationRouteGroup := database.Group("/evacuation")
+	evacuationRouteGroup.POST("", gocontainer.Handle((*evacuation.EvacuationController).StartHandler))
+	evacuationRouteGroup.GET("/active", gocontainer.Handle((*evacuation.EvacuationController).ActiveHandler))
+	evacuationRouteGroup.GET("/:id", gocontainer.Handle((*evacuation.EvacuationController).ReportHandler))
+	evacuationRouteGroup.POST("/:id/check", gocontainer.Handle((*evacuation.EvacuationController).CheckHandler))
+	evacuationRouteGroup.POST("/:id/end", gocontainer.Handle((*evacuation.EvacuationController).EndHandler))
+	evacuationRouteGroup.GET("/:id/export", gocontainer.Handle((*evacuation.EvacuationController).ExportHandler))
+
+	database.GET("/timesheets", gocontainer.Handle((*timesheet.TimesheetController).TimesheetHandler))
+	database.GET("/timesheets/export", gocontainer.Handle((*timesheet.TimesheetController).ExportHandler))
+
+	database.GET("/devices", gocontainer.Handle((*device.DeviceController).ListHandler))
+	database.PUT("/devices/:id", gocontainer.Handle((*device.DeviceController).SaveHandler))
+
+	database.GET("/rooms", gocontainer.Handle((*attendance.TimetableController).RoomsHandler))
+	database.POST("/rooms", gocontainer.Handle((*attendance.TimetableController).CreateRoomHandler))
+	database.GET("/periods", gocontainer.Handle((*attendance.TimetableController).PeriodsHandler))
+	database.POST("/periods", gocontainer.Handle((*attendance.TimetableController).CreatePeriodHandler))
+	database.POST("/classes", gocontainer.Handle((*attendance.TimetableController).CreateClassHandler))
+	database.GET("/classes/:id/members", gocontainer.Handle((*attendance.TimetableController).MembersHandler))
+	database.PUT("/classes/:id/members", gocontainer.Handle((*attendance.TimetableController).SetMembersHandler))
+	database.POST("/lessons", gocontainer.Handle((*attendance.TimetableController).CreateLessonHandler))
+
+	database.POST("/attendance/scan", gocontainer.Handle((*attendance.AttendanceController).ScanHandler))
+	database.GET("/attendance/lessons/:id", gocontainer.Handle((*attendance.AttendanceController).RegisterHandler))
+	database.PATCH("/attendance/lessons/:id", gocontainer.Handle((*attendance.AttendanceController).AdjustHandler))
+	database.GET("/attendance/teachers/:id/lessons", gocontainer.Handle((*attendance.AttendanceController).TeacherLessonsHandler))
+
+	database.GET("/visitors/:id/pickup-persons", gocontainer.Handle((*dismissal.PickupController).ListHandler))
+	database.POST("/visitors/:id/pickup-persons", gocontainer.Handle((*dismissal.PickupController).CreateHandler))
+	database.DELETE("/pickup-persons/:id", gocontainer.Handle((*dismissal.PickupController).DeleteHandler))
+
+	database.GET("/dismissals", gocontainer.Handle((*dismissal.DismissalController).ListHandler))
+	database.POST("/dismissals", gocontainer.Handle((*dismissal.DismissalController).DismissHandler))
+
+	database.GET("/watchlist", gocontainer.Handle((*watchlist.WatchlistController).ListHandler))
+	database.POST("/watchlist", gocontainer.Handle((*watchlist.WatchlistController).CreateHandler))
+	database.DELETE("/watchlist/:id", gocontainer.Handle((*watchlist.WatchlistController).DeleteHandler))
+	database.GET("/watchlist/hits", gocontainer.Handle((*watchlist.WatchlistController).HitsHandler))
+	database.POST("/guests/screen", gocontainer.Handle((*watchlist.WatchlistController).GuestHandler))
+
+	database.POST("/hall-passes", gocontainer.Handle((*hallpass.HallPassController).IssueHandler))
+	database.GET("/hall-passes/active", gocontainer.Handle((*hallpass.HallPassController).ActiveHandler))
+	database.GET("/hall-passes/report", gocontainer.Handle((*hallpass.HallPassController).ReportHandler))
+	database.POST("/hall-passes/scan", gocontainer.Handle((*hallpass.HallPassController).ScanHandler))
+	database.POST("/hall-passes/:id/close", gocontainer.Handle((*hallpass.HallPassController).CloseHandler))
+
+	apiRouteGroup.GET("/late-reasons", gocontainer.Handle((*tardy.TardyController).ReasonsHandler))
+	database.POST("/tracks/:id/late-reason", gocontainer.Handle((*tardy.TardyController).ReasonHandler))
+	database.GET("/tracks/:id/tardy-slip", gocontainer.Handle((*tardy.TardyController).SlipHandler))
+	database.POST("/tracks/:id/tardy-slip/print", gocontainer.Handle((*tardy.TardyController).PrintHandler))
+	database.GET("/tardies", gocontainer.Handle((*tardy.TardyController).ReportHandler))
+
+	database.GET("/consequences", gocontainer.Handle((*consequence.ConsequenceController).ListHandler))
+	database.PATCH("/consequences/:id", gocontainer.Handle((*consequence.ConsequenceController).ResolveHandler))
+	database.GET("/consequence-rules", gocontainer.Handle((*consequence.ConsequenceController).RulesHandler))
+	database.POST("/consequence-rules", gocontainer.Handle((*consequence.ConsequenceController).CreateRuleHandler))
+	database.DELETE("/consequence-rules/:id", gocontainer.Handle((*consequence.ConsequenceController).DeleteRuleHandler))
+
+	database.POST("/detentions/sessions", gocontainer.Handle((*detention.DetentionController).CreateSessionHandler))
+	database.GET("/detentions/sessions", gocontainer.Handle((*detention.DetentionController).ListSessionsHandler))
+	database.GET("/detentions/sessions/:id", gocontainer.Handle((*detention.DetentionController).SessionHandler))
+	database.POST("/detentions/sessions/:id/assignments", gocontainer.Handle((*detention.DetentionController).AssignHandler))
+	database.PATCH("/detentions/sessions/:id/assignments/:visitorId", gocontainer.Handle((*detention.DetentionController).MarkHandler))
+	database.POST("/detentions/sessions/:id/fill", gocontainer.Handle((*detention.DetentionController).FillHandler))
+	database.POST("/detentions/sessions/:id/close", gocontainer.Handle((*detention.DetentionController).CloseHandler))
+	database.POST("/detentions/scan", gocontainer.Handle((*detention.DetentionController).ScanHandler))
+
+	database.POST("/excusals", gocontainer.Handle((*excusal.ExcusalController).CreateHandler))
+	database.GET("/excusals", gocontainer.Handle((*excusal.ExcusalController).ListHandler))
+	database.DELETE("/excusals/:id", gocontainer.Handle((*excusal.ExcusalController).RevokeHandler))
+
+	database.GET("/bus-routes", gocontainer.Handle((*transport.BusRouteController).ListHandler))
+	database.POST("/bus-routes", gocontainer.Handle((*transport.BusRouteController).CreateHandler))
+	database.POST("/bus-routes/arrived", gocontainer.Handle((*transport.BusRouteController).DeviceArrivedHandler))
+	database.GET("/bus-routes/:id", gocontainer.Handle((*transport.BusRouteController).RouteHandler))
+	database.DELETE("/bus-routes/:id", gocontainer.Handle((*transport.BusRouteController).DeleteHandler))
+	database.GET("/bus-routes/:id/riders", gocontainer.Handle((*transport.BusRouteController).RidersHandler))
+	database.PUT("/bus-routes/:id/riders", gocontainer.Handle((*transport.BusRouteController).AssignRiderHandler))
+	database.DELETE("/bus-routes/:id/riders/:visitorId", gocontainer.Handle((*transport.BusRouteController).RemoveRiderHandler))
+	database.GET("/bus-routes/:id/missing", gocontainer.Handle((*transport.BusRouteController).MissingHandler))
+	database.POST("/bus-routes/:id/arrived", gocontainer.Handle((*transport.BusRouteController).ArrivedHandler))
+
+	apiRouteGroup.GET("/site", gocontainer.Handle((*site.SiteController).CurrentHandler))
+	apiRouteGroup.GET("/sites", gocontainer.Handle((*site.SiteController).ListHandler))
+	apiRouteGroup.POST("/sites", gocontainer.Handle((*site.SiteController).CreateHandler))
+	apiRouteGroup.PUT("/sites/:id", gocontainer.Handle((*site.SiteController).UpdateHandler))
+	apiRouteGroup.GET("/sites/:id/closed-days", gocontainer.Handle((*site.SiteController).ClosedDaysHandler))
+	apiRouteGroup.PUT("/sites/:id/closed-days/:date", gocontainer.Handle((*site.SiteController).AddClosedDayHandler))
+	apiRouteGroup.DELETE("/sites/:id/closed-days/:date", gocontainer.Handle((*site.SiteController).RemoveClosedDayHandler))
+
+	apiRouteGroup.GET("/branding", gocontainer.Handle((*tenant.BrandingController).BrandingHandler))
+
+	apiRouteGroup.POST("/kiosk", tenant.EnrollHandler())
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/buzyka/imlate/api"
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/apispec"
+	"github.com/buzyka/imlate/internal/infrastructure/gocontainer"
+	"github.com/buzyka/imlate/internal/infrastructure/repository"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/tenant"
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	routes(r, &config.Config{}, &tenant.Resolver{}, &tenant.TenantController{})
+	return r
+}
+
+func TestRoutesAreDocumented(t *testing.T) {
+	// Setup
+	doc, err := apispec.Load()
+	assert.NoError(t, err)
+
+	// Execute
+	registered := map[string]bool{}
+	for _, route := range newRouter().Routes() {
+		registered[route.Method+" "+pathParam.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+
+	// Assert
+	documented := map[string]bool{}
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			documented[method+" "+path] = true
+		}
+	}
+	for route := range registered {
+		method, path, _ := strings.Cut(route, " ")
+		if !documented[route] && !strings.HasPrefix(path, "/api/v1/") {
+			// A deprecated alias of a path of /api/v1, see deprecated.
+			route = method + " /api/v1" + strings.TrimPrefix(path, "/api")
+		}
+		assert.True(t, documented[route], "%s is not documented in api/openapi.yaml", route)
+	}
+	for route := range documented {
+		assert.True(t, registered[route], "%s is documented but not registered", route)
+	}
+}
+
+func TestOpenAPIIsServed(t *testing.T) {
+	// Setup
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/openapi.yaml", nil)
+
+	// Execute
+	newRouter().ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Equal(t, api.OpenAPI, w.Body.Bytes())
+}
+
+func TestLegacyPathsAreDeprecated(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		deprecated bool
+		successor  string
+	}{
+		{name: "legacy path", path: "/api/admin/tenants", deprecated: true, successor: "</api/v1/admin/tenants>; rel=\"successor-version\""},
+		{name: "versioned path", path: "/api/v1/admin/tenants"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+
+			// Execute
+			newRouter().ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.JSONEq(t, `{"code":"forbidden","error":"Tenant provisioning is disabled"}`, w.Body.String())
+			if tt.deprecated {
+				assert.Equal(t, "true", w.Header().Get("Deprecation"))
+				assert.Equal(t, tt.successor, w.Header().Get("Link"))
+			} else {
+				assert.Empty(t, w.Header().Get("Deprecation"))
+			}
+		})
+	}
+}
+
+func TestUnknownRouteWillReturnErrorEnvelope(t *testing.T) {
+	// Setup
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/unknown", nil)
+
+	// Execute
+	newRouter().ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response util.ExtendedFailureResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "not_found", response.Code)
+}
+
+func TestKioskOfTenantIsServedOnItsHostname(t *testing.T) {
+	// Setup
+	t.Chdir("../..")
+	oldGlobal := container.Global
+	container.Global = container.New()
+	defer func() {
+		container.Global = oldGlobal
+	}()
+	cfg := &config.Config{
+		DatabaseEngine: "memory",
+		DefaultTenant:  "default",
+		DefaultSite:    "main",
+	}
+	gocontainer.Build(cfg)
+	var tenants entity.TenantRepository
+	assert.NoError(t, container.Resolve(&tenants))
+	riverside := &entity.Tenant{Code: "riverside", Name: "Riverside School", Hostname: "riverside.example.org"}
+	assert.NoError(t, tenants.Provision(riverside, &entity.Site{Code: "main", Name: "Main site"}, tenant.HashToken("secret")))
+	tr, s, err := gocontainer.Resolve("riverside", "main")
+	assert.NoError(t, err)
+	var visitors entity.VisitorRepository
+	assert.NoError(t, gocontainer.ForSite(tr, s).Resolve(&visitors))
+	memory := visitors.(*repository.MemoryVisitor)
+	assert.NoError(t, memory.Memory.Seed(memory.Scope, repository.MemorySeed{Visitors: []repository.SeedVisitor{
+		{Visitor: entity.Visitor{Id: 7, Name: "Ann", Surname: "Lee"}},
+	}}))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	var resolver *tenant.Resolver
+	assert.NoError(t, container.Resolve(&resolver))
+	routes(r, cfg, resolver, &tenant.TenantController{})
+	send := func(method string, path string, body string, cookie string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Host = "riverside.example.org"
+		req.Header.Set("Content-Type", "application/json")
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: tenant.KioskCookie, Value: cookie})
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Execute
+	page := send(http.MethodGet, "/", "", "")
+	unenrolled := send(http.MethodPost, "/api/v1/track", `{"visitor_id":7,"signed_in":true}`, "")
+	enrolled := send(http.MethodPost, "/api/v1/track", `{"visitor_id":7,"signed_in":true}`, "secret")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, page.Code)
+	assert.Contains(t, page.Body.String(), "<html")
+	assert.Equal(t, http.StatusUnauthorized, unenrolled.Code)
+	assert.Equal(t, http.StatusOK, enrolled.Code, enrolled.Body.String())
+	assert.Contains(t, enrolled.Body.String(), `"message":"tracked"`)
+}
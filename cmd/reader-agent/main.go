@@ -0,0 +1,62 @@
+// Command reader-agent connects a keyboard-wedge or serial card reader to the
+// server without a browser: it reads one card id per line from a tty device or
+// stdin and prints the result of every scan as a line of JSON.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/readeragent"
+	"github.com/subosito/gotenv"
+)
+
+func main() {
+	_ = gotenv.Load(".env")
+
+	cfg, err := readeragent.NewFromEnv()
+	if err != nil {
+		panic(fmt.Sprintf("Error loading config from env: %v\n", err))
+	}
+	logger := logging.NewLogger(cfg.Debug)
+	defer logger.Sync()
+
+	// Serial readers keep their line settings, e.g. stty -F /dev/ttyUSB0 9600.
+	var input io.Reader = os.Stdin
+	if cfg.Input != "-" {
+		device, err := os.Open(cfg.Input)
+		if err != nil {
+			panic(fmt.Sprintf("Error opening reader input: %v\n", err))
+		}
+		defer device.Close()
+		input = device
+	}
+
+	agent := &readeragent.Agent{
+		Client: &readeragent.Client{
+			BaseURL:   cfg.ServerURL,
+			Token:     cfg.Token,
+			Site:      cfg.Site,
+			Direction: cfg.Direction,
+			HTTP:      &http.Client{Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second},
+		},
+		Queue:        &readeragent.Queue{Path: cfg.QueueFile},
+		Output:       os.Stdout,
+		Hook:         cfg.Hook,
+		SyncInterval: time.Duration(cfg.SyncIntervalSeconds) * time.Second,
+		Logger:       logger,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := agent.Run(ctx, input); err != nil {
+		logger.Errorf("Error reading scans: %s", err.Error())
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 )
@@ -11,8 +12,43 @@ var localIPs = []string{"127.0.0.1", "::1"}
 type Config struct {
 	Debug                                  bool     `env:"DEBUG" envDefault:"false"`
 	Environment                            string   `env:"ENVIRONMENT" envDefault:"production"` // possible values: development, staging, production.
-	DatabaseEngine						   string   `env:"DATABASE_ENGINE" envDefault:"mysql"`
+	DatabaseEngine						   string   `env:"DATABASE_ENGINE" envDefault:"mysql"` // memory runs without a database, see db.MemoryEngine.
 	DatabaseURL                            string   `env:"DATABASE_URL" envDefault:"trackme:trackme@/tracker"`
+	DatabaseQueryTimeoutMs                 int      `env:"DATABASE_QUERY_TIMEOUT_MS" envDefault:"5000"` // queries of visitors and tracks fail after this long, 0 leaves them to the request.
+	DatabaseSeedPath                       string   `env:"DATABASE_SEED_PATH"` // JSON file of the visitors the memory engine starts with, see repository.LoadMemorySeed.
+	ScanDebounceSeconds                    int      `env:"SCAN_DEBOUNCE_SECONDS" envDefault:"0"` // repeat scans of the same visitor within this window are ignored, 0 disables.
+	AntiPassback                           bool     `env:"ANTI_PASSBACK" envDefault:"false"`     // reject sign-in at an entrance when the visitor is already in.
+	IdempotencyKeyTTLHours                 int      `env:"IDEMPOTENCY_KEY_TTL_HOURS" envDefault:"24"` // responses to requests with an Idempotency-Key are replayed this long, 0 disables.
+	StaffContractedHoursPerDay             float64  `env:"STAFF_CONTRACTED_HOURS_PER_DAY" envDefault:"8"`
+	StaffContractedHoursPerWeek            float64  `env:"STAFF_CONTRACTED_HOURS_PER_WEEK" envDefault:"40"`
+	AttendanceEarlyScanMinutes             int      `env:"ATTENDANCE_EARLY_SCAN_MINUTES" envDefault:"10"` // room scans are accepted this long before a period starts.
+	AttendanceLateAfterMinutes             int      `env:"ATTENDANCE_LATE_AFTER_MINUTES" envDefault:"5"`  // room scans after period start plus this grace are late.
+	StaffAlertChannel                      string   `env:"STAFF_ALERT_CHANNEL" envDefault:"log"` // possible values: log, webhook.
+	StaffAlertWebhookURL                   string   `env:"STAFF_ALERT_WEBHOOK_URL"`
+	SafeguardingAlertChannel               string   `env:"SAFEGUARDING_ALERT_CHANNEL" envDefault:"log"` // possible values: log, webhook.
+	SafeguardingAlertWebhookURL            string   `env:"SAFEGUARDING_ALERT_WEBHOOK_URL"`
+	HallPassMaxMinutes                     int      `env:"HALL_PASS_MAX_MINUTES" envDefault:"10"` // used when the teacher does not set a duration.
+	HallPassDailyLimit                     int      `env:"HALL_PASS_DAILY_LIMIT" envDefault:"3"`  // passes per student per day, 0 disables the limit.
+	SchoolTimezone                         string   `env:"SCHOOL_TIMEZONE" envDefault:"Local"` // IANA zone of the school, days and clock times are counted in it.
+	SchoolDayStartsAt                      string   `env:"SCHOOL_DAY_STARTS_AT" envDefault:"08:30"`  // first sign-in after this time plus the grace is late, empty disables.
+	LateGraceMinutes                       int      `env:"LATE_GRACE_MINUTES" envDefault:"0"`
+	LateReasons                            []string `env:"LATE_REASONS" envSeparator:"," envDefault:"bus,appointment,overslept,other"`
+	ConsequenceRules                       string   `env:"CONSEQUENCE_RULES"` // JSON array of rules, see consequence.ParseRules.
+	SlipPrinter                            string   `env:"SLIP_PRINTER"` // file:<path> or tcp://<host:port> for ESC/POS printers, empty disables printing.
+	BusArrivalGraceMinutes                 int      `env:"BUS_ARRIVAL_GRACE_MINUTES" envDefault:"15"` // late sign-ins of riders up to this long after their bus arrived are excused.
+	DefaultSite                            string   `env:"DEFAULT_SITE" envDefault:"main"` // code of the site serving requests that name none, see the X-Site header.
+	DefaultTenant                          string   `env:"DEFAULT_TENANT" envDefault:"default"` // code of the tenant serving requests without an API token to a hostname of no other tenant.
+	SuperAdminToken                        string   `env:"SUPER_ADMIN_TOKEN"` // bearer token of the tenant provisioning API, empty disables it.
+	BrandingPath                           string   `env:"BRANDING_PATH" envDefault:"./website/branding"` // holds a directory of logos per tenant code.
+	MqttBrokerURL                          string   `env:"MQTT_BROKER_URL"` // tcp://host:1883 of the broker networked readers publish to, empty disables.
+	MqttClientId                           string   `env:"MQTT_CLIENT_ID" envDefault:"imlate-server"`
+	MqttUsername                           string   `env:"MQTT_USERNAME"`
+	MqttPassword                           string   `env:"MQTT_PASSWORD"`
+	MqttScanTopic                          string   `env:"MQTT_SCAN_TOPIC" envDefault:"imlate/{tenant}/{site}/{device}/scan"` // {tenant} and {site} codes may be left out for the defaults, broker ACLs must keep readers to their own topics.
+	MqttResultTopic                        string   `env:"MQTT_RESULT_TOPIC" envDefault:"imlate/{tenant}/{site}/{device}/result"`
+	MqttKeyField                           string   `env:"MQTT_KEY_FIELD" envDefault:"card_id"` // JSON field of the card id, payloads which are no JSON object are the card id.
+	MqttDirectionField                     string   `env:"MQTT_DIRECTION_FIELD" envDefault:"direction"`
+	GrpcListenAddress                      string   `env:"GRPC_LISTEN_ADDRESS"` // host:port of the gRPC Tracking service, e.g. :9090, empty disables it.
 }
 
 type MysqlDBConfig struct {
@@ -33,6 +69,9 @@ func NewFromEnv() (Config, error) {
 	if err != nil {
 		return cfg, err
 	}
+	if _, err := time.LoadLocation(cfg.SchoolTimezone); err != nil {
+		return cfg, fmt.Errorf("invalid SCHOOL_TIMEZONE: %w", err)
+	}
 	switch cfg.DatabaseEngine {
 	case "mysql":
 		if url, ok := getDatabaseURLForMysqlFromEnv(); ok {
@@ -47,6 +86,15 @@ func NewFromEnv() (Config, error) {
 	return cfg, nil
 }
 
+// Location returns the time zone of the school, NewFromEnv has checked it.
+func (c *Config) Location() *time.Location {
+	loc, err := time.LoadLocation(c.SchoolTimezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
 func getDatabaseURLForSqliteFromEnv() (url string, ok bool) {
 	cfg := &SqliteDBConfig{}
 	if err := env.Parse(cfg); err != nil {
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -118,3 +119,17 @@ func loadTestEnvVariables(t *testing.T, env map[string]string) {
 		assert.NoError(t, err, "failed to set environment variable %s", key)
 	}
 }
+
+func TestNewFromEnvWithUnknownTimezoneWillFail(t *testing.T) {
+	t.Setenv("SCHOOL_TIMEZONE", "Mars/Olympus_Mons")
+
+	_, err := NewFromEnv()
+
+	assert.Error(t, err)
+}
+
+func TestLocationWillLoadSchoolTimezone(t *testing.T) {
+	cfg := &Config{SchoolTimezone: "UTC"}
+
+	assert.Equal(t, time.UTC, cfg.Location())
+}
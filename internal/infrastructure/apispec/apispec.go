@@ -0,0 +1,91 @@
+package apispec
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/buzyka/imlate/api"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// Validator checks requests and responses against the OpenAPI document of
+// api.OpenAPI, handler tests use it to keep the document and the handlers in
+// line.
+type Validator struct {
+	Doc    *openapi3.T
+	router routers.Router
+}
+
+// Load parses and validates api.OpenAPI.
+func Load() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(api.OpenAPI)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func NewValidator() (*Validator, error) {
+	doc, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{Doc: doc, router: router}, nil
+}
+
+// ValidateRequest fails for requests of undocumented routes and requests
+// which do not match their operation. The body is left for the handler.
+func (v *Validator) ValidateRequest(req *http.Request) error {
+	input, err := v.requestInput(req)
+	if err != nil {
+		return err
+	}
+	return openapi3filter.ValidateRequest(req.Context(), input)
+}
+
+// ValidateResponse fails for responses of undocumented statuses and bodies
+// which do not match the response of the operation of the request.
+func (v *Validator) ValidateResponse(req *http.Request, status int, header http.Header, body []byte) error {
+	input, err := v.requestInput(req)
+	if err != nil {
+		return err
+	}
+	return openapi3filter.ValidateResponse(req.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 status,
+		Header:                 header,
+		Body:                   io.NopCloser(bytes.NewReader(body)),
+		Options:                input.Options,
+	})
+}
+
+func (v *Validator) requestInput(req *http.Request) (*openapi3filter.RequestValidationInput, error) {
+	route, pathParams, err := v.router.FindRoute(req)
+	if err != nil {
+		return nil, err
+	}
+	return &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			// Tenants are resolved by the handlers, see tenant.Resolver.
+			AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+			IncludeResponseStatus: true,
+			SkipSettingDefaults:   true,
+		},
+	}, nil
+}
@@ -0,0 +1,70 @@
+package apispec
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewValidatorWillLoadTheDocument(t *testing.T) {
+	validator, err := NewValidator()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, validator.Doc.Paths.Find("/api/v1/find-and-track"))
+}
+
+func TestValidateRequest(t *testing.T) {
+	validator, err := NewValidator()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		valid  bool
+	}{
+		{name: "documented", method: http.MethodPost, path: "/api/v1/find-and-track", body: `{"visit_key":"KEY123","direction":"in"}`, valid: true},
+		{name: "invalid body", method: http.MethodPost, path: "/api/v1/find-and-track", body: `{"visit_key":7}`},
+		{name: "invalid enum", method: http.MethodPost, path: "/api/v1/find-and-track", body: `{"visit_key":"KEY123","direction":"up"}`},
+		{name: "missing required", method: http.MethodPatch, path: "/api/v1/add-key", body: `{"visitor_id":7}`},
+		{name: "invalid path parameter", method: http.MethodGet, path: "/api/v1/evacuation/abc"},
+		{name: "undocumented route", method: http.MethodGet, path: "/api/v1/unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			err := validator.ValidateRequest(req)
+
+			assert.Equal(t, tt.valid, err == nil, err)
+		})
+	}
+}
+
+func TestValidateRequestWillKeepTheBody(t *testing.T) {
+	validator, _ := NewValidator()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/find-and-track", strings.NewReader(`{"visit_key":"KEY123"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	assert.NoError(t, validator.ValidateRequest(req))
+
+	body, _ := io.ReadAll(req.Body)
+	assert.Equal(t, `{"visit_key":"KEY123"}`, string(body))
+}
+
+func TestValidateResponse(t *testing.T) {
+	validator, _ := NewValidator()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search/KEY123", nil)
+	header := http.Header{"Content-Type": []string{"application/json; charset=utf-8"}}
+
+	assert.NoError(t, validator.ValidateResponse(req, http.StatusOK, header, []byte(`{"visitor":{"id":7,"name":"Jane"},"key":"KEY123"}`)))
+	assert.NoError(t, validator.ValidateResponse(req, http.StatusInternalServerError, header, []byte(`{"code":"internal_error","error":"db down"}`)))
+	assert.Error(t, validator.ValidateResponse(req, http.StatusInternalServerError, header, []byte(`{"error":"db down"}`)))
+	assert.Error(t, validator.ValidateResponse(req, http.StatusOK, header, []byte(`{"visitor":{"id":"seven"}}`)))
+}
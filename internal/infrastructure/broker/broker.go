@@ -0,0 +1,97 @@
+package broker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// qos is at-least-once for scans and results, a scan handled twice within
+// the debounce window returns the first result.
+const qos = 1
+
+// Options configure the connection to an MQTT broker.
+type Options struct {
+	URL      string
+	ClientId string
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+// MQTT is a MessageBroker on an MQTT 3.1.1 broker. Subscriptions are made
+// again after a reconnect.
+type MQTT struct {
+	client  mqtt.Client
+	timeout time.Duration
+
+	mu            sync.Mutex
+	subscriptions map[string]mqtt.MessageHandler
+}
+
+// Connect opens the connection, failing when the broker cannot be reached
+// within the timeout.
+func Connect(options Options) (entity.MessageBroker, error) {
+	if options.Timeout <= 0 {
+		options.Timeout = 10 * time.Second
+	}
+	b := &MQTT{timeout: options.Timeout, subscriptions: map[string]mqtt.MessageHandler{}}
+	b.client = mqtt.NewClient(b.clientOptions(options))
+	if err := wait(b.client.Connect(), options.Timeout); err != nil {
+		return nil, fmt.Errorf("connecting to MQTT broker %s: %w", options.URL, err)
+	}
+	return b, nil
+}
+
+// clientOptions hands every message to a handler of its own: a handler run
+// in order blocks the client, which then neither delivers the other scans
+// nor the acknowledgement of a result the handler publishes.
+func (b *MQTT) clientOptions(options Options) *mqtt.ClientOptions {
+	return mqtt.NewClientOptions().
+		AddBroker(options.URL).
+		SetClientID(options.ClientId).
+		SetUsername(options.Username).
+		SetPassword(options.Password).
+		SetCleanSession(false).
+		SetAutoReconnect(true).
+		SetConnectTimeout(options.Timeout).
+		SetOrderMatters(false).
+		SetOnConnectHandler(b.resubscribe)
+}
+
+func (b *MQTT) Subscribe(filter string, handler entity.MessageHandler) error {
+	callback := func(_ mqtt.Client, message mqtt.Message) {
+		handler(message.Topic(), message.Payload())
+	}
+	b.mu.Lock()
+	b.subscriptions[filter] = callback
+	b.mu.Unlock()
+	return wait(b.client.Subscribe(filter, qos, callback), b.timeout)
+}
+
+func (b *MQTT) Publish(topic string, payload []byte) error {
+	return wait(b.client.Publish(topic, qos, false, payload), b.timeout)
+}
+
+func (b *MQTT) Close() {
+	b.client.Disconnect(250)
+}
+
+// resubscribe restores the subscriptions when the broker lost the session.
+func (b *MQTT) resubscribe(client mqtt.Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for filter, callback := range b.subscriptions {
+		client.Subscribe(filter, qos, callback)
+	}
+}
+
+func wait(token mqtt.Token, timeout time.Duration) error {
+	if !token.WaitTimeout(timeout) {
+		return fmt.Errorf("MQTT operation timed out after %s", timeout)
+	}
+	return token.Error()
+}
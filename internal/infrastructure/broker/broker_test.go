@@ -0,0 +1,79 @@
+package broker
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientOptions_HandlersDoNotBlockTheClient(t *testing.T) {
+	b := &MQTT{}
+
+	options := b.clientOptions(Options{URL: "tcp://localhost:1883", ClientId: "imlate-test", Timeout: time.Second})
+
+	reader := mqtt.NewOptionsReader(options)
+	assert.False(t, reader.Order())
+}
+
+func TestConnect_UnreachableBroker(t *testing.T) {
+	_, err := Connect(Options{URL: "tcp://127.0.0.1:1", ClientId: "imlate-test", Timeout: time.Second})
+
+	assert.Error(t, err)
+}
+
+// Runs against a local broker, e.g. mosquitto started with
+// MQTT_TEST_BROKER_URL=tcp://localhost:1883.
+func TestMQTT_DeliversPublishedMessages(t *testing.T) {
+	url := os.Getenv("MQTT_TEST_BROKER_URL")
+	if url == "" {
+		t.Skip("MQTT_TEST_BROKER_URL is not set")
+	}
+	client, err := Connect(Options{URL: url, ClientId: fmt.Sprintf("imlate-test-%d", time.Now().UnixNano())})
+	assert.NoError(t, err)
+	defer client.Close()
+
+	received := make(chan string, 1)
+	assert.NoError(t, client.Subscribe("imlate-test/+/scan", func(topic string, payload []byte) {
+		received <- topic + " " + string(payload)
+	}))
+	assert.NoError(t, client.Publish("imlate-test/door-1/scan", []byte("ABC123")))
+
+	select {
+	case message := <-received:
+		assert.Equal(t, "imlate-test/door-1/scan ABC123", message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not delivered")
+	}
+}
+
+// Results are published from the handler of the scan, which must not wait
+// for the client it runs on.
+func TestMQTT_PublishesFromHandler(t *testing.T) {
+	url := os.Getenv("MQTT_TEST_BROKER_URL")
+	if url == "" {
+		t.Skip("MQTT_TEST_BROKER_URL is not set")
+	}
+	client, err := Connect(Options{URL: url, ClientId: fmt.Sprintf("imlate-test-%d", time.Now().UnixNano()), Timeout: 5 * time.Second})
+	assert.NoError(t, err)
+	defer client.Close()
+
+	received := make(chan string, 1)
+	assert.NoError(t, client.Subscribe("imlate-test/+/result", func(topic string, payload []byte) {
+		received <- topic + " " + string(payload)
+	}))
+	assert.NoError(t, client.Subscribe("imlate-test/+/scan", func(topic string, payload []byte) {
+		assert.NoError(t, client.Publish("imlate-test/door-1/result", payload))
+	}))
+	assert.NoError(t, client.Publish("imlate-test/door-1/scan", []byte("ABC123")))
+
+	select {
+	case message := <-received:
+		assert.Equal(t, "imlate-test/door-1/result ABC123", message)
+	case <-time.After(10 * time.Second):
+		t.Fatal("result was not published")
+	}
+}
@@ -2,12 +2,14 @@ package db
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/buzyka/imlate/internal/infrastructure/util"
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/mysql"
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
@@ -18,15 +20,42 @@ import (
 
 var dbEngine string = "mysql"
 
+// MemoryEngine keeps tenants, sites, visitors and tracks in memory, see
+// repository.MemoryStore. The connection Open returns for it has no database
+// behind it, repositories without a memory implementation fail with
+// ErrNoDatabase.
+const MemoryEngine = "memory"
+
+var ErrNoDatabase = errors.New("Not available without a database, DATABASE_ENGINE is memory")
+
 // List of supported engines
-var supportedEngines = []string{"mysql", "sqlite3"}
+var supportedEngines = []string{"mysql", "sqlite3", MemoryEngine}
+
+func init() {
+	sql.Register(MemoryEngine, noDatabase{})
+}
+
+// noDatabase is the driver of the memory engine, every connection fails.
+type noDatabase struct{}
+
+func (noDatabase) Open(name string) (driver.Conn, error) {
+	return nil, ErrNoDatabase
+}
 
 func Open(engine string, dataSourceName string, logger *zap.SugaredLogger) (*sql.DB, error) {
 	if !util.InArray(engine, supportedEngines) {
 		panic(fmt.Sprintf("Unsupported database engine: %s", engine))
 	}
 	dbEngine = engine
-	db, err := sql.Open(dbEngine, dataSourceName)
+	if engine == MemoryEngine {
+		logger.Info("[DB] Memory engine, data is lost when the app stops")
+		return sql.Open(MemoryEngine, "")
+	}
+	sessionSourceName := dataSourceName
+	if engine == "mysql" {
+		sessionSourceName = utcDataSourceName(dataSourceName)
+	}
+	db, err := sql.Open(dbEngine, sessionSourceName)
 	if err != nil {
 		panic(err)
 	}
@@ -46,6 +75,22 @@ func Open(engine string, dataSourceName string, logger *zap.SugaredLogger) (*sql
 	return db, nil
 }
 
+// utcDataSourceName makes MySQL sessions read and write times in UTC,
+// whatever the zone of the database server, the school zone applies only
+// when times are shown or split into days.
+func utcDataSourceName(dataSourceName string) string {
+	cfg, err := mysql.ParseDSN(dataSourceName)
+	if err != nil {
+		return dataSourceName
+	}
+	cfg.Loc = time.UTC
+	if cfg.Params == nil {
+		cfg.Params = map[string]string{}
+	}
+	cfg.Params["time_zone"] = "'+00:00'"
+	return cfg.FormatDSN()
+}
+
 func MigrateUp(dataSourceName string) error {
 	m, err := migrate.New(getMigrationSourceURL(), dbEngine+"://"+dataSourceName)
 	if err != nil {
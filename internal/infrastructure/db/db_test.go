@@ -37,6 +37,16 @@ func TestOpenWithIncorrectConnectionWillReturnError(t *testing.T) {
 	assert.Contains(t, logBuffer.String(), "Error opening a connection to the database")
 }
 
+func TestOpenMemoryEngineWillFailQueries(t *testing.T) {
+	logger := zaptest.NewLogger(t).Sugar()
+
+	db, err := Open(MemoryEngine, "", logger)
+
+	if assert.NoError(t, err) {
+		assert.ErrorIs(t, db.QueryRow("SELECT 1").Scan(new(int)), ErrNoDatabase)
+	}
+}
+
 func TestOpen(t *testing.T) {
 	logBuffer := &bytes.Buffer{}
 	logger := zap.New(zapcore.NewCore(
@@ -168,3 +178,14 @@ func (tc *MigrationTestCase) createTestMigration(content string) {
 	err = writer.Flush()
 	assert.Nil(tc.t, err)
 }
+
+func TestUtcDataSourceNameWillSetSessionTimeZone(t *testing.T) {
+	dsn := utcDataSourceName("trackme:trackme@tcp(db:3306)/tracker")
+
+	assert.Contains(t, dsn, "time_zone=%27%2B00%3A00%27")
+	assert.Contains(t, dsn, "tcp(db:3306)/tracker")
+}
+
+func TestUtcDataSourceNameWithInvalidURLWillKeepIt(t *testing.T) {
+	assert.Equal(t, "notURL", utcDataSourceName("notURL"))
+}
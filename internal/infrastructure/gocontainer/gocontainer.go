@@ -2,12 +2,23 @@ package gocontainer
 
 import (
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/buzyka/imlate/internal/config"
 	"github.com/buzyka/imlate/internal/infrastructure/db"
 	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/infrastructure/notification"
+	"github.com/buzyka/imlate/internal/infrastructure/printer"
 	"github.com/buzyka/imlate/internal/infrastructure/repository"
+	"github.com/buzyka/imlate/internal/isb/consequence"
 	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/site"
+	"github.com/buzyka/imlate/internal/isb/tenant"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/buzyka/imlate/internal/isb/transport"
+	"github.com/buzyka/imlate/internal/isb/visitor"
+	"github.com/buzyka/imlate/internal/isb/watchlist"
 	"github.com/golobby/container/v3"
 	"go.uber.org/zap"
 )
@@ -18,28 +29,390 @@ func Build(cfg *config.Config) {
 	if err != nil {
 		panic(err.Error())
 	}
+	var memory *repository.MemoryStore
+	if cfg.DatabaseEngine == db.MemoryEngine {
+		memory, err = newMemoryStore(cfg)
+		if err != nil {
+			panic(err.Error())
+		}
+	}
+
+	var tenants entity.TenantRepository = &repository.Tenant{
+		Connection: connection,
+	}
+	if memory != nil {
+		tenants = &repository.MemoryTenant{Memory: memory}
+	}
+	defaultTenant, err := tenants.GetByCode(cfg.DefaultTenant)
+	if err != nil {
+		panic(err.Error())
+	}
+	if defaultTenant == nil {
+		panic(fmt.Sprintf("default tenant %q not found", cfg.DefaultTenant))
+	}
+
+	sites := func (t *entity.Tenant) entity.SiteRepository {
+		if memory != nil {
+			return &repository.MemorySite{Memory: memory, Scope: repository.Scope{TenantId: t.Id}}
+		}
+		return &repository.Site{
+			Connection: connection,
+			Scope:      repository.Scope{TenantId: t.Id},
+		}
+	}
+	defaultSite, err := sites(defaultTenant).GetByCode(cfg.DefaultSite)
+	if err != nil {
+		panic(err.Error())
+	}
+	if defaultSite == nil {
+		panic(fmt.Sprintf("default site %q not found", cfg.DefaultSite))
+	}
+
+	// One feed and one delivery queue for all sites, containers are rebuilt
+	// when a site changes.
+	feed := tracker.NewFeed()
+	deliveries := consequence.NewDeliveries()
+	register(container.Global, cfg, logger, connection, memory, feed, deliveries, defaultTenant, defaultSite)
+	registry.reset(defaultTenant, defaultSite, container.Global, func (t *entity.Tenant, s *entity.Site) container.Container {
+		c := container.New()
+		register(c, cfg, logger, connection, memory, feed, deliveries, t, s)
+		return c
+	})
+	registry.tenants = tenants
+	registry.tenantCode = defaultTenant.Code
+	registry.sites = sites
+	registry.siteCode = cfg.DefaultSite
+	registry.memory = memory != nil
+
+	container.MustSingleton(container.Global, func () entity.TenantRepository {
+		return tenants
+	})
+
+	container.MustSingleton(container.Global, func () *tenant.Resolver {
+		return &tenant.Resolver{
+			Repository:  tenants,
+			DefaultCode: defaultTenant.Code,
+		}
+	})
+}
+
+// newMemoryStore provisions the default tenant and site of the memory engine
+// and seeds them with the visitors of DATABASE_SEED_PATH.
+func newMemoryStore(cfg *config.Config) (*repository.MemoryStore, error) {
+	memory := repository.NewMemoryStore()
+	t := &entity.Tenant{Code: cfg.DefaultTenant, Name: "Default school"}
+	s := &entity.Site{Code: cfg.DefaultSite, Name: "Main site"}
+	if err := (&repository.MemoryTenant{Memory: memory}).Provision(t, s, ""); err != nil {
+		return nil, err
+	}
+	if cfg.DatabaseSeedPath == "" {
+		return memory, nil
+	}
+	seed, err := repository.LoadMemorySeed(cfg.DatabaseSeedPath)
+	if err != nil {
+		return nil, err
+	}
+	return memory, memory.Seed(repository.Scope{TenantId: t.Id, SiteId: s.Id}, seed)
+}
+
+// register fills the container with the services of one site of a tenant,
+// repositories are scoped to both and the configuration carries the school
+// day of the site. Sites, visitors, tracks and idempotency keys are kept in
+// memory when the store is set, the other repositories need a database and
+// their endpoints are not served then, see DatabaseMiddleware.
+func register(c container.Container, cfg *config.Config, logger *zap.SugaredLogger, connection *sql.DB, memory *repository.MemoryStore, feed *tracker.Feed, deliveries *consequence.Deliveries, t *entity.Tenant, s *entity.Site) {
+	cfg = site.Config(cfg, s)
+	scope := repository.Scope{TenantId: t.Id, SiteId: s.Id}
+	timeout := repository.Timeout{QueryTimeout: time.Duration(cfg.DatabaseQueryTimeoutMs) * time.Millisecond}
+
+	container.MustSingleton(c, func () *entity.Site {
+		return s
+	})
+
+	container.MustSingleton(c, func () *entity.Tenant {
+		return t
+	})
 
-	container.MustSingleton(container.Global, func () *config.Config {
+	container.MustSingleton(c, func () entity.SiteRepository {
+		if memory != nil {
+			return &repository.MemorySite{Memory: memory, Scope: scope}
+		}
+		return &repository.Site{
+			Connection: connection,
+			Scope:      scope,
+		}
+	})
+
+	container.MustSingleton(c, func () *config.Config {
 		return cfg		
 	})
 
-	container.MustSingleton(container.Global, func () *zap.SugaredLogger {
+	container.MustSingleton(c, func () *time.Location {
+		return cfg.Location()
+	})
+
+	container.MustSingleton(c, func () *zap.SugaredLogger {
 		return logger
 	})
 
-	container.MustSingleton(container.Global, func() *sql.DB {		
+	container.MustSingleton(c, func() *sql.DB {		
 		return connection
 	})
 
-	container.MustSingleton(container.Global, func () entity.VisitorRepository {
+	container.MustSingleton(c, func () entity.VisitorRepository {
+		if memory != nil {
+			return &repository.MemoryVisitor{Memory: memory, Scope: scope}
+		}
 		return &repository.Visitor{
 			Connection: connection,
+			Scope:      scope,
+			Timeout:    timeout,
 		}
 	})
 
-	container.MustSingleton(container.Global, func () entity.VisitorTrackRepository {
+	container.MustSingleton(c, func () entity.VisitorTrackRepository {
+		if memory != nil {
+			return &repository.MemoryVisitorTrack{Memory: memory, Scope: scope}
+		}
 		return &repository.VisitorTrack{
 			Connection: connection,
+			Scope:      scope,
+			Timeout:    timeout,
+		}
+	})
+
+	container.MustSingleton(c, func() entity.IdempotencyRepository {
+		if memory != nil {
+			return &repository.MemoryIdempotency{Memory: memory, Scope: scope}
+		}
+		return &repository.Idempotency{
+			Connection: connection,
+			Scope:      scope,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.EvacuationRepository {
+		return &repository.Evacuation{
+			Connection: connection,
+			Scope:      scope,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.TimesheetRepository {
+		return &repository.Timesheet{
+			Connection: connection,
+			Scope:      scope,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.DeviceRepository {
+		return &repository.Device{
+			Connection: connection,
+			Scope:      scope,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.TimetableRepository {
+		return &repository.Timetable{
+			Connection: connection,
+			Scope:      scope,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.AttendanceRepository {
+		return &repository.Attendance{
+			Connection: connection,
+			Scope:      scope,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.PickupPersonRepository {
+		return &repository.PickupPerson{
+			Connection: connection,
+			Scope:      scope,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.DismissalRepository {
+		return &repository.Dismissal{
+			Connection: connection,
+			Scope:      scope,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.WatchlistRepository {
+		return &repository.Watchlist{
+			Connection: connection,
+			Scope:      scope,
+			Timeout:    timeout,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.HallPassRepository {
+		return &repository.HallPass{
+			Connection: connection,
+			Scope:      scope,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.TardyRepository {
+		return &repository.Tardy{
+			Connection: connection,
+			Scope:      scope,
+			Timeout:    timeout,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.ConsequenceRuleRepository {
+		return &repository.ConsequenceRule{
+			Connection: connection,
+			Scope:      scope,
+			Timeout:    timeout,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.ConsequenceRepository {
+		return &repository.Consequence{
+			Connection: connection,
+			Scope:      scope,
+			Timeout:    timeout,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.DetentionRepository {
+		return &repository.Detention{
+			Connection: connection,
+			Scope:      scope,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.ExcusalRepository {
+		return &repository.Excusal{
+			Connection: connection,
+			Scope:      scope,
+			Timeout:    timeout,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.BusRouteRepository {
+		return &repository.BusRoute{
+			Connection: connection,
+			Scope:      scope,
+			Timeout:    timeout,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.RouteRoster {
+		return &repository.BusRoute{
+			Connection: connection,
+			Scope:      scope,
+			Timeout:    timeout,
+		}
+	})
+
+	container.MustSingleton(c, func () entity.SlipPrinter {
+		slipPrinter, err := printer.New(cfg.SlipPrinter)
+		if err != nil {
+			panic(err.Error())
+		}
+		return slipPrinter
+	})
+
+	container.MustSingleton(c, func () entity.Notifier {
+		return notification.New(cfg.StaffAlertChannel, cfg.StaffAlertWebhookURL, logger)
+	})
+
+	// Safeguarding alerts go to their own channel, designated staff only.
+	container.MustSingleton(c, func () *watchlist.Screener {
+		return &watchlist.Screener{
+			Repository: &repository.Watchlist{
+				Connection: connection,
+				Scope:      scope,
+				Timeout:    timeout,
+			},
+			Notifier: notification.New(cfg.SafeguardingAlertChannel, cfg.SafeguardingAlertWebhookURL, logger),
+		}
+	})
+
+	container.MustSingleton(c, func () *consequence.Engine {
+		rules, err := consequence.ParseRules(cfg.ConsequenceRules)
+		if err != nil {
+			panic(err.Error())
+		}
+		return &consequence.Engine{
+			Rules: rules,
+			RuleRepository: &repository.ConsequenceRule{
+				Connection: connection,
+				Scope:      scope,
+				Timeout:    timeout,
+			},
+			ConsequenceRepository: &repository.Consequence{
+				Connection: connection,
+				Scope:      scope,
+				Timeout:    timeout,
+			},
+			TardyRepository: &repository.Tardy{
+				Connection: connection,
+				Scope:      scope,
+				Timeout:    timeout,
+			},
+			Notifier: notification.New(cfg.StaffAlertChannel, cfg.StaffAlertWebhookURL, logger),
+			NewWebhook: func(url string) entity.Notifier {
+				return notification.New(notification.ChannelWebhook, url, logger)
+			},
+			Deliveries: deliveries,
+		}
+	})
+
+	container.MustSingleton(c, func () *transport.Arrivals {
+		return &transport.Arrivals{
+			Routes: &repository.BusRoute{
+				Connection: connection,
+				Scope:      scope,
+				Timeout:    timeout,
+			},
+			Excusals: &repository.Excusal{
+				Connection: connection,
+				Scope:      scope,
+				Timeout:    timeout,
+			},
+			Tardies: &repository.Tardy{
+				Connection: connection,
+				Scope:      scope,
+				Timeout:    timeout,
+			},
+			Grace: time.Duration(cfg.BusArrivalGraceMinutes) * time.Minute,
+			Location: cfg.Location(),
+		}
+	})
+
+	container.MustSingleton(c, func () *tracker.Feed {
+		return feed
+	})
+
+	container.MustSingleton(c, func () *tracker.Debouncer {
+		return tracker.NewDebouncer(time.Duration(cfg.ScanDebounceSeconds) * time.Second)
+	})
+
+	// The services are filled from the bindings above, the HTTP handlers,
+	// the networked readers and the gRPC service share them.
+	container.MustSingleton(c, func () *visitor.VisitorService {
+		service := &visitor.VisitorService{}
+		container.MustFill(c, service)
+		return service
+	})
+
+	container.MustSingleton(c, func () *tracker.TrackingService {
+		service := &tracker.TrackingService{}
+		container.MustFill(c, service)
+		if memory != nil {
+			// Screening, tardies, consequences and bus arrivals need the
+			// database, scans are tracked without them.
+			service.Screener = nil
+			service.TardyRepository = nil
+			service.Consequences = nil
+			service.Arrivals = nil
 		}
+		return service
 	})
-}
\ No newline at end of file
+}
@@ -1,11 +1,22 @@
 package gocontainer
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/idempotency"
+	"github.com/buzyka/imlate/internal/isb/tenant"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/gin-gonic/gin"
 	"github.com/golobby/container/v3"
 	"github.com/stretchr/testify/assert"
 )
@@ -71,3 +82,199 @@ func TestBuild_NilConfig(t *testing.T) {
 		Build(nil)
 	})
 }
+
+func TestBuild_MemoryEngine(t *testing.T) {
+	// Setup
+	testContainer := container.New()
+	oldGlobal := container.Global
+	container.Global = testContainer
+	defer func() {
+		container.Global = oldGlobal
+	}()
+
+	cfg := &config.Config{
+		DatabaseEngine:   "memory",
+		DatabaseSeedPath: "../../../demo/seed.json",
+		DefaultTenant:    "default",
+		DefaultSite:      "main",
+	}
+
+	// Execute
+	Build(cfg)
+
+	// Assert
+	var visitors entity.VisitorRepository
+	if assert.NoError(t, container.Resolve(&visitors)) {
+		details, err := visitors.FindByKey(context.Background(), "KEY123")
+		assert.NoError(t, err)
+		if assert.NotNil(t, details.Visitor) {
+			assert.Equal(t, "Jane", details.Visitor.Name)
+		}
+	}
+}
+
+func TestBuild_MemoryEngineServesKeyedFindAndTrack(t *testing.T) {
+	// Setup
+	testContainer := container.New()
+	oldGlobal := container.Global
+	container.Global = testContainer
+	defer func() {
+		container.Global = oldGlobal
+	}()
+
+	cfg := &config.Config{
+		DatabaseEngine:         "memory",
+		DatabaseSeedPath:       "../../../demo/seed.json",
+		DefaultTenant:          "default",
+		DefaultSite:            "main",
+		IdempotencyKeyTTLHours: 24,
+	}
+	Build(cfg)
+	var resolver *tenant.Resolver
+	assert.NoError(t, container.Resolve(&resolver))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/v1/find-and-track",
+		resolver.Middleware(),
+		SiteMiddleware(),
+		Handle((*idempotency.Guard).Middleware),
+		Handle((*tracker.TrackerController).FindAndTrackHandler),
+	)
+	send := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v1/find-and-track", bytes.NewBufferString(`{"visit_key":"KEY123","signed_in":true}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(idempotency.Header, "scan-1")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Execute
+	first := send()
+	retry := send()
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	assert.Equal(t, http.StatusOK, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(idempotency.ReplayedHeader))
+	var response tracker.TrackResponse
+	if assert.NoError(t, json.Unmarshal(first.Body.Bytes(), &response)) {
+		assert.Equal(t, "sign-in", response.TrackType)
+	}
+}
+
+func TestBuild_MemoryEngineServesNoDatabaseEndpoints(t *testing.T) {
+	// Setup
+	testContainer := container.New()
+	oldGlobal := container.Global
+	container.Global = testContainer
+	defer func() {
+		container.Global = oldGlobal
+		registry.memory = false
+	}()
+
+	cfg := &config.Config{
+		DatabaseEngine: "memory",
+		DefaultTenant:  "default",
+		DefaultSite:    "main",
+	}
+	Build(cfg)
+	var service *tracker.TrackingService
+	assert.NoError(t, container.Resolve(&service))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/timesheets", DatabaseMiddleware(), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/timesheets", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"`+NotAvailableCode+`"`)
+	assert.Nil(t, service.Screener)
+	assert.Nil(t, service.TardyRepository)
+	assert.Nil(t, service.Consequences)
+	assert.Nil(t, service.Arrivals)
+}
+
+func TestBuild_MemoryEngineSyncsScanOlderThanOnlineTrack(t *testing.T) {
+	// Setup
+	testContainer := container.New()
+	oldGlobal := container.Global
+	container.Global = testContainer
+	defer func() {
+		container.Global = oldGlobal
+		registry.memory = false
+	}()
+
+	cfg := &config.Config{
+		DatabaseEngine:   "memory",
+		DatabaseSeedPath: "../../../demo/seed.json",
+		DefaultTenant:    "default",
+		DefaultSite:      "main",
+	}
+	Build(cfg)
+	var service *tracker.TrackingService
+	assert.NoError(t, container.Resolve(&service))
+	var tracks entity.VisitorTrackRepository
+	assert.NoError(t, container.Resolve(&tracks))
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	signIn, err := tracks.Store(context.Background(), &entity.VisitTrack{VisitorId: 1, VisitKey: "KEY123", SignedIn: true, CreatedAt: day.Add(9 * time.Hour)})
+	assert.NoError(t, err)
+	signOut, err := tracks.Store(context.Background(), &entity.VisitTrack{VisitorId: 1, VisitKey: "KEY123", SignedIn: false, CreatedAt: day.Add(12 * time.Hour)})
+	assert.NoError(t, err)
+	nextDay, err := tracks.Store(context.Background(), &entity.VisitTrack{VisitorId: 1, VisitKey: "KEY123", SignedIn: true, CreatedAt: day.Add(32 * time.Hour)})
+	assert.NoError(t, err)
+
+	// Execute
+	results, err := service.Sync(context.Background(), []tracker.Scan{
+		{Id: "scan-1", VisitKey: "KEY123", ScannedAt: day.Add(8 * time.Hour)},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) && assert.Equal(t, tracker.ScanTracked, results[0].Status) {
+		assert.Equal(t, "sign-in", results[0].Track.TrackType)
+	}
+	for _, expected := range []struct {
+		track    *entity.VisitTrack
+		signedIn bool
+	}{
+		{signIn, false},
+		{signOut, true},
+		{nextDay, true},
+	} {
+		stored, err := tracks.GetById(context.Background(), int64(expected.track.Id))
+		if assert.NoError(t, err) && assert.NotNil(t, stored) {
+			assert.Equal(t, expected.signedIn, stored.SignedIn, "track at %s", stored.CreatedAt)
+		}
+	}
+}
+
+func TestBuild_MemoryEngineMissingSeed(t *testing.T) {
+	// Setup
+	testContainer := container.New()
+	oldGlobal := container.Global
+	container.Global = testContainer
+	defer func() {
+		container.Global = oldGlobal
+	}()
+
+	cfg := &config.Config{
+		DatabaseEngine:   "memory",
+		DatabaseSeedPath: "/does/not/exist/seed.json",
+		DefaultTenant:    "default",
+		DefaultSite:      "main",
+	}
+
+	// Execute & Assert
+	assert.Panics(t, func() {
+		Build(cfg)
+	})
+}
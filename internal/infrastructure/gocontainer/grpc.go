@@ -0,0 +1,43 @@
+package gocontainer
+
+import (
+	imlatev1 "github.com/buzyka/imlate/api/imlate/v1"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/grpcapi"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/buzyka/imlate/internal/isb/visitor"
+	"github.com/golobby/container/v3"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// GrpcServer returns the gRPC server of the Tracking service. Calls are
+// served by the services of their tenant and site, the same the HTTP
+// requests are served by.
+func GrpcServer() *grpc.Server {
+	var logger *zap.SugaredLogger
+	container.MustResolve(container.Global, &logger)
+	var feed *tracker.Feed
+	container.MustResolve(container.Global, &feed)
+
+	server := grpc.NewServer()
+	imlatev1.RegisterTrackingServer(server, &grpcapi.Server{
+		Tenants:       registry.tenants,
+		DefaultTenant: registry.tenantCode,
+		Sites:         registry.sites,
+		DefaultSite:   registry.siteCode,
+		Trackers: func(t *entity.Tenant, s *entity.Site) grpcapi.Tracker {
+			var service *tracker.TrackingService
+			container.MustResolve(ForSite(t, s), &service)
+			return service
+		},
+		Finders: func(t *entity.Tenant, s *entity.Site) grpcapi.Finder {
+			var service *visitor.VisitorService
+			container.MustResolve(ForSite(t, s), &service)
+			return service
+		},
+		Feed:   feed,
+		Logger: logger,
+	})
+	return server
+}
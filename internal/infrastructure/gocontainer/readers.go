@@ -0,0 +1,56 @@
+package gocontainer
+
+import (
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/broker"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/ingest"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/golobby/container/v3"
+	"go.uber.org/zap"
+)
+
+// ReaderSubscriber connects to the broker of the networked readers, nil
+// when none is configured. Scans are tracked by the tracker of the reader's
+// site, the same the kiosk requests are served by.
+func ReaderSubscriber(cfg *config.Config) (*ingest.Subscriber, error) {
+	if cfg.MqttBrokerURL == "" {
+		return nil, nil
+	}
+	scans, err := ingest.ParseTemplate(cfg.MqttScanTopic)
+	if err != nil {
+		return nil, err
+	}
+	results, err := ingest.ParseTemplate(cfg.MqttResultTopic)
+	if err != nil {
+		return nil, err
+	}
+	messageBroker, err := broker.Connect(broker.Options{
+		URL:      cfg.MqttBrokerURL,
+		ClientId: cfg.MqttClientId,
+		Username: cfg.MqttUsername,
+		Password: cfg.MqttPassword,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var logger *zap.SugaredLogger
+	container.MustResolve(container.Global, &logger)
+	return &ingest.Subscriber{
+		Broker:  messageBroker,
+		Scans:   scans,
+		Results: results,
+		Mapping: ingest.Mapping{
+			KeyField:       cfg.MqttKeyField,
+			DirectionField: cfg.MqttDirectionField,
+		},
+		Resolve: Resolve,
+		Trackers: func(t *entity.Tenant, s *entity.Site) ingest.Tracker {
+			var service *tracker.TrackingService
+			container.MustResolve(ForSite(t, s), &service)
+			return service
+		},
+		Logger: logger,
+	}, nil
+}
@@ -0,0 +1,174 @@
+package gocontainer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/site"
+	"github.com/buzyka/imlate/internal/isb/tenant"
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/v3"
+)
+
+var registry = &siteRegistry{}
+
+// ErrUnknownSite is returned by Resolve for codes naming no tenant or site.
+var ErrUnknownSite = errors.New("unknown tenant or site")
+
+// NotAvailableCode is the code of requests to endpoints the memory engine
+// does not serve, see DatabaseMiddleware.
+const NotAvailableCode = "not_available"
+
+// siteKey names a site of a tenant, site ids are unique across tenants but
+// the tenant is part of what a container is built for.
+type siteKey struct {
+	tenantId int64
+	siteId   int64
+}
+
+// siteContainer is the container of a site built for the settings of the
+// site and its tenant at the time.
+type siteContainer struct {
+	tenant    *entity.Tenant
+	site      *entity.Site
+	container container.Container
+}
+
+// siteRegistry keeps one container per site of each tenant, built on first
+// use and again once the settings of the site or tenant change.
+type siteRegistry struct {
+	mu         sync.Mutex
+	build      func(t *entity.Tenant, s *entity.Site) container.Container
+	tenants    entity.TenantRepository
+	tenantCode string
+	sites      func(t *entity.Tenant) entity.SiteRepository
+	siteCode   string
+	containers map[siteKey]*siteContainer
+	global     *siteContainer
+	// memory is set when records are kept by the memory engine, which keeps
+	// tenants, sites, visitors, tracks and idempotency keys only.
+	memory bool
+}
+
+func (r *siteRegistry) reset(defaultTenant *entity.Tenant, defaultSite *entity.Site, c container.Container, build func(t *entity.Tenant, s *entity.Site) container.Container) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.build = build
+	r.containers = map[siteKey]*siteContainer{
+		{defaultTenant.Id, defaultSite.Id}: {tenant: defaultTenant, site: defaultSite, container: c},
+	}
+}
+
+func (r *siteRegistry) get(t *entity.Tenant, s *entity.Site) *siteContainer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t == nil || s == nil || r.build == nil {
+		if r.global == nil {
+			r.global = &siteContainer{container: container.Global}
+		}
+		return r.global
+	}
+	key := siteKey{t.Id, s.Id}
+	if existing, ok := r.containers[key]; ok && existing.tenant.Equal(t) && existing.site.Equal(s) {
+		return existing
+	}
+	built := &siteContainer{tenant: t, site: s, container: r.build(t, s)}
+	r.containers[key] = built
+	return built
+}
+
+// ForSite returns the container of the site of the tenant, the global one
+// without either.
+func ForSite(t *entity.Tenant, s *entity.Site) container.Container {
+	return registry.get(t, s).container
+}
+
+// Resolve returns the tenant and the site of the codes, empty codes name the
+// defaults. Entry points other than HTTP, readers on a message broker for
+// one, use it the way requests use the tenant and site middlewares.
+func Resolve(tenantCode string, siteCode string) (*entity.Tenant, *entity.Site, error) {
+	if tenantCode == "" {
+		tenantCode = registry.tenantCode
+	}
+	if siteCode == "" {
+		siteCode = registry.siteCode
+	}
+	t, err := registry.tenants.GetByCode(tenantCode)
+	if err != nil {
+		return nil, nil, err
+	}
+	if t == nil {
+		return nil, nil, fmt.Errorf("%w: tenant %q", ErrUnknownSite, tenantCode)
+	}
+	s, err := registry.sites(t).GetByCode(siteCode)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s == nil {
+		return nil, nil, fmt.Errorf("%w: site %q of tenant %q", ErrUnknownSite, siteCode, tenantCode)
+	}
+	return t, s, nil
+}
+
+// SiteMiddleware resolves the site of the request among the sites of its
+// tenant, see tenant.Resolver and site.Resolver.
+func SiteMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		resolver := &site.Resolver{
+			Repository:  registry.sites(tenant.FromContext(ctx.Request.Context())),
+			DefaultCode: registry.siteCode,
+		}
+		resolver.Middleware()(ctx)
+	}
+}
+
+// DatabaseMiddleware answers 501 to requests of endpoints whose records the
+// memory engine does not keep, they need a database.
+func DatabaseMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if registry.memory {
+			ctx.AbortWithStatusJSON(http.StatusNotImplemented, util.ExtendedFailureResponse{
+				Code:  NotAvailableCode,
+				Error: "Not available in memory mode, it needs a database",
+			})
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// Handle serves each request with a controller filled from the container of
+// the request's tenant and site.
+func Handle[T any](handler func(*T) gin.HandlerFunc) gin.HandlerFunc {
+	type siteHandler struct {
+		source *siteContainer
+		handle gin.HandlerFunc
+	}
+	var mu sync.Mutex
+	handlers := map[siteKey]*siteHandler{}
+	return func(ctx *gin.Context) {
+		t := tenant.FromContext(ctx.Request.Context())
+		s := site.FromContext(ctx.Request.Context())
+		source := registry.get(t, s)
+		var key siteKey
+		if t != nil && s != nil {
+			key = siteKey{t.Id, s.Id}
+		}
+
+		mu.Lock()
+		current, ok := handlers[key]
+		if !ok || current.source != source {
+			controller := new(T)
+			container.MustFill(source.container, controller)
+			current = &siteHandler{source: source, handle: handler(controller)}
+			handlers[key] = current
+		}
+		mu.Unlock()
+
+		current.handle(ctx)
+	}
+}
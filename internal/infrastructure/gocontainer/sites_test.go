@@ -0,0 +1,174 @@
+package gocontainer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/site"
+	"github.com/buzyka/imlate/internal/isb/tenant"
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type siteNameController struct {
+	Site *entity.Site `container:"type"`
+}
+
+func (c *siteNameController) NameHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, c.Site.Name)
+	}
+}
+
+var defaultTenant = &entity.Tenant{Id: 1, Code: "default", Name: "Default school"}
+
+func newTestRegistry(defaultSite *entity.Site) *int {
+	builds := 0
+	build := func(t *entity.Tenant, s *entity.Site) container.Container {
+		builds++
+		c := container.New()
+		container.MustSingleton(c, func() *entity.Site {
+			return s
+		})
+		container.MustSingleton(c, func() *entity.Tenant {
+			return t
+		})
+		return c
+	}
+	registry.reset(defaultTenant, defaultSite, build(defaultTenant, defaultSite), build)
+	builds = 0
+	return &builds
+}
+
+func serveForSite(handler gin.HandlerFunc, s *entity.Site) string {
+	return serveForTenantSite(handler, defaultTenant, s)
+}
+
+func serveForTenantSite(handler gin.HandlerFunc, t *entity.Tenant, s *entity.Site) string {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("GET", "/", nil)
+	ctx.Request = ctx.Request.WithContext(tenant.NewContext(site.NewContext(ctx.Request.Context(), s), t))
+	handler(ctx)
+	return w.Body.String()
+}
+
+func TestHandle_FillsControllerPerSite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mainSite := &entity.Site{Id: 1, Code: "main", Name: "Main site"}
+	builds := newTestRegistry(mainSite)
+	handler := Handle((*siteNameController).NameHandler)
+
+	assert.Equal(t, "Main site", serveForSite(handler, &entity.Site{Id: 1, Code: "main", Name: "Main site"}))
+	assert.Equal(t, "North campus", serveForSite(handler, &entity.Site{Id: 2, Code: "north", Name: "North campus"}))
+	assert.Equal(t, "North campus", serveForSite(handler, &entity.Site{Id: 2, Code: "north", Name: "North campus"}))
+	assert.Equal(t, 1, *builds)
+}
+
+func TestHandle_RebuildsWhenSiteChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	builds := newTestRegistry(&entity.Site{Id: 1, Code: "main", Name: "Main site"})
+	handler := Handle((*siteNameController).NameHandler)
+
+	assert.Equal(t, "Main site", serveForSite(handler, &entity.Site{Id: 1, Code: "main", Name: "Main site"}))
+	assert.Equal(t, "Main campus", serveForSite(handler, &entity.Site{Id: 1, Code: "main", Name: "Main campus"}))
+	assert.Equal(t, 1, *builds)
+}
+
+type tenantNameController struct {
+	Tenant *entity.Tenant `container:"type"`
+}
+
+func (c *tenantNameController) NameHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, c.Tenant.Name)
+	}
+}
+
+func TestHandle_FillsControllerPerTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mainSite := &entity.Site{Id: 1, Code: "main", Name: "Main site"}
+	builds := newTestRegistry(mainSite)
+	handler := Handle((*tenantNameController).NameHandler)
+	riverside := &entity.Tenant{Id: 2, Code: "riverside", Name: "Riverside School"}
+	riversideSite := &entity.Site{Id: 5, Code: "main", Name: "Main site"}
+
+	assert.Equal(t, "Default school", serveForSite(handler, mainSite))
+	assert.Equal(t, "Riverside School", serveForTenantSite(handler, riverside, riversideSite))
+	assert.Equal(t, "Riverside School", serveForTenantSite(handler, riverside, riversideSite))
+	assert.Equal(t, 1, *builds)
+}
+
+func TestHandle_RebuildsWhenTenantChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mainSite := &entity.Site{Id: 1, Code: "main", Name: "Main site"}
+	builds := newTestRegistry(mainSite)
+	handler := Handle((*tenantNameController).NameHandler)
+	renamed := &entity.Tenant{Id: 1, Code: "default", Name: "Renamed school"}
+
+	assert.Equal(t, "Default school", serveForSite(handler, mainSite))
+	assert.Equal(t, "Renamed school", serveForTenantSite(handler, renamed, mainSite))
+	assert.Equal(t, 1, *builds)
+}
+
+type codeTenants struct {
+	entity.TenantRepository
+	tenants []*entity.Tenant
+}
+
+func (r *codeTenants) GetByCode(code string) (*entity.Tenant, error) {
+	for _, t := range r.tenants {
+		if t.Code == code {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+type codeSites struct {
+	entity.SiteRepository
+	sites []*entity.Site
+}
+
+func (r *codeSites) GetByCode(code string) (*entity.Site, error) {
+	for _, s := range r.sites {
+		if s.Code == code {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestResolve_DefaultsAndCodes(t *testing.T) {
+	mainSite := &entity.Site{Id: 1, Code: "main", Name: "Main site"}
+	northSite := &entity.Site{Id: 2, Code: "north", Name: "North campus"}
+	otherTenant := &entity.Tenant{Id: 2, Code: "oak", Name: "Oak school"}
+	newTestRegistry(mainSite)
+	registry.tenants = &codeTenants{tenants: []*entity.Tenant{defaultTenant, otherTenant}}
+	registry.tenantCode = defaultTenant.Code
+	registry.sites = func(t *entity.Tenant) entity.SiteRepository {
+		if t.Id == defaultTenant.Id {
+			return &codeSites{sites: []*entity.Site{mainSite, northSite}}
+		}
+		return &codeSites{}
+	}
+	registry.siteCode = mainSite.Code
+
+	tn, s, err := Resolve("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultTenant, tn)
+	assert.Equal(t, mainSite, s)
+
+	_, s, err = Resolve("default", "north")
+	assert.NoError(t, err)
+	assert.Equal(t, northSite, s)
+
+	_, _, err = Resolve("oak", "")
+	assert.ErrorIs(t, err, ErrUnknownSite)
+
+	_, _, err = Resolve("missing", "")
+	assert.ErrorIs(t, err, ErrUnknownSite)
+}
@@ -56,6 +56,11 @@ func timeEncoder() zapcore.TimeEncoder {
 	}
 }
 
+// NewContext returns a copy of ctx carrying the logger.
+func NewContext(ctx context.Context, logger *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
 func FromContext(ctx context.Context) *zap.SugaredLogger {
 	if logger, ok := ctx.Value(loggerKey).(*zap.SugaredLogger); ok {
 		return logger
@@ -76,7 +81,6 @@ func Fallback() *zap.SugaredLogger {
 
 func NewLoggingMiddleware(logger *zap.SugaredLogger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		updatedCtx := context.WithValue(c.Request.Context(), loggerKey, logger)
-		c.Request = c.Request.WithContext(updatedCtx)
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), logger))
 	}
 }
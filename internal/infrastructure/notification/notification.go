@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"go.uber.org/zap"
+)
+
+const (
+	ChannelLog     = "log"
+	ChannelWebhook = "webhook"
+)
+
+// New returns the notifier for the channel, unknown channels fall back to the log.
+func New(channel string, webhookURL string, logger *zap.SugaredLogger) entity.Notifier {
+	if channel == ChannelWebhook && webhookURL != "" {
+		return &Webhook{
+			URL:    webhookURL,
+			Client: &http.Client{Timeout: 5 * time.Second},
+			Logger: logger,
+		}
+	}
+	return &Log{Logger: logger}
+}
+
+// Log writes notifications to the application log.
+type Log struct {
+	Logger *zap.SugaredLogger
+}
+
+func (n *Log) Notify(notification entity.Notification) error {
+	n.Logger.Warnw(notification.Subject,
+		"notification_type", notification.Type,
+		"message", notification.Message,
+		"data", notification.Data,
+	)
+	return nil
+}
+
+// Webhook posts notifications as JSON to a URL, e.g. a chat or paging integration.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+	Logger *zap.SugaredLogger
+}
+
+func (n *Webhook) Notify(notification entity.Notification) error {
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	response, err := n.Client.Post(n.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		n.Logger.Errorf("Error sending %s notification: %s", notification.Type, err.Error())
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusBadRequest {
+		err = fmt.Errorf("webhook responded with status %d", response.StatusCode)
+		n.Logger.Errorf("Error sending %s notification: %s", notification.Type, err.Error())
+		return err
+	}
+	return nil
+}
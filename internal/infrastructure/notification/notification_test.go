@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNewWillSelectChannel(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	assert.IsType(t, &Log{}, New(ChannelLog, "", logger))
+	assert.IsType(t, &Log{}, New(ChannelWebhook, "", logger))
+	assert.IsType(t, &Webhook{}, New(ChannelWebhook, "http://localhost/hook", logger))
+}
+
+func TestLogNotifyWillWriteWarning(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	notifier := &Log{Logger: zap.New(core).Sugar()}
+
+	err := notifier.Notify(entity.Notification{Type: "test", Subject: "Something happened"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, logs.FilterMessage("Something happened").Len())
+}
+
+func TestWebhookNotifyWillPostJSON(t *testing.T) {
+	var received entity.Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := New(ChannelWebhook, server.URL, zap.NewNop().Sugar())
+	err := notifier.Notify(entity.Notification{Type: "test", Subject: "Hello", Data: map[string]any{"visitor_id": 1}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", received.Subject)
+	assert.False(t, received.CreatedAt.IsZero())
+}
+
+func TestWebhookNotifyWithErrorStatusWillReturnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := New(ChannelWebhook, server.URL, zap.NewNop().Sugar())
+	err := notifier.Notify(entity.Notification{Type: "test"})
+
+	assert.Error(t, err)
+}
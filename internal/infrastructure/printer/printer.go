@@ -0,0 +1,73 @@
+package printer
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const (
+	filePrefix = "file:"
+	tcpPrefix  = "tcp://"
+)
+
+// New returns the printer for the target, either "file:/dev/usb/lp0" for a
+// local device or spool file, or "tcp://host:9100" for a network printer.
+// An empty target disables printing.
+func New(target string) (entity.SlipPrinter, error) {
+	switch {
+	case target == "":
+		return &Disabled{}, nil
+	case strings.HasPrefix(target, filePrefix):
+		return &File{Path: strings.TrimPrefix(target, filePrefix)}, nil
+	case strings.HasPrefix(target, tcpPrefix):
+		return &Socket{Address: strings.TrimPrefix(target, tcpPrefix), Timeout: 5 * time.Second}, nil
+	}
+	return nil, fmt.Errorf("unsupported slip printer target %q, expected file:<path> or tcp://<host:port>", target)
+}
+
+type Disabled struct{}
+
+func (p *Disabled) Print(data []byte) error {
+	return entity.ErrPrinterNotConfigured
+}
+
+// File appends to a file, which also covers printer device files.
+type File struct {
+	Path string
+}
+
+func (p *File) Print(data []byte) error {
+	file, err := os.OpenFile(p.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Socket writes to a raw TCP printer port, usually 9100.
+type Socket struct {
+	Address string
+	Timeout time.Duration
+}
+
+func (p *Socket) Print(data []byte) error {
+	conn, err := net.DialTimeout("tcp", p.Address, p.Timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetWriteDeadline(time.Now().Add(p.Timeout)); err != nil {
+		return err
+	}
+	_, err = conn.Write(data)
+	return err
+}
@@ -0,0 +1,63 @@
+package printer
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_Targets(t *testing.T) {
+	p, err := New("")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, p.Print([]byte("slip")), entity.ErrPrinterNotConfigured)
+
+	p, err = New("file:/dev/usb/lp0")
+	assert.NoError(t, err)
+	assert.Equal(t, &File{Path: "/dev/usb/lp0"}, p)
+
+	p, err = New("tcp://printer:9100")
+	assert.NoError(t, err)
+	assert.Equal(t, "printer:9100", p.(*Socket).Address)
+
+	_, err = New("lpr://printer")
+	assert.Error(t, err)
+}
+
+func TestFile_PrintAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slips.bin")
+	p := &File{Path: path}
+
+	assert.NoError(t, p.Print([]byte("one")))
+	assert.NoError(t, p.Print([]byte("two")))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "onetwo", string(content))
+}
+
+func TestSocket_PrintWritesToConnection(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		data, _ := io.ReadAll(conn)
+		received <- data
+	}()
+
+	p, err := New("tcp://" + listener.Addr().String())
+	assert.NoError(t, err)
+	assert.NoError(t, p.Print([]byte{0x1b, 0x40}))
+	assert.Equal(t, []byte{0x1b, 0x40}, <-received)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const attendanceSelect = "SELECT id, lesson_id, visitor_id, date, status, scanned_at, minutes_late, note FROM attendance"
+
+type Attendance struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+}
+
+func (r *Attendance) FindOne(lessonId int64, visitorId int32, date string) (*entity.Attendance, error) {
+	list, err := r.find(attendanceSelect+" WHERE lesson_id = ? AND visitor_id = ? AND date = ?"+r.tenantAnd(""), lessonId, visitorId, date)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0], nil
+}
+
+func (r *Attendance) FindByLessonAndDate(lessonId int64, date string) ([]*entity.Attendance, error) {
+	return r.find(attendanceSelect+" WHERE lesson_id = ? AND date = ?"+r.tenantAnd(""), lessonId, date)
+}
+
+func (r *Attendance) Save(attendance *entity.Attendance) error {
+	var scannedAt sql.NullString
+	if attendance.ScannedAt != nil {
+		scannedAt = sql.NullString{String: attendance.ScannedAt.UTC().Format(dateTimeLayout), Valid: true}
+	}
+	res, err := r.Connection.Exec(
+		"INSERT INTO attendance (tenant_id, lesson_id, visitor_id, date, status, scanned_at, minutes_late, note) VALUES (?, ?, ?, ?, ?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id), status = VALUES(status), scanned_at = VALUES(scanned_at), minutes_late = VALUES(minutes_late), note = VALUES(note)",
+		r.TenantId,
+		attendance.LessonId,
+		attendance.VisitorId,
+		attendance.Date,
+		attendance.Status,
+		scannedAt,
+		attendance.MinutesLate,
+		attendance.Note,
+	)
+	if err != nil {
+		return err
+	}
+	attendance.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *Attendance) find(query string, args ...any) ([]*entity.Attendance, error) {
+	rows, err := r.Connection.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*entity.Attendance{}
+	for rows.Next() {
+		var date []byte
+		var scannedAt, note sql.NullString
+		attendance := &entity.Attendance{}
+		err := rows.Scan(
+			&attendance.Id,
+			&attendance.LessonId,
+			&attendance.VisitorId,
+			&date,
+			&attendance.Status,
+			&scannedAt,
+			&attendance.MinutesLate,
+			&note,
+		)
+		if err != nil {
+			return nil, err
+		}
+		attendance.Date = string(date)
+		if attendance.ScannedAt, err = parseNullDateTime(scannedAt); err != nil {
+			return nil, err
+		}
+		attendance.Note = note.String
+		list = append(list, attendance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttendanceSave_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Attendance{
+		Connection: db,
+	}
+
+	scannedAt := time.Date(2024, 9, 2, 8, 42, 0, 0, time.UTC)
+	mock.ExpectExec("INSERT INTO attendance").
+		WithArgs(int64(0), int64(3), int32(7), "2024-09-02", "late", "2024-09-02 08:42:00", 12, "").
+		WillReturnResult(sqlmock.NewResult(11, 1))
+
+	// Execute
+	attendance := &entity.Attendance{
+		LessonId:    3,
+		VisitorId:   7,
+		Date:        "2024-09-02",
+		Status:      entity.AttendanceLate,
+		ScannedAt:   &scannedAt,
+		MinutesLate: 12,
+	}
+	err = repo.Save(attendance)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), attendance.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttendanceFindOne_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Attendance{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "lesson_id", "visitor_id", "date", "status", "scanned_at", "minutes_late", "note"}).
+		AddRow(11, 3, 7, "2024-09-02", "late", "2024-09-02 08:42:00", 12, nil)
+	mock.ExpectQuery("SELECT id, lesson_id, visitor_id, date, status, scanned_at, minutes_late, note FROM attendance").
+		WithArgs(int64(3), int32(7), "2024-09-02").
+		WillReturnRows(rows)
+
+	// Execute
+	attendance, err := repo.FindOne(3, 7, "2024-09-02")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AttendanceLate, attendance.Status)
+	assert.Equal(t, 12, attendance.MinutesLate)
+	assert.Equal(t, time.Date(2024, 9, 2, 8, 42, 0, 0, time.UTC), *attendance.ScannedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttendanceFindOne_NotFound(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Attendance{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT id, lesson_id").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// Execute
+	attendance, err := repo.FindOne(3, 7, "2024-09-02")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, attendance)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
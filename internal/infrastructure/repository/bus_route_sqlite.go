@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const busRouteSelect = "SELECT r.id, r.name, r.device_id, COUNT(br.visitor_id) FROM bus_routes AS r LEFT JOIN bus_riders AS br ON br.route_id = r.id"
+
+const busRiderSelect = "SELECT br.route_id, br.stop_id, s.name, v.id, v.name, v.surname, v.grade FROM bus_riders AS br INNER JOIN visitors AS v ON v.id = br.visitor_id LEFT JOIN bus_stops AS s ON s.id = br.stop_id"
+
+type BusRoute struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+	Timeout
+}
+
+func (r *BusRoute) FindAll(ctx context.Context) ([]*entity.BusRoute, error) {
+	return r.findRoutes(ctx, busRouteSelect+r.where("r")+" GROUP BY r.id ORDER BY r.name")
+}
+
+func (r *BusRoute) GetById(ctx context.Context, id int64) (*entity.BusRoute, error) {
+	routes, err := r.findRoutes(ctx, busRouteSelect+" WHERE r.id = ?"+r.and("r")+" GROUP BY r.id", id)
+	if err != nil || len(routes) == 0 {
+		return nil, err
+	}
+	return r.withStops(ctx, routes[0])
+}
+
+func (r *BusRoute) FindByDeviceId(ctx context.Context, deviceId string) (*entity.BusRoute, error) {
+	routes, err := r.findRoutes(ctx, busRouteSelect+" WHERE r.device_id = ?"+r.and("r")+" GROUP BY r.id", deviceId)
+	if err != nil || len(routes) == 0 {
+		return nil, err
+	}
+	return r.withStops(ctx, routes[0])
+}
+
+func (r *BusRoute) Store(ctx context.Context, route *entity.BusRoute) error {
+	var deviceId sql.NullString
+	if route.DeviceId != "" {
+		deviceId = sql.NullString{String: route.DeviceId, Valid: true}
+	}
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	tx, err := r.Connection.BeginTx(queryCtx, nil)
+	if err != nil {
+		return r.failed(queryCtx, "Storing bus route", err)
+	}
+	res, err := tx.ExecContext(queryCtx, "INSERT INTO bus_routes (tenant_id, site_id, name, device_id) VALUES (?, ?, ?, ?)", r.TenantId, r.SiteId, route.Name, deviceId)
+	if err != nil {
+		_ = tx.Rollback()
+		return r.failed(queryCtx, "Storing bus route", err)
+	}
+	routeId, err := res.LastInsertId()
+	if err != nil {
+		_ = tx.Rollback()
+		return r.failed(queryCtx, "Storing bus route", err)
+	}
+	for position, stop := range route.Stops {
+		var pickupAt sql.NullString
+		if stop.PickupAt != "" {
+			pickupAt = sql.NullString{String: stop.PickupAt, Valid: true}
+		}
+		res, err := tx.ExecContext(queryCtx, "INSERT INTO bus_stops (tenant_id, route_id, name, position, pickup_at) VALUES (?, ?, ?, ?, ?)", r.TenantId, routeId, stop.Name, position, pickupAt)
+		if err != nil {
+			_ = tx.Rollback()
+			return r.failed(queryCtx, "Storing bus route", err)
+		}
+		if stop.Id, err = res.LastInsertId(); err != nil {
+			_ = tx.Rollback()
+			return r.failed(queryCtx, "Storing bus route", err)
+		}
+		stop.RouteId = routeId
+		stop.Position = position
+	}
+	if err := tx.Commit(); err != nil {
+		return r.failed(queryCtx, "Storing bus route", err)
+	}
+	route.Id = routeId
+	return nil
+}
+
+func (r *BusRoute) Delete(ctx context.Context, id int64) error {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	_, err := r.Connection.ExecContext(queryCtx, "DELETE FROM bus_routes WHERE id = ?"+r.and(""), id)
+	return r.failed(queryCtx, "Deleting bus route", err)
+}
+
+func (r *BusRoute) AssignRider(ctx context.Context, routeId int64, visitorId int32, stopId int64) error {
+	var stop sql.NullInt64
+	if stopId > 0 {
+		stop = sql.NullInt64{Int64: stopId, Valid: true}
+	}
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	_, err := r.Connection.ExecContext(
+		queryCtx,
+		"INSERT INTO bus_riders (tenant_id, visitor_id, route_id, stop_id) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE route_id = VALUES(route_id), stop_id = VALUES(stop_id)",
+		r.TenantId,
+		visitorId,
+		routeId,
+		stop,
+	)
+	return r.failed(queryCtx, "Assigning bus rider", err)
+}
+
+func (r *BusRoute) RemoveRider(ctx context.Context, routeId int64, visitorId int32) error {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	_, err := r.Connection.ExecContext(queryCtx, "DELETE FROM bus_riders WHERE route_id = ? AND visitor_id = ?"+r.tenantAnd(""), routeId, visitorId)
+	return r.failed(queryCtx, "Removing bus rider", err)
+}
+
+func (r *BusRoute) FindRiders(ctx context.Context, routeId int64) ([]*entity.BusRider, error) {
+	return r.findRiders(ctx, busRiderSelect+" WHERE br.route_id = ?"+r.tenantAnd("br")+" ORDER BY v.surname, v.name", routeId)
+}
+
+func (r *BusRoute) FindRidersWithoutTrackSince(ctx context.Context, routeId int64, since time.Time) ([]*entity.BusRider, error) {
+	return r.findRiders(ctx,
+		busRiderSelect+" WHERE br.route_id = ?"+r.tenantAnd("br")+" AND NOT EXISTS (SELECT 1 FROM track AS t WHERE t.visitor_id = br.visitor_id AND t.created_at > ?) ORDER BY s.position, v.surname, v.name",
+		routeId,
+		since,
+	)
+}
+
+func (r *BusRoute) FindVisitorIdsByRoute(ctx context.Context, routeId int64) ([]int32, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(queryCtx, "SELECT visitor_id FROM bus_riders WHERE route_id = ?"+r.tenantAnd(""), routeId)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Finding riders of bus route", err)
+	}
+	defer rows.Close()
+
+	ids := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, r.failed(queryCtx, "Finding riders of bus route", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Finding riders of bus route", err)
+	}
+	return ids, nil
+}
+
+func (r *BusRoute) FindRouteIdByVisitorId(ctx context.Context, visitorId int32) (int64, error) {
+	var routeId int64
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	err := r.Connection.QueryRowContext(queryCtx, "SELECT route_id FROM bus_riders WHERE visitor_id = ?"+r.tenantAnd(""), visitorId).Scan(&routeId)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return routeId, r.failed(queryCtx, "Finding bus route of visitor", err)
+}
+
+func (r *BusRoute) StoreArrival(ctx context.Context, arrival *entity.BusArrival) error {
+	var deviceId sql.NullString
+	if arrival.DeviceId != "" {
+		deviceId = sql.NullString{String: arrival.DeviceId, Valid: true}
+	}
+	var excusalId sql.NullInt64
+	if arrival.ExcusalId > 0 {
+		excusalId = sql.NullInt64{Int64: arrival.ExcusalId, Valid: true}
+	}
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	res, err := r.Connection.ExecContext(
+		queryCtx,
+		"INSERT INTO bus_arrivals (tenant_id, route_id, device_id, arrived_at, excusal_id) VALUES (?, ?, ?, ?, ?)",
+		r.TenantId,
+		arrival.RouteId,
+		deviceId,
+		arrival.ArrivedAt,
+		excusalId,
+	)
+	if err != nil {
+		return r.failed(queryCtx, "Storing bus arrival", err)
+	}
+	arrival.Id, err = res.LastInsertId()
+	return r.failed(queryCtx, "Storing bus arrival", err)
+}
+
+func (r *BusRoute) FindLatestArrival(ctx context.Context, routeId int64, since time.Time) (*entity.BusArrival, error) {
+	var deviceId sql.NullString
+	var excusalId sql.NullInt64
+	var arrivedAtRaw []byte
+	arrival := &entity.BusArrival{}
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	err := r.Connection.QueryRowContext(
+		queryCtx,
+		"SELECT id, route_id, device_id, arrived_at, excusal_id FROM bus_arrivals WHERE route_id = ? AND arrived_at >= ?"+r.tenantAnd("")+" ORDER BY arrived_at DESC LIMIT 1",
+		routeId,
+		since,
+	).Scan(&arrival.Id, &arrival.RouteId, &deviceId, &arrivedAtRaw, &excusalId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, r.failed(queryCtx, "Finding latest bus arrival", err)
+	}
+	arrival.DeviceId = deviceId.String
+	arrival.ExcusalId = excusalId.Int64
+	if arrival.ArrivedAt, err = parseDateTime(arrivedAtRaw); err != nil {
+		return nil, err
+	}
+	return arrival, nil
+}
+
+func (r *BusRoute) withStops(ctx context.Context, route *entity.BusRoute) (*entity.BusRoute, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(queryCtx, "SELECT id, route_id, name, position, pickup_at FROM bus_stops WHERE route_id = ?"+r.tenantAnd("")+" ORDER BY position", route.Id)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Finding bus stops", err)
+	}
+	defer rows.Close()
+
+	route.Stops = []*entity.BusStop{}
+	for rows.Next() {
+		var pickupAt sql.NullString
+		stop := &entity.BusStop{}
+		if err := rows.Scan(&stop.Id, &stop.RouteId, &stop.Name, &stop.Position, &pickupAt); err != nil {
+			return nil, r.failed(queryCtx, "Finding bus stops", err)
+		}
+		stop.PickupAt = pickupAt.String
+		route.Stops = append(route.Stops, stop)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Finding bus stops", err)
+	}
+	return route, nil
+}
+
+func (r *BusRoute) findRoutes(ctx context.Context, query string, args ...any) ([]*entity.BusRoute, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(queryCtx, query, args...)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Finding bus routes", err)
+	}
+	defer rows.Close()
+
+	routes := []*entity.BusRoute{}
+	for rows.Next() {
+		var deviceId sql.NullString
+		route := &entity.BusRoute{}
+		if err := rows.Scan(&route.Id, &route.Name, &deviceId, &route.Riders); err != nil {
+			return nil, r.failed(queryCtx, "Finding bus routes", err)
+		}
+		route.DeviceId = deviceId.String
+		routes = append(routes, route)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Finding bus routes", err)
+	}
+	return routes, nil
+}
+
+func (r *BusRoute) findRiders(ctx context.Context, query string, args ...any) ([]*entity.BusRider, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(queryCtx, query, args...)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Finding bus riders", err)
+	}
+	defer rows.Close()
+
+	riders := []*entity.BusRider{}
+	for rows.Next() {
+		var stopId sql.NullInt64
+		var stopName, surname sql.NullString
+		var grade sql.NullInt32
+		rider := &entity.BusRider{Visitor: &entity.Visitor{}}
+		err := rows.Scan(
+			&rider.RouteId,
+			&stopId,
+			&stopName,
+			&rider.Visitor.Id,
+			&rider.Visitor.Name,
+			&surname,
+			&grade,
+		)
+		if err != nil {
+			return nil, r.failed(queryCtx, "Finding bus riders", err)
+		}
+		rider.StopId = stopId.Int64
+		rider.StopName = stopName.String
+		rider.Visitor.Surname = surname.String
+		rider.Visitor.Grade = int(grade.Int32)
+		riders = append(riders, rider)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Finding bus riders", err)
+	}
+	return riders, nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var busRiderColumns = []string{"route_id", "stop_id", "stop_name", "id", "name", "surname", "grade"}
+
+func TestBusRouteStore_WithStops(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &BusRoute{
+		Connection: db,
+		Scope:      Scope{SiteId: 2},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO bus_routes").
+		WithArgs(int64(0), int64(2), "North", "bus-1").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec("INSERT INTO bus_stops").
+		WithArgs(int64(0), int64(2), "Mill Lane", 0, "07:40").
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("INSERT INTO bus_stops").
+		WithArgs(int64(0), int64(2), "Church", 1, nil).
+		WillReturnResult(sqlmock.NewResult(6, 1))
+	mock.ExpectCommit()
+
+	// Execute
+	route := &entity.BusRoute{
+		Name:     "North",
+		DeviceId: "bus-1",
+		Stops:    []*entity.BusStop{{Name: "Mill Lane", PickupAt: "07:40"}, {Name: "Church"}},
+	}
+	err = repo.Store(context.Background(), route)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), route.Id)
+	assert.Equal(t, int64(6), route.Stops[1].Id)
+	assert.Equal(t, 1, route.Stops[1].Position)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBusRouteGetById_WithStops(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &BusRoute{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM bus_routes AS r LEFT JOIN bus_riders AS br ON br.route_id = r.id WHERE r.id = \\? GROUP BY r.id").
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "device_id", "riders"}).AddRow(2, "North", nil, 31))
+	mock.ExpectQuery("SELECT (.+) FROM bus_stops WHERE route_id = \\? ORDER BY position").
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "route_id", "name", "position", "pickup_at"}).AddRow(5, 2, "Mill Lane", 0, "07:40"))
+
+	// Execute
+	route, err := repo.GetById(context.Background(), 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 31, route.Riders)
+	assert.Equal(t, "", route.DeviceId)
+	assert.Len(t, route.Stops, 1)
+	assert.Equal(t, "07:40", route.Stops[0].PickupAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBusRouteGetById_NotFound(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &BusRoute{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM bus_routes").
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "device_id", "riders"}))
+
+	// Execute
+	route, err := repo.GetById(context.Background(), 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, route)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBusRouteAssignRider_Upserts(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &BusRoute{
+		Connection: db,
+	}
+
+	mock.ExpectExec("INSERT INTO bus_riders (.+) ON DUPLICATE KEY UPDATE").
+		WithArgs(int64(0), int32(7), int64(2), nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execute
+	err = repo.AssignRider(context.Background(), 2, 7, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBusRouteFindRidersWithoutTrackSince_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &BusRoute{
+		Connection: db,
+	}
+
+	since := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows(busRiderColumns).
+		AddRow(2, 5, "Mill Lane", 7, "Tom", "Doe", 7).
+		AddRow(2, nil, nil, 8, "Ann", nil, nil)
+	mock.ExpectQuery("SELECT (.+) FROM bus_riders AS br (.+) WHERE br.route_id = \\? AND NOT EXISTS \\(SELECT 1 FROM track AS t WHERE t.visitor_id = br.visitor_id AND t.created_at > \\?\\)").
+		WithArgs(int64(2), since).
+		WillReturnRows(rows)
+
+	// Execute
+	riders, err := repo.FindRidersWithoutTrackSince(context.Background(), 2, since)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, riders, 2)
+	assert.Equal(t, "Mill Lane", riders[0].StopName)
+	assert.Equal(t, "Doe", riders[0].Visitor.Surname)
+	assert.Equal(t, int64(0), riders[1].StopId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBusRouteFindRouteIdByVisitorId_NotRiding(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &BusRoute{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT route_id FROM bus_riders WHERE visitor_id = \\?").
+		WithArgs(int32(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"route_id"}))
+
+	// Execute
+	routeId, err := repo.FindRouteIdByVisitorId(context.Background(), 7)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), routeId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBusRouteFindLatestArrival_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &BusRoute{
+		Connection: db,
+	}
+
+	since := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT (.+) FROM bus_arrivals WHERE route_id = \\? AND arrived_at >= \\? ORDER BY arrived_at DESC LIMIT 1").
+		WithArgs(int64(2), since).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "route_id", "device_id", "arrived_at", "excusal_id"}).
+			AddRow(3, 2, "bus-1", []byte("2024-09-02 08:52:00"), 4))
+
+	// Execute
+	arrival, err := repo.FindLatestArrival(context.Background(), 2, since)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), arrival.ExcusalId)
+	assert.Equal(t, time.Date(2024, 9, 2, 8, 52, 0, 0, time.UTC), arrival.ArrivedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+type ConsequenceRule struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+	Timeout
+}
+
+func (r *ConsequenceRule) FindAll(ctx context.Context) ([]*entity.ConsequenceRule, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(queryCtx, "SELECT id, name, threshold, window_days, grades, action, recipient, webhook_url FROM consequence_rules"+r.where("")+" ORDER BY id")
+	if err != nil {
+		return nil, r.failed(queryCtx, "Finding consequence rules", err)
+	}
+	defer rows.Close()
+
+	rules := []*entity.ConsequenceRule{}
+	for rows.Next() {
+		var grades, recipient, webhookURL sql.NullString
+		rule := &entity.ConsequenceRule{Source: entity.RuleSourceDatabase}
+		err := rows.Scan(&rule.Id, &rule.Name, &rule.Threshold, &rule.WindowDays, &grades, &rule.Action, &recipient, &webhookURL)
+		if err != nil {
+			return nil, r.failed(queryCtx, "Finding consequence rules", err)
+		}
+		if rule.Grades, err = splitGrades(grades.String); err != nil {
+			return nil, err
+		}
+		rule.Recipient = recipient.String
+		rule.WebhookURL = webhookURL.String
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Finding consequence rules", err)
+	}
+	return rules, nil
+}
+
+func (r *ConsequenceRule) Store(ctx context.Context, rule *entity.ConsequenceRule) error {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	res, err := r.Connection.ExecContext(
+		queryCtx,
+		"INSERT INTO consequence_rules (tenant_id, site_id, name, threshold, window_days, grades, action, recipient, webhook_url) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		r.TenantId,
+		r.SiteId,
+		rule.Name,
+		rule.Threshold,
+		rule.WindowDays,
+		joinGrades(rule.Grades),
+		rule.Action,
+		rule.Recipient,
+		rule.WebhookURL,
+	)
+	if err != nil {
+		return r.failed(queryCtx, "Storing consequence rule", err)
+	}
+	rule.Source = entity.RuleSourceDatabase
+	rule.Id, err = res.LastInsertId()
+	return r.failed(queryCtx, "Storing consequence rule", err)
+}
+
+func (r *ConsequenceRule) Delete(ctx context.Context, id int64) error {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	_, err := r.Connection.ExecContext(queryCtx, "DELETE FROM consequence_rules WHERE id = ?"+r.and(""), id)
+	return r.failed(queryCtx, "Deleting consequence rule", err)
+}
+
+func joinGrades(grades []int) string {
+	parts := make([]string, 0, len(grades))
+	for _, grade := range grades {
+		parts = append(parts, strconv.Itoa(grade))
+	}
+	return strings.Join(parts, ",")
+}
+
+func splitGrades(raw string) ([]int, error) {
+	grades := []int{}
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		grade, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		grades = append(grades, grade)
+	}
+	return grades, nil
+}
+
+const consequenceSelect = "SELECT c.id, c.rule, c.visitor_id, c.action, c.late_count, c.status, c.note, c.created_at, c.resolved_at, v.name, v.surname, v.grade FROM consequences AS c INNER JOIN visitors AS v ON v.id = c.visitor_id"
+
+type Consequence struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+	Timeout
+}
+
+func (r *Consequence) Store(ctx context.Context, consequence *entity.Consequence) error {
+	var resolvedAt sql.NullTime
+	if consequence.ResolvedAt != nil {
+		resolvedAt = sql.NullTime{Time: *consequence.ResolvedAt, Valid: true}
+	}
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	res, err := r.Connection.ExecContext(
+		queryCtx,
+		"INSERT INTO consequences (tenant_id, rule, visitor_id, action, late_count, status, note, created_at, resolved_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		r.TenantId,
+		consequence.Rule,
+		consequence.VisitorId,
+		consequence.Action,
+		consequence.LateCount,
+		consequence.Status,
+		consequence.Note,
+		consequence.CreatedAt,
+		resolvedAt,
+	)
+	if err != nil {
+		return r.failed(queryCtx, "Storing consequence", err)
+	}
+	consequence.Id, err = res.LastInsertId()
+	return r.failed(queryCtx, "Storing consequence", err)
+}
+
+func (r *Consequence) GetById(ctx context.Context, id int64) (*entity.Consequence, error) {
+	consequences, err := r.find(ctx, consequenceSelect+" WHERE c.id = ?"+r.and("v"), id)
+	if err != nil {
+		return nil, err
+	}
+	if len(consequences) == 0 {
+		return nil, nil
+	}
+	return consequences[0], nil
+}
+
+func (r *Consequence) FindByStatus(ctx context.Context, status string) ([]*entity.Consequence, error) {
+	return r.find(ctx, consequenceSelect+" WHERE c.status = ?"+r.and("v")+" ORDER BY c.created_at", status)
+}
+
+func (r *Consequence) CountByRuleAndVisitorIdSince(ctx context.Context, rule string, visitorId int32, since time.Time) (int, error) {
+	var count int
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	err := r.Connection.QueryRowContext(
+		queryCtx,
+		"SELECT COUNT(*) FROM consequences WHERE rule = ? AND visitor_id = ? AND created_at >= ? AND status <> ?"+r.tenantAnd(""),
+		rule,
+		visitorId,
+		since,
+		entity.ConsequenceCancelled,
+	).Scan(&count)
+	return count, r.failed(queryCtx, "Counting consequences", err)
+}
+
+func (r *Consequence) UpdateStatus(ctx context.Context, id int64, status string, note string, at time.Time) error {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	_, err := r.Connection.ExecContext(queryCtx, "UPDATE consequences SET status = ?, note = ?, resolved_at = ? WHERE id = ?"+r.tenantAnd(""), status, note, at, id)
+	return r.failed(queryCtx, "Updating consequence", err)
+}
+
+func (r *Consequence) find(ctx context.Context, query string, args ...any) ([]*entity.Consequence, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(queryCtx, query, args...)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Finding consequences", err)
+	}
+	defer rows.Close()
+
+	consequences := []*entity.Consequence{}
+	for rows.Next() {
+		var note, resolvedAt, surname sql.NullString
+		var createdAtRaw []byte
+		consequence := &entity.Consequence{Visitor: &entity.Visitor{}}
+		err := rows.Scan(
+			&consequence.Id,
+			&consequence.Rule,
+			&consequence.VisitorId,
+			&consequence.Action,
+			&consequence.LateCount,
+			&consequence.Status,
+			&note,
+			&createdAtRaw,
+			&resolvedAt,
+			&consequence.Visitor.Name,
+			&surname,
+			&consequence.Visitor.Grade,
+		)
+		if err != nil {
+			return nil, r.failed(queryCtx, "Finding consequences", err)
+		}
+		consequence.Visitor.Id = consequence.VisitorId
+		consequence.Visitor.Surname = surname.String
+		consequence.Note = note.String
+		if consequence.CreatedAt, err = parseDateTime(createdAtRaw); err != nil {
+			return nil, err
+		}
+		if consequence.ResolvedAt, err = parseNullDateTime(resolvedAt); err != nil {
+			return nil, err
+		}
+		consequences = append(consequences, consequence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Finding consequences", err)
+	}
+	return consequences, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsequenceRuleFindAll_ParsesGrades(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &ConsequenceRule{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "threshold", "window_days", "grades", "action", "recipient", "webhook_url"}).
+		AddRow(1, "3 lates a week", 3, 7, "7, 8", entity.ConsequenceDetention, nil, nil).
+		AddRow(2, "monthly", 8, 30, "", entity.ConsequenceNotify, "head of year", nil)
+	mock.ExpectQuery("SELECT id, name, threshold, window_days, grades, action, recipient, webhook_url FROM consequence_rules").
+		WillReturnRows(rows)
+
+	// Execute
+	rules, err := repo.FindAll(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, []int{7, 8}, rules[0].Grades)
+	assert.Equal(t, []int{}, rules[1].Grades)
+	assert.Equal(t, "head of year", rules[1].Recipient)
+	assert.Equal(t, entity.RuleSourceDatabase, rules[1].Source)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConsequenceRuleStore_JoinsGrades(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &ConsequenceRule{
+		Connection: db,
+		Scope:      Scope{SiteId: 2},
+	}
+
+	mock.ExpectExec("INSERT INTO consequence_rules").
+		WithArgs(int64(0), int64(2), "3 lates a week", 3, 7, "7,8", entity.ConsequenceDetention, "", "").
+		WillReturnResult(sqlmock.NewResult(4, 1))
+
+	// Execute
+	rule := &entity.ConsequenceRule{Name: "3 lates a week", Threshold: 3, WindowDays: 7, Grades: []int{7, 8}, Action: entity.ConsequenceDetention}
+	err = repo.Store(context.Background(), rule)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), rule.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConsequenceCountByRuleAndVisitorIdSince_IgnoresCancelled(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Consequence{
+		Connection: db,
+	}
+
+	since := time.Date(2024, 8, 30, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM consequences WHERE rule = \\? AND visitor_id = \\? AND created_at >= \\? AND status <> \\?").
+		WithArgs("3 lates a week", int32(7), since, entity.ConsequenceCancelled).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	// Execute
+	count, err := repo.CountByRuleAndVisitorIdSince(context.Background(), "3 lates a week", 7, since)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConsequenceFindByStatus_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Consequence{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "rule", "visitor_id", "action", "late_count", "status", "note", "created_at", "resolved_at", "name", "surname", "grade"}).
+		AddRow(1, "3 lates a week", 7, entity.ConsequenceDetention, 3, entity.ConsequencePending, nil, []byte("2024-09-05 08:45:00"), nil, "Tom", "Doe", 8)
+	mock.ExpectQuery("SELECT (.+) FROM consequences AS c INNER JOIN visitors AS v ON v.id = c.visitor_id WHERE c.status = ?").
+		WithArgs(entity.ConsequencePending).
+		WillReturnRows(rows)
+
+	// Execute
+	consequences, err := repo.FindByStatus(context.Background(), entity.ConsequencePending)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, consequences, 1)
+	assert.Equal(t, 8, consequences[0].Visitor.Grade)
+	assert.Nil(t, consequences[0].ResolvedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
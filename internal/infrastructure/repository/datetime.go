@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+)
+
+const dateTimeLayout = "2006-01-02 15:04:05"
+
+// now stamps new records, the database keeps UTC and the school zone applies
+// only when times are shown or split into days.
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+func parseDateTime(raw []byte) (time.Time, error) {
+	return time.Parse(dateTimeLayout, string(raw))
+}
+
+func parseNullDateTime(raw sql.NullString) (*time.Time, error) {
+	if !raw.Valid || raw.String == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(dateTimeLayout, raw.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
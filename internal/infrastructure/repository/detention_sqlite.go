@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const detentionSessionSelect = "SELECT s.id, s.starts_at, s.ends_at, s.room_id, s.supervisor_id, s.capacity, s.closed_at, COUNT(a.id) FROM detention_sessions AS s LEFT JOIN detention_assignments AS a ON a.session_id = s.id"
+
+const detentionAssignmentSelect = "SELECT a.id, a.session_id, a.visitor_id, a.consequence_id, a.status, a.attended_at, v.name, v.surname, v.grade FROM detention_assignments AS a INNER JOIN visitors AS v ON v.id = a.visitor_id"
+
+type Detention struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+}
+
+func (r *Detention) CreateSession(session *entity.DetentionSession) error {
+	res, err := r.Connection.Exec(
+		"INSERT INTO detention_sessions (tenant_id, site_id, starts_at, ends_at, room_id, supervisor_id, capacity) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		r.TenantId,
+		r.SiteId,
+		session.StartsAt,
+		session.EndsAt,
+		session.RoomId,
+		session.SupervisorId,
+		session.Capacity,
+	)
+	if err != nil {
+		return err
+	}
+	session.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *Detention) GetSessionById(id int64) (*entity.DetentionSession, error) {
+	sessions, err := r.findSessions(detentionSessionSelect+" WHERE s.id = ?"+r.and("s")+" GROUP BY s.id", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return sessions[0], nil
+}
+
+func (r *Detention) FindSessionsBetween(from time.Time, to time.Time) ([]*entity.DetentionSession, error) {
+	return r.findSessions(detentionSessionSelect+" WHERE s.starts_at >= ? AND s.starts_at < ?"+r.and("s")+" GROUP BY s.id ORDER BY s.starts_at", from, to)
+}
+
+func (r *Detention) FindOpenSessionsByRoom(roomId int64) ([]*entity.DetentionSession, error) {
+	return r.findSessions(detentionSessionSelect+" WHERE s.room_id = ? AND s.closed_at IS NULL"+r.and("s")+" GROUP BY s.id ORDER BY s.starts_at", roomId)
+}
+
+func (r *Detention) CloseSession(id int64, at time.Time) error {
+	_, err := r.Connection.Exec("UPDATE detention_sessions SET closed_at = ? WHERE id = ?"+r.tenantAnd(""), at, id)
+	return err
+}
+
+func (r *Detention) Assign(assignment *entity.DetentionAssignment) error {
+	var consequenceId sql.NullInt64
+	if assignment.ConsequenceId > 0 {
+		consequenceId = sql.NullInt64{Int64: assignment.ConsequenceId, Valid: true}
+	}
+	res, err := r.Connection.Exec(
+		"INSERT INTO detention_assignments (tenant_id, session_id, visitor_id, consequence_id, status) VALUES (?, ?, ?, ?, ?)",
+		r.TenantId,
+		assignment.SessionId,
+		assignment.VisitorId,
+		consequenceId,
+		assignment.Status,
+	)
+	if err != nil {
+		return err
+	}
+	assignment.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *Detention) FindAssignments(sessionId int64) ([]*entity.DetentionAssignment, error) {
+	return r.findAssignments(detentionAssignmentSelect+" WHERE a.session_id = ?"+r.tenantAnd("a")+" ORDER BY v.surname, v.name", sessionId)
+}
+
+func (r *Detention) FindAssignment(sessionId int64, visitorId int32) (*entity.DetentionAssignment, error) {
+	assignments, err := r.findAssignments(detentionAssignmentSelect+" WHERE a.session_id = ?"+r.tenantAnd("a")+" AND a.visitor_id = ?", sessionId, visitorId)
+	if err != nil {
+		return nil, err
+	}
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+	return assignments[0], nil
+}
+
+func (r *Detention) UpdateAssignment(id int64, status string, attendedAt *time.Time) error {
+	var attended sql.NullTime
+	if attendedAt != nil {
+		attended = sql.NullTime{Time: *attendedAt, Valid: true}
+	}
+	_, err := r.Connection.Exec("UPDATE detention_assignments SET status = ?, attended_at = ? WHERE id = ?"+r.tenantAnd(""), status, attended, id)
+	return err
+}
+
+func (r *Detention) findSessions(query string, args ...any) ([]*entity.DetentionSession, error) {
+	rows, err := r.Connection.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*entity.DetentionSession{}
+	for rows.Next() {
+		var startsAtRaw, endsAtRaw []byte
+		var closedAt sql.NullString
+		session := &entity.DetentionSession{}
+		err := rows.Scan(
+			&session.Id,
+			&startsAtRaw,
+			&endsAtRaw,
+			&session.RoomId,
+			&session.SupervisorId,
+			&session.Capacity,
+			&closedAt,
+			&session.Assigned,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if session.StartsAt, err = parseDateTime(startsAtRaw); err != nil {
+			return nil, err
+		}
+		if session.EndsAt, err = parseDateTime(endsAtRaw); err != nil {
+			return nil, err
+		}
+		if session.ClosedAt, err = parseNullDateTime(closedAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *Detention) findAssignments(query string, args ...any) ([]*entity.DetentionAssignment, error) {
+	rows, err := r.Connection.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := []*entity.DetentionAssignment{}
+	for rows.Next() {
+		var consequenceId sql.NullInt64
+		var attendedAt, surname sql.NullString
+		assignment := &entity.DetentionAssignment{Visitor: &entity.Visitor{}}
+		err := rows.Scan(
+			&assignment.Id,
+			&assignment.SessionId,
+			&assignment.VisitorId,
+			&consequenceId,
+			&assignment.Status,
+			&attendedAt,
+			&assignment.Visitor.Name,
+			&surname,
+			&assignment.Visitor.Grade,
+		)
+		if err != nil {
+			return nil, err
+		}
+		assignment.Visitor.Id = assignment.VisitorId
+		assignment.Visitor.Surname = surname.String
+		assignment.ConsequenceId = consequenceId.Int64
+		if assignment.AttendedAt, err = parseNullDateTime(attendedAt); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var detentionSessionColumns = []string{"id", "starts_at", "ends_at", "room_id", "supervisor_id", "capacity", "closed_at", "assigned"}
+
+var detentionAssignmentColumns = []string{"id", "session_id", "visitor_id", "consequence_id", "status", "attended_at", "name", "surname", "grade"}
+
+func TestDetentionCreateSession_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Detention{
+		Connection: db,
+		Scope:      Scope{SiteId: 2},
+	}
+
+	startsAt := time.Date(2024, 9, 2, 12, 30, 0, 0, time.UTC)
+	endsAt := time.Date(2024, 9, 2, 13, 0, 0, 0, time.UTC)
+	mock.ExpectExec("INSERT INTO detention_sessions").
+		WithArgs(int64(0), int64(2), startsAt, endsAt, int64(4), int32(2), 15).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	// Execute
+	session := &entity.DetentionSession{StartsAt: startsAt, EndsAt: endsAt, RoomId: 4, SupervisorId: 2, Capacity: 15}
+	err = repo.CreateSession(session)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), session.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDetentionGetSessionById_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Detention{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows(detentionSessionColumns).
+		AddRow(3, []byte("2024-09-02 12:30:00"), []byte("2024-09-02 13:00:00"), 4, 2, 15, nil, 6)
+	mock.ExpectQuery("SELECT (.+) FROM detention_sessions AS s LEFT JOIN detention_assignments AS a ON a.session_id = s.id WHERE s.id = \\? GROUP BY s.id").
+		WithArgs(int64(3)).
+		WillReturnRows(rows)
+
+	// Execute
+	session, err := repo.GetSessionById(3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 9, 2, 12, 30, 0, 0, time.UTC), session.StartsAt)
+	assert.Equal(t, 6, session.Assigned)
+	assert.False(t, session.Full())
+	assert.False(t, session.Closed())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDetentionGetSessionById_NotFound(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Detention{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM detention_sessions").
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows(detentionSessionColumns))
+
+	// Execute
+	session, err := repo.GetSessionById(3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, session)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDetentionAssign_WithoutConsequence(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Detention{
+		Connection: db,
+	}
+
+	mock.ExpectExec("INSERT INTO detention_assignments").
+		WithArgs(int64(0), int64(3), int32(7), nil, entity.DetentionAssigned).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+
+	// Execute
+	assignment := &entity.DetentionAssignment{SessionId: 3, VisitorId: 7, Status: entity.DetentionAssigned}
+	err = repo.Assign(assignment)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), assignment.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDetentionFindAssignment_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Detention{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows(detentionAssignmentColumns).
+		AddRow(5, 3, 7, 11, entity.DetentionAttended, "2024-09-02 12:31:00", "Tom", nil, 8)
+	mock.ExpectQuery("SELECT (.+) FROM detention_assignments AS a INNER JOIN visitors AS v ON v.id = a.visitor_id WHERE a.session_id = \\? AND a.visitor_id = \\?").
+		WithArgs(int64(3), int32(7)).
+		WillReturnRows(rows)
+
+	// Execute
+	assignment, err := repo.FindAssignment(3, 7)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), assignment.ConsequenceId)
+	assert.Equal(t, "Tom", assignment.Visitor.Name)
+	assert.Equal(t, int32(7), assignment.Visitor.Id)
+	assert.Equal(t, time.Date(2024, 9, 2, 12, 31, 0, 0, time.UTC), *assignment.AttendedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDetentionUpdateAssignment_Missed(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Detention{
+		Connection: db,
+	}
+
+	mock.ExpectExec("UPDATE detention_assignments SET status = \\?, attended_at = \\? WHERE id = \\?").
+		WithArgs(entity.DetentionMissed, nil, int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execute
+	err = repo.UpdateAssignment(5, entity.DetentionMissed, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+type Device struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+}
+
+func (r *Device) FindById(id string) (*entity.Device, error) {
+	var name sql.NullString
+	var roomId sql.NullInt64
+	device := &entity.Device{}
+	err := r.Connection.QueryRow("SELECT id, name, room_id FROM devices WHERE id = ?"+r.and(""), id).
+		Scan(&device.Id, &name, &roomId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	device.Name = name.String
+	device.RoomId = roomId.Int64
+	return device, nil
+}
+
+func (r *Device) FindAll() ([]*entity.Device, error) {
+	rows, err := r.Connection.Query("SELECT id, name, room_id FROM devices" + r.where("") + " ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devices := []*entity.Device{}
+	for rows.Next() {
+		var name sql.NullString
+		var roomId sql.NullInt64
+		device := &entity.Device{}
+		if err := rows.Scan(&device.Id, &name, &roomId); err != nil {
+			return nil, err
+		}
+		device.Name = name.String
+		device.RoomId = roomId.Int64
+		devices = append(devices, device)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+func (r *Device) Save(device *entity.Device) error {
+	var roomId sql.NullInt64
+	if device.InRoom() {
+		roomId = sql.NullInt64{Int64: device.RoomId, Valid: true}
+	}
+	_, err := r.Connection.Exec(
+		"INSERT INTO devices (tenant_id, id, site_id, name, room_id) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE site_id = VALUES(site_id), name = VALUES(name), room_id = VALUES(room_id)",
+		r.TenantId,
+		device.Id,
+		r.SiteId,
+		device.Name,
+		roomId,
+	)
+	return err
+}
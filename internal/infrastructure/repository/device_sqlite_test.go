@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceFindById_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Device{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT id, name, room_id FROM devices WHERE id = ?").
+		WithArgs("room-101").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "room_id"}).AddRow("room-101", "Reader", 101))
+
+	// Execute
+	device, err := repo.FindById("room-101")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Reader", device.Name)
+	assert.True(t, device.InRoom())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceFindById_NotFound(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Device{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT id, name, room_id FROM devices WHERE id = ?").
+		WithArgs("unknown").
+		WillReturnError(sql.ErrNoRows)
+
+	// Execute
+	device, err := repo.FindById("unknown")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, device)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceSave_WithoutRoomStoresNull(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Device{
+		Connection: db,
+		Scope:      Scope{SiteId: 2},
+	}
+
+	mock.ExpectExec("INSERT INTO devices").
+		WithArgs(int64(0), "entrance", int64(2), "Main entrance", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execute
+	err = repo.Save(&entity.Device{Id: "entrance", Name: "Main entrance"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceFindAll_ScopedToSite(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Device{
+		Connection: db,
+		Scope:      Scope{SiteId: 2},
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "room_id"}).
+		AddRow("entrance", "North entrance", nil)
+	mock.ExpectQuery("SELECT id, name, room_id FROM devices WHERE site_id = 2 ORDER BY id").
+		WillReturnRows(rows)
+
+	// Execute
+	devices, err := repo.FindAll()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, devices, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceFindAll_ScopedToTenantAndSite(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Device{
+		Connection: db,
+		Scope:      Scope{TenantId: 3, SiteId: 2},
+	}
+
+	mock.ExpectQuery("SELECT id, name, room_id FROM devices WHERE tenant_id = 3 AND site_id = 2 ORDER BY id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "room_id"}))
+
+	// Execute
+	devices, err := repo.FindAll()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, devices, 0)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const pickupPersonSelect = "SELECT id, visitor_id, name, relationship, image, key_id FROM pickup_persons"
+
+type PickupPerson struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+}
+
+func (r *PickupPerson) FindById(id int64) (*entity.PickupPerson, error) {
+	persons, err := r.find(pickupPersonSelect+" WHERE id = ?"+r.tenantAnd(""), id)
+	if err != nil || len(persons) == 0 {
+		return nil, err
+	}
+	return persons[0], nil
+}
+
+// FindByKey returns all authorizations of the card, one adult may collect several students.
+func (r *PickupPerson) FindByKey(key string) ([]*entity.PickupPerson, error) {
+	return r.find(pickupPersonSelect+" WHERE key_id = ?"+r.tenantAnd(""), strings.ToUpper(key))
+}
+
+func (r *PickupPerson) FindByVisitorId(visitorId int32) ([]*entity.PickupPerson, error) {
+	return r.find(pickupPersonSelect+" WHERE visitor_id = ?"+r.tenantAnd("")+" ORDER BY name", visitorId)
+}
+
+func (r *PickupPerson) Store(person *entity.PickupPerson) error {
+	var key sql.NullString
+	if person.Key != "" {
+		person.Key = strings.ToUpper(person.Key)
+		key = sql.NullString{String: person.Key, Valid: true}
+	}
+	res, err := r.Connection.Exec(
+		"INSERT INTO pickup_persons (tenant_id, visitor_id, name, relationship, image, key_id) VALUES (?, ?, ?, ?, ?, ?)",
+		r.TenantId,
+		person.VisitorId,
+		person.Name,
+		person.Relationship,
+		person.Image,
+		key,
+	)
+	if err != nil {
+		return err
+	}
+	person.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *PickupPerson) Delete(id int64) error {
+	_, err := r.Connection.Exec("DELETE FROM pickup_persons WHERE id = ?"+r.tenantAnd(""), id)
+	return err
+}
+
+func (r *PickupPerson) find(query string, args ...any) ([]*entity.PickupPerson, error) {
+	rows, err := r.Connection.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	persons := []*entity.PickupPerson{}
+	for rows.Next() {
+		var relationship, image, key sql.NullString
+		person := &entity.PickupPerson{}
+		if err := rows.Scan(&person.Id, &person.VisitorId, &person.Name, &relationship, &image, &key); err != nil {
+			return nil, err
+		}
+		person.Relationship = relationship.String
+		person.Image = image.String
+		person.Key = key.String
+		persons = append(persons, person)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return persons, nil
+}
+
+type Dismissal struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+}
+
+func (r *Dismissal) Store(dismissal *entity.Dismissal) (*entity.Dismissal, error) {
+	var pickupPersonId sql.NullInt64
+	if dismissal.PickupPersonId > 0 {
+		pickupPersonId = sql.NullInt64{Int64: dismissal.PickupPersonId, Valid: true}
+	}
+	res, err := r.Connection.Exec(
+		"INSERT INTO dismissals (tenant_id, visitor_id, pickup_person_id, collector_name, reason, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		r.TenantId,
+		dismissal.VisitorId,
+		pickupPersonId,
+		dismissal.CollectorName,
+		dismissal.Reason,
+		dismissal.Status,
+		now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if dismissal.Id, err = res.LastInsertId(); err != nil {
+		return nil, err
+	}
+	var createdAtRaw []byte
+	if err := r.Connection.QueryRow("SELECT created_at FROM dismissals WHERE id = ?"+r.tenantAnd(""), dismissal.Id).Scan(&createdAtRaw); err != nil {
+		return nil, err
+	}
+	if dismissal.CreatedAt, err = parseDateTime(createdAtRaw); err != nil {
+		return nil, err
+	}
+	return dismissal, nil
+}
+
+func (r *Dismissal) FindBetween(from time.Time, to time.Time) ([]*entity.Dismissal, error) {
+	rows, err := r.Connection.Query(
+		"SELECT d.id, d.visitor_id, d.pickup_person_id, d.collector_name, d.reason, d.status, d.created_at, v.name, v.surname FROM dismissals AS d INNER JOIN visitors AS v ON v.id = d.visitor_id WHERE d.created_at >= ? AND d.created_at < ?"+r.and("v")+" ORDER BY d.created_at",
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dismissals := []*entity.Dismissal{}
+	for rows.Next() {
+		var pickupPersonId sql.NullInt64
+		var collectorName, reason, surname sql.NullString
+		var createdAtRaw []byte
+		dismissal := &entity.Dismissal{Visitor: &entity.Visitor{}}
+		err := rows.Scan(
+			&dismissal.Id,
+			&dismissal.VisitorId,
+			&pickupPersonId,
+			&collectorName,
+			&reason,
+			&dismissal.Status,
+			&createdAtRaw,
+			&dismissal.Visitor.Name,
+			&surname,
+		)
+		if err != nil {
+			return nil, err
+		}
+		dismissal.Visitor.Id = dismissal.VisitorId
+		dismissal.Visitor.Surname = surname.String
+		dismissal.PickupPersonId = pickupPersonId.Int64
+		dismissal.CollectorName = collectorName.String
+		dismissal.Reason = reason.String
+		if dismissal.CreatedAt, err = parseDateTime(createdAtRaw); err != nil {
+			return nil, err
+		}
+		dismissals = append(dismissals, dismissal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return dismissals, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickupPersonStore_UppercasesKey(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &PickupPerson{
+		Connection: db,
+	}
+
+	mock.ExpectExec("INSERT INTO pickup_persons").
+		WithArgs(int64(0), int32(7), "Jane Doe", "mother", "", "ADULT1").
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	// Execute
+	person := &entity.PickupPerson{VisitorId: 7, Name: "Jane Doe", Relationship: "mother", Key: "adult1"}
+	err = repo.Store(person)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), person.Id)
+	assert.Equal(t, "ADULT1", person.Key)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPickupPersonFindByVisitorId_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &PickupPerson{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "visitor_id", "name", "relationship", "image", "key_id"}).
+		AddRow(3, 7, "Jane Doe", "mother", "/img/jane.jpg", nil).
+		AddRow(4, 7, "John Doe", nil, nil, "ADULT2")
+	mock.ExpectQuery("SELECT id, visitor_id, name, relationship, image, key_id FROM pickup_persons WHERE visitor_id = ?").
+		WithArgs(int32(7)).
+		WillReturnRows(rows)
+
+	// Execute
+	persons, err := repo.FindByVisitorId(7)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, persons, 2)
+	assert.Equal(t, "/img/jane.jpg", persons[0].Image)
+	assert.Equal(t, "", persons[0].Key)
+	assert.Equal(t, "ADULT2", persons[1].Key)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDismissalStore_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Dismissal{
+		Connection: db,
+	}
+
+	mock.ExpectExec("INSERT INTO dismissals").
+		WithArgs(int64(0), int32(7), nil, "Mr Stranger", "", entity.DismissalRefused, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectQuery("SELECT created_at FROM dismissals WHERE id = ?").
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow("2024-09-02 11:15:00"))
+
+	// Execute
+	dismissal, err := repo.Store(&entity.Dismissal{VisitorId: 7, CollectorName: "Mr Stranger", Status: entity.DismissalRefused})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), dismissal.Id)
+	assert.Equal(t, time.Date(2024, 9, 2, 11, 15, 0, 0, time.UTC), dismissal.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/go-sql-driver/mysql"
+)
+
+// duplicateEntry is the MySQL error of an insert violating a unique key.
+const duplicateEntry = 1062
+
+type Evacuation struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+}
+
+// Start opens a new session with a snapshot of the given visitors. A site
+// has one active session at most, the unique active key of the table keeps
+// concurrent starts from opening a second one.
+func (r *Evacuation) Start(note string, visitors []*entity.Visitor) (*entity.EvacuationSession, error) {
+	tx, err := r.Connection.Begin()
+	if err != nil {
+		return nil, err
+	}
+	res, err := tx.Exec(
+		"INSERT INTO evacuation_session (tenant_id, site_id, note, started_at) SELECT ?, ?, ?, ? FROM DUAL "+
+			"WHERE NOT EXISTS (SELECT 1 FROM evacuation_session WHERE tenant_id = ? AND site_id = ? AND ended_at IS NULL)",
+		r.TenantId,
+		r.SiteId,
+		note,
+		now(),
+		r.TenantId,
+		r.SiteId,
+	)
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntry {
+		_ = tx.Rollback()
+		return nil, entity.ErrEvacuationActive
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if affected == 0 {
+		_ = tx.Rollback()
+		return nil, entity.ErrEvacuationActive
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	for _, visitor := range visitors {
+		if _, err := tx.Exec("INSERT INTO evacuation_entry (tenant_id, session_id, visitor_id) VALUES (?, ?, ?)", r.TenantId, id, visitor.Id); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetById(id)
+}
+
+func (r *Evacuation) GetById(id int64) (*entity.EvacuationSession, error) {
+	row := r.Connection.QueryRow("SELECT id, note, started_at, ended_at FROM evacuation_session WHERE id = ?"+r.and(""), id)
+	session, err := r.scanSession(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return session, err
+}
+
+// GetActive returns the latest session which has not been ended yet.
+func (r *Evacuation) GetActive() (*entity.EvacuationSession, error) {
+	row := r.Connection.QueryRow("SELECT id, note, started_at, ended_at FROM evacuation_session WHERE ended_at IS NULL" + r.and("") + " ORDER BY id DESC LIMIT 1")
+	session, err := r.scanSession(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return session, err
+}
+
+func (r *Evacuation) FindEntries(sessionId int64) ([]*entity.EvacuationEntry, error) {
+	rows, err := r.Connection.Query(
+		"SELECT e.session_id, e.accounted_at, e.assembly_point, v.id, v.name, v.surname, v.grade, v.image FROM evacuation_entry AS e INNER JOIN visitors AS v ON v.id = e.visitor_id WHERE e.session_id = ?"+r.tenantAnd("e")+" ORDER BY v.surname, v.name",
+		sessionId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*entity.EvacuationEntry{}
+	for rows.Next() {
+		var accountedAt, assemblyPoint, tmpImage sql.NullString
+		var tmpGrade sql.NullInt32
+		entry := &entity.EvacuationEntry{Visitor: &entity.Visitor{}}
+		err := rows.Scan(
+			&entry.SessionId,
+			&accountedAt,
+			&assemblyPoint,
+			&entry.Visitor.Id,
+			&entry.Visitor.Name,
+			&entry.Visitor.Surname,
+			&tmpGrade,
+			&tmpImage,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if entry.AccountedAt, err = parseNullDateTime(accountedAt); err != nil {
+			return nil, err
+		}
+		entry.AssemblyPoint = assemblyPoint.String
+		if tmpGrade.Valid {
+			entry.Visitor.Grade = int(tmpGrade.Int32)
+		}
+		entry.Visitor.Image = tmpImage.String
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *Evacuation) MarkAccounted(sessionId int64, visitorId int32, assemblyPoint string) error {
+	res, err := r.Connection.Exec(
+		"UPDATE evacuation_entry SET accounted_at = ?, assembly_point = ? WHERE session_id = ? AND visitor_id = ? AND accounted_at IS NULL"+r.tenantAnd(""),
+		now(),
+		assemblyPoint,
+		sessionId,
+		visitorId,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	// Already accounted visitors are not an error, unknown ones are.
+	var count int
+	err = r.Connection.QueryRow(
+		"SELECT COUNT(*) FROM evacuation_entry WHERE session_id = ? AND visitor_id = ?"+r.tenantAnd(""),
+		sessionId,
+		visitorId,
+	).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return entity.ErrEvacuationEntryNotFound
+	}
+	return nil
+}
+
+// End closes the session, one which already ended keeps its end time.
+func (r *Evacuation) End(sessionId int64) error {
+	res, err := r.Connection.Exec("UPDATE evacuation_session SET ended_at = ? WHERE id = ? AND ended_at IS NULL"+r.tenantAnd(""), now(), sessionId)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entity.ErrEvacuationEnded
+	}
+	return nil
+}
+
+func (r *Evacuation) scanSession(row *sql.Row) (*entity.EvacuationSession, error) {
+	var startedAtRaw []byte
+	var note, endedAt sql.NullString
+	session := &entity.EvacuationSession{}
+	if err := row.Scan(&session.Id, &note, &startedAtRaw, &endedAt); err != nil {
+		return nil, err
+	}
+	var err error
+	session.Note = note.String
+	if session.StartedAt, err = parseDateTime(startedAtRaw); err != nil {
+		return nil, err
+	}
+	if session.EndedAt, err = parseNullDateTime(endedAt); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
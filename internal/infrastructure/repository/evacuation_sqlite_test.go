@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvacuationStart_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Evacuation{
+		Connection: db,
+		Scope:      Scope{SiteId: 2},
+	}
+
+	visitors := []*entity.Visitor{{Id: 1}, {Id: 2}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO evacuation_session \\(tenant_id, site_id, note, started_at\\) SELECT \\?, \\?, \\?, \\? FROM DUAL WHERE NOT EXISTS").
+		WithArgs(int64(0), int64(2), "drill", sqlmock.AnyArg(), int64(0), int64(2)).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec("INSERT INTO evacuation_entry").
+		WithArgs(int64(0), int64(7), int32(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO evacuation_entry").
+		WithArgs(int64(0), int64(7), int32(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT id, note, started_at, ended_at FROM evacuation_session WHERE id = ?").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "note", "started_at", "ended_at"}).
+			AddRow(7, "drill", "2024-09-02 10:00:00", nil))
+
+	// Execute
+	session, err := repo.Start("drill", visitors)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), session.Id)
+	assert.Equal(t, "drill", session.Note)
+	assert.True(t, session.Active())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEvacuationStart_EntryErrorWillRollback(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Evacuation{
+		Connection: db,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO evacuation_session").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec("INSERT INTO evacuation_entry").
+		WillReturnError(errors.New("insert failed"))
+	mock.ExpectRollback()
+
+	// Execute
+	session, err := repo.Start("", []*entity.Visitor{{Id: 1}})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, session)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEvacuationStart_ActiveSessionOfTheSite(t *testing.T) {
+	tests := []struct {
+		name   string
+		insert func(*sqlmock.ExpectedExec)
+	}{
+		{
+			name: "active session found by the insert",
+			insert: func(exec *sqlmock.ExpectedExec) {
+				exec.WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "concurrent start hits the unique active key",
+			insert: func(exec *sqlmock.ExpectedExec) {
+				exec.WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1-2-1' for key 'uniq.evacuation_session.tenant_id.site_id.active'"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := &Evacuation{
+				Connection: db,
+				Scope:      Scope{TenantId: 1, SiteId: 2},
+			}
+
+			mock.ExpectBegin()
+			tt.insert(mock.ExpectExec("INSERT INTO evacuation_session").
+				WithArgs(int64(1), int64(2), "drill", sqlmock.AnyArg(), int64(1), int64(2)))
+			mock.ExpectRollback()
+
+			// Execute
+			session, err := repo.Start("drill", []*entity.Visitor{{Id: 1}})
+
+			// Assert
+			assert.ErrorIs(t, err, entity.ErrEvacuationActive)
+			assert.Nil(t, session)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestEvacuationEnd_AlreadyEnded(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Evacuation{
+		Connection: db,
+	}
+
+	mock.ExpectExec("UPDATE evacuation_session SET ended_at = \\? WHERE id = \\? AND ended_at IS NULL").
+		WithArgs(sqlmock.AnyArg(), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Execute
+	err = repo.End(7)
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrEvacuationEnded)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEvacuationGetActive_NoSession(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Evacuation{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT id, note, started_at, ended_at FROM evacuation_session WHERE ended_at IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "note", "started_at", "ended_at"}))
+
+	// Execute
+	session, err := repo.GetActive()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, session)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEvacuationFindEntries_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Evacuation{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows([]string{"session_id", "accounted_at", "assembly_point", "id", "name", "surname", "grade", "image"}).
+		AddRow(7, "2024-09-02 10:05:00", "Field A", 1, "John", "Doe", 10, nil).
+		AddRow(7, nil, nil, 2, "Jane", "Smith", nil, "/img.jpg")
+	mock.ExpectQuery("SELECT e.session_id, e.accounted_at, e.assembly_point").
+		WithArgs(int64(7)).
+		WillReturnRows(rows)
+
+	// Execute
+	entries, err := repo.FindEntries(7)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.True(t, entries[0].Accounted())
+	assert.Equal(t, "Field A", entries[0].AssemblyPoint)
+	assert.Equal(t, 10, entries[0].Visitor.Grade)
+	assert.False(t, entries[1].Accounted())
+	assert.Equal(t, "/img.jpg", entries[1].Visitor.Image)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEvacuationMarkAccounted_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Evacuation{
+		Connection: db,
+	}
+
+	mock.ExpectExec("UPDATE evacuation_entry SET accounted_at").
+		WithArgs(sqlmock.AnyArg(), "Field A", int64(7), int32(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execute
+	err = repo.MarkAccounted(7, 1, "Field A")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEvacuationMarkAccounted_UnknownVisitor(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Evacuation{
+		Connection: db,
+	}
+
+	mock.ExpectExec("UPDATE evacuation_entry SET accounted_at").
+		WithArgs(sqlmock.AnyArg(), "", int64(7), int32(99)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM evacuation_entry").
+		WithArgs(int64(7), int32(99)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	// Execute
+	err = repo.MarkAccounted(7, 99, "")
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrEvacuationEntryNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEvacuationMarkAccounted_AlreadyAccounted(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Evacuation{
+		Connection: db,
+	}
+
+	mock.ExpectExec("UPDATE evacuation_entry SET accounted_at").
+		WithArgs(sqlmock.AnyArg(), "", int64(7), int32(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM evacuation_entry").
+		WithArgs(int64(7), int32(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	// Execute
+	err = repo.MarkAccounted(7, 1, "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
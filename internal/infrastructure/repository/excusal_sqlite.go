@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const excusalSelect = "SELECT id, reason, starts_at, ends_at, route_id, grades, visitor_ids, excused_count, created_at, revoked_at FROM excusals"
+
+type Excusal struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+	Timeout
+}
+
+func (r *Excusal) Store(ctx context.Context, excusal *entity.Excusal, tardyIds []int64) error {
+	var routeId sql.NullInt64
+	if excusal.RouteId > 0 {
+		routeId = sql.NullInt64{Int64: excusal.RouteId, Valid: true}
+	}
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	tx, err := r.Connection.BeginTx(queryCtx, nil)
+	if err != nil {
+		return r.failed(queryCtx, "Storing excusal", err)
+	}
+	res, err := tx.ExecContext(
+		queryCtx,
+		"INSERT INTO excusals (tenant_id, site_id, reason, starts_at, ends_at, route_id, grades, visitor_ids, excused_count, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		r.TenantId,
+		r.SiteId,
+		excusal.Reason,
+		excusal.From,
+		excusal.To,
+		routeId,
+		joinGrades(excusal.Grades),
+		joinVisitorIds(excusal.VisitorIds),
+		len(tardyIds),
+		excusal.CreatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return r.failed(queryCtx, "Storing excusal", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		_ = tx.Rollback()
+		return r.failed(queryCtx, "Storing excusal", err)
+	}
+	for _, tardyId := range tardyIds {
+		if _, err := tx.ExecContext(queryCtx, "UPDATE tardies SET excusal_id = ? WHERE id = ? AND excusal_id IS NULL"+r.tenantAnd(""), id, tardyId); err != nil {
+			_ = tx.Rollback()
+			return r.failed(queryCtx, "Storing excusal", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return r.failed(queryCtx, "Storing excusal", err)
+	}
+	excusal.Id = id
+	excusal.Excused = len(tardyIds)
+	return nil
+}
+
+func (r *Excusal) Excuse(ctx context.Context, id int64, tardyId int64) error {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	tx, err := r.Connection.BeginTx(queryCtx, nil)
+	if err != nil {
+		return r.failed(queryCtx, "Excusing tardy", err)
+	}
+	res, err := tx.ExecContext(queryCtx, "UPDATE tardies SET excusal_id = ? WHERE id = ? AND excusal_id IS NULL"+r.tenantAnd(""), id, tardyId)
+	if err != nil {
+		_ = tx.Rollback()
+		return r.failed(queryCtx, "Excusing tardy", err)
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		_ = tx.Rollback()
+		return r.failed(queryCtx, "Excusing tardy", err)
+	}
+	if _, err := tx.ExecContext(queryCtx, "UPDATE excusals SET excused_count = excused_count + 1 WHERE id = ?"+r.tenantAnd(""), id); err != nil {
+		_ = tx.Rollback()
+		return r.failed(queryCtx, "Excusing tardy", err)
+	}
+	return r.failed(queryCtx, "Excusing tardy", tx.Commit())
+}
+
+func (r *Excusal) GetById(ctx context.Context, id int64) (*entity.Excusal, error) {
+	excusals, err := r.find(ctx, excusalSelect+" WHERE id = ?"+r.and(""), id)
+	if err != nil {
+		return nil, err
+	}
+	if len(excusals) == 0 {
+		return nil, nil
+	}
+	return excusals[0], nil
+}
+
+// FindBetween returns excusals whose window overlaps the given range.
+func (r *Excusal) FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.Excusal, error) {
+	return r.find(ctx, excusalSelect+" WHERE starts_at < ? AND ends_at > ?"+r.and("")+" ORDER BY starts_at", to, from)
+}
+
+func (r *Excusal) Revoke(ctx context.Context, id int64, at time.Time) error {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	tx, err := r.Connection.BeginTx(queryCtx, nil)
+	if err != nil {
+		return r.failed(queryCtx, "Revoking excusal", err)
+	}
+	if _, err := tx.ExecContext(queryCtx, "UPDATE tardies SET excusal_id = NULL WHERE excusal_id = ?"+r.tenantAnd(""), id); err != nil {
+		_ = tx.Rollback()
+		return r.failed(queryCtx, "Revoking excusal", err)
+	}
+	if _, err := tx.ExecContext(queryCtx, "UPDATE excusals SET revoked_at = ? WHERE id = ?"+r.tenantAnd(""), at, id); err != nil {
+		_ = tx.Rollback()
+		return r.failed(queryCtx, "Revoking excusal", err)
+	}
+	return r.failed(queryCtx, "Revoking excusal", tx.Commit())
+}
+
+func (r *Excusal) find(ctx context.Context, query string, args ...any) ([]*entity.Excusal, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(queryCtx, query, args...)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Finding excusals", err)
+	}
+	defer rows.Close()
+
+	excusals := []*entity.Excusal{}
+	for rows.Next() {
+		var startsAtRaw, endsAtRaw, createdAtRaw []byte
+		var routeId sql.NullInt64
+		var grades, visitorIds, revokedAt sql.NullString
+		excusal := &entity.Excusal{}
+		err := rows.Scan(
+			&excusal.Id,
+			&excusal.Reason,
+			&startsAtRaw,
+			&endsAtRaw,
+			&routeId,
+			&grades,
+			&visitorIds,
+			&excusal.Excused,
+			&createdAtRaw,
+			&revokedAt,
+		)
+		if err != nil {
+			return nil, r.failed(queryCtx, "Finding excusals", err)
+		}
+		excusal.RouteId = routeId.Int64
+		if excusal.Grades, err = splitGrades(grades.String); err != nil {
+			return nil, err
+		}
+		if excusal.VisitorIds, err = splitVisitorIds(visitorIds.String); err != nil {
+			return nil, err
+		}
+		if excusal.From, err = parseDateTime(startsAtRaw); err != nil {
+			return nil, err
+		}
+		if excusal.To, err = parseDateTime(endsAtRaw); err != nil {
+			return nil, err
+		}
+		if excusal.CreatedAt, err = parseDateTime(createdAtRaw); err != nil {
+			return nil, err
+		}
+		if excusal.RevokedAt, err = parseNullDateTime(revokedAt); err != nil {
+			return nil, err
+		}
+		excusals = append(excusals, excusal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Finding excusals", err)
+	}
+	return excusals, nil
+}
+
+func joinVisitorIds(ids []int32) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.Itoa(int(id)))
+	}
+	return strings.Join(parts, ",")
+}
+
+func splitVisitorIds(raw string) ([]int32, error) {
+	ids := []int32{}
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, int32(id))
+	}
+	return ids, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var excusalColumns = []string{"id", "reason", "starts_at", "ends_at", "route_id", "grades", "visitor_ids", "excused_count", "created_at", "revoked_at"}
+
+func TestExcusalStore_MarksTardies(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Excusal{
+		Connection: db,
+		Scope:      Scope{SiteId: 2},
+	}
+
+	from := time.Date(2024, 9, 2, 8, 30, 0, 0, time.UTC)
+	to := time.Date(2024, 9, 2, 9, 15, 0, 0, time.UTC)
+	createdAt := time.Date(2024, 9, 2, 9, 20, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO excusals").
+		WithArgs(int64(0), int64(2), "Bus 12 late", from, to, nil, "7,8", "", 2, createdAt).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectExec("UPDATE tardies SET excusal_id = \\? WHERE id = \\? AND excusal_id IS NULL").
+		WithArgs(int64(4), int64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE tardies SET excusal_id = \\? WHERE id = \\? AND excusal_id IS NULL").
+		WithArgs(int64(4), int64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Execute
+	excusal := &entity.Excusal{Reason: "Bus 12 late", From: from, To: to, Grades: []int{7, 8}, CreatedAt: createdAt}
+	err = repo.Store(context.Background(), excusal, []int64{10, 11})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), excusal.Id)
+	assert.Equal(t, 2, excusal.Excused)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExcusalStore_RollsBackOnError(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Excusal{
+		Connection: db,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO excusals").
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectExec("UPDATE tardies").
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	// Execute
+	excusal := &entity.Excusal{Reason: "Bus 12 late"}
+	err = repo.Store(context.Background(), excusal, []int64{10})
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, int64(0), excusal.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExcusalGetById_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Excusal{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows(excusalColumns).
+		AddRow(4, "Bus 12 late", []byte("2024-09-02 08:30:00"), []byte("2024-09-02 09:15:00"), nil, nil, "7,9", 2, []byte("2024-09-02 09:20:00"), "2024-09-02 10:00:00")
+	mock.ExpectQuery("SELECT (.+) FROM excusals WHERE id = \\?").
+		WithArgs(int64(4)).
+		WillReturnRows(rows)
+
+	// Execute
+	excusal, err := repo.GetById(context.Background(), 4)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{7, 9}, excusal.VisitorIds)
+	assert.Empty(t, excusal.Grades)
+	assert.True(t, excusal.Revoked())
+	assert.Equal(t, time.Date(2024, 9, 2, 8, 30, 0, 0, time.UTC), excusal.From)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExcusalRevoke_ClearsTardies(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Excusal{
+		Connection: db,
+	}
+
+	at := time.Date(2024, 9, 2, 10, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE tardies SET excusal_id = NULL WHERE excusal_id = \\?").
+		WithArgs(int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("UPDATE excusals SET revoked_at = \\? WHERE id = \\?").
+		WithArgs(at, int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Execute
+	err = repo.Revoke(context.Background(), 4, at)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExcusalExcuse_CountsTardy(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Excusal{
+		Connection: db,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE tardies SET excusal_id = \\? WHERE id = \\? AND excusal_id IS NULL").
+		WithArgs(int64(4), int64(12)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE excusals SET excused_count = excused_count \\+ 1 WHERE id = \\?").
+		WithArgs(int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Execute
+	err = repo.Excuse(context.Background(), 4, 12)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const hallPassSelect = "SELECT p.id, p.visitor_id, p.teacher_id, p.destination, p.max_minutes, p.issued_at, p.started_at, p.closed_at, v.name, v.surname FROM hall_passes AS p INNER JOIN visitors AS v ON v.id = p.visitor_id"
+
+type HallPass struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+}
+
+func (r *HallPass) Store(pass *entity.HallPass) error {
+	var startedAt sql.NullTime
+	if pass.StartedAt != nil {
+		startedAt = sql.NullTime{Time: *pass.StartedAt, Valid: true}
+	}
+	res, err := r.Connection.Exec(
+		"INSERT INTO hall_passes (tenant_id, visitor_id, teacher_id, destination, max_minutes, issued_at, started_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		r.TenantId,
+		pass.VisitorId,
+		pass.TeacherId,
+		pass.Destination,
+		pass.MaxMinutes,
+		pass.IssuedAt,
+		startedAt,
+	)
+	if err != nil {
+		return err
+	}
+	pass.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *HallPass) GetById(id int64) (*entity.HallPass, error) {
+	return r.findOne(hallPassSelect+" WHERE p.id = ?"+r.and("v"), id)
+}
+
+func (r *HallPass) FindOpenByVisitorId(visitorId int32) (*entity.HallPass, error) {
+	return r.findOne(hallPassSelect+" WHERE p.visitor_id = ? AND p.closed_at IS NULL"+r.tenantAnd("p")+" ORDER BY p.issued_at DESC LIMIT 1", visitorId)
+}
+
+func (r *HallPass) FindOpen() ([]*entity.HallPass, error) {
+	return r.find(hallPassSelect + " WHERE p.closed_at IS NULL" + r.and("v") + " ORDER BY p.issued_at")
+}
+
+func (r *HallPass) FindBetween(from time.Time, to time.Time) ([]*entity.HallPass, error) {
+	return r.find(hallPassSelect+" WHERE p.issued_at >= ? AND p.issued_at < ?"+r.and("v")+" ORDER BY p.issued_at", from, to)
+}
+
+func (r *HallPass) CountByVisitorIdSince(visitorId int32, since time.Time) (int, error) {
+	var count int
+	err := r.Connection.QueryRow("SELECT COUNT(*) FROM hall_passes WHERE visitor_id = ? AND issued_at >= ?"+r.tenantAnd(""), visitorId, since).Scan(&count)
+	return count, err
+}
+
+func (r *HallPass) Start(id int64, at time.Time) error {
+	_, err := r.Connection.Exec("UPDATE hall_passes SET started_at = ? WHERE id = ? AND started_at IS NULL"+r.tenantAnd(""), at, id)
+	return err
+}
+
+func (r *HallPass) Close(id int64, at time.Time) error {
+	_, err := r.Connection.Exec("UPDATE hall_passes SET closed_at = ? WHERE id = ? AND closed_at IS NULL"+r.tenantAnd(""), at, id)
+	return err
+}
+
+func (r *HallPass) findOne(query string, args ...any) (*entity.HallPass, error) {
+	passes, err := r.find(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(passes) == 0 {
+		return nil, nil
+	}
+	return passes[0], nil
+}
+
+func (r *HallPass) find(query string, args ...any) ([]*entity.HallPass, error) {
+	rows, err := r.Connection.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	passes := []*entity.HallPass{}
+	for rows.Next() {
+		var issuedAtRaw []byte
+		var startedAt, closedAt, surname sql.NullString
+		pass := &entity.HallPass{Visitor: &entity.Visitor{}}
+		err := rows.Scan(
+			&pass.Id,
+			&pass.VisitorId,
+			&pass.TeacherId,
+			&pass.Destination,
+			&pass.MaxMinutes,
+			&issuedAtRaw,
+			&startedAt,
+			&closedAt,
+			&pass.Visitor.Name,
+			&surname,
+		)
+		if err != nil {
+			return nil, err
+		}
+		pass.Visitor.Id = pass.VisitorId
+		pass.Visitor.Surname = surname.String
+		if pass.IssuedAt, err = parseDateTime(issuedAtRaw); err != nil {
+			return nil, err
+		}
+		if pass.StartedAt, err = parseNullDateTime(startedAt); err != nil {
+			return nil, err
+		}
+		if pass.ClosedAt, err = parseNullDateTime(closedAt); err != nil {
+			return nil, err
+		}
+		passes = append(passes, pass)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return passes, nil
+}
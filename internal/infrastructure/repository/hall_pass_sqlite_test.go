@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var hallPassColumns = []string{"id", "visitor_id", "teacher_id", "destination", "max_minutes", "issued_at", "started_at", "closed_at", "name", "surname"}
+
+func TestHallPassStore_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &HallPass{
+		Connection: db,
+	}
+
+	issuedAt := time.Date(2024, 9, 2, 10, 15, 0, 0, time.UTC)
+	mock.ExpectExec("INSERT INTO hall_passes").
+		WithArgs(int64(0), int32(7), int32(2), "nurse", 10, issuedAt, nil).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+
+	// Execute
+	pass := &entity.HallPass{VisitorId: 7, TeacherId: 2, Destination: "nurse", MaxMinutes: 10, IssuedAt: issuedAt}
+	err = repo.Store(pass)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), pass.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHallPassFindOpenByVisitorId_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &HallPass{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows(hallPassColumns).
+		AddRow(5, 7, 2, "toilet", 10, []byte("2024-09-02 10:15:00"), "2024-09-02 10:16:00", nil, "Tom", "Doe")
+	mock.ExpectQuery("SELECT (.+) FROM hall_passes AS p INNER JOIN visitors AS v ON v.id = p.visitor_id WHERE p.visitor_id = \\? AND p.closed_at IS NULL").
+		WithArgs(int32(7)).
+		WillReturnRows(rows)
+
+	// Execute
+	pass, err := repo.FindOpenByVisitorId(7)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), pass.Id)
+	assert.Equal(t, "Tom", pass.Visitor.Name)
+	assert.Equal(t, time.Date(2024, 9, 2, 10, 16, 0, 0, time.UTC), *pass.StartedAt)
+	assert.Nil(t, pass.ClosedAt)
+	assert.Equal(t, entity.HallPassOut, pass.Status())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHallPassFindOpenByVisitorId_NoneOpen(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &HallPass{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM hall_passes").
+		WithArgs(int32(7)).
+		WillReturnRows(sqlmock.NewRows(hallPassColumns))
+
+	// Execute
+	pass, err := repo.FindOpenByVisitorId(7)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, pass)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHallPassCountByVisitorIdSince_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &HallPass{
+		Connection: db,
+	}
+
+	since := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM hall_passes WHERE visitor_id = \\? AND issued_at >= \\?").
+		WithArgs(int32(7), since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	// Execute
+	count, err := repo.CountByVisitorIdSince(7, since)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
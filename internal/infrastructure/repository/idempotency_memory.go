@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"slices"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// MemoryIdempotency is the entity.IdempotencyRepository of the memory
+// engine, see MemoryStore. Keys are unique within the site.
+type MemoryIdempotency struct {
+	Memory *MemoryStore
+	Scope
+}
+
+// Reserve drops an expired request of the key before claiming it.
+func (r *MemoryIdempotency) Reserve(request *entity.IdempotentRequest) (bool, error) {
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	current := now()
+	r.Memory.requests = slices.DeleteFunc(r.Memory.requests, func(stored *memoryRequest) bool {
+		return r.holds(stored, request.Key) && !stored.request.ExpiresAt.After(current)
+	})
+	if r.find(request.Key) != nil {
+		return false, nil
+	}
+	reserved := entity.IdempotentRequest{Key: request.Key, RequestHash: request.RequestHash, ExpiresAt: request.ExpiresAt.UTC()}
+	r.Memory.requests = append(r.Memory.requests, &memoryRequest{tenantId: r.TenantId, siteId: r.SiteId, request: reserved})
+	return true, nil
+}
+
+func (r *MemoryIdempotency) GetByKey(key string) (*entity.IdempotentRequest, error) {
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	stored := r.find(key)
+	if stored == nil || !stored.request.ExpiresAt.After(now()) {
+		return nil, nil
+	}
+	request := stored.request
+	request.Body = slices.Clone(stored.request.Body)
+	return &request, nil
+}
+
+func (r *MemoryIdempotency) Complete(request *entity.IdempotentRequest) error {
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	if stored := r.find(request.Key); stored != nil {
+		stored.request.StatusCode = request.StatusCode
+		stored.request.ContentType = request.ContentType
+		stored.request.Body = slices.Clone(request.Body)
+	}
+	return nil
+}
+
+func (r *MemoryIdempotency) Release(key string) error {
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	r.Memory.requests = slices.DeleteFunc(r.Memory.requests, func(stored *memoryRequest) bool {
+		return r.holds(stored, key)
+	})
+	return nil
+}
+
+// find returns the stored request of the key, callers hold the lock.
+func (r *MemoryIdempotency) find(key string) *memoryRequest {
+	for _, stored := range r.Memory.requests {
+		if r.holds(stored, key) {
+			return stored
+		}
+	}
+	return nil
+}
+
+func (r *MemoryIdempotency) holds(stored *memoryRequest, key string) bool {
+	return stored.request.Key == key && stored.tenantId == r.TenantId && stored.siteId == r.SiteId
+}
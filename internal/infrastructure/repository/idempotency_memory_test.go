@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryIdempotency_ReserveCompleteAndRelease(t *testing.T) {
+	// Setup
+	memory := NewMemoryStore()
+	repo := &MemoryIdempotency{Memory: memory, Scope: Scope{TenantId: 1, SiteId: 3}}
+	otherSite := &MemoryIdempotency{Memory: memory, Scope: Scope{TenantId: 1, SiteId: 5}}
+	request := &entity.IdempotentRequest{Key: "scan-1", RequestHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+
+	// Execute
+	reserved, err := repo.Reserve(request)
+	again, againErr := repo.Reserve(request)
+	elsewhere, elsewhereErr := otherSite.Reserve(request)
+	request.StatusCode = http.StatusOK
+	request.Body = []byte(`{"track_id":1}`)
+	assert.NoError(t, repo.Complete(request))
+	stored, getErr := repo.GetByKey("scan-1")
+	assert.NoError(t, repo.Release("scan-1"))
+	released, _ := repo.GetByKey("scan-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+	assert.NoError(t, againErr)
+	assert.False(t, again)
+	assert.NoError(t, elsewhereErr)
+	assert.True(t, elsewhere)
+	assert.NoError(t, getErr)
+	if assert.NotNil(t, stored) {
+		assert.True(t, stored.Completed())
+		assert.Equal(t, `{"track_id":1}`, string(stored.Body))
+	}
+	assert.Nil(t, released)
+}
+
+func TestMemoryIdempotency_ExpiredKeyIsReservedAgain(t *testing.T) {
+	// Setup
+	repo := &MemoryIdempotency{Memory: NewMemoryStore(), Scope: Scope{TenantId: 1, SiteId: 3}}
+	_, err := repo.Reserve(&entity.IdempotentRequest{Key: "scan-1", RequestHash: "old", ExpiresAt: time.Now().Add(-time.Minute)})
+	assert.NoError(t, err)
+
+	// Execute
+	expired, getErr := repo.GetByKey("scan-1")
+	reserved, reserveErr := repo.Reserve(&entity.IdempotentRequest{Key: "scan-1", RequestHash: "new", ExpiresAt: time.Now().Add(time.Hour)})
+
+	// Assert
+	assert.NoError(t, getErr)
+	assert.Nil(t, expired)
+	assert.NoError(t, reserveErr)
+	assert.True(t, reserved)
+}
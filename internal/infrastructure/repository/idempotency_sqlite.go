@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+type Idempotency struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+}
+
+// Reserve drops an expired request of the key before claiming it, the
+// primary key lets only one of concurrent requests win.
+func (r *Idempotency) Reserve(request *entity.IdempotentRequest) (bool, error) {
+	current := now()
+	_, err := r.Connection.Exec(
+		"DELETE FROM idempotency_keys WHERE idempotency_key = ? AND expires_at <= ?"+r.and(""),
+		request.Key,
+		current,
+	)
+	if err != nil {
+		return false, err
+	}
+	res, err := r.Connection.Exec(
+		"INSERT IGNORE INTO idempotency_keys (tenant_id, site_id, idempotency_key, request_hash, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?)",
+		r.TenantId,
+		r.SiteId,
+		request.Key,
+		request.RequestHash,
+		current,
+		request.ExpiresAt.UTC(),
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *Idempotency) GetByKey(key string) (*entity.IdempotentRequest, error) {
+	var contentType sql.NullString
+	var expiresAtRaw []byte
+	request := &entity.IdempotentRequest{}
+	err := r.Connection.QueryRow(
+		"SELECT idempotency_key, request_hash, status_code, content_type, body, expires_at FROM idempotency_keys WHERE idempotency_key = ? AND expires_at > ?"+r.and(""),
+		key,
+		now(),
+	).Scan(&request.Key, &request.RequestHash, &request.StatusCode, &contentType, &request.Body, &expiresAtRaw)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	request.ContentType = contentType.String
+	request.ExpiresAt, err = parseDateTime(expiresAtRaw)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (r *Idempotency) Complete(request *entity.IdempotentRequest) error {
+	_, err := r.Connection.Exec(
+		"UPDATE idempotency_keys SET status_code = ?, content_type = ?, body = ? WHERE idempotency_key = ?"+r.and(""),
+		request.StatusCode,
+		nullString(request.ContentType),
+		request.Body,
+		request.Key,
+	)
+	return err
+}
+
+func (r *Idempotency) Release(key string) error {
+	_, err := r.Connection.Exec("DELETE FROM idempotency_keys WHERE idempotency_key = ?"+r.and(""), key)
+	return err
+}
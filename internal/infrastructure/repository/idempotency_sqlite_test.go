@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyReserve_ClaimsFreeKey(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Idempotency{
+		Connection: db,
+		Scope:      Scope{TenantId: 1, SiteId: 2},
+	}
+
+	expiresAt := time.Date(2024, 9, 3, 8, 0, 0, 0, time.UTC)
+	mock.ExpectExec("DELETE FROM idempotency_keys WHERE idempotency_key = \\? AND expires_at <= \\? AND tenant_id = 1 AND site_id = 2").
+		WithArgs("key-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT IGNORE INTO idempotency_keys").
+		WithArgs(int64(1), int64(2), "key-1", "hash", sqlmock.AnyArg(), expiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execute
+	reserved, err := repo.Reserve(&entity.IdempotentRequest{Key: "key-1", RequestHash: "hash", ExpiresAt: expiresAt})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyReserve_KeyTaken(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Idempotency{
+		Connection: db,
+	}
+
+	mock.ExpectExec("DELETE FROM idempotency_keys").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT IGNORE INTO idempotency_keys").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Execute
+	reserved, err := repo.Reserve(&entity.IdempotentRequest{Key: "key-1", RequestHash: "hash", ExpiresAt: time.Now()})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, reserved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyGetByKey_ReturnsStoredResponse(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Idempotency{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows([]string{"idempotency_key", "request_hash", "status_code", "content_type", "body", "expires_at"}).
+		AddRow("key-1", "hash", 200, "application/json", []byte(`{"ok":true}`), "2024-09-03 08:00:00")
+	mock.ExpectQuery("SELECT (.+) FROM idempotency_keys WHERE idempotency_key = \\? AND expires_at > \\?").
+		WithArgs("key-1", sqlmock.AnyArg()).
+		WillReturnRows(rows)
+
+	// Execute
+	request, err := repo.GetByKey("key-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, request.Completed())
+	assert.Equal(t, `{"ok":true}`, string(request.Body))
+	assert.Equal(t, time.Date(2024, 9, 3, 8, 0, 0, 0, time.UTC), request.ExpiresAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyGetByKey_Unknown(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Idempotency{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM idempotency_keys").
+		WillReturnRows(sqlmock.NewRows([]string{"idempotency_key", "request_hash", "status_code", "content_type", "body", "expires_at"}))
+
+	// Execute
+	request, err := repo.GetByKey("key-2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, request)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
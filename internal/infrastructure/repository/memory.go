@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// MemoryStore keeps the tenants, sites, visitors, tracks and idempotency
+// keys of the memory engine (DATABASE_ENGINE=memory), so the app runs as a demo without a
+// database and tests get repositories which behave like the SQL ones. The
+// Memory* repositories of a store share it, it is safe for concurrent use
+// and lost on restart.
+type MemoryStore struct {
+	mu            sync.RWMutex
+	tenants       []*memoryTenant
+	sites         []*memorySite
+	closedDays    []*memoryClosedDay
+	visitors      []*memoryVisitor
+	keys          []*memoryKey
+	tracks        []*memoryTrack
+	requests      []*memoryRequest
+	lastTenantId  int64
+	lastSiteId    int64
+	lastVisitorId int32
+	lastTrackId   int
+}
+
+type memoryTenant struct {
+	tenant    entity.Tenant
+	tokenHash string
+}
+
+type memorySite struct {
+	tenantId int64
+	site     entity.Site
+}
+
+type memoryClosedDay struct {
+	tenantId int64
+	day      entity.ClosedDay
+}
+
+type memoryVisitor struct {
+	tenantId int64
+	visitor  entity.Visitor
+}
+
+type memoryKey struct {
+	tenantId  int64
+	visitorId int32
+	key       string
+}
+
+type memoryTrack struct {
+	tenantId int64
+	siteId   int64
+	track    entity.VisitTrack
+}
+
+type memoryRequest struct {
+	tenantId int64
+	siteId   int64
+	request  entity.IdempotentRequest
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// MemorySeed is the data the memory engine starts with, see
+// DATABASE_SEED_PATH.
+type MemorySeed struct {
+	Visitors []SeedVisitor `json:"visitors"`
+}
+
+// SeedVisitor is a visitor of the seed with the keys it scans, visitors
+// without an id get the next free one.
+type SeedVisitor struct {
+	entity.Visitor
+	Keys []string `json:"keys"`
+}
+
+// LoadMemorySeed reads a JSON seed like
+// {"visitors": [{"name": "Jane", "surname": "Doe", "grade": 5, "keys": ["KEY123"]}]}.
+func LoadMemorySeed(path string) (MemorySeed, error) {
+	seed := MemorySeed{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return seed, err
+	}
+	if err := json.Unmarshal(data, &seed); err != nil {
+		return seed, fmt.Errorf("invalid seed %s: %w", path, err)
+	}
+	return seed, nil
+}
+
+// Seed adds the visitors of the seed and their keys to the tenant. Visitors
+// without a home site get the one of the scope.
+func (s *MemoryStore) Seed(scope Scope, seed MemorySeed) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, seeded := range seed.Visitors {
+		visitor := seeded.Visitor
+		if visitor.Id == 0 {
+			visitor.Id = s.lastVisitorId + 1
+		}
+		if s.visitor(scope.TenantId, visitor.Id) != nil {
+			return fmt.Errorf("Duplicate entry '%d' for key 'visitors.PRIMARY'", visitor.Id)
+		}
+		if visitor.SiteId == 0 {
+			visitor.SiteId = scope.SiteId
+		}
+		s.visitors = append(s.visitors, &memoryVisitor{tenantId: scope.TenantId, visitor: visitor})
+		s.lastVisitorId = max(s.lastVisitorId, visitor.Id)
+		for _, key := range seeded.Keys {
+			if err := s.addKey(scope.TenantId, visitor.Id, key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// visitor returns the stored visitor of the tenant, nil when there is none.
+// Callers hold the lock.
+func (s *MemoryStore) visitor(tenantId int64, id int32) *memoryVisitor {
+	for _, stored := range s.visitors {
+		if stored.visitor.Id == id && inTenant(tenantId, stored.tenantId) {
+			return stored
+		}
+	}
+	return nil
+}
+
+// key returns the stored key of the tenant, keys are matched case
+// insensitively like by the database. Callers hold the lock.
+func (s *MemoryStore) key(tenantId int64, key string) *memoryKey {
+	for _, stored := range s.keys {
+		if strings.EqualFold(stored.key, key) && inTenant(tenantId, stored.tenantId) {
+			return stored
+		}
+	}
+	return nil
+}
+
+// addKey stores a key of the visitor, keys are unique within the tenant.
+// Callers hold the lock.
+func (s *MemoryStore) addKey(tenantId int64, visitorId int32, key string) error {
+	if s.key(tenantId, key) != nil {
+		return fmt.Errorf("Duplicate entry '%s' for key 'visitor_key.PRIMARY'", key)
+	}
+	s.keys = append(s.keys, &memoryKey{tenantId: tenantId, visitorId: visitorId, key: key})
+	return nil
+}
+
+// inTenant reports whether a record of the tenant is read by a scope of
+// scopeId, zero reads across tenants like Scope does.
+func inTenant(scopeId int64, tenantId int64) bool {
+	return scopeId == 0 || scopeId == tenantId
+}
+
+// memoryQuery fails a query whose context is done the way a database query
+// would, see Timeout.failed.
+func memoryQuery(ctx context.Context, query string) error {
+	err := ctx.Err()
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &entity.QueryTimeoutError{Query: query, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// seededMemory returns a store with Jane Doe (7, KEY123) and John Smith
+// (KEY456) in tenant 1 and Ann Lee (KEY999) in tenant 2.
+func seededMemory(t *testing.T) *MemoryStore {
+	t.Helper()
+	memory := NewMemoryStore()
+	assert.NoError(t, memory.Seed(Scope{TenantId: 1, SiteId: 3}, MemorySeed{Visitors: []SeedVisitor{
+		{Visitor: entity.Visitor{Id: 7, Name: "Jane", Surname: "Doe", Grade: 5}, Keys: []string{"KEY123"}},
+		{Visitor: entity.Visitor{Name: "John", Surname: "Smith", Grade: 6}, Keys: []string{"KEY456"}},
+	}}))
+	assert.NoError(t, memory.Seed(Scope{TenantId: 2, SiteId: 4}, MemorySeed{Visitors: []SeedVisitor{
+		{Visitor: entity.Visitor{Name: "Ann", Surname: "Lee"}, Keys: []string{"KEY999"}},
+	}}))
+	return memory
+}
+
+func TestLoadMemorySeed_Success(t *testing.T) {
+	// Setup
+	path := filepath.Join(t.TempDir(), "seed.json")
+	err := os.WriteFile(path, []byte(`{"visitors": [{"name": "Jane", "surname": "Doe", "grade": 5, "keys": ["KEY123", "CARD1"]}]}`), 0o600)
+	assert.NoError(t, err)
+
+	// Execute
+	seed, err := LoadMemorySeed(path)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, seed.Visitors, 1) {
+		assert.Equal(t, "Jane", seed.Visitors[0].Name)
+		assert.Equal(t, 5, seed.Visitors[0].Grade)
+		assert.Equal(t, []string{"KEY123", "CARD1"}, seed.Visitors[0].Keys)
+	}
+}
+
+func TestLoadMemorySeed_InvalidJson(t *testing.T) {
+	// Setup
+	path := filepath.Join(t.TempDir(), "seed.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"visitors": [`), 0o600))
+
+	// Execute
+	_, err := LoadMemorySeed(path)
+
+	// Assert
+	assert.ErrorContains(t, err, "invalid seed")
+}
+
+func TestLoadMemorySeed_DemoSeed(t *testing.T) {
+	// Execute
+	seed, err := LoadMemorySeed("../../../demo/seed.json")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, seed.Visitors)
+}
+
+func TestMemorySeed_AssignsIdsAndSite(t *testing.T) {
+	// Setup
+	memory := seededMemory(t)
+	repo := &MemoryVisitor{Memory: memory, Scope: Scope{TenantId: 1, SiteId: 3}}
+
+	// Execute
+	john, err := repo.FindById(context.Background(), 8)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "John", john.Name)
+	assert.Equal(t, int64(3), john.SiteId)
+}
+
+func TestMemorySeed_RejectsDuplicates(t *testing.T) {
+	// Setup
+	memory := seededMemory(t)
+
+	// Execute
+	idErr := memory.Seed(Scope{TenantId: 1}, MemorySeed{Visitors: []SeedVisitor{{Visitor: entity.Visitor{Id: 7}}}})
+	keyErr := memory.Seed(Scope{TenantId: 1}, MemorySeed{Visitors: []SeedVisitor{{Keys: []string{"key456"}}}})
+
+	// Assert
+	assert.ErrorContains(t, idErr, "Duplicate entry '7'")
+	assert.ErrorContains(t, keyErr, "Duplicate entry 'key456'")
+}
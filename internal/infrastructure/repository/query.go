@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// Timeout bounds every query of a repository, zero leaves queries to the
+// deadline of the context they run with.
+type Timeout struct {
+	QueryTimeout time.Duration
+}
+
+// query derives the context of a single query from the one of the request.
+func (t Timeout) query(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t.QueryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, t.QueryTimeout)
+}
+
+// failed turns the error of a query whose context ran out of time into an
+// entity.QueryTimeoutError, other errors are returned as they are.
+func (t Timeout) failed(ctx context.Context, query string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &entity.QueryTimeoutError{Query: query, Limit: t.QueryTimeout, Err: err}
+	}
+	return err
+}
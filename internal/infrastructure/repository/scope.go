@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Scope is the tenant and site a repository serves. Records a site owns and
+// every listing or report are filtered by both, records shared by the
+// campuses of a school, people first of all, by the tenant only. Zero ids
+// read across tenants or sites, writes always need them.
+type Scope struct {
+	TenantId int64
+	SiteId   int64
+}
+
+// where starts a WHERE clause limiting the table of the alias to the scope.
+func (s Scope) where(alias string) string {
+	return clause(" WHERE ", s.conditions(alias, true))
+}
+
+// and extends a WHERE clause limiting the table of the alias to the scope.
+func (s Scope) and(alias string) string {
+	return clause(" AND ", s.conditions(alias, true))
+}
+
+// tenantWhere starts a WHERE clause limiting the table of the alias to the tenant.
+func (s Scope) tenantWhere(alias string) string {
+	return clause(" WHERE ", s.conditions(alias, false))
+}
+
+// tenantAnd extends a WHERE clause limiting the table of the alias to the tenant.
+func (s Scope) tenantAnd(alias string) string {
+	return clause(" AND ", s.conditions(alias, false))
+}
+
+func (s Scope) conditions(alias string, withSite bool) []string {
+	prefix := ""
+	if alias != "" {
+		prefix = alias + "."
+	}
+	conditions := []string{}
+	if s.TenantId > 0 {
+		conditions = append(conditions, prefix+"tenant_id = "+strconv.FormatInt(s.TenantId, 10))
+	}
+	if withSite && s.SiteId > 0 {
+		conditions = append(conditions, prefix+"site_id = "+strconv.FormatInt(s.SiteId, 10))
+	}
+	return conditions
+}
+
+func clause(keyword string, conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return keyword + strings.Join(conditions, " AND ")
+}
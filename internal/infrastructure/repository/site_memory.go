@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// MemorySite is the entity.SiteRepository of the memory engine, see
+// MemoryStore.
+type MemorySite struct {
+	Memory *MemoryStore
+	Scope
+}
+
+func (r *MemorySite) FindAll() ([]*entity.Site, error) {
+	return r.find(func(*memorySite) bool { return true }), nil
+}
+
+func (r *MemorySite) GetById(id int64) (*entity.Site, error) {
+	return r.first(func(stored *memorySite) bool { return stored.site.Id == id }), nil
+}
+
+func (r *MemorySite) GetByCode(code string) (*entity.Site, error) {
+	return r.first(func(stored *memorySite) bool { return stored.site.Code == code }), nil
+}
+
+func (r *MemorySite) Store(site *entity.Site) error {
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	for _, stored := range r.Memory.sites {
+		if stored.tenantId == r.TenantId && stored.site.Code == site.Code && stored.site.Id != site.Id {
+			return fmt.Errorf("Duplicate entry '%s' for key 'sites.tenant_code'", site.Code)
+		}
+	}
+	copied := *site
+	if copied.LateGraceMinutes != nil {
+		minutes := *copied.LateGraceMinutes
+		copied.LateGraceMinutes = &minutes
+	}
+	if site.Id > 0 {
+		for _, stored := range r.Memory.sites {
+			if stored.site.Id == site.Id && inTenant(r.TenantId, stored.tenantId) {
+				stored.site = copied
+			}
+		}
+		return nil
+	}
+	r.Memory.lastSiteId++
+	site.Id = r.Memory.lastSiteId
+	copied.Id = site.Id
+	r.Memory.sites = append(r.Memory.sites, &memorySite{tenantId: r.TenantId, site: copied})
+	return nil
+}
+
+func (r *MemorySite) FindClosedDays(siteId int64, from time.Time, to time.Time) ([]*entity.ClosedDay, error) {
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	first, last := from.Format(dateLayout), to.Format(dateLayout)
+	days := []*entity.ClosedDay{}
+	for _, stored := range r.Memory.closedDays {
+		if stored.day.SiteId == siteId && stored.day.Date >= first && stored.day.Date < last && inTenant(r.TenantId, stored.tenantId) {
+			day := stored.day
+			days = append(days, &day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	return days, nil
+}
+
+// AddClosedDay stores the day, the reason of a day already closed is updated.
+func (r *MemorySite) AddClosedDay(day *entity.ClosedDay) error {
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	for _, stored := range r.Memory.closedDays {
+		if stored.tenantId == r.TenantId && stored.day.SiteId == day.SiteId && stored.day.Date == day.Date {
+			stored.day.Reason = day.Reason
+			return nil
+		}
+	}
+	r.Memory.closedDays = append(r.Memory.closedDays, &memoryClosedDay{tenantId: r.TenantId, day: *day})
+	return nil
+}
+
+func (r *MemorySite) RemoveClosedDay(siteId int64, date string) error {
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	kept := r.Memory.closedDays[:0]
+	for _, stored := range r.Memory.closedDays {
+		if stored.day.SiteId == siteId && stored.day.Date == date && inTenant(r.TenantId, stored.tenantId) {
+			continue
+		}
+		kept = append(kept, stored)
+	}
+	r.Memory.closedDays = kept
+	return nil
+}
+
+func (r *MemorySite) IsClosedOn(siteId int64, date time.Time) (bool, error) {
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	for _, stored := range r.Memory.closedDays {
+		if stored.day.SiteId == siteId && stored.day.Date == date.Format(dateLayout) && inTenant(r.TenantId, stored.tenantId) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *MemorySite) first(match func(*memorySite) bool) *entity.Site {
+	sites := r.find(match)
+	if len(sites) == 0 {
+		return nil
+	}
+	return sites[0]
+}
+
+func (r *MemorySite) find(match func(*memorySite) bool) []*entity.Site {
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	sites := []*entity.Site{}
+	for _, stored := range r.Memory.sites {
+		if inTenant(r.TenantId, stored.tenantId) && match(stored) {
+			site := stored.site
+			if site.LateGraceMinutes != nil {
+				minutes := *site.LateGraceMinutes
+				site.LateGraceMinutes = &minutes
+			}
+			sites = append(sites, &site)
+		}
+	}
+	return sites
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemorySiteStore_CreatesAndUpdates(t *testing.T) {
+	// Setup
+	repo := &MemorySite{Memory: NewMemoryStore(), Scope: Scope{TenantId: 1}}
+	site := &entity.Site{Code: "north", Name: "North campus"}
+	assert.NoError(t, repo.Store(site))
+
+	// Execute
+	site.Name = "North"
+	err := repo.Store(site)
+	duplicate := repo.Store(&entity.Site{Code: "north"})
+
+	// Assert
+	assert.NoError(t, err)
+	found, _ := repo.GetByCode("north")
+	if assert.NotNil(t, found) {
+		assert.Equal(t, "North", found.Name)
+	}
+	assert.ErrorContains(t, duplicate, "Duplicate entry 'north'")
+}
+
+func TestMemorySiteClosedDays(t *testing.T) {
+	// Setup
+	repo := &MemorySite{Memory: NewMemoryStore(), Scope: Scope{TenantId: 1}}
+	assert.NoError(t, repo.AddClosedDay(&entity.ClosedDay{SiteId: 3, Date: "2025-03-05", Reason: "Strike"}))
+	assert.NoError(t, repo.AddClosedDay(&entity.ClosedDay{SiteId: 3, Date: "2025-03-04", Reason: "Holiday"}))
+	assert.NoError(t, repo.AddClosedDay(&entity.ClosedDay{SiteId: 3, Date: "2025-03-05", Reason: "Snow"}))
+
+	// Execute
+	days, err := repo.FindClosedDays(3, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC))
+	closed, closedErr := repo.IsClosedOn(3, time.Date(2025, 3, 5, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, repo.RemoveClosedDay(3, "2025-03-05"))
+	reopened, _ := repo.IsClosedOn(3, time.Date(2025, 3, 5, 10, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, days, 2) {
+		assert.Equal(t, "2025-03-04", days[0].Date)
+		assert.Equal(t, "Snow", days[1].Reason)
+	}
+	assert.NoError(t, closedErr)
+	assert.True(t, closed)
+	assert.False(t, reopened)
+}
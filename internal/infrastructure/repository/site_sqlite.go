@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const siteSelect = "SELECT id, code, name, school_day_starts_at, late_grace_minutes FROM sites"
+
+const dateLayout = "2006-01-02"
+
+type Site struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+}
+
+func (r *Site) FindAll() ([]*entity.Site, error) {
+	return r.find(siteSelect + r.tenantWhere("") + " ORDER BY id")
+}
+
+func (r *Site) GetById(id int64) (*entity.Site, error) {
+	sites, err := r.find(siteSelect+" WHERE id = ?"+r.tenantAnd(""), id)
+	if err != nil || len(sites) == 0 {
+		return nil, err
+	}
+	return sites[0], nil
+}
+
+func (r *Site) GetByCode(code string) (*entity.Site, error) {
+	sites, err := r.find(siteSelect+" WHERE code = ?"+r.tenantAnd(""), code)
+	if err != nil || len(sites) == 0 {
+		return nil, err
+	}
+	return sites[0], nil
+}
+
+func (r *Site) Store(site *entity.Site) error {
+	var startsAt sql.NullString
+	if site.SchoolDayStartsAt != "" {
+		startsAt = sql.NullString{String: site.SchoolDayStartsAt, Valid: true}
+	}
+	var grace sql.NullInt64
+	if site.LateGraceMinutes != nil {
+		grace = sql.NullInt64{Int64: int64(*site.LateGraceMinutes), Valid: true}
+	}
+	if site.Id > 0 {
+		_, err := r.Connection.Exec(
+			"UPDATE sites SET code = ?, name = ?, school_day_starts_at = ?, late_grace_minutes = ? WHERE id = ?"+r.tenantAnd(""),
+			site.Code,
+			site.Name,
+			startsAt,
+			grace,
+			site.Id,
+		)
+		return err
+	}
+	res, err := r.Connection.Exec(
+		"INSERT INTO sites (tenant_id, code, name, school_day_starts_at, late_grace_minutes) VALUES (?, ?, ?, ?, ?)",
+		r.TenantId,
+		site.Code,
+		site.Name,
+		startsAt,
+		grace,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	site.Id = id
+	return nil
+}
+
+func (r *Site) FindClosedDays(siteId int64, from time.Time, to time.Time) ([]*entity.ClosedDay, error) {
+	rows, err := r.Connection.Query(
+		"SELECT site_id, date, reason FROM site_closed_days WHERE site_id = ? AND date >= ? AND date < ?"+r.tenantAnd("")+" ORDER BY date",
+		siteId,
+		from.Format(dateLayout),
+		to.Format(dateLayout),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := []*entity.ClosedDay{}
+	for rows.Next() {
+		var date []byte
+		var reason sql.NullString
+		day := &entity.ClosedDay{}
+		if err := rows.Scan(&day.SiteId, &date, &reason); err != nil {
+			return nil, err
+		}
+		day.Date = string(date)
+		day.Reason = reason.String
+		days = append(days, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+func (r *Site) AddClosedDay(day *entity.ClosedDay) error {
+	_, err := r.Connection.Exec(
+		"INSERT INTO site_closed_days (tenant_id, site_id, date, reason) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE reason = VALUES(reason)",
+		r.TenantId,
+		day.SiteId,
+		day.Date,
+		day.Reason,
+	)
+	return err
+}
+
+func (r *Site) RemoveClosedDay(siteId int64, date string) error {
+	_, err := r.Connection.Exec("DELETE FROM site_closed_days WHERE site_id = ? AND date = ?"+r.tenantAnd(""), siteId, date)
+	return err
+}
+
+func (r *Site) IsClosedOn(siteId int64, date time.Time) (bool, error) {
+	var count int
+	err := r.Connection.QueryRow(
+		"SELECT COUNT(*) FROM site_closed_days WHERE site_id = ? AND date = ?"+r.tenantAnd(""),
+		siteId,
+		date.Format(dateLayout),
+	).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *Site) find(query string, args ...any) ([]*entity.Site, error) {
+	rows, err := r.Connection.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sites := []*entity.Site{}
+	for rows.Next() {
+		var startsAt sql.NullString
+		var grace sql.NullInt64
+		site := &entity.Site{}
+		if err := rows.Scan(&site.Id, &site.Code, &site.Name, &startsAt, &grace); err != nil {
+			return nil, err
+		}
+		site.SchoolDayStartsAt = startsAt.String
+		if grace.Valid {
+			minutes := int(grace.Int64)
+			site.LateGraceMinutes = &minutes
+		}
+		sites = append(sites, site)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sites, nil
+}
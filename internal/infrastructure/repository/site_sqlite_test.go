@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var siteColumns = []string{"id", "code", "name", "school_day_starts_at", "late_grace_minutes"}
+
+func TestSiteGetByCode_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Site{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows(siteColumns).
+		AddRow(2, "north", "North campus", "08:15", 5)
+	mock.ExpectQuery("SELECT id, code, name, school_day_starts_at, late_grace_minutes FROM sites WHERE code = \\?").
+		WithArgs("north").
+		WillReturnRows(rows)
+
+	// Execute
+	site, err := repo.GetByCode("north")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), site.Id)
+	assert.Equal(t, "08:15", site.SchoolDayStartsAt)
+	assert.Equal(t, 5, *site.LateGraceMinutes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSiteGetByCode_NotFound(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Site{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM sites WHERE code = \\?").
+		WithArgs("south").
+		WillReturnRows(sqlmock.NewRows(siteColumns))
+
+	// Execute
+	site, err := repo.GetByCode("south")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, site)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSiteStore_InsertsWithoutOverrides(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Site{
+		Connection: db,
+	}
+
+	mock.ExpectExec("INSERT INTO sites").
+		WithArgs(int64(0), "south", "South campus", nil, nil).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	// Execute
+	site := &entity.Site{Code: "south", Name: "South campus"}
+	err = repo.Store(site)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), site.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSiteIsClosedOn_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Site{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM site_closed_days WHERE site_id = \\? AND date = \\?").
+		WithArgs(int64(2), "2024-12-24").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	// Execute
+	closed, err := repo.IsClosedOn(2, time.Date(2024, 12, 24, 9, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, closed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const tardySelect = "SELECT t.id, t.track_id, t.visitor_id, t.reason, t.note, t.minutes_late, t.tracked_at, t.excusal_id, e.reason, v.name, v.surname, v.grade FROM tardies AS t INNER JOIN visitors AS v ON v.id = t.visitor_id LEFT JOIN excusals AS e ON e.id = t.excusal_id"
+
+type Tardy struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+	Timeout
+}
+
+func (r *Tardy) Save(ctx context.Context, tardy *entity.Tardy) error {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	res, err := r.Connection.ExecContext(
+		queryCtx,
+		"INSERT INTO tardies (tenant_id, site_id, track_id, visitor_id, reason, note, minutes_late, tracked_at) VALUES (?, (SELECT site_id FROM track WHERE id = ?), ?, ?, ?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id), reason = VALUES(reason), note = VALUES(note), minutes_late = VALUES(minutes_late)",
+		r.TenantId,
+		tardy.TrackId,
+		tardy.TrackId,
+		tardy.VisitorId,
+		tardy.Reason,
+		tardy.Note,
+		tardy.MinutesLate,
+		tardy.TrackedAt,
+	)
+	if err != nil {
+		return r.failed(queryCtx, "Saving tardy", err)
+	}
+	tardy.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *Tardy) GetByTrackId(ctx context.Context, trackId int64) (*entity.Tardy, error) {
+	tardies, err := r.find(ctx, tardySelect+" WHERE t.track_id = ?"+r.tenantAnd("t"), trackId)
+	if err != nil {
+		return nil, err
+	}
+	if len(tardies) == 0 {
+		return nil, nil
+	}
+	return tardies[0], nil
+}
+
+// FindBetween returns the tardies recorded at the site of the repository,
+// wherever the students belong to.
+func (r *Tardy) FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.Tardy, error) {
+	return r.find(ctx, tardySelect+" WHERE t.tracked_at >= ? AND t.tracked_at < ?"+r.and("t")+" ORDER BY t.tracked_at", from, to)
+}
+
+func (r *Tardy) CountByVisitorIdSince(ctx context.Context, visitorId int32, since time.Time) (int, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	var count int
+	err := r.Connection.QueryRowContext(queryCtx, "SELECT COUNT(*) FROM tardies WHERE visitor_id = ? AND tracked_at >= ? AND excusal_id IS NULL"+r.tenantAnd(""), visitorId, since).Scan(&count)
+	if err != nil {
+		return 0, r.failed(queryCtx, "Counting tardies", err)
+	}
+	return count, nil
+}
+
+func (r *Tardy) find(ctx context.Context, query string, args ...any) ([]*entity.Tardy, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(queryCtx, query, args...)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Finding tardies", err)
+	}
+	defer rows.Close()
+
+	tardies := []*entity.Tardy{}
+	for rows.Next() {
+		var note, excusalReason, surname sql.NullString
+		var excusalId sql.NullInt64
+		var grade sql.NullInt32
+		var trackedAtRaw []byte
+		tardy := &entity.Tardy{Visitor: &entity.Visitor{}}
+		err := rows.Scan(
+			&tardy.Id,
+			&tardy.TrackId,
+			&tardy.VisitorId,
+			&tardy.Reason,
+			&note,
+			&tardy.MinutesLate,
+			&trackedAtRaw,
+			&excusalId,
+			&excusalReason,
+			&tardy.Visitor.Name,
+			&surname,
+			&grade,
+		)
+		if err != nil {
+			return nil, r.failed(queryCtx, "Finding tardies", err)
+		}
+		tardy.Visitor.Id = tardy.VisitorId
+		tardy.Visitor.Surname = surname.String
+		tardy.Visitor.Grade = int(grade.Int32)
+		tardy.Note = note.String
+		tardy.ExcusalId = excusalId.Int64
+		tardy.ExcusalReason = excusalReason.String
+		if tardy.TrackedAt, err = parseDateTime(trackedAtRaw); err != nil {
+			return nil, err
+		}
+		tardies = append(tardies, tardy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Finding tardies", err)
+	}
+	return tardies, nil
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var tardyColumns = []string{"id", "track_id", "visitor_id", "reason", "note", "minutes_late", "tracked_at", "excusal_id", "excusal_reason", "name", "surname", "grade"}
+
+func TestTardySave_Upserts(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Tardy{
+		Connection: db,
+	}
+
+	trackedAt := time.Date(2024, 9, 2, 8, 42, 0, 0, time.UTC)
+	mock.ExpectExec("INSERT INTO tardies \\(tenant_id, site_id, (.+)\\) VALUES \\(\\?, \\(SELECT site_id FROM track WHERE id = \\?\\), (.+) ON DUPLICATE KEY UPDATE").
+		WithArgs(int64(0), int64(10), int64(10), int32(7), "bus", "", 12, trackedAt).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	// Execute
+	tardy := &entity.Tardy{TrackId: 10, VisitorId: 7, Reason: "bus", MinutesLate: 12, TrackedAt: trackedAt}
+	err = repo.Save(context.Background(), tardy)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), tardy.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTardyGetByTrackId_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Tardy{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows(tardyColumns).
+		AddRow(3, 10, 7, "bus", nil, 12, []byte("2024-09-02 08:42:00"), nil, nil, "Tom", "Doe", 8)
+	mock.ExpectQuery("SELECT (.+) FROM tardies AS t INNER JOIN visitors AS v ON v.id = t.visitor_id LEFT JOIN excusals AS e ON e.id = t.excusal_id WHERE t.track_id = ?").
+		WithArgs(int64(10)).
+		WillReturnRows(rows)
+
+	// Execute
+	tardy, err := repo.GetByTrackId(context.Background(), 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "bus", tardy.Reason)
+	assert.Equal(t, "Doe", tardy.Visitor.Surname)
+	assert.Equal(t, 8, tardy.Visitor.Grade)
+	assert.Equal(t, time.Date(2024, 9, 2, 8, 42, 0, 0, time.UTC), tardy.TrackedAt)
+	assert.False(t, tardy.Excused())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTardyGetByTrackId_NotFound(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Tardy{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM tardies").
+		WithArgs(int64(11)).
+		WillReturnRows(sqlmock.NewRows(tardyColumns))
+
+	// Execute
+	tardy, err := repo.GetByTrackId(context.Background(), 11)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, tardy)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTardyFindBetween_IncludesExcusal(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Tardy{
+		Connection: db,
+	}
+
+	from := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	rows := sqlmock.NewRows(tardyColumns).
+		AddRow(3, 10, 7, "bus", nil, 12, []byte("2024-09-02 08:42:00"), 4, "Bus 12 late", "Tom", "Doe", 8)
+	mock.ExpectQuery("SELECT (.+) FROM tardies (.+) WHERE t.tracked_at >= \\? AND t.tracked_at < \\? ORDER BY t.tracked_at").
+		WithArgs(from, to).
+		WillReturnRows(rows)
+
+	// Execute
+	tardies, err := repo.FindBetween(context.Background(), from, to)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, tardies, 1)
+	assert.True(t, tardies[0].Excused())
+	assert.Equal(t, "Bus 12 late", tardies[0].ExcusalReason)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTardyCountByVisitorIdSince_SkipsExcused(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Tardy{
+		Connection: db,
+	}
+
+	since := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tardies WHERE visitor_id = \\? AND tracked_at >= \\? AND excusal_id IS NULL").
+		WithArgs(int32(7), since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	// Execute
+	count, err := repo.CountByVisitorIdSince(context.Background(), 7, since)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTardyCountByVisitorIdSince_QueryTimeout(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Tardy{
+		Connection: db,
+		Timeout:    Timeout{QueryTimeout: 10 * time.Millisecond},
+	}
+
+	since := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tardies WHERE visitor_id = \\? AND tracked_at >= \\? AND excusal_id IS NULL").
+		WithArgs(int32(7), since).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	// Execute
+	count, err := repo.CountByVisitorIdSince(context.Background(), 7, since)
+
+	// Assert
+	assert.Equal(t, 0, count)
+	assert.ErrorIs(t, err, entity.ErrQueryTimeout)
+	assert.EqualError(t, err, "Counting tardies timed out after 10ms")
+}
+
+func TestTardyFindBetween_ScopedToSiteOfTrack(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Tardy{
+		Connection: db,
+		Scope:      Scope{SiteId: 2},
+	}
+
+	from := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	mock.ExpectQuery("SELECT (.+) FROM tardies (.+) WHERE t.tracked_at >= \\? AND t.tracked_at < \\? AND t.site_id = 2 ORDER BY t.tracked_at").
+		WithArgs(from, to).
+		WillReturnRows(sqlmock.NewRows(tardyColumns))
+
+	// Execute
+	tardies, err := repo.FindBetween(context.Background(), from, to)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, tardies)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
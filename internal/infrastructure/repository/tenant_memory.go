@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// MemoryTenant is the entity.TenantRepository of the memory engine, see
+// MemoryStore.
+type MemoryTenant struct {
+	Memory *MemoryStore
+}
+
+func (r *MemoryTenant) FindAll() ([]*entity.Tenant, error) {
+	return r.find(func(*memoryTenant) bool { return true }), nil
+}
+
+func (r *MemoryTenant) GetById(id int64) (*entity.Tenant, error) {
+	return r.first(func(stored *memoryTenant) bool { return stored.tenant.Id == id }), nil
+}
+
+func (r *MemoryTenant) GetByCode(code string) (*entity.Tenant, error) {
+	return r.first(func(stored *memoryTenant) bool { return stored.tenant.Code == code }), nil
+}
+
+func (r *MemoryTenant) GetByHostname(hostname string) (*entity.Tenant, error) {
+	return r.first(func(stored *memoryTenant) bool { return hostname != "" && stored.tenant.Hostname == hostname }), nil
+}
+
+func (r *MemoryTenant) GetByTokenHash(hash string) (*entity.Tenant, error) {
+	return r.first(func(stored *memoryTenant) bool { return hash != "" && stored.tokenHash == hash }), nil
+}
+
+func (r *MemoryTenant) Provision(tenant *entity.Tenant, site *entity.Site, tokenHash string) error {
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	if err := r.unique(tenant); err != nil {
+		return err
+	}
+	r.Memory.lastTenantId++
+	r.Memory.lastSiteId++
+	tenant.Id = r.Memory.lastTenantId
+	site.Id = r.Memory.lastSiteId
+	r.Memory.tenants = append(r.Memory.tenants, &memoryTenant{tenant: *tenant, tokenHash: tokenHash})
+	r.Memory.sites = append(r.Memory.sites, &memorySite{tenantId: tenant.Id, site: entity.Site{Id: site.Id, Code: site.Code, Name: site.Name}})
+	return nil
+}
+
+func (r *MemoryTenant) Update(tenant *entity.Tenant) error {
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	if err := r.unique(tenant); err != nil {
+		return err
+	}
+	for _, stored := range r.Memory.tenants {
+		if stored.tenant.Id == tenant.Id {
+			stored.tenant = *tenant
+		}
+	}
+	return nil
+}
+
+func (r *MemoryTenant) SetTokenHash(id int64, hash string) error {
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	for _, stored := range r.Memory.tenants {
+		if stored.tenant.Id == id {
+			stored.tokenHash = hash
+		}
+	}
+	return nil
+}
+
+// unique rejects codes and hostnames of other tenants like the unique
+// indexes of the database. Callers hold the lock.
+func (r *MemoryTenant) unique(tenant *entity.Tenant) error {
+	for _, stored := range r.Memory.tenants {
+		if stored.tenant.Id == tenant.Id {
+			continue
+		}
+		if stored.tenant.Code == tenant.Code {
+			return fmt.Errorf("Duplicate entry '%s' for key 'tenants.code'", tenant.Code)
+		}
+		if tenant.Hostname != "" && stored.tenant.Hostname == tenant.Hostname {
+			return fmt.Errorf("Duplicate entry '%s' for key 'tenants.hostname'", tenant.Hostname)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryTenant) first(match func(*memoryTenant) bool) *entity.Tenant {
+	tenants := r.find(match)
+	if len(tenants) == 0 {
+		return nil
+	}
+	return tenants[0]
+}
+
+func (r *MemoryTenant) find(match func(*memoryTenant) bool) []*entity.Tenant {
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	tenants := []*entity.Tenant{}
+	for _, stored := range r.Memory.tenants {
+		if match(stored) {
+			tenant := stored.tenant
+			tenants = append(tenants, &tenant)
+		}
+	}
+	return tenants
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryTenantProvision_CreatesTenantAndSite(t *testing.T) {
+	// Setup
+	memory := NewMemoryStore()
+	repo := &MemoryTenant{Memory: memory}
+	tenant := &entity.Tenant{Code: "north", Name: "North School", Hostname: "north.example.com"}
+	site := &entity.Site{Code: "main", Name: "Main site"}
+
+	// Execute
+	err := repo.Provision(tenant, site, "hash")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), tenant.Id)
+	byHost, _ := repo.GetByHostname("north.example.com")
+	byToken, _ := repo.GetByTokenHash("hash")
+	sites, _ := (&MemorySite{Memory: memory, Scope: Scope{TenantId: tenant.Id}}).FindAll()
+	assert.Equal(t, tenant, byHost)
+	assert.Equal(t, tenant, byToken)
+	if assert.Len(t, sites, 1) {
+		assert.Equal(t, "main", sites[0].Code)
+	}
+}
+
+func TestMemoryTenantProvision_DuplicateCode(t *testing.T) {
+	// Setup
+	repo := &MemoryTenant{Memory: NewMemoryStore()}
+	assert.NoError(t, repo.Provision(&entity.Tenant{Code: "north"}, &entity.Site{Code: "main"}, ""))
+
+	// Execute
+	err := repo.Provision(&entity.Tenant{Code: "north"}, &entity.Site{Code: "main"}, "")
+
+	// Assert
+	assert.ErrorContains(t, err, "Duplicate entry 'north'")
+}
+
+func TestMemoryTenantGetByTokenHash_EmptyNeverMatches(t *testing.T) {
+	// Setup
+	repo := &MemoryTenant{Memory: NewMemoryStore()}
+	assert.NoError(t, repo.Provision(&entity.Tenant{Code: "north"}, &entity.Site{Code: "main"}, ""))
+
+	// Execute
+	byToken, err := repo.GetByTokenHash("")
+	byHost, hostErr := repo.GetByHostname("")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, byToken)
+	assert.NoError(t, hostErr)
+	assert.Nil(t, byHost)
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const tenantSelect = "SELECT id, code, name, hostname, logo FROM tenants"
+
+type Tenant struct {
+	Connection *sql.DB `container:"type"`
+}
+
+func (r *Tenant) FindAll() ([]*entity.Tenant, error) {
+	return r.find(tenantSelect + " ORDER BY id")
+}
+
+func (r *Tenant) GetById(id int64) (*entity.Tenant, error) {
+	return r.first(tenantSelect+" WHERE id = ?", id)
+}
+
+func (r *Tenant) GetByCode(code string) (*entity.Tenant, error) {
+	return r.first(tenantSelect+" WHERE code = ?", code)
+}
+
+func (r *Tenant) GetByHostname(hostname string) (*entity.Tenant, error) {
+	return r.first(tenantSelect+" WHERE hostname = ?", hostname)
+}
+
+func (r *Tenant) GetByTokenHash(hash string) (*entity.Tenant, error) {
+	return r.first(tenantSelect+" WHERE api_token_hash = ?", hash)
+}
+
+func (r *Tenant) Provision(tenant *entity.Tenant, site *entity.Site, tokenHash string) error {
+	tx, err := r.Connection.Begin()
+	if err != nil {
+		return err
+	}
+	res, err := tx.Exec(
+		"INSERT INTO tenants (code, name, hostname, logo, api_token_hash) VALUES (?, ?, ?, ?, ?)",
+		tenant.Code,
+		tenant.Name,
+		nullString(tenant.Hostname),
+		nullString(tenant.Logo),
+		tokenHash,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	tenantId, err := res.LastInsertId()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	res, err = tx.Exec(
+		"INSERT INTO sites (tenant_id, code, name) VALUES (?, ?, ?)",
+		tenantId,
+		site.Code,
+		site.Name,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	siteId, err := res.LastInsertId()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	tenant.Id = tenantId
+	site.Id = siteId
+	return nil
+}
+
+func (r *Tenant) Update(tenant *entity.Tenant) error {
+	_, err := r.Connection.Exec(
+		"UPDATE tenants SET code = ?, name = ?, hostname = ?, logo = ? WHERE id = ?",
+		tenant.Code,
+		tenant.Name,
+		nullString(tenant.Hostname),
+		nullString(tenant.Logo),
+		tenant.Id,
+	)
+	return err
+}
+
+func (r *Tenant) SetTokenHash(id int64, hash string) error {
+	_, err := r.Connection.Exec("UPDATE tenants SET api_token_hash = ? WHERE id = ?", hash, id)
+	return err
+}
+
+func (r *Tenant) first(query string, args ...any) (*entity.Tenant, error) {
+	tenants, err := r.find(query, args...)
+	if err != nil || len(tenants) == 0 {
+		return nil, err
+	}
+	return tenants[0], nil
+}
+
+func (r *Tenant) find(query string, args ...any) ([]*entity.Tenant, error) {
+	rows, err := r.Connection.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenants := []*entity.Tenant{}
+	for rows.Next() {
+		var hostname, logo sql.NullString
+		tenant := &entity.Tenant{}
+		if err := rows.Scan(&tenant.Id, &tenant.Code, &tenant.Name, &hostname, &logo); err != nil {
+			return nil, err
+		}
+		tenant.Hostname = hostname.String
+		tenant.Logo = logo.String
+		tenants = append(tenants, tenant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+// nullString stores empty values as NULL, unique columns allow many of those.
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
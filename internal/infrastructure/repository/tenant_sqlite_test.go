@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var tenantColumns = []string{"id", "code", "name", "hostname", "logo"}
+
+func TestTenantGetByHostname_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Tenant{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows(tenantColumns).
+		AddRow(2, "riverside", "Riverside School", "riverside.example.org", nil)
+	mock.ExpectQuery("SELECT id, code, name, hostname, logo FROM tenants WHERE hostname = \\?").
+		WithArgs("riverside.example.org").
+		WillReturnRows(rows)
+
+	// Execute
+	tenant, err := repo.GetByHostname("riverside.example.org")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), tenant.Id)
+	assert.Equal(t, "Riverside School", tenant.Name)
+	assert.Equal(t, "", tenant.Logo)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantGetByTokenHash_NotFound(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Tenant{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM tenants WHERE api_token_hash = \\?").
+		WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows(tenantColumns))
+
+	// Execute
+	tenant, err := repo.GetByTokenHash("abc")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, tenant)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantProvision_CreatesTenantAndSite(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Tenant{
+		Connection: db,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tenants \\(code, name, hostname, logo, api_token_hash\\)").
+		WithArgs("riverside", "Riverside School", "riverside.example.org", nil, "hash").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec("INSERT INTO sites \\(tenant_id, code, name\\)").
+		WithArgs(int64(2), "main", "Main site").
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectCommit()
+
+	// Execute
+	tenant := &entity.Tenant{Code: "riverside", Name: "Riverside School", Hostname: "riverside.example.org"}
+	site := &entity.Site{Code: "main", Name: "Main site"}
+	err = repo.Provision(tenant, site, "hash")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), tenant.Id)
+	assert.Equal(t, int64(5), site.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantProvision_RollsBackOnSiteError(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Tenant{
+		Connection: db,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tenants").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec("INSERT INTO sites").
+		WillReturnError(errors.New("duplicate"))
+	mock.ExpectRollback()
+
+	// Execute
+	tenant := &entity.Tenant{Code: "riverside", Name: "Riverside School"}
+	err = repo.Provision(tenant, &entity.Site{Code: "main", Name: "Main site"}, "hash")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), tenant.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+type Timesheet struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+}
+
+// FindStaffTracksBetween returns the tracks of staff recorded at the site of
+// the repository, staff of another site included.
+func (r *Timesheet) FindStaffTracksBetween(from time.Time, to time.Time) ([]*entity.VisitTrack, error) {
+	rows, err := r.Connection.Query(
+		"SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.created_at, v.name, v.surname, v.image FROM track AS t INNER JOIN visitors AS v ON v.id = t.visitor_id WHERE v.is_student = 0 AND t.created_at >= ? AND t.created_at < ?"+r.and("t")+" ORDER BY t.visitor_id, t.created_at, t.id",
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tracks := []*entity.VisitTrack{}
+	visitors := map[int32]*entity.Visitor{}
+	for rows.Next() {
+		var createdAtRaw []byte
+		var tmpSurname, tmpImage sql.NullString
+		var name string
+		track := &entity.VisitTrack{}
+		err := rows.Scan(
+			&track.Id,
+			&track.VisitorId,
+			&track.VisitKey,
+			&track.SignedIn,
+			&createdAtRaw,
+			&name,
+			&tmpSurname,
+			&tmpImage,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if track.CreatedAt, err = parseDateTime(createdAtRaw); err != nil {
+			return nil, err
+		}
+		visitor, ok := visitors[track.VisitorId]
+		if !ok {
+			visitor = &entity.Visitor{
+				Id:      track.VisitorId,
+				Name:    name,
+				Surname: tmpSurname.String,
+				Image:   tmpImage.String,
+			}
+			visitors[track.VisitorId] = visitor
+		}
+		track.Visitor = visitor
+		tracks = append(tracks, track)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tracks, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindStaffTracksBetween_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Timesheet{
+		Connection: db,
+	}
+
+	from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "created_at", "name", "surname", "image"}).
+		AddRow(1, 5, "KEY5", true, "2024-09-02 08:00:00", "Anna", "Smith", nil).
+		AddRow(2, 5, "KEY5", true, "2024-09-02 17:00:00", "Anna", "Smith", nil)
+	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.created_at, v.name, v.surname, v.image FROM track AS t INNER JOIN visitors AS v").
+		WithArgs(from, to).
+		WillReturnRows(rows)
+
+	// Execute
+	tracks, err := repo.FindStaffTracksBetween(from, to)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, tracks, 2)
+	assert.Equal(t, int32(5), tracks[0].VisitorId)
+	assert.Equal(t, "Anna", tracks[0].Visitor.Name)
+	assert.Same(t, tracks[0].Visitor, tracks[1].Visitor)
+	assert.Equal(t, time.Date(2024, 9, 2, 17, 0, 0, 0, time.UTC), tracks[1].CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindStaffTracksBetween_ScopedToSiteOfTrack(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Timesheet{
+		Connection: db,
+		Scope:      Scope{TenantId: 1, SiteId: 2},
+	}
+
+	from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT (.+) FROM track AS t (.+) AND t.created_at < \\? AND t.tenant_id = 1 AND t.site_id = 2 ORDER BY").
+		WithArgs(from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "created_at", "name", "surname", "image"}))
+
+	// Execute
+	tracks, err := repo.FindStaffTracksBetween(from, to)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, tracks)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindStaffTracksBetween_QueryError(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Timesheet{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT t.id").WillReturnError(errors.New("query failed"))
+
+	// Execute
+	tracks, err := repo.FindStaffTracksBetween(time.Now(), time.Now())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, tracks)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
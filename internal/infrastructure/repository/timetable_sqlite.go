@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const lessonSelect = "SELECT l.id, l.class_id, l.period_id, l.room_id, l.weekday, c.name, c.teacher_id, p.name, p.starts_at, p.ends_at, r.name FROM lessons AS l INNER JOIN classes AS c ON c.id = l.class_id INNER JOIN periods AS p ON p.id = l.period_id INNER JOIN rooms AS r ON r.id = l.room_id"
+
+type Timetable struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+}
+
+func (r *Timetable) CreateRoom(room *entity.Room) error {
+	res, err := r.Connection.Exec("INSERT INTO rooms (tenant_id, site_id, name) VALUES (?, ?, ?)", r.TenantId, r.SiteId, room.Name)
+	if err != nil {
+		return err
+	}
+	room.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *Timetable) FindRooms() ([]*entity.Room, error) {
+	rows, err := r.Connection.Query("SELECT id, name FROM rooms" + r.where("") + " ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rooms := []*entity.Room{}
+	for rows.Next() {
+		room := &entity.Room{}
+		if err := rows.Scan(&room.Id, &room.Name); err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, room)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+func (r *Timetable) CreatePeriod(period *entity.Period) error {
+	res, err := r.Connection.Exec("INSERT INTO periods (tenant_id, site_id, name, starts_at, ends_at) VALUES (?, ?, ?, ?, ?)", r.TenantId, r.SiteId, period.Name, period.StartsAt, period.EndsAt)
+	if err != nil {
+		return err
+	}
+	period.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *Timetable) FindPeriods() ([]*entity.Period, error) {
+	rows, err := r.Connection.Query("SELECT id, name, starts_at, ends_at FROM periods" + r.where("") + " ORDER BY starts_at")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := []*entity.Period{}
+	for rows.Next() {
+		var startsAt, endsAt []byte
+		period := &entity.Period{}
+		if err := rows.Scan(&period.Id, &period.Name, &startsAt, &endsAt); err != nil {
+			return nil, err
+		}
+		period.StartsAt = clockFromTime(startsAt)
+		period.EndsAt = clockFromTime(endsAt)
+		periods = append(periods, period)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+func (r *Timetable) CreateClass(class *entity.SchoolClass) error {
+	var teacherId sql.NullInt32
+	if class.TeacherId > 0 {
+		teacherId = sql.NullInt32{Int32: class.TeacherId, Valid: true}
+	}
+	res, err := r.Connection.Exec("INSERT INTO classes (tenant_id, site_id, name, teacher_id) VALUES (?, ?, ?, ?)", r.TenantId, r.SiteId, class.Name, teacherId)
+	if err != nil {
+		return err
+	}
+	class.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *Timetable) FindClassById(id int64) (*entity.SchoolClass, error) {
+	var teacherId sql.NullInt32
+	class := &entity.SchoolClass{}
+	err := r.Connection.QueryRow("SELECT id, name, teacher_id FROM classes WHERE id = ?"+r.and(""), id).
+		Scan(&class.Id, &class.Name, &teacherId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	class.TeacherId = teacherId.Int32
+	return class, nil
+}
+
+// SetClassMembers replaces the roster of the class.
+func (r *Timetable) SetClassMembers(classId int64, visitorIds []int32) error {
+	tx, err := r.Connection.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM class_members WHERE class_id = ?"+r.tenantAnd(""), classId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	for _, visitorId := range visitorIds {
+		if _, err := tx.Exec("INSERT INTO class_members (tenant_id, class_id, visitor_id) VALUES (?, ?, ?)", r.TenantId, classId, visitorId); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *Timetable) FindClassMembers(classId int64) ([]*entity.Visitor, error) {
+	rows, err := r.Connection.Query(
+		"SELECT v.id, v.name, v.surname, v.grade, v.image FROM visitors AS v INNER JOIN class_members AS cm ON cm.visitor_id = v.id WHERE cm.class_id = ?"+r.tenantAnd("cm")+" ORDER BY v.surname, v.name",
+		classId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	visitors := []*entity.Visitor{}
+	for rows.Next() {
+		var tmpGrade sql.NullInt32
+		var tmpSurname, tmpImage sql.NullString
+		visitor := &entity.Visitor{}
+		if err := rows.Scan(&visitor.Id, &visitor.Name, &tmpSurname, &tmpGrade, &tmpImage); err != nil {
+			return nil, err
+		}
+		visitor.Surname = tmpSurname.String
+		visitor.Grade = int(tmpGrade.Int32)
+		visitor.Image = tmpImage.String
+		visitors = append(visitors, visitor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return visitors, nil
+}
+
+func (r *Timetable) IsClassMember(classId int64, visitorId int32) (bool, error) {
+	var count int
+	err := r.Connection.QueryRow("SELECT COUNT(*) FROM class_members WHERE class_id = ? AND visitor_id = ?"+r.tenantAnd(""), classId, visitorId).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *Timetable) CreateLesson(lesson *entity.Lesson) error {
+	res, err := r.Connection.Exec(
+		"INSERT INTO lessons (tenant_id, class_id, period_id, room_id, weekday) VALUES (?, ?, ?, ?, ?)",
+		r.TenantId,
+		lesson.ClassId,
+		lesson.PeriodId,
+		lesson.RoomId,
+		int(lesson.Weekday),
+	)
+	if err != nil {
+		return err
+	}
+	lesson.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *Timetable) FindLessonById(id int64) (*entity.Lesson, error) {
+	lessons, err := r.findLessons(lessonSelect+" WHERE l.id = ?"+r.and("c"), id)
+	if err != nil || len(lessons) == 0 {
+		return nil, err
+	}
+	return lessons[0], nil
+}
+
+func (r *Timetable) FindLessonsByRoom(roomId int64, weekday time.Weekday) ([]*entity.Lesson, error) {
+	return r.findLessons(lessonSelect+" WHERE l.room_id = ? AND l.weekday = ?"+r.and("c")+" ORDER BY p.starts_at", roomId, int(weekday))
+}
+
+func (r *Timetable) FindLessonsByTeacher(teacherId int32, weekday time.Weekday) ([]*entity.Lesson, error) {
+	return r.findLessons(lessonSelect+" WHERE c.teacher_id = ? AND l.weekday = ?"+r.and("c")+" ORDER BY p.starts_at", teacherId, int(weekday))
+}
+
+func (r *Timetable) findLessons(query string, args ...any) ([]*entity.Lesson, error) {
+	rows, err := r.Connection.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lessons := []*entity.Lesson{}
+	for rows.Next() {
+		var weekday int
+		var teacherId sql.NullInt32
+		var startsAt, endsAt []byte
+		lesson := &entity.Lesson{
+			Class:  &entity.SchoolClass{},
+			Period: &entity.Period{},
+			Room:   &entity.Room{},
+		}
+		err := rows.Scan(
+			&lesson.Id,
+			&lesson.ClassId,
+			&lesson.PeriodId,
+			&lesson.RoomId,
+			&weekday,
+			&lesson.Class.Name,
+			&teacherId,
+			&lesson.Period.Name,
+			&startsAt,
+			&endsAt,
+			&lesson.Room.Name,
+		)
+		if err != nil {
+			return nil, err
+		}
+		lesson.Weekday = time.Weekday(weekday)
+		lesson.Class.Id = lesson.ClassId
+		lesson.Class.TeacherId = teacherId.Int32
+		lesson.Period.Id = lesson.PeriodId
+		lesson.Period.StartsAt = clockFromTime(startsAt)
+		lesson.Period.EndsAt = clockFromTime(endsAt)
+		lesson.Room.Id = lesson.RoomId
+		lessons = append(lessons, lesson)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return lessons, nil
+}
+
+// clockFromTime converts a TIME column ("08:30:00") to "08:30".
+func clockFromTime(raw []byte) string {
+	if len(raw) > 5 {
+		return string(raw[:5])
+	}
+	return string(raw)
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePeriod_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Timetable{
+		Connection: db,
+		Scope:      Scope{SiteId: 2},
+	}
+
+	mock.ExpectExec("INSERT INTO periods").
+		WithArgs(int64(0), int64(2), "Period 1", "08:30", "09:15").
+		WillReturnResult(sqlmock.NewResult(4, 1))
+
+	// Execute
+	period := &entity.Period{Name: "Period 1", StartsAt: "08:30", EndsAt: "09:15"}
+	err = repo.CreatePeriod(period)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), period.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetClassMembers_ReplacesRoster(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Timetable{
+		Connection: db,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM class_members").WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("INSERT INTO class_members").WithArgs(int64(0), int64(2), int32(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO class_members").WithArgs(int64(0), int64(2), int32(8)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Execute
+	err = repo.SetClassMembers(2, []int32{7, 8})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetClassMembers_InsertErrorWillRollback(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Timetable{
+		Connection: db,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM class_members").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO class_members").WillReturnError(errors.New("unknown visitor"))
+	mock.ExpectRollback()
+
+	// Execute
+	err = repo.SetClassMembers(2, []int32{99})
+
+	// Assert
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLessonsByRoom_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Timetable{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "class_id", "period_id", "room_id", "weekday", "c.name", "teacher_id", "p.name", "starts_at", "ends_at", "r.name"}).
+		AddRow(3, 2, 1, 101, 1, "7B Maths", 4, "Period 1", "08:30:00", "09:15:00", "Room 101")
+	mock.ExpectQuery("SELECT l.id, l.class_id, l.period_id, l.room_id, l.weekday").
+		WithArgs(int64(101), 1).
+		WillReturnRows(rows)
+
+	// Execute
+	lessons, err := repo.FindLessonsByRoom(101, time.Monday)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, lessons, 1)
+	assert.Equal(t, time.Monday, lessons[0].Weekday)
+	assert.Equal(t, "7B Maths", lessons[0].Class.Name)
+	assert.Equal(t, int32(4), lessons[0].Class.TeacherId)
+	assert.Equal(t, "08:30", lessons[0].Period.StartsAt)
+	assert.Equal(t, "09:15", lessons[0].Period.EndsAt)
+	assert.Equal(t, "Room 101", lessons[0].Room.Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLessonById_NotFound(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Timetable{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT l.id").
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// Execute
+	lesson, err := repo.FindLessonById(3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, lesson)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// MemoryVisitor is the entity.VisitorRepository of the memory engine, see
+// MemoryStore. Visitors keep the image they were seeded with.
+type MemoryVisitor struct {
+	Memory *MemoryStore
+	Scope
+}
+
+func (r *MemoryVisitor) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	if err := memoryQuery(ctx, "Finding visitor by key"); err != nil {
+		return nil, err
+	}
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	stored := r.Memory.key(r.TenantId, key)
+	if stored == nil {
+		return &entity.VisitDetails{}, nil
+	}
+	visitor := r.Memory.visitor(stored.tenantId, stored.visitorId)
+	if visitor == nil {
+		return &entity.VisitDetails{}, nil
+	}
+	found := visitor.visitor
+	return &entity.VisitDetails{Visitor: &found, Key: stored.key}, nil
+}
+
+func (r *MemoryVisitor) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
+	if err := memoryQuery(ctx, "Finding visitor by id"); err != nil {
+		return nil, err
+	}
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	visitor := r.Memory.visitor(r.TenantId, id)
+	if visitor == nil {
+		return &entity.Visitor{}, nil
+	}
+	found := visitor.visitor
+	return &found, nil
+}
+
+// FindAllWithKeys lists every key of the tenant with its visitor, ordered by
+// visitor like Visitor.FindAllWithKeys.
+func (r *MemoryVisitor) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	if err := memoryQuery(ctx, "Listing visitor keys"); err != nil {
+		return nil, err
+	}
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	roster := []*entity.VisitDetails{}
+	for _, key := range r.Memory.keys {
+		if !inTenant(r.TenantId, key.tenantId) {
+			continue
+		}
+		visitor := r.Memory.visitor(key.tenantId, key.visitorId)
+		if visitor == nil {
+			continue
+		}
+		found := visitor.visitor
+		roster = append(roster, &entity.VisitDetails{Visitor: &found, Key: key.key})
+	}
+	sort.SliceStable(roster, func(i, j int) bool {
+		a, b := roster[i].Visitor, roster[j].Visitor
+		switch {
+		case a.Surname != b.Surname:
+			return a.Surname < b.Surname
+		case a.Name != b.Name:
+			return a.Name < b.Name
+		case a.Id != b.Id:
+			return a.Id < b.Id
+		}
+		return roster[i].Key < roster[j].Key
+	})
+	return roster, nil
+}
+
+func (r *MemoryVisitor) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
+	if err := memoryQuery(ctx, "Adding visitor key"); err != nil {
+		return err
+	}
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	if stored := r.Memory.key(r.TenantId, key); stored != nil {
+		if stored.visitorId == visitor.Id {
+			return nil
+		}
+		return entity.ErrKeyAssigned
+	}
+	return r.Memory.addKey(r.TenantId, visitor.Id, key)
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryFindByKey_IgnoresCase(t *testing.T) {
+	// Setup
+	repo := &MemoryVisitor{Memory: seededMemory(t), Scope: Scope{TenantId: 1, SiteId: 3}}
+
+	// Execute
+	result, err := repo.FindByKey(context.Background(), "key123")
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.NotNil(t, result.Visitor) {
+		assert.Equal(t, int32(7), result.Visitor.Id)
+		assert.Equal(t, "Jane", result.Visitor.Name)
+	}
+	assert.Equal(t, "KEY123", result.Key)
+}
+
+func TestMemoryFindByKey_NotFound(t *testing.T) {
+	// Setup
+	repo := &MemoryVisitor{Memory: seededMemory(t), Scope: Scope{TenantId: 1, SiteId: 3}}
+
+	// Execute
+	unknown, err := repo.FindByKey(context.Background(), "UNKNOWN")
+	otherTenant, otherErr := repo.FindByKey(context.Background(), "KEY999")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, unknown.Visitor)
+	assert.NoError(t, otherErr)
+	assert.Nil(t, otherTenant.Visitor)
+}
+
+func TestMemoryFindById_ReturnsCopy(t *testing.T) {
+	// Setup
+	repo := &MemoryVisitor{Memory: seededMemory(t), Scope: Scope{TenantId: 1, SiteId: 3}}
+	found, err := repo.FindById(context.Background(), 7)
+	assert.NoError(t, err)
+	found.Name = "Changed"
+
+	// Execute
+	result, err := repo.FindById(context.Background(), 7)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane", result.Name)
+}
+
+func TestMemoryFindById_NotFound(t *testing.T) {
+	// Setup
+	repo := &MemoryVisitor{Memory: seededMemory(t), Scope: Scope{TenantId: 1, SiteId: 3}}
+
+	// Execute
+	result, err := repo.FindById(context.Background(), 42)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Visitor{}, result)
+}
+
+func TestMemoryFindAllWithKeys_OrdersByVisitor(t *testing.T) {
+	// Setup
+	memory := seededMemory(t)
+	repo := &MemoryVisitor{Memory: memory, Scope: Scope{TenantId: 1, SiteId: 3}}
+	assert.NoError(t, repo.AddKeyToVisitor(context.Background(), &entity.Visitor{Id: 7}, "CARD1"))
+
+	// Execute
+	roster, err := repo.FindAllWithKeys(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	keys := []string{}
+	for _, details := range roster {
+		keys = append(keys, details.Key)
+	}
+	assert.Equal(t, []string{"CARD1", "KEY123", "KEY456"}, keys)
+}
+
+func TestMemoryAddKeyToVisitor_KeyOfAnotherVisitor(t *testing.T) {
+	// Setup
+	repo := &MemoryVisitor{Memory: seededMemory(t), Scope: Scope{TenantId: 1, SiteId: 3}}
+
+	// Execute
+	again := repo.AddKeyToVisitor(context.Background(), &entity.Visitor{Id: 7}, "key123")
+	taken := repo.AddKeyToVisitor(context.Background(), &entity.Visitor{Id: 7}, "KEY456")
+
+	// Assert
+	assert.NoError(t, again)
+	assert.ErrorIs(t, taken, entity.ErrKeyAssigned)
+}
+
+func TestMemoryAddKeyToVisitor_KeyOfAnotherTenant(t *testing.T) {
+	// Setup
+	memory := seededMemory(t)
+	repo := &MemoryVisitor{Memory: memory, Scope: Scope{TenantId: 2, SiteId: 4}}
+	ann, err := repo.FindByKey(context.Background(), "KEY999")
+	assert.NoError(t, err)
+
+	// Execute
+	err = repo.AddKeyToVisitor(context.Background(), ann.Visitor, "KEY123")
+	inTenant2, _ := repo.FindByKey(context.Background(), "KEY123")
+	inTenant1, _ := (&MemoryVisitor{Memory: memory, Scope: Scope{TenantId: 1, SiteId: 3}}).FindByKey(context.Background(), "KEY123")
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.NotNil(t, inTenant2.Visitor) && assert.NotNil(t, inTenant1.Visitor) {
+		assert.Equal(t, "Ann", inTenant2.Visitor.Name)
+		assert.Equal(t, "Jane", inTenant1.Visitor.Name)
+	}
+}
+
+func TestMemoryFindByKey_Timeout(t *testing.T) {
+	// Setup
+	repo := &MemoryVisitor{Memory: seededMemory(t), Scope: Scope{TenantId: 1, SiteId: 3}}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	// Execute
+	_, err := repo.FindByKey(ctx, "KEY123")
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrQueryTimeout)
+}
+
+func TestMemoryFindByKey_Canceled(t *testing.T) {
+	// Setup
+	repo := &MemoryVisitor{Memory: seededMemory(t), Scope: Scope{TenantId: 1, SiteId: 3}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Execute
+	_, err := repo.FindByKey(ctx, "KEY123")
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, entity.ErrQueryTimeout)
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"math/rand"
@@ -12,14 +13,18 @@ import (
 
 type Visitor struct {
 	Connection *sql.DB `container:"type"`
+	Scope
+	Timeout
 }
 
-func (r *Visitor) FindByKey(key string) (*entity.VisitDetails, error) {
+func (r *Visitor) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
 	var tmpGrade sql.NullInt32
 	var tmpImage sql.NullString
 	
 	key = strings.ToUpper(key)
-	row := r.Connection.QueryRow("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk ON vk.visitor_id = v.id WHERE vk.key_id = ?", key)
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	row := r.Connection.QueryRowContext(queryCtx, "SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk ON vk.visitor_id = v.id WHERE vk.key_id = ?"+r.tenantAnd("v"), key)
 	
 	visitor := &entity.Visitor{}
 	visit := &entity.VisitDetails{
@@ -32,13 +37,14 @@ func (r *Visitor) FindByKey(key string) (*entity.VisitDetails, error) {
 		&visitor.Surname, 
 		&tmpGrade, 
 		&tmpImage,
+		&visitor.SiteId,
 		&visit.Key,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return &entity.VisitDetails{}, nil
 		}
-		return nil, err
+		return nil, r.failed(queryCtx, "Finding visitor by key", err)
 	}
 	if tmpGrade.Valid {
 		visitor.Grade = int(tmpGrade.Int32)
@@ -51,11 +57,13 @@ func (r *Visitor) FindByKey(key string) (*entity.VisitDetails, error) {
 	return visit, nil
 }
 
-func (r *Visitor) FindById(id int32) (*entity.Visitor, error) {
+func (r *Visitor) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
 	var tmpGrade sql.NullInt32
 	var tmpImage sql.NullString
 	
-	row := r.Connection.QueryRow("SELECT id, name, surname, grade, image FROM visitors WHERE id = ?", id)
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	row := r.Connection.QueryRowContext(queryCtx, "SELECT id, name, surname, grade, image, site_id FROM visitors WHERE id = ?"+r.tenantAnd(""), id)
 	student := &entity.Visitor{}
 	err := row.Scan(
 		&student.Id, 
@@ -63,12 +71,13 @@ func (r *Visitor) FindById(id int32) (*entity.Visitor, error) {
 		&student.Surname, 
 		&tmpGrade, 
 		&tmpImage,
+		&student.SiteId,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return &entity.Visitor{}, nil
 		}
-		return nil, err
+		return nil, r.failed(queryCtx, "Finding visitor by id", err)
 	}
 	if tmpGrade.Valid {
 		student.Grade = int(tmpGrade.Int32)
@@ -81,23 +90,67 @@ func (r *Visitor) FindById(id int32) (*entity.Visitor, error) {
 	return student, nil
 }
 
-func (r *Visitor) AddKeyToVisitor(visitor *entity.Visitor, key string) error {
-	details, err := r.FindByKey(key)
+// FindAllWithKeys lists every key of the tenant with its visitor, ordered by
+// visitor. Visitors without a photo keep an empty image, the list is cached
+// by kiosks and must not change between calls.
+func (r *Visitor) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(queryCtx, "SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk ON vk.visitor_id = v.id"+r.tenantWhere("v")+" ORDER BY v.surname, v.name, v.id, vk.key_id")
+	if err != nil {
+		return nil, r.failed(queryCtx, "Listing visitor keys", err)
+	}
+	defer rows.Close()
+
+	roster := []*entity.VisitDetails{}
+	for rows.Next() {
+		var tmpGrade sql.NullInt32
+		var tmpImage sql.NullString
+		visitor := &entity.Visitor{}
+		visit := &entity.VisitDetails{Visitor: visitor}
+		if err := rows.Scan(&visitor.Id, &visitor.Name, &visitor.Surname, &tmpGrade, &tmpImage, &visitor.SiteId, &visit.Key); err != nil {
+			return nil, r.failed(queryCtx, "Listing visitor keys", err)
+		}
+		if tmpGrade.Valid {
+			visitor.Grade = int(tmpGrade.Int32)
+		}
+		visitor.Image = tmpImage.String
+		roster = append(roster, visit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Listing visitor keys", err)
+	}
+	return roster, nil
+}
+
+func (r *Visitor) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
+	details, err := r.FindByKey(ctx, key)
 	if err != nil{
-		return fmt.Errorf("Search by key error: %s", err.Error())
+		return fmt.Errorf("Search by key error: %w", err)
 	}
 
 	if details.Visitor != nil && details.Visitor.Id > 0{
 		if details.Visitor.Id == visitor.Id {
 			return nil
 		}
-		return fmt.Errorf("Key already assigned to another visitor")
+		return entity.ErrKeyAssigned
 	}
 
-	_, err = r.Connection.Exec("INSERT INTO visitor_key (visitor_id, key_id) VALUES (?, ?)", visitor.Id, key)
+	// keys are unique within the tenant, a key added for another visitor
+	// since the lookup is ignored
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	res, err := r.Connection.ExecContext(queryCtx, "INSERT IGNORE INTO visitor_key (tenant_id, visitor_id, key_id) VALUES (?, ?, ?)", r.TenantId, visitor.Id, key)
+	if err != nil {
+		return r.failed(queryCtx, "Adding visitor key", err)
+	}
+	affected, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if affected == 0 {
+		return entity.ErrKeyAssigned
+	}
 	return nil
 }
 
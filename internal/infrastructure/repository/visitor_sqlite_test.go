@@ -1,10 +1,12 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/buzyka/imlate/internal/isb/entity"
@@ -29,15 +31,15 @@ func TestFindByKey_Success(t *testing.T) {
 		Image:   "/assets/img/teachers/1.jpg",
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "key_id"}).
-		AddRow(1, "John", "Doe", 10, "/assets/img/teachers/1.jpg", "ABC123")
+	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "site_id", "key_id"}).
+		AddRow(1, "John", "Doe", 10, "/assets/img/teachers/1.jpg", 1, "ABC123")
 
-	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
 		WithArgs("ABC123").
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.FindByKey("abc123")
+	result, err := repo.FindByKey(context.Background(), "abc123")
 
 	// Assert
 	assert.NoError(t, err)
@@ -52,6 +54,30 @@ func TestFindByKey_Success(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestFindByKey_ScopedToTenant(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Visitor{
+		Connection: db,
+		Scope:      Scope{TenantId: 3, SiteId: 2},
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM visitors AS v INNER JOIN visitor_key AS vk ON vk.visitor_id = v.id WHERE vk.key_id = \\? AND v.tenant_id = 3$").
+		WithArgs("ABC123").
+		WillReturnError(sql.ErrNoRows)
+
+	// Execute
+	result, err := repo.FindByKey(context.Background(), "abc123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result.Visitor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestFindByKey_NotFound(t *testing.T) {
 	// Setup
 	db, mock, err := sqlmock.New()
@@ -62,12 +88,12 @@ func TestFindByKey_NotFound(t *testing.T) {
 		Connection: db,
 	}
 
-	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
 		WithArgs("NOTFOUND").
 		WillReturnError(sql.ErrNoRows)
 
 	// Execute
-	result, err := repo.FindByKey("notfound")
+	result, err := repo.FindByKey(context.Background(), "notfound")
 
 	// Assert
 	assert.NoError(t, err)
@@ -87,12 +113,12 @@ func TestFindByKey_DatabaseError(t *testing.T) {
 	}
 
 	expectedError := errors.New("database connection error")
-	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
 		WithArgs("ERROR").
 		WillReturnError(expectedError)
 
 	// Execute
-	result, err := repo.FindByKey("error")
+	result, err := repo.FindByKey(context.Background(), "error")
 
 	// Assert
 	assert.Error(t, err)
@@ -101,6 +127,61 @@ func TestFindByKey_DatabaseError(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestFindByKey_QueryTimeout(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Visitor{
+		Connection: db,
+		Timeout:    Timeout{QueryTimeout: 10 * time.Millisecond},
+	}
+
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+		WithArgs("SLOW").
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// Execute
+	result, err := repo.FindByKey(context.Background(), "slow")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, entity.ErrQueryTimeout)
+	var timeoutErr *entity.QueryTimeoutError
+	if assert.ErrorAs(t, err, &timeoutErr) {
+		assert.Equal(t, 10*time.Millisecond, timeoutErr.Limit)
+	}
+}
+
+func TestFindByKey_CancelledRequest(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Visitor{
+		Connection: db,
+		Timeout:    Timeout{QueryTimeout: time.Second},
+	}
+
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+		WithArgs("GONE").
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Execute
+	result, err := repo.FindByKey(ctx, "gone")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, entity.ErrQueryTimeout)
+}
+
 func TestFindByKey_NullGrade(t *testing.T) {
 	// Setup
 	db, mock, err := sqlmock.New()
@@ -111,15 +192,15 @@ func TestFindByKey_NullGrade(t *testing.T) {
 		Connection: db,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "key_id"}).
-		AddRow(1, "John", "Doe", nil, "/assets/img/teachers/1.jpg", "KEY123")
+	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "site_id", "key_id"}).
+		AddRow(1, "John", "Doe", nil, "/assets/img/teachers/1.jpg", 1, "KEY123")
 
-	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
 		WithArgs("KEY123").
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.FindByKey("key123")
+	result, err := repo.FindByKey(context.Background(), "key123")
 
 	// Assert
 	assert.NoError(t, err)
@@ -139,15 +220,15 @@ func TestFindByKey_NullImage(t *testing.T) {
 		Connection: db,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "key_id"}).
-		AddRow(1, "John", "Doe", 10, nil, "KEY123")
+	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "site_id", "key_id"}).
+		AddRow(1, "John", "Doe", 10, nil, 1, "KEY123")
 
-	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
 		WithArgs("KEY123").
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.FindByKey("key123")
+	result, err := repo.FindByKey(context.Background(), "key123")
 
 	// Assert
 	assert.NoError(t, err)
@@ -169,15 +250,15 @@ func TestFindById_Success(t *testing.T) {
 		Connection: db,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image"}).
-		AddRow(123, "Jane", "Smith", 11, "/assets/img/teachers/2.jpg")
+	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "site_id"}).
+		AddRow(123, "Jane", "Smith", 11, "/assets/img/teachers/2.jpg", 1)
 
-	mock.ExpectQuery("SELECT id, name, surname, grade, image FROM visitors WHERE id = ?").
+	mock.ExpectQuery("SELECT id, name, surname, grade, image, site_id FROM visitors WHERE id = ?").
 		WithArgs(int32(123)).
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.FindById(123)
+	result, err := repo.FindById(context.Background(), 123)
 
 	// Assert
 	assert.NoError(t, err)
@@ -200,12 +281,12 @@ func TestFindById_NotFound(t *testing.T) {
 		Connection: db,
 	}
 
-	mock.ExpectQuery("SELECT id, name, surname, grade, image FROM visitors WHERE id = ?").
+	mock.ExpectQuery("SELECT id, name, surname, grade, image, site_id FROM visitors WHERE id = ?").
 		WithArgs(int32(999)).
 		WillReturnError(sql.ErrNoRows)
 
 	// Execute
-	result, err := repo.FindById(999)
+	result, err := repo.FindById(context.Background(), 999)
 
 	// Assert
 	assert.NoError(t, err)
@@ -225,12 +306,12 @@ func TestFindById_DatabaseError(t *testing.T) {
 	}
 
 	expectedError := errors.New("connection lost")
-	mock.ExpectQuery("SELECT id, name, surname, grade, image FROM visitors WHERE id = ?").
+	mock.ExpectQuery("SELECT id, name, surname, grade, image, site_id FROM visitors WHERE id = ?").
 		WithArgs(int32(123)).
 		WillReturnError(expectedError)
 
 	// Execute
-	result, err := repo.FindById(123)
+	result, err := repo.FindById(context.Background(), 123)
 
 	// Assert
 	assert.Error(t, err)
@@ -249,15 +330,15 @@ func TestFindById_NullValues(t *testing.T) {
 		Connection: db,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image"}).
-		AddRow(456, "Bob", "Johnson", nil, nil)
+	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "site_id"}).
+		AddRow(456, "Bob", "Johnson", nil, nil, 1)
 
-	mock.ExpectQuery("SELECT id, name, surname, grade, image FROM visitors WHERE id = ?").
+	mock.ExpectQuery("SELECT id, name, surname, grade, image, site_id FROM visitors WHERE id = ?").
 		WithArgs(int32(456)).
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.FindById(456)
+	result, err := repo.FindById(context.Background(), 456)
 
 	// Assert
 	assert.NoError(t, err)
@@ -286,17 +367,17 @@ func TestAddKeyToVisitor_Success(t *testing.T) {
 	}
 
 	// Expect FindByKey to return empty result (key not assigned)
-	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
 		WithArgs("NEWKEY").
 		WillReturnError(sql.ErrNoRows)
 
 	// Expect INSERT query
-	mock.ExpectExec("INSERT INTO visitor_key").
-		WithArgs(int32(100), "NEWKEY").
+	mock.ExpectExec("INSERT IGNORE INTO visitor_key").
+		WithArgs(int64(0), int32(100), "NEWKEY").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Execute
-	err = repo.AddKeyToVisitor(visitor, "NEWKEY")
+	err = repo.AddKeyToVisitor(context.Background(), visitor, "NEWKEY")
 
 	// Assert
 	assert.NoError(t, err)
@@ -320,15 +401,15 @@ func TestAddKeyToVisitor_SameVisitor(t *testing.T) {
 	}
 
 	// Key already assigned to the same visitor
-	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "key_id"}).
-		AddRow(100, "Alice", "Brown", 10, "/test.jpg", "EXISTKEY")
+	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "site_id", "key_id"}).
+		AddRow(100, "Alice", "Brown", 10, "/test.jpg", 1, "EXISTKEY")
 
-	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
 		WithArgs("EXISTKEY").
 		WillReturnRows(rows)
 
 	// Execute
-	err = repo.AddKeyToVisitor(visitor, "EXISTKEY")
+	err = repo.AddKeyToVisitor(context.Background(), visitor, "EXISTKEY")
 
 	// Assert
 	assert.NoError(t, err)
@@ -352,19 +433,76 @@ func TestAddKeyToVisitor_DifferentVisitor(t *testing.T) {
 	}
 
 	// Key already assigned to different visitor
-	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "key_id"}).
-		AddRow(200, "Charlie", "Davis", 9, "/test.jpg", "TAKEN")
+	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "site_id", "key_id"}).
+		AddRow(200, "Charlie", "Davis", 9, "/test.jpg", 1, "TAKEN")
 
-	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
 		WithArgs("TAKEN").
 		WillReturnRows(rows)
 
 	// Execute
-	err = repo.AddKeyToVisitor(visitor, "TAKEN")
+	err = repo.AddKeyToVisitor(context.Background(), visitor, "TAKEN")
 
 	// Assert
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "Key already assigned to another visitor")
+	assert.ErrorIs(t, err, entity.ErrKeyAssigned)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddKeyToVisitor_KeyOfAnotherTenant(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Visitor{
+		Connection: db,
+		Scope:      Scope{TenantId: 2, SiteId: 4},
+	}
+
+	visitor := &entity.Visitor{Id: 200, Name: "Ann", Surname: "Lee"}
+
+	// SHARED belongs to a visitor of tenant 1, the lookup of tenant 2 misses it
+	mock.ExpectQuery("SELECT (.+) FROM visitors AS v INNER JOIN visitor_key AS vk ON vk.visitor_id = v.id WHERE vk.key_id = \\? AND v.tenant_id = 2$").
+		WithArgs("SHARED").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec("INSERT IGNORE INTO visitor_key").
+		WithArgs(int64(2), int32(200), "SHARED").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execute
+	err = repo.AddKeyToVisitor(context.Background(), visitor, "SHARED")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddKeyToVisitor_AssignedSinceLookup(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Visitor{
+		Connection: db,
+		Scope:      Scope{TenantId: 2, SiteId: 4},
+	}
+
+	visitor := &entity.Visitor{Id: 200, Name: "Ann", Surname: "Lee"}
+
+	mock.ExpectQuery("SELECT (.+) FROM visitors AS v INNER JOIN visitor_key AS vk").
+		WithArgs("RACED").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec("INSERT IGNORE INTO visitor_key").
+		WithArgs(int64(2), int32(200), "RACED").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Execute
+	err = repo.AddKeyToVisitor(context.Background(), visitor, "RACED")
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrKeyAssigned)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -385,12 +523,12 @@ func TestAddKeyToVisitor_SearchError(t *testing.T) {
 	}
 
 	expectedError := errors.New("database error")
-	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
 		WithArgs("ERRORKEY").
 		WillReturnError(expectedError)
 
 	// Execute
-	err = repo.AddKeyToVisitor(visitor, "ERRORKEY")
+	err = repo.AddKeyToVisitor(context.Background(), visitor, "ERRORKEY")
 
 	// Assert
 	assert.Error(t, err)
@@ -415,17 +553,17 @@ func TestAddKeyToVisitor_InsertError(t *testing.T) {
 	}
 
 	// Key not assigned
-	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
 		WithArgs("NEWKEY").
 		WillReturnError(sql.ErrNoRows)
 
 	expectedError := errors.New("insert failed")
-	mock.ExpectExec("INSERT INTO visitor_key").
-		WithArgs(int32(100), "NEWKEY").
+	mock.ExpectExec("INSERT IGNORE INTO visitor_key").
+		WithArgs(int64(0), int32(100), "NEWKEY").
 		WillReturnError(expectedError)
 
 	// Execute
-	err = repo.AddKeyToVisitor(visitor, "NEWKEY")
+	err = repo.AddKeyToVisitor(context.Background(), visitor, "NEWKEY")
 
 	// Assert
 	assert.Error(t, err)
@@ -500,16 +638,16 @@ func TestFindByKey_CaseInsensitive(t *testing.T) {
 		Connection: db,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "key_id"}).
-		AddRow(1, "John", "Doe", 10, "/assets/img/teachers/1.jpg", "MIXEDCASE")
+	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "site_id", "key_id"}).
+		AddRow(1, "John", "Doe", 10, "/assets/img/teachers/1.jpg", 1, "MIXEDCASE")
 
 	// The query should receive uppercase version
-	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image, v.site_id, vk.key_id FROM visitors AS v INNER JOIN visitor_key AS vk").
 		WithArgs("MIXEDCASE").
 		WillReturnRows(rows)
 
 	// Execute with lowercase
-	result, err := repo.FindByKey("MixedCase")
+	result, err := repo.FindByKey(context.Background(), "MixedCase")
 
 	// Assert
 	assert.NoError(t, err)
@@ -518,3 +656,33 @@ func TestFindByKey_CaseInsensitive(t *testing.T) {
 	assert.Equal(t, "MIXEDCASE", result.Key)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestFindAllWithKeys_ScopedToTenant(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Visitor{
+		Connection: db,
+		Scope:      Scope{TenantId: 3, SiteId: 4},
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image", "site_id", "key_id"}).
+		AddRow(1, "John", "Doe", 10, "/img/john.jpg", 4, "ABC123").
+		AddRow(2, "Jane", "Roe", nil, nil, 5, "DEF456")
+	mock.ExpectQuery("FROM visitors AS v INNER JOIN visitor_key AS vk ON vk.visitor_id = v.id WHERE v.tenant_id = 3 ORDER BY").
+		WillReturnRows(rows)
+
+	// Execute
+	roster, err := repo.FindAllWithKeys(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, roster, 2)
+	assert.Equal(t, "ABC123", roster[0].Key)
+	assert.Equal(t, "/img/john.jpg", roster[0].Visitor.Image)
+	assert.Equal(t, "", roster[1].Visitor.Image)
+	assert.Equal(t, 0, roster[1].Visitor.Grade)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// MemoryVisitorTrack is the entity.VisitorTrackRepository of the memory
+// engine, see MemoryStore.
+type MemoryVisitorTrack struct {
+	Memory *MemoryStore
+	Scope
+	// Now stamps tracks which carry no scan time, the current time when nil.
+	Now func() time.Time
+}
+
+// Store records the scan at its CreatedAt or now, scan ids of offline
+// kiosks are unique within the tenant.
+func (r *MemoryVisitorTrack) Store(ctx context.Context, vt *entity.VisitTrack) (*entity.VisitTrack, error) {
+	if err := memoryQuery(ctx, "Storing track"); err != nil {
+		return nil, err
+	}
+	createdAt := r.now()
+	if !vt.CreatedAt.IsZero() {
+		createdAt = vt.CreatedAt.UTC()
+	}
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	return r.insert(vt, createdAt)
+}
+
+// StoreBackdated records a scan made before tracks of the visitor stored
+// already and flips the direction of the visitor's tracks after it and
+// before until, under the same lock.
+func (r *MemoryVisitorTrack) StoreBackdated(ctx context.Context, vt *entity.VisitTrack, until time.Time) (*entity.VisitTrack, error) {
+	if err := memoryQuery(ctx, "Storing synced track"); err != nil {
+		return nil, err
+	}
+	createdAt := vt.CreatedAt.UTC()
+	r.Memory.mu.Lock()
+	defer r.Memory.mu.Unlock()
+	if r.Memory.trackOfClient(r.TenantId, vt.ClientId) != nil {
+		return nil, fmt.Errorf("Duplicate entry '%s' for key 'track.idx_client_id'", vt.ClientId)
+	}
+	for _, stored := range r.Memory.tracks {
+		track := &stored.track
+		if track.VisitorId == vt.VisitorId && track.CreatedAt.After(createdAt) && track.CreatedAt.Before(until) && r.inScope(stored) {
+			track.SignedIn = !track.SignedIn
+		}
+	}
+	return r.insert(vt, createdAt)
+}
+
+// insert appends the track, the caller holds the lock.
+func (r *MemoryVisitorTrack) insert(vt *entity.VisitTrack, createdAt time.Time) (*entity.VisitTrack, error) {
+	if r.Memory.trackOfClient(r.TenantId, vt.ClientId) != nil {
+		return nil, fmt.Errorf("Duplicate entry '%s' for key 'track.idx_client_id'", vt.ClientId)
+	}
+	r.Memory.lastTrackId++
+	vt.Id = r.Memory.lastTrackId
+	vt.CreatedAt = createdAt
+	stored := *vt
+	stored.Visitor = nil
+	r.Memory.tracks = append(r.Memory.tracks, &memoryTrack{tenantId: r.TenantId, siteId: r.SiteId, track: stored})
+	return vt, nil
+}
+
+func (r *MemoryVisitorTrack) GetById(ctx context.Context, id int64) (*entity.VisitTrack, error) {
+	if err := memoryQuery(ctx, "Getting track"); err != nil {
+		return nil, err
+	}
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	for _, stored := range r.Memory.tracks {
+		if int64(stored.track.Id) == id && r.inScope(stored) {
+			track := stored.track
+			return &track, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetByClientId returns the track stored for a kiosk scan id, nil when the
+// scan was not synced yet.
+func (r *MemoryVisitorTrack) GetByClientId(ctx context.Context, clientId string) (*entity.VisitTrack, error) {
+	if err := memoryQuery(ctx, "Getting synced track"); err != nil {
+		return nil, err
+	}
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	stored := r.Memory.trackOfClient(r.TenantId, clientId)
+	if stored == nil {
+		return nil, nil
+	}
+	track := stored.track
+	return &track, nil
+}
+
+func (r *MemoryVisitorTrack) CountEventsByVisitorIdSince(ctx context.Context, visitorId int32, date time.Time) (int, error) {
+	if err := memoryQuery(ctx, "Counting tracks"); err != nil {
+		return 0, err
+	}
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	count := 0
+	for _, stored := range r.Memory.tracks {
+		if stored.track.VisitorId == visitorId && stored.track.CreatedAt.After(date) && r.inScope(stored) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountEventsByVisitorIdBetween counts the tracks after from up to and
+// including to.
+func (r *MemoryVisitorTrack) CountEventsByVisitorIdBetween(ctx context.Context, visitorId int32, from time.Time, to time.Time) (int, error) {
+	if err := memoryQuery(ctx, "Counting tracks"); err != nil {
+		return 0, err
+	}
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	count := 0
+	for _, stored := range r.Memory.tracks {
+		track := stored.track
+		if track.VisitorId == visitorId && track.CreatedAt.After(from) && !track.CreatedAt.After(to) && r.inScope(stored) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FindPresentVisitorsSince returns visitors with an odd number of tracks since
+// the given date, ordered by surname and name.
+func (r *MemoryVisitorTrack) FindPresentVisitorsSince(ctx context.Context, date time.Time) ([]*entity.Visitor, error) {
+	if err := memoryQuery(ctx, "Finding present visitors"); err != nil {
+		return nil, err
+	}
+	r.Memory.mu.RLock()
+	defer r.Memory.mu.RUnlock()
+	counts := map[*memoryVisitor]int{}
+	for _, stored := range r.Memory.tracks {
+		if !stored.track.CreatedAt.After(date) || !r.inScope(stored) {
+			continue
+		}
+		if visitor := r.Memory.visitor(stored.tenantId, stored.track.VisitorId); visitor != nil {
+			counts[visitor]++
+		}
+	}
+	visitors := []*entity.Visitor{}
+	for visitor, count := range counts {
+		if count%2 == 1 {
+			present := visitor.visitor
+			present.SiteId = 0
+			visitors = append(visitors, &present)
+		}
+	}
+	sort.Slice(visitors, func(i, j int) bool {
+		if visitors[i].Surname != visitors[j].Surname {
+			return visitors[i].Surname < visitors[j].Surname
+		}
+		if visitors[i].Name != visitors[j].Name {
+			return visitors[i].Name < visitors[j].Name
+		}
+		return visitors[i].Id < visitors[j].Id
+	})
+	return visitors, nil
+}
+
+// inScope reports whether the track belongs to the tenant and site of the
+// repository, zero ids read across them like Scope does.
+func (r *MemoryVisitorTrack) inScope(stored *memoryTrack) bool {
+	return inTenant(r.TenantId, stored.tenantId) && (r.SiteId == 0 || r.SiteId == stored.siteId)
+}
+
+func (r *MemoryVisitorTrack) now() time.Time {
+	if r.Now != nil {
+		return r.Now().UTC()
+	}
+	return now()
+}
+
+// trackOfClient returns the track of the kiosk scan id stored for the
+// tenant, nil when there is none. Callers hold the lock.
+func (s *MemoryStore) trackOfClient(tenantId int64, clientId string) *memoryTrack {
+	for _, stored := range s.tracks {
+		if clientId != "" && stored.track.ClientId == clientId && inTenant(tenantId, stored.tenantId) {
+			return stored
+		}
+	}
+	return nil
+}
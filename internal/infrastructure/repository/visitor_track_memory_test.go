@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func memoryTracks(memory *MemoryStore, siteId int64, at time.Time) *MemoryVisitorTrack {
+	return &MemoryVisitorTrack{
+		Memory: memory,
+		Scope:  Scope{TenantId: 1, SiteId: siteId},
+		Now:    func() time.Time { return at },
+	}
+}
+
+func TestMemoryStoreTrack_StampsAndFindsTrack(t *testing.T) {
+	// Setup
+	at := time.Date(2025, 3, 4, 8, 15, 0, 0, time.UTC)
+	repo := memoryTracks(seededMemory(t), 3, at)
+
+	// Execute
+	stored, err := repo.Store(context.Background(), &entity.VisitTrack{VisitorId: 7, VisitKey: "KEY123", SignedIn: true, Visitor: &entity.Visitor{Id: 7}})
+	assert.NoError(t, err)
+	found, findErr := repo.GetById(context.Background(), int64(stored.Id))
+
+	// Assert
+	assert.NoError(t, findErr)
+	assert.Equal(t, 1, stored.Id)
+	assert.Equal(t, at, stored.CreatedAt)
+	if assert.NotNil(t, found) {
+		assert.Equal(t, "KEY123", found.VisitKey)
+		assert.Nil(t, found.Visitor)
+	}
+}
+
+func TestMemoryStoreTrack_KeepsScanTime(t *testing.T) {
+	// Setup
+	repo := memoryTracks(seededMemory(t), 3, time.Now())
+	scannedAt := time.Date(2025, 3, 4, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	// Execute
+	stored, err := repo.Store(context.Background(), &entity.VisitTrack{VisitorId: 7, CreatedAt: scannedAt})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, stored.CreatedAt.Equal(scannedAt))
+	assert.Equal(t, time.UTC, stored.CreatedAt.Location())
+}
+
+func TestMemoryStoreTrack_DuplicateClientId(t *testing.T) {
+	// Setup
+	memory := seededMemory(t)
+	repo := memoryTracks(memory, 3, time.Now())
+	_, err := repo.Store(context.Background(), &entity.VisitTrack{VisitorId: 7, ClientId: "scan-1"})
+	assert.NoError(t, err)
+
+	// Execute
+	_, err = memoryTracks(memory, 5, time.Now()).Store(context.Background(), &entity.VisitTrack{VisitorId: 7, ClientId: "scan-1"})
+	synced, findErr := repo.GetByClientId(context.Background(), "scan-1")
+	unsynced, unsyncedErr := repo.GetByClientId(context.Background(), "")
+
+	// Assert
+	assert.ErrorContains(t, err, "Duplicate entry 'scan-1'")
+	assert.NoError(t, findErr)
+	if assert.NotNil(t, synced) {
+		assert.Equal(t, 1, synced.Id)
+	}
+	assert.NoError(t, unsyncedErr)
+	assert.Nil(t, unsynced)
+}
+
+func TestMemoryGetById_OtherSite(t *testing.T) {
+	// Setup
+	memory := seededMemory(t)
+	stored, err := memoryTracks(memory, 3, time.Now()).Store(context.Background(), &entity.VisitTrack{VisitorId: 7})
+	assert.NoError(t, err)
+
+	// Execute
+	found, err := memoryTracks(memory, 5, time.Now()).GetById(context.Background(), int64(stored.Id))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestMemoryCountEvents_SinceAndBetween(t *testing.T) {
+	// Setup
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	repo := memoryTracks(seededMemory(t), 3, day)
+	for _, hour := range []int{8, 12, 16} {
+		_, err := repo.Store(context.Background(), &entity.VisitTrack{VisitorId: 7, CreatedAt: day.Add(time.Duration(hour) * time.Hour)})
+		assert.NoError(t, err)
+	}
+	_, err := repo.Store(context.Background(), &entity.VisitTrack{VisitorId: 8, CreatedAt: day.Add(9 * time.Hour)})
+	assert.NoError(t, err)
+
+	// Execute
+	since, sinceErr := repo.CountEventsByVisitorIdSince(context.Background(), 7, day.Add(8*time.Hour))
+	between, betweenErr := repo.CountEventsByVisitorIdBetween(context.Background(), 7, day, day.Add(12*time.Hour))
+
+	// Assert
+	assert.NoError(t, sinceErr)
+	assert.Equal(t, 2, since)
+	assert.NoError(t, betweenErr)
+	assert.Equal(t, 2, between)
+}
+
+func TestMemoryFindPresentVisitorsSince(t *testing.T) {
+	// Setup
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	repo := memoryTracks(seededMemory(t), 3, day)
+	for _, track := range []entity.VisitTrack{
+		{VisitorId: 8, CreatedAt: day.Add(8 * time.Hour)},
+		{VisitorId: 7, CreatedAt: day.Add(8 * time.Hour)},
+		{VisitorId: 7, CreatedAt: day.Add(9 * time.Hour)},
+		{VisitorId: 7, CreatedAt: day.Add(10 * time.Hour)},
+		{VisitorId: 8, CreatedAt: day.Add(-time.Hour)},
+	} {
+		_, err := repo.Store(context.Background(), &track)
+		assert.NoError(t, err)
+	}
+
+	// Execute
+	present, err := repo.FindPresentVisitorsSince(context.Background(), day)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, present, 2) {
+		assert.Equal(t, "Doe", present[0].Surname)
+		assert.Equal(t, "Smith", present[1].Surname)
+		assert.Zero(t, present[0].SiteId)
+	}
+}
+
+func TestMemoryStoreTrack_Concurrent(t *testing.T) {
+	// Setup
+	memory := seededMemory(t)
+	repo := memoryTracks(memory, 3, time.Now())
+	visitors := &MemoryVisitor{Memory: memory, Scope: repo.Scope}
+	wg := sync.WaitGroup{}
+
+	// Execute
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.Store(context.Background(), &entity.VisitTrack{VisitorId: 7, ClientId: fmt.Sprintf("scan-%d", i)})
+			assert.NoError(t, err)
+			_, err = visitors.FindByKey(context.Background(), "KEY123")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	count, err := repo.CountEventsByVisitorIdSince(context.Background(), 7, time.Time{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 50, count)
+}
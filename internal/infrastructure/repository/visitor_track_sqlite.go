@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"encoding/csv"
 	"fmt"
@@ -14,69 +15,223 @@ import (
 
 type VisitorTrack struct {
 	Connection *sql.DB `container:"type"`
+	Scope
+	Timeout
 }
 
-func (r *VisitorTrack) Store(vt *entity.VisitTrack) (*entity.VisitTrack, error) {
-	res, err := r.Connection.Exec(
-		"INSERT INTO track (visitor_id, key_id, sign_in, created_at) VALUES (?, ?, ?,  NOW())",
+// Store records the scan at its CreatedAt, the scan time reported by an
+// offline kiosk, or now when it is not set.
+func (r *VisitorTrack) Store(ctx context.Context, vt *entity.VisitTrack) (*entity.VisitTrack, error) {
+	createdAt := now()
+	if !vt.CreatedAt.IsZero() {
+		createdAt = vt.CreatedAt.UTC()
+	}
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	res, err := r.Connection.ExecContext(
+		queryCtx,
+		"INSERT INTO track (tenant_id, site_id, visitor_id, key_id, sign_in, client_id, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		r.TenantId,
+		r.SiteId,
 		vt.VisitorId,
 		vt.VisitKey,
 		vt.SignedIn,
+		nullString(vt.ClientId),
+		createdAt,
 	)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Storing track", err)
+	}
+	id, err := res.LastInsertId()
 	if err != nil {
 		return nil, err
 	}
+	return r.stored(ctx, vt, id)
+}
+
+// StoreBackdated records a scan made before tracks of the visitor stored
+// already and flips the direction of the visitor's tracks after it and
+// before until, the start of the next day, in the same transaction.
+func (r *VisitorTrack) StoreBackdated(ctx context.Context, vt *entity.VisitTrack, until time.Time) (*entity.VisitTrack, error) {
+	createdAt := vt.CreatedAt.UTC()
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	tx, err := r.Connection.BeginTx(queryCtx, nil)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Storing synced track", err)
+	}
+	defer tx.Rollback()
+	res, err := tx.ExecContext(
+		queryCtx,
+		"INSERT INTO track (tenant_id, site_id, visitor_id, key_id, sign_in, client_id, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		r.TenantId,
+		r.SiteId,
+		vt.VisitorId,
+		vt.VisitKey,
+		vt.SignedIn,
+		nullString(vt.ClientId),
+		createdAt,
+	)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Storing synced track", err)
+	}
+	_, err = tx.ExecContext(
+		queryCtx,
+		"UPDATE track SET sign_in = NOT sign_in WHERE visitor_id = ? AND created_at > ? AND created_at < ?"+r.and(""),
+		vt.VisitorId,
+		createdAt,
+		until.UTC(),
+	)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Reclassifying later tracks", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, r.failed(queryCtx, "Storing synced track", err)
+	}
 	id, err := res.LastInsertId()
 	if err != nil {
 		return nil, err
 	}
-	vt1, err1 := r.GetById(id)
-	if err1 != nil {
-		return nil, err1
+	return r.stored(ctx, vt, id)
+}
+
+// stored completes the track with the id and time the database stored.
+func (r *VisitorTrack) stored(ctx context.Context, vt *entity.VisitTrack, id int64) (*entity.VisitTrack, error) {
+	vt1, err := r.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if vt1 == nil {
+		return nil, fmt.Errorf("stored track %d not found", id)
 	}
 	vt.Id = vt1.Id
 	vt.VisitKey = vt1.VisitKey
 	vt.CreatedAt = vt1.CreatedAt
 	r.writeToTheFile(vt)
-	return vt, err1
+	return vt, nil
+}
+
+func (r *VisitorTrack) GetById(ctx context.Context, id int64) (*entity.VisitTrack, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	row := r.Connection.QueryRowContext(queryCtx, "SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.client_id, t.created_at FROM track AS t WHERE id = ?"+r.and("t"), id)
+	track, err := r.scanTrack(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return track, r.failed(queryCtx, "Getting track", err)
 }
 
-func (r *VisitorTrack) GetById(id int64) (*entity.VisitTrack, error) {
+// GetByClientId returns the track stored for a kiosk scan id, nil when the
+// scan was not synced yet. Scan ids are unique within the tenant.
+func (r *VisitorTrack) GetByClientId(ctx context.Context, clientId string) (*entity.VisitTrack, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	row := r.Connection.QueryRowContext(queryCtx, "SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.client_id, t.created_at FROM track AS t WHERE t.client_id = ?"+r.tenantAnd("t"), clientId)
+	track, err := r.scanTrack(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return track, r.failed(queryCtx, "Getting synced track", err)
+}
+
+func (r *VisitorTrack) scanTrack(row *sql.Row) (*entity.VisitTrack, error) {
 	var createdAtRaw []byte
+	var clientId sql.NullString
 
-	row := r.Connection.QueryRow("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.created_at FROM track AS t WHERE id = ?", id)
 	track := &entity.VisitTrack{}
 	err := row.Scan(
 		&track.Id,
 		&track.VisitorId,
 		&track.VisitKey,
 		&track.SignedIn,
+		&clientId,
 		&createdAtRaw,
 	)
 	if err != nil {
 		return nil, err
 	}
+	track.ClientId = clientId.String
 
-	track.CreatedAt, err = time.Parse("2006-01-02 15:04:05", string(createdAtRaw))
+	track.CreatedAt, err = parseDateTime(createdAtRaw)
 	if err != nil {
 		return nil, err
 	}
 	return track, nil
 }
 
-func (r *VisitorTrack) CountEventsByVisitorIdSince(visitorId int32, date time.Time) (int, error) {
+func (r *VisitorTrack) CountEventsByVisitorIdSince(ctx context.Context, visitorId int32, date time.Time) (int, error) {
 	var count int
-	err := r.Connection.QueryRow(
-		"SELECT COUNT(*) FROM track WHERE visitor_id = ? AND created_at > ?",
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	err := r.Connection.QueryRowContext(
+		queryCtx,
+		"SELECT COUNT(*) FROM track WHERE visitor_id = ? AND created_at > ?"+r.and(""),
 		visitorId,
 		date,
 	).Scan(&count)
 	if err != nil {
-		return 0, err
+		return 0, r.failed(queryCtx, "Counting tracks", err)
+	}
+	return count, nil
+}
+
+// CountEventsByVisitorIdBetween counts the tracks after from up to and
+// including to, so a scan synced late is classified by the scans before it.
+func (r *VisitorTrack) CountEventsByVisitorIdBetween(ctx context.Context, visitorId int32, from time.Time, to time.Time) (int, error) {
+	var count int
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	err := r.Connection.QueryRowContext(
+		queryCtx,
+		"SELECT COUNT(*) FROM track WHERE visitor_id = ? AND created_at > ? AND created_at <= ?"+r.and(""),
+		visitorId,
+		from,
+		to,
+	).Scan(&count)
+	if err != nil {
+		return 0, r.failed(queryCtx, "Counting tracks", err)
 	}
 	return count, nil
 }
 
+// FindPresentVisitorsSince returns visitors with an odd number of tracks since
+// the given date, i.e. those who are currently signed in.
+func (r *VisitorTrack) FindPresentVisitorsSince(ctx context.Context, date time.Time) ([]*entity.Visitor, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(
+		queryCtx,
+		"SELECT v.id, v.name, v.surname, v.grade, v.image FROM visitors AS v INNER JOIN track AS t ON t.visitor_id = v.id WHERE t.created_at > ?"+r.and("t")+" GROUP BY v.id, v.name, v.surname, v.grade, v.image HAVING MOD(COUNT(t.id), 2) = 1 ORDER BY v.surname, v.name",
+		date,
+	)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Finding present visitors", err)
+	}
+	defer rows.Close()
+
+	visitors := []*entity.Visitor{}
+	for rows.Next() {
+		var tmpGrade sql.NullInt32
+		var tmpImage sql.NullString
+		visitor := &entity.Visitor{}
+		if err := rows.Scan(&visitor.Id, &visitor.Name, &visitor.Surname, &tmpGrade, &tmpImage); err != nil {
+			return nil, r.failed(queryCtx, "Finding present visitors", err)
+		}
+		if tmpGrade.Valid {
+			visitor.Grade = int(tmpGrade.Int32)
+		}
+		if tmpImage.Valid {
+			visitor.Image = tmpImage.String
+		}
+		visitors = append(visitors, visitor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Finding present visitors", err)
+	}
+	return visitors, nil
+}
+
 func (r *VisitorTrack) writeToTheFile(vt *entity.VisitTrack) {
 	rootPath, err := getRootPath()
 	if err != nil {
@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"regexp"
 	"testing"
 	"time"
 
@@ -40,19 +42,19 @@ func TestStore_Success(t *testing.T) {
 
 	// Expect INSERT
 	mock.ExpectExec("INSERT INTO track").
-		WithArgs(int32(123), "KEY123", true).
+		WithArgs(int64(0), int64(0), int32(123), "KEY123", true, sql.NullString{}, sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Expect SELECT for GetById
-	rows := sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "created_at"}).
-		AddRow(1, 123, "KEY123", true, expectedTime.Format("2006-01-02 15:04:05"))
+	rows := sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "client_id", "created_at"}).
+		AddRow(1, 123, "KEY123", true, nil, expectedTime.Format("2006-01-02 15:04:05"))
 
-	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.created_at FROM track AS t WHERE id = ?").
+	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.client_id, t.created_at FROM track AS t WHERE id = ?").
 		WithArgs(int64(1)).
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.Store(visitTrack)
+	result, err := repo.Store(context.Background(), visitTrack)
 
 	// Assert
 	assert.NoError(t, err)
@@ -64,6 +66,71 @@ func TestStore_Success(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestStoreBackdated_FlipsLaterTracksOfTheDay(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &VisitorTrack{
+		Connection: db,
+		Scope:      Scope{TenantId: 1, SiteId: 2},
+	}
+	scannedAt := time.Date(2025, 3, 4, 8, 0, 0, 0, time.UTC)
+	nextDay := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO track").
+		WithArgs(int64(1), int64(2), int32(123), "KEY123", true, sql.NullString{String: "scan-1", Valid: true}, scannedAt).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE track SET sign_in = NOT sign_in WHERE visitor_id = ? AND created_at > ? AND created_at < ? AND tenant_id = 1 AND site_id = 2")).
+		WithArgs(int32(123), scannedAt, nextDay).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.client_id, t.created_at FROM track AS t WHERE id = ?").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "client_id", "created_at"}).
+			AddRow(7, 123, "KEY123", true, "scan-1", scannedAt.Format("2006-01-02 15:04:05")))
+
+	// Execute
+	result, err := repo.StoreBackdated(context.Background(), &entity.VisitTrack{VisitorId: 123, VisitKey: "KEY123", SignedIn: true, ClientId: "scan-1", CreatedAt: scannedAt, Visitor: &entity.Visitor{Id: 123}}, nextDay)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.NotNil(t, result) {
+		assert.Equal(t, 7, result.Id)
+		assert.Equal(t, scannedAt, result.CreatedAt.UTC())
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreBackdated_RollsBackWhenFlippingFails(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &VisitorTrack{
+		Connection: db,
+	}
+	scannedAt := time.Date(2025, 3, 4, 8, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO track").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec("UPDATE track SET sign_in = NOT sign_in").
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	// Execute
+	result, err := repo.StoreBackdated(context.Background(), &entity.VisitTrack{VisitorId: 123, VisitKey: "KEY123", CreatedAt: scannedAt}, scannedAt.Add(16*time.Hour))
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestStore_InsertError(t *testing.T) {
 	// Setup
 	db, mock, err := sqlmock.New()
@@ -82,11 +149,11 @@ func TestStore_InsertError(t *testing.T) {
 
 	expectedError := errors.New("insert failed")
 	mock.ExpectExec("INSERT INTO track").
-		WithArgs(int32(123), "KEY123", true).
+		WithArgs(int64(0), int64(0), int32(123), "KEY123", true, sql.NullString{}, sqlmock.AnyArg()).
 		WillReturnError(expectedError)
 
 	// Execute
-	result, err := repo.Store(visitTrack)
+	result, err := repo.Store(context.Background(), visitTrack)
 
 	// Assert
 	assert.Error(t, err)
@@ -113,11 +180,11 @@ func TestStore_LastInsertIdError(t *testing.T) {
 
 	expectedError := errors.New("last insert id error")
 	mock.ExpectExec("INSERT INTO track").
-		WithArgs(int32(123), "KEY123", true).
+		WithArgs(int64(0), int64(0), int32(123), "KEY123", true, sql.NullString{}, sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewErrorResult(expectedError))
 
 	// Execute
-	result, err := repo.Store(visitTrack)
+	result, err := repo.Store(context.Background(), visitTrack)
 
 	// Assert
 	assert.Error(t, err)
@@ -150,16 +217,16 @@ func TestStore_GetByIdError(t *testing.T) {
 	}
 
 	mock.ExpectExec("INSERT INTO track").
-		WithArgs(int32(123), "KEY123", true).
+		WithArgs(int64(0), int64(0), int32(123), "KEY123", true, sql.NullString{}, sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	expectedError := errors.New("query failed")
-	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.created_at FROM track AS t WHERE id = ?").
+	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.client_id, t.created_at FROM track AS t WHERE id = ?").
 		WithArgs(int64(1)).
 		WillReturnError(expectedError)
 
 	// Execute
-	result, err := repo.Store(visitTrack)
+	result, err := repo.Store(context.Background(), visitTrack)
 
 	// Assert
 	assert.Error(t, err)
@@ -180,15 +247,15 @@ func TestGetById_Success(t *testing.T) {
 
 	expectedTime := time.Date(2023, 12, 10, 14, 30, 0, 0, time.UTC)
 
-	rows := sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "created_at"}).
-		AddRow(5, 456, "KEY789", false, expectedTime.Format("2006-01-02 15:04:05"))
+	rows := sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "client_id", "created_at"}).
+		AddRow(5, 456, "KEY789", false, nil, expectedTime.Format("2006-01-02 15:04:05"))
 
-	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.created_at FROM track AS t WHERE id = ?").
+	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.client_id, t.created_at FROM track AS t WHERE id = ?").
 		WithArgs(int64(5)).
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetById(5)
+	result, err := repo.GetById(context.Background(), 5)
 
 	// Assert
 	assert.NoError(t, err)
@@ -201,28 +268,46 @@ func TestGetById_Success(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetById_NotFound(t *testing.T) {
-	// Setup
-	db, mock, err := sqlmock.New()
-	assert.NoError(t, err)
-	defer db.Close()
-
-	repo := &VisitorTrack{
-		Connection: db,
+func TestGetById_MissingTrackOnEveryEngine(t *testing.T) {
+	tests := []struct {
+		name string
+		repo func(t *testing.T) (entity.VisitorTrackRepository, func())
+	}{
+		{
+			name: "sql",
+			repo: func(t *testing.T) (entity.VisitorTrackRepository, func()) {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.client_id, t.created_at FROM track AS t WHERE id = ?").
+					WithArgs(int64(999)).
+					WillReturnError(sql.ErrNoRows)
+				return &VisitorTrack{Connection: db}, func() {
+					assert.NoError(t, mock.ExpectationsWereMet())
+					db.Close()
+				}
+			},
+		},
+		{
+			name: "memory",
+			repo: func(t *testing.T) (entity.VisitorTrackRepository, func()) {
+				return memoryTracks(seededMemory(t), 3, time.Now()), func() {}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			repo, done := tt.repo(t)
+			defer done()
+
+			// Execute
+			result, err := repo.GetById(context.Background(), 999)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Nil(t, result)
+		})
 	}
-
-	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.created_at FROM track AS t WHERE id = ?").
-		WithArgs(int64(999)).
-		WillReturnError(sql.ErrNoRows)
-
-	// Execute
-	result, err := repo.GetById(999)
-
-	// Assert
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Equal(t, sql.ErrNoRows, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestGetById_DatabaseError(t *testing.T) {
@@ -236,12 +321,12 @@ func TestGetById_DatabaseError(t *testing.T) {
 	}
 
 	expectedError := errors.New("connection timeout")
-	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.created_at FROM track AS t WHERE id = ?").
+	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.client_id, t.created_at FROM track AS t WHERE id = ?").
 		WithArgs(int64(1)).
 		WillReturnError(expectedError)
 
 	// Execute
-	result, err := repo.GetById(1)
+	result, err := repo.GetById(context.Background(), 1)
 
 	// Assert
 	assert.Error(t, err)
@@ -260,15 +345,15 @@ func TestGetById_InvalidTimeFormat(t *testing.T) {
 		Connection: db,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "created_at"}).
-		AddRow(5, 456, "KEY789", false, "invalid-date")
+	rows := sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "client_id", "created_at"}).
+		AddRow(5, 456, "KEY789", false, nil, "invalid-date")
 
-	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.created_at FROM track AS t WHERE id = ?").
+	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.client_id, t.created_at FROM track AS t WHERE id = ?").
 		WithArgs(int64(5)).
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetById(5)
+	result, err := repo.GetById(context.Background(), 5)
 
 	// Assert
 	assert.Error(t, err)
@@ -296,7 +381,7 @@ func TestCountEventsByVisitorIdSince_Success(t *testing.T) {
 		WillReturnRows(rows)
 
 	// Execute
-	count, err := repo.CountEventsByVisitorIdSince(123, startDate)
+	count, err := repo.CountEventsByVisitorIdSince(context.Background(), 123, startDate)
 
 	// Assert
 	assert.NoError(t, err)
@@ -304,6 +389,33 @@ func TestCountEventsByVisitorIdSince_Success(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestCountEventsByVisitorIdSince_QueryTimeout(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &VisitorTrack{
+		Connection: db,
+		Timeout:    Timeout{QueryTimeout: 10 * time.Millisecond},
+	}
+
+	startDate := time.Date(2023, 12, 10, 0, 0, 0, 0, time.Local)
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM track WHERE visitor_id = \\? AND created_at > \\?").
+		WithArgs(int32(123), startDate).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+	// Execute
+	count, err := repo.CountEventsByVisitorIdSince(context.Background(), 123, startDate)
+
+	// Assert
+	assert.Equal(t, 0, count)
+	assert.ErrorIs(t, err, entity.ErrQueryTimeout)
+	assert.EqualError(t, err, "Counting tracks timed out after 10ms")
+}
+
 func TestCountEventsByVisitorIdSince_ZeroCount(t *testing.T) {
 	// Setup
 	db, mock, err := sqlmock.New()
@@ -323,7 +435,7 @@ func TestCountEventsByVisitorIdSince_ZeroCount(t *testing.T) {
 		WillReturnRows(rows)
 
 	// Execute
-	count, err := repo.CountEventsByVisitorIdSince(456, startDate)
+	count, err := repo.CountEventsByVisitorIdSince(context.Background(), 456, startDate)
 
 	// Assert
 	assert.NoError(t, err)
@@ -349,7 +461,7 @@ func TestCountEventsByVisitorIdSince_DatabaseError(t *testing.T) {
 		WillReturnError(expectedError)
 
 	// Execute
-	count, err := repo.CountEventsByVisitorIdSince(123, startDate)
+	count, err := repo.CountEventsByVisitorIdSince(context.Background(), 123, startDate)
 
 	// Assert
 	assert.Error(t, err)
@@ -378,7 +490,7 @@ func TestCountEventsByVisitorIdSince_ScanError(t *testing.T) {
 		WillReturnRows(rows)
 
 	// Execute
-	count, err := repo.CountEventsByVisitorIdSince(123, startDate)
+	count, err := repo.CountEventsByVisitorIdSince(context.Background(), 123, startDate)
 
 	// Assert
 	assert.Error(t, err)
@@ -412,18 +524,18 @@ func TestStore_SignedInFalse(t *testing.T) {
 	expectedTime := time.Now()
 
 	mock.ExpectExec("INSERT INTO track").
-		WithArgs(int32(789), "KEYOUT", false).
+		WithArgs(int64(0), int64(0), int32(789), "KEYOUT", false, sql.NullString{}, sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(10, 1))
 
-	rows := sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "created_at"}).
-		AddRow(10, 789, "KEYOUT", false, expectedTime.Format("2006-01-02 15:04:05"))
+	rows := sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "client_id", "created_at"}).
+		AddRow(10, 789, "KEYOUT", false, nil, expectedTime.Format("2006-01-02 15:04:05"))
 
-	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.created_at FROM track AS t WHERE id = ?").
+	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.client_id, t.created_at FROM track AS t WHERE id = ?").
 		WithArgs(int64(10)).
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.Store(visitTrack)
+	result, err := repo.Store(context.Background(), visitTrack)
 
 	// Assert
 	assert.NoError(t, err)
@@ -452,10 +564,176 @@ func TestCountEventsByVisitorIdSince_LargeCount(t *testing.T) {
 		WillReturnRows(rows)
 
 	// Execute
-	count, err := repo.CountEventsByVisitorIdSince(999, startDate)
+	count, err := repo.CountEventsByVisitorIdSince(context.Background(), 999, startDate)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, 1000, count)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestFindPresentVisitorsSince_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &VisitorTrack{
+		Connection: db,
+	}
+
+	since := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "name", "surname", "grade", "image"}).
+		AddRow(1, "John", "Doe", 10, "/a.jpg").
+		AddRow(2, "Jane", "Smith", nil, nil)
+	mock.ExpectQuery("SELECT v.id, v.name, v.surname, v.grade, v.image FROM visitors AS v INNER JOIN track AS t").
+		WithArgs(since).
+		WillReturnRows(rows)
+
+	// Execute
+	visitors, err := repo.FindPresentVisitorsSince(context.Background(), since)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, visitors, 2)
+	assert.Equal(t, int32(1), visitors[0].Id)
+	assert.Equal(t, 10, visitors[0].Grade)
+	assert.Equal(t, 0, visitors[1].Grade)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindPresentVisitorsSince_QueryError(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &VisitorTrack{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT v.id").WillReturnError(errors.New("query failed"))
+
+	// Execute
+	visitors, err := repo.FindPresentVisitorsSince(context.Background(), time.Now())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, visitors)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStore_KeepsClientScanTime(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &VisitorTrack{
+		Connection: db,
+		Scope:      Scope{TenantId: 1, SiteId: 2},
+	}
+
+	scannedAt := time.Date(2024, 9, 2, 8, 40, 0, 0, time.FixedZone("BST", 3600))
+	visitTrack := &entity.VisitTrack{
+		VisitorId: 123,
+		VisitKey:  "KEY123",
+		SignedIn:  true,
+		ClientId:  "scan-1",
+		CreatedAt: scannedAt,
+		Visitor:   &entity.Visitor{Id: 123, Name: "John", Surname: "Doe"},
+	}
+
+	mock.ExpectExec("INSERT INTO track").
+		WithArgs(int64(1), int64(2), int32(123), "KEY123", true, sql.NullString{String: "scan-1", Valid: true}, scannedAt.UTC()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	rows := sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "client_id", "created_at"}).
+		AddRow(1, 123, "KEY123", true, "scan-1", "2024-09-02 07:40:00")
+	mock.ExpectQuery("SELECT t.id, t.visitor_id, t.key_id, t.sign_in, t.client_id, t.created_at FROM track AS t WHERE id = ?").
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	// Execute
+	result, err := repo.Store(context.Background(), visitTrack)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, scannedAt.Equal(result.CreatedAt))
+	assert.Equal(t, "scan-1", result.ClientId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByClientId_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &VisitorTrack{
+		Connection: db,
+		Scope:      Scope{TenantId: 1, SiteId: 2},
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "visitor_id", "key_id", "sign_in", "client_id", "created_at"}).
+		AddRow(7, 123, "KEY123", true, "scan-1", "2024-09-02 07:40:00")
+	mock.ExpectQuery("SELECT (.+) FROM track AS t WHERE t.client_id = \\? AND t.tenant_id = 1$").
+		WithArgs("scan-1").
+		WillReturnRows(rows)
+
+	// Execute
+	track, err := repo.GetByClientId(context.Background(), "scan-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 7, track.Id)
+	assert.Equal(t, "scan-1", track.ClientId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByClientId_NotSynced(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &VisitorTrack{
+		Connection: db,
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM track AS t WHERE t.client_id = ?").
+		WithArgs("scan-2").
+		WillReturnError(sql.ErrNoRows)
+
+	// Execute
+	track, err := repo.GetByClientId(context.Background(), "scan-2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, track)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountEventsByVisitorIdBetween_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &VisitorTrack{
+		Connection: db,
+	}
+
+	from := time.Date(2024, 9, 1, 23, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 9, 2, 7, 40, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM track WHERE visitor_id = \\? AND created_at > \\? AND created_at <= \\?").
+		WithArgs(int32(123), from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	// Execute
+	count, err := repo.CountEventsByVisitorIdBetween(context.Background(), 123, from, to)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
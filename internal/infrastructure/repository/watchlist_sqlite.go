@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+type Watchlist struct {
+	Connection *sql.DB `container:"type"`
+	Scope
+	Timeout
+}
+
+func (r *Watchlist) FindActive(ctx context.Context) ([]*entity.WatchlistEntry, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(queryCtx, "SELECT id, visitor_id, name, surname, category, notes, active, created_at FROM watchlist WHERE active = 1"+r.tenantAnd("")+" ORDER BY id")
+	if err != nil {
+		return nil, r.failed(queryCtx, "Finding watchlist entries", err)
+	}
+	defer rows.Close()
+
+	entries := []*entity.WatchlistEntry{}
+	for rows.Next() {
+		var visitorId sql.NullInt32
+		var name, surname, notes sql.NullString
+		var createdAtRaw []byte
+		entry := &entity.WatchlistEntry{}
+		err := rows.Scan(&entry.Id, &visitorId, &name, &surname, &entry.Category, &notes, &entry.Active, &createdAtRaw)
+		if err != nil {
+			return nil, r.failed(queryCtx, "Finding watchlist entries", err)
+		}
+		entry.VisitorId = visitorId.Int32
+		entry.Name = name.String
+		entry.Surname = surname.String
+		entry.Notes = notes.String
+		if entry.CreatedAt, err = parseDateTime(createdAtRaw); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Finding watchlist entries", err)
+	}
+	return entries, nil
+}
+
+func (r *Watchlist) Store(ctx context.Context, entry *entity.WatchlistEntry) error {
+	var visitorId sql.NullInt32
+	if entry.VisitorId > 0 {
+		visitorId = sql.NullInt32{Int32: entry.VisitorId, Valid: true}
+	}
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	res, err := r.Connection.ExecContext(
+		queryCtx,
+		"INSERT INTO watchlist (tenant_id, visitor_id, name, surname, category, notes, active, created_at) VALUES (?, ?, ?, ?, ?, ?, 1, ?)",
+		r.TenantId,
+		visitorId,
+		entry.Name,
+		entry.Surname,
+		entry.Category,
+		entry.Notes,
+		now(),
+	)
+	if err != nil {
+		return r.failed(queryCtx, "Storing watchlist entry", err)
+	}
+	entry.Active = true
+	entry.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *Watchlist) Deactivate(ctx context.Context, id int64) error {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	_, err := r.Connection.ExecContext(queryCtx, "UPDATE watchlist SET active = 0 WHERE id = ?"+r.tenantAnd(""), id)
+	return r.failed(queryCtx, "Deactivating watchlist entry", err)
+}
+
+func (r *Watchlist) StoreHit(ctx context.Context, hit *entity.WatchlistHit) error {
+	var visitorId sql.NullInt32
+	if hit.VisitorId > 0 {
+		visitorId = sql.NullInt32{Int32: hit.VisitorId, Valid: true}
+	}
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	res, err := r.Connection.ExecContext(
+		queryCtx,
+		"INSERT INTO watchlist_hits (tenant_id, entry_id, visitor_id, source, details, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+		r.TenantId,
+		hit.EntryId,
+		visitorId,
+		hit.Source,
+		hit.Details,
+		now(),
+	)
+	if err != nil {
+		return r.failed(queryCtx, "Storing watchlist hit", err)
+	}
+	hit.Id, err = res.LastInsertId()
+	return err
+}
+
+func (r *Watchlist) FindHitsBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.WatchlistHit, error) {
+	queryCtx, cancel := r.query(ctx)
+	defer cancel()
+	rows, err := r.Connection.QueryContext(
+		queryCtx,
+		"SELECT id, entry_id, visitor_id, source, details, created_at FROM watchlist_hits WHERE created_at >= ? AND created_at < ?"+r.tenantAnd("")+" ORDER BY created_at",
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, r.failed(queryCtx, "Finding watchlist hits", err)
+	}
+	defer rows.Close()
+
+	hits := []*entity.WatchlistHit{}
+	for rows.Next() {
+		var visitorId sql.NullInt32
+		var details sql.NullString
+		var createdAtRaw []byte
+		hit := &entity.WatchlistHit{}
+		if err := rows.Scan(&hit.Id, &hit.EntryId, &visitorId, &hit.Source, &details, &createdAtRaw); err != nil {
+			return nil, r.failed(queryCtx, "Finding watchlist hits", err)
+		}
+		hit.VisitorId = visitorId.Int32
+		hit.Details = details.String
+		if hit.CreatedAt, err = parseDateTime(createdAtRaw); err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.failed(queryCtx, "Finding watchlist hits", err)
+	}
+	return hits, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchlistFindActive_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Watchlist{
+		Connection: db,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "visitor_id", "name", "surname", "category", "notes", "active", "created_at"}).
+		AddRow(1, 7, "Tom", "Doe", entity.WatchlistNoAdmit, nil, true, []byte("2024-09-02 08:00:00")).
+		AddRow(2, nil, "John", "Stranger", entity.WatchlistCustody, "not allowed to collect Tom", true, []byte("2024-09-03 09:30:00"))
+	mock.ExpectQuery("SELECT id, visitor_id, name, surname, category, notes, active, created_at FROM watchlist WHERE active = 1").
+		WillReturnRows(rows)
+
+	// Execute
+	entries, err := repo.FindActive(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, int32(7), entries[0].VisitorId)
+	assert.Equal(t, "", entries[0].Notes)
+	assert.Equal(t, int32(0), entries[1].VisitorId)
+	assert.Equal(t, entity.WatchlistCustody, entries[1].Category)
+	assert.Equal(t, time.Date(2024, 9, 3, 9, 30, 0, 0, time.UTC), entries[1].CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWatchlistStore_GuestWithoutVisitor(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Watchlist{
+		Connection: db,
+	}
+
+	mock.ExpectExec("INSERT INTO watchlist").
+		WithArgs(int64(0), nil, "John", "Stranger", entity.WatchlistCustody, "", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+
+	// Execute
+	entry := &entity.WatchlistEntry{Name: "John", Surname: "Stranger", Category: entity.WatchlistCustody}
+	err = repo.Store(context.Background(), entry)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), entry.Id)
+	assert.True(t, entry.Active)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWatchlistStoreHit_Success(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Watchlist{
+		Connection: db,
+	}
+
+	mock.ExpectExec("INSERT INTO watchlist_hits").
+		WithArgs(int64(0), int64(1), int32(7), "scan", "Tom Doe", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(9, 1))
+
+	// Execute
+	hit := &entity.WatchlistHit{EntryId: 1, VisitorId: 7, Source: "scan", Details: "Tom Doe"}
+	err = repo.StoreHit(context.Background(), hit)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), hit.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,10 +1,43 @@
 package util
 
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+)
+
 type FailureResponse struct {
 	Error string `json:"error"`
 }
 
+// ExtendedFailureResponse is the body of every error response, Code tells
+// clients what went wrong without parsing Error.
 type ExtendedFailureResponse struct {
 	Code  string `json:"code"`
 	Error string `json:"error"`
 }
+
+func NewFailureResponse(e *exception.Exception) ExtendedFailureResponse {
+	return ExtendedFailureResponse{
+		Code:  e.ErrorCode(),
+		Error: e.Error.Error(),
+	}
+}
+
+// timeout is implemented by errors of operations which ran out of time, like
+// entity.QueryTimeoutError and context.DeadlineExceeded.
+type timeout interface {
+	Timeout() bool
+}
+
+// ServerFailure is the status and body of an error the client is not to
+// blame for: 503 when the operation ran out of time and may be retried, 500
+// otherwise.
+func ServerFailure(err error) (int, ExtendedFailureResponse) {
+	var t timeout
+	if errors.As(err, &t) && t.Timeout() {
+		return http.StatusServiceUnavailable, NewFailureResponse(exception.Timeout(err))
+	}
+	return http.StatusInternalServerError, NewFailureResponse(exception.Internal(err))
+}
@@ -0,0 +1,32 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFailureResponse(t *testing.T) {
+	response := NewFailureResponse(exception.Validation(errors.New("Invalid date")))
+
+	assert.Equal(t, ExtendedFailureResponse{Code: "validation", Error: "Invalid date"}, response)
+}
+
+func TestServerFailureWillAnswerTimeoutsWithServiceUnavailable(t *testing.T) {
+	status, response := ServerFailure(fmt.Errorf("Counting tracks: %w", context.DeadlineExceeded))
+
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "timeout", response.Code)
+}
+
+func TestServerFailureWillAnswerOtherErrorsWithInternalError(t *testing.T) {
+	status, response := ServerFailure(errors.New("Connection refused"))
+
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, ExtendedFailureResponse{Code: "internal_error", Error: "Connection refused"}, response)
+}
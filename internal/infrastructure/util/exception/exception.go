@@ -2,11 +2,23 @@ package exception
 
 const (
 	ValidationType                      = "validation"
+	NotFoundType                        = "not_found"
+	ConflictType                        = "conflict"
+	UnauthorizedType                    = "unauthorized"
+	ForbiddenType                       = "forbidden"
+	InternalType                        = "internal_error"
+	TimeoutType                         = "timeout"
 	ExternalServiceErrorType            = "external_service_error"
 	ExternalServiceWarningType          = "external_service_warning"
 	ExternalResponseProcessingErrorType = "external_respons_processiong_error"
 )
 
+// codes are the machine-readable codes of the types whose names are not
+// fit to be one.
+var codes = map[string]string{
+	ExternalResponseProcessingErrorType: "external_response_processing_error",
+}
+
 type Exception struct {
 	Type  string
 	Error error
@@ -17,6 +29,31 @@ func Validation(err error) *Exception {
 	return CreateException(ValidationType, err)
 }
 
+func NotFound(err error) *Exception {
+	return CreateException(NotFoundType, err)
+}
+
+func Conflict(err error) *Exception {
+	return CreateException(ConflictType, err)
+}
+
+func Unauthorized(err error) *Exception {
+	return CreateException(UnauthorizedType, err)
+}
+
+func Forbidden(err error) *Exception {
+	return CreateException(ForbiddenType, err)
+}
+
+func Internal(err error) *Exception {
+	return CreateException(InternalType, err)
+}
+
+// Timeout is an operation which ran out of time, the request may be retried.
+func Timeout(err error) *Exception {
+	return CreateException(TimeoutType, err)
+}
+
 func ExternalServiceError(err error) *Exception {
 	return CreateException(ExternalServiceErrorType, err)
 }
@@ -40,3 +77,14 @@ func (e *Exception) SetCode(code string) *Exception {
 	e.Code = code
 	return e
 }
+
+// ErrorCode is the code set on the exception, else the one of its type.
+func (e *Exception) ErrorCode() string {
+	if e.Code != "" {
+		return e.Code
+	}
+	if code, ok := codes[e.Type]; ok {
+		return code
+	}
+	return e.Type
+}
@@ -59,3 +59,20 @@ func TestExternalServiceError(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorCode(t *testing.T) {
+	var tests = []struct {
+		name         string
+		exception    *Exception
+		expectedCode string
+	}{
+		{name: "code of the type", exception: NotFound(fmt.Errorf("Test Error")), expectedCode: "not_found"},
+		{name: "code set", exception: Conflict(fmt.Errorf("Test Error")).SetCode("anti_passback"), expectedCode: "anti_passback"},
+		{name: "code of a type not fit to be one", exception: ExternalResponseProcessingError(fmt.Errorf("Test Error")), expectedCode: "external_response_processing_error"},
+	}
+	for _, testData := range tests {
+		t.Run(testData.name, func(t *testing.T) {
+			assert.Equal(t, testData.expectedCode, testData.exception.ErrorCode())
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package util
+
+import "time"
+
+const dateLayout = "2006-01-02"
+
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// StartOfNextDay returns midnight after t in its location, days of a DST
+// change are 23 or 25 hours long.
+func StartOfNextDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+}
+
+// In returns the time in the location, as it is without one.
+func In(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	return t.In(loc)
+}
+
+// ParseDate parses a YYYY-MM-DD date as the start of that day in the
+// location, the local one without.
+func ParseDate(value string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.ParseInLocation(dateLayout, value, loc)
+}
@@ -0,0 +1,62 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	_ "time/tzdata"
+)
+
+func TestStartOfDayWillKeepDateAndLocation(t *testing.T) {
+	loc := time.FixedZone("test", 2*60*60)
+	start := StartOfDay(time.Date(2024, 9, 2, 13, 45, 10, 500, loc))
+
+	assert.Equal(t, time.Date(2024, 9, 2, 0, 0, 0, 0, loc), start)
+}
+
+func TestStartOfDayWillUseLocationOfTime(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+	// 23:30 UTC on September 1st is already September 2nd in Tokyo
+	scannedAt := time.Date(2024, 9, 1, 23, 30, 0, 0, time.UTC)
+
+	start := StartOfDay(In(scannedAt, loc))
+
+	assert.Equal(t, time.Date(2024, 9, 1, 15, 0, 0, 0, time.UTC), start.UTC())
+}
+
+func TestStartOfNextDayWillSpanDSTChanges(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	spring := StartOfDay(time.Date(2024, 3, 31, 12, 0, 0, 0, loc))
+	autumn := StartOfDay(time.Date(2024, 10, 27, 12, 0, 0, 0, loc))
+
+	assert.Equal(t, 23*time.Hour, StartOfNextDay(spring).Sub(spring))
+	assert.Equal(t, 25*time.Hour, StartOfNextDay(autumn).Sub(autumn))
+	assert.Equal(t, time.Date(2024, 10, 28, 0, 0, 0, 0, loc), StartOfNextDay(autumn))
+}
+
+func TestParseDateWillStartDayInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	day, err := ParseDate("2024-03-10", loc)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 10, 5, 0, 0, 0, time.UTC), day.UTC())
+	assert.Equal(t, time.Date(2024, 3, 11, 4, 0, 0, 0, time.UTC), StartOfNextDay(day).UTC())
+}
+
+func TestParseDateWillRejectInvalidDate(t *testing.T) {
+	_, err := ParseDate("10.03.2024", time.UTC)
+
+	assert.Error(t, err)
+}
+
+func TestInWithoutLocationWillKeepTime(t *testing.T) {
+	at := time.Date(2024, 9, 2, 8, 0, 0, 0, time.FixedZone("test", 3600))
+
+	assert.Equal(t, at, In(at, nil))
+}
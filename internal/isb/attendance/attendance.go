@@ -0,0 +1,338 @@
+package attendance
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type ScanRequest struct {
+	DeviceID string `json:"device_id"`
+	VisitKey string `json:"visit_key"`
+}
+
+type ScanResponse struct {
+	Visitor     *entity.Visitor `json:"visitor"`
+	Lesson      *entity.Lesson  `json:"lesson"`
+	Date        string          `json:"date"`
+	Status      string          `json:"status"`
+	MinutesLate int             `json:"minutes_late"`
+}
+
+// AdjustRequest is used by teachers to correct a register mark.
+type AdjustRequest struct {
+	Date        string `json:"date"`
+	VisitorID   int32  `json:"visitor_id"`
+	Status      string `json:"status"`
+	MinutesLate int    `json:"minutes_late"`
+	Note        string `json:"note"`
+}
+
+type RegisterEntry struct {
+	Visitor     *entity.Visitor `json:"visitor"`
+	Status      string          `json:"status"`
+	ScannedAt   *time.Time      `json:"scanned_at"`
+	MinutesLate int             `json:"minutes_late"`
+	Note        string          `json:"note"`
+}
+
+type Register struct {
+	Lesson  *entity.Lesson   `json:"lesson"`
+	Date    string           `json:"date"`
+	Entries []*RegisterEntry `json:"entries"`
+	Totals  map[string]int   `json:"totals"`
+}
+
+type AttendanceController struct {
+	AttendanceRepository entity.AttendanceRepository `container:"type"`
+	TimetableRepository  entity.TimetableRepository  `container:"type"`
+	DeviceRepository     entity.DeviceRepository     `container:"type"`
+	VisitorRepository    entity.VisitorRepository    `container:"type"`
+	Config               *config.Config              `container:"type"`
+	Now                  func() time.Time
+	Location             *time.Location `container:"type"`
+}
+
+// ScanHandler records a scan at a room reader as attendance for the current lesson.
+func (ac *AttendanceController) ScanHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request ScanRequest
+		if err := ctx.Bind(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		device, err := ac.DeviceRepository.FindById(request.DeviceID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if device == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Device not exists"))))
+			return
+		}
+		if !device.InRoom() {
+			ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(errors.New("Device is not bound to a room"))))
+			return
+		}
+		details, err := ac.VisitorRepository.FindByKey(ctx.Request.Context(), request.VisitKey)
+		if errors.Is(err, entity.ErrQueryTimeout) {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		if err != nil || details == nil || details.Visitor == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor not exists"))))
+			return
+		}
+
+		now := ac.now()
+		lessons, err := ac.TimetableRepository.FindLessonsByRoom(device.RoomId, now.Weekday())
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		lesson := CurrentLesson(lessons, now, ac.policy())
+		if lesson == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("No lesson in progress"))))
+			return
+		}
+		member, err := ac.TimetableRepository.IsClassMember(lesson.ClassId, details.Visitor.Id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if !member {
+			ctx.JSON(http.StatusForbidden, util.NewFailureResponse(exception.Forbidden(errors.New("Visitor is not on the class roster"))))
+			return
+		}
+
+		date := now.Format(dateLayout)
+		existing, err := ac.AttendanceRepository.FindOne(lesson.Id, details.Visitor.Id, date)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		// Repeated scans keep the first mark.
+		if existing != nil && existing.ScannedAt != nil {
+			ctx.JSON(http.StatusOK, ScanResponse{
+				Visitor:     details.Visitor,
+				Lesson:      lesson,
+				Date:        date,
+				Status:      existing.Status,
+				MinutesLate: existing.MinutesLate,
+			})
+			return
+		}
+
+		status, minutesLate, err := Classify(lesson, now, ac.policy())
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		mark := &entity.Attendance{
+			LessonId:    lesson.Id,
+			VisitorId:   details.Visitor.Id,
+			Date:        date,
+			Status:      status,
+			ScannedAt:   &now,
+			MinutesLate: minutesLate,
+		}
+		if existing != nil {
+			mark.Note = existing.Note
+		}
+		if err := ac.AttendanceRepository.Save(mark); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, ScanResponse{
+			Visitor:     details.Visitor,
+			Lesson:      lesson,
+			Date:        date,
+			Status:      status,
+			MinutesLate: minutesLate,
+		})
+	}
+}
+
+// RegisterHandler returns the attendance sheet of a lesson for a date.
+func (ac *AttendanceController) RegisterHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		lesson, ok := ac.lessonFromParam(ctx)
+		if !ok {
+			return
+		}
+		date := ctx.DefaultQuery("date", ac.now().Format(dateLayout))
+		if _, err := time.Parse(dateLayout, date); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid date, expected YYYY-MM-DD"))))
+			return
+		}
+		ac.respondWithRegister(ctx, lesson, date)
+	}
+}
+
+// AdjustHandler lets the teacher change a mark in the register.
+func (ac *AttendanceController) AdjustHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request AdjustRequest
+		if err := ctx.Bind(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		if request.Date == "" {
+			request.Date = ac.now().Format(dateLayout)
+		}
+		if _, err := time.Parse(dateLayout, request.Date); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid date, expected YYYY-MM-DD"))))
+			return
+		}
+		if !util.InArray(request.Status, entity.AttendanceStatuses) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid status"))))
+			return
+		}
+		lesson, ok := ac.lessonFromParam(ctx)
+		if !ok {
+			return
+		}
+		member, err := ac.TimetableRepository.IsClassMember(lesson.ClassId, request.VisitorID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if !member {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor is not on the class roster"))))
+			return
+		}
+		existing, err := ac.AttendanceRepository.FindOne(lesson.Id, request.VisitorID, request.Date)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		mark := &entity.Attendance{
+			LessonId:    lesson.Id,
+			VisitorId:   request.VisitorID,
+			Date:        request.Date,
+			Status:      request.Status,
+			MinutesLate: request.MinutesLate,
+			Note:        request.Note,
+		}
+		if request.Status != entity.AttendanceLate {
+			mark.MinutesLate = 0
+		}
+		if existing != nil {
+			mark.ScannedAt = existing.ScannedAt
+		}
+		if err := ac.AttendanceRepository.Save(mark); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ac.respondWithRegister(ctx, lesson, request.Date)
+	}
+}
+
+// TeacherLessonsHandler lists the lessons a teacher has on a date.
+func (ac *AttendanceController) TeacherLessonsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		teacherId, err := strconv.ParseInt(ctx.Param("id"), 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid teacher id"))))
+			return
+		}
+		date, err := time.Parse(dateLayout, ctx.DefaultQuery("date", ac.now().Format(dateLayout)))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid date, expected YYYY-MM-DD"))))
+			return
+		}
+		lessons, err := ac.TimetableRepository.FindLessonsByTeacher(int32(teacherId), date.Weekday())
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, lessons)
+	}
+}
+
+func (ac *AttendanceController) respondWithRegister(ctx *gin.Context, lesson *entity.Lesson, date string) {
+	members, err := ac.TimetableRepository.FindClassMembers(lesson.ClassId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return
+	}
+	marks, err := ac.AttendanceRepository.FindByLessonAndDate(lesson.Id, date)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return
+	}
+	ctx.JSON(http.StatusOK, NewRegister(lesson, date, members, marks))
+}
+
+func (ac *AttendanceController) lessonFromParam(ctx *gin.Context) (*entity.Lesson, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid lesson id"))))
+		return nil, false
+	}
+	lesson, err := ac.TimetableRepository.FindLessonById(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return nil, false
+	}
+	if lesson == nil {
+		ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Lesson not exists"))))
+		return nil, false
+	}
+	return lesson, true
+}
+
+func (ac *AttendanceController) policy() Policy {
+	if ac.Config == nil {
+		return Policy{}
+	}
+	return Policy{
+		EarlyScan: time.Duration(ac.Config.AttendanceEarlyScanMinutes) * time.Minute,
+		LateAfter: time.Duration(ac.Config.AttendanceLateAfterMinutes) * time.Minute,
+	}
+}
+
+func (ac *AttendanceController) now() time.Time {
+	if ac.Now != nil {
+		return util.In(ac.Now(), ac.Location)
+	}
+	return util.In(time.Now(), ac.Location)
+}
+
+// NewRegister combines the class roster with the marks, members without a mark are absent.
+func NewRegister(lesson *entity.Lesson, date string, members []*entity.Visitor, marks []*entity.Attendance) Register {
+	byVisitor := map[int32]*entity.Attendance{}
+	for _, mark := range marks {
+		byVisitor[mark.VisitorId] = mark
+	}
+	register := Register{
+		Lesson:  lesson,
+		Date:    date,
+		Entries: make([]*RegisterEntry, 0, len(members)),
+		Totals:  map[string]int{},
+	}
+	for _, status := range entity.AttendanceStatuses {
+		register.Totals[status] = 0
+	}
+	for _, member := range members {
+		entry := &RegisterEntry{Visitor: member, Status: entity.AttendanceAbsent}
+		if mark, ok := byVisitor[member.Id]; ok {
+			entry.Status = mark.Status
+			entry.ScannedAt = mark.ScannedAt
+			entry.MinutesLate = mark.MinutesLate
+			entry.Note = mark.Note
+		}
+		register.Totals[entry.Status]++
+		register.Entries = append(register.Entries, entry)
+	}
+	return register
+}
@@ -0,0 +1,374 @@
+package attendance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAttendanceRepository is a mock implementation of entity.AttendanceRepository
+type MockAttendanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttendanceRepository) FindOne(lessonId int64, visitorId int32, date string) (*entity.Attendance, error) {
+	args := m.Called(lessonId, visitorId, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Attendance), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) FindByLessonAndDate(lessonId int64, date string) ([]*entity.Attendance, error) {
+	args := m.Called(lessonId, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Attendance), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) Save(attendance *entity.Attendance) error {
+	args := m.Called(attendance)
+	return args.Error(0)
+}
+
+// MockTimetableRepository is a mock implementation of entity.TimetableRepository
+type MockTimetableRepository struct {
+	mock.Mock
+}
+
+func (m *MockTimetableRepository) CreateRoom(room *entity.Room) error {
+	return m.Called(room).Error(0)
+}
+
+func (m *MockTimetableRepository) FindRooms() ([]*entity.Room, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.Room), args.Error(1)
+}
+
+func (m *MockTimetableRepository) CreatePeriod(period *entity.Period) error {
+	return m.Called(period).Error(0)
+}
+
+func (m *MockTimetableRepository) FindPeriods() ([]*entity.Period, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.Period), args.Error(1)
+}
+
+func (m *MockTimetableRepository) CreateClass(class *entity.SchoolClass) error {
+	return m.Called(class).Error(0)
+}
+
+func (m *MockTimetableRepository) FindClassById(id int64) (*entity.SchoolClass, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SchoolClass), args.Error(1)
+}
+
+func (m *MockTimetableRepository) SetClassMembers(classId int64, visitorIds []int32) error {
+	return m.Called(classId, visitorIds).Error(0)
+}
+
+func (m *MockTimetableRepository) FindClassMembers(classId int64) ([]*entity.Visitor, error) {
+	args := m.Called(classId)
+	return args.Get(0).([]*entity.Visitor), args.Error(1)
+}
+
+func (m *MockTimetableRepository) IsClassMember(classId int64, visitorId int32) (bool, error) {
+	args := m.Called(classId, visitorId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTimetableRepository) CreateLesson(lesson *entity.Lesson) error {
+	return m.Called(lesson).Error(0)
+}
+
+func (m *MockTimetableRepository) FindLessonById(id int64) (*entity.Lesson, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Lesson), args.Error(1)
+}
+
+func (m *MockTimetableRepository) FindLessonsByRoom(roomId int64, weekday time.Weekday) ([]*entity.Lesson, error) {
+	args := m.Called(roomId, weekday)
+	return args.Get(0).([]*entity.Lesson), args.Error(1)
+}
+
+func (m *MockTimetableRepository) FindLessonsByTeacher(teacherId int32, weekday time.Weekday) ([]*entity.Lesson, error) {
+	args := m.Called(teacherId, weekday)
+	return args.Get(0).([]*entity.Lesson), args.Error(1)
+}
+
+// MockDeviceRepository is a mock implementation of entity.DeviceRepository
+type MockDeviceRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeviceRepository) FindById(id string) (*entity.Device, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Device), args.Error(1)
+}
+
+func (m *MockDeviceRepository) FindAll() ([]*entity.Device, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.Device), args.Error(1)
+}
+
+func (m *MockDeviceRepository) Save(device *entity.Device) error {
+	return m.Called(device).Error(0)
+}
+
+// MockVisitorRepository is a mock implementation of entity.VisitorRepository
+type MockVisitorRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorRepository) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Visitor), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
+	args := m.Called(visitor, key)
+	return args.Error(0)
+}
+
+type testMocks struct {
+	attendance *MockAttendanceRepository
+	timetable  *MockTimetableRepository
+	devices    *MockDeviceRepository
+	visitors   *MockVisitorRepository
+}
+
+// newTestController returns a controller frozen at Monday 2024-09-02 08:42.
+func newTestController() (*AttendanceController, testMocks) {
+	mocks := testMocks{
+		attendance: new(MockAttendanceRepository),
+		timetable:  new(MockTimetableRepository),
+		devices:    new(MockDeviceRepository),
+		visitors:   new(MockVisitorRepository),
+	}
+	controller := &AttendanceController{
+		AttendanceRepository: mocks.attendance,
+		TimetableRepository:  mocks.timetable,
+		DeviceRepository:     mocks.devices,
+		VisitorRepository:    mocks.visitors,
+		Config:               &config.Config{AttendanceEarlyScanMinutes: 10, AttendanceLateAfterMinutes: 5},
+		Now: func() time.Time {
+			return time.Date(2024, 9, 2, 8, 42, 0, 0, time.UTC)
+		},
+	}
+	return controller, mocks
+}
+
+func performJSON(handler gin.HandlerFunc, method string, target string, body any, params gin.Params) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, target, bytes.NewBuffer(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	handler(c)
+	return w
+}
+
+func TestScanHandler_RecordsLateAttendance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	visitor := &entity.Visitor{Id: 7, Name: "John"}
+	lesson := newLesson(3, time.Monday, "08:30", "09:15")
+	mocks.devices.On("FindById", "room-101").Return(&entity.Device{Id: "room-101", RoomId: 101}, nil)
+	mocks.visitors.On("FindByKey", "KEY7").Return(&entity.VisitDetails{Visitor: visitor, Key: "KEY7"}, nil)
+	mocks.timetable.On("FindLessonsByRoom", int64(101), time.Monday).Return([]*entity.Lesson{lesson}, nil)
+	mocks.timetable.On("IsClassMember", lesson.ClassId, int32(7)).Return(true, nil)
+	mocks.attendance.On("FindOne", int64(3), int32(7), "2024-09-02").Return(nil, nil)
+	mocks.attendance.On("Save", mock.MatchedBy(func(a *entity.Attendance) bool {
+		return a.LessonId == 3 && a.VisitorId == 7 && a.Status == entity.AttendanceLate && a.MinutesLate == 12 && a.ScannedAt != nil
+	})).Return(nil)
+
+	w := performJSON(controller.ScanHandler(), "POST", "/api/attendance/scan", ScanRequest{DeviceID: "room-101", VisitKey: "KEY7"}, nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ScanResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, entity.AttendanceLate, response.Status)
+	assert.Equal(t, 12, response.MinutesLate)
+	mocks.attendance.AssertExpectations(t)
+}
+
+func TestScanHandler_RepeatedScanKeepsFirstMark(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	scannedAt := time.Date(2024, 9, 2, 8, 28, 0, 0, time.UTC)
+	lesson := newLesson(3, time.Monday, "08:30", "09:15")
+	mocks.devices.On("FindById", "room-101").Return(&entity.Device{Id: "room-101", RoomId: 101}, nil)
+	mocks.visitors.On("FindByKey", "KEY7").Return(&entity.VisitDetails{Visitor: &entity.Visitor{Id: 7}}, nil)
+	mocks.timetable.On("FindLessonsByRoom", int64(101), time.Monday).Return([]*entity.Lesson{lesson}, nil)
+	mocks.timetable.On("IsClassMember", lesson.ClassId, int32(7)).Return(true, nil)
+	mocks.attendance.On("FindOne", int64(3), int32(7), "2024-09-02").Return(&entity.Attendance{
+		Status:    entity.AttendancePresent,
+		ScannedAt: &scannedAt,
+	}, nil)
+
+	w := performJSON(controller.ScanHandler(), "POST", "/api/attendance/scan", ScanRequest{DeviceID: "room-101", VisitKey: "KEY7"}, nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ScanResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, entity.AttendancePresent, response.Status)
+	mocks.attendance.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestScanHandler_DeviceNotInRoom(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.devices.On("FindById", "entrance").Return(&entity.Device{Id: "entrance"}, nil)
+
+	w := performJSON(controller.ScanHandler(), "POST", "/api/attendance/scan", ScanRequest{DeviceID: "entrance", VisitKey: "KEY7"}, nil)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestScanHandler_NoLessonInProgress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.devices.On("FindById", "room-101").Return(&entity.Device{Id: "room-101", RoomId: 101}, nil)
+	mocks.visitors.On("FindByKey", "KEY7").Return(&entity.VisitDetails{Visitor: &entity.Visitor{Id: 7}}, nil)
+	mocks.timetable.On("FindLessonsByRoom", int64(101), time.Monday).Return([]*entity.Lesson{
+		newLesson(3, time.Monday, "10:00", "10:45"),
+	}, nil)
+
+	w := performJSON(controller.ScanHandler(), "POST", "/api/attendance/scan", ScanRequest{DeviceID: "room-101", VisitKey: "KEY7"}, nil)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestScanHandler_VisitorNotOnRoster(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	lesson := newLesson(3, time.Monday, "08:30", "09:15")
+	mocks.devices.On("FindById", "room-101").Return(&entity.Device{Id: "room-101", RoomId: 101}, nil)
+	mocks.visitors.On("FindByKey", "KEY7").Return(&entity.VisitDetails{Visitor: &entity.Visitor{Id: 7}}, nil)
+	mocks.timetable.On("FindLessonsByRoom", int64(101), time.Monday).Return([]*entity.Lesson{lesson}, nil)
+	mocks.timetable.On("IsClassMember", lesson.ClassId, int32(7)).Return(false, nil)
+
+	w := performJSON(controller.ScanHandler(), "POST", "/api/attendance/scan", ScanRequest{DeviceID: "room-101", VisitKey: "KEY7"}, nil)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mocks.attendance.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestRegisterHandler_MarksMissingMembersAbsent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	lesson := newLesson(3, time.Monday, "08:30", "09:15")
+	mocks.timetable.On("FindLessonById", int64(3)).Return(lesson, nil)
+	mocks.timetable.On("FindClassMembers", lesson.ClassId).Return([]*entity.Visitor{{Id: 7}, {Id: 8}, {Id: 9}}, nil)
+	mocks.attendance.On("FindByLessonAndDate", int64(3), "2024-09-02").Return([]*entity.Attendance{
+		{VisitorId: 7, Status: entity.AttendancePresent},
+		{VisitorId: 8, Status: entity.AttendanceLate, MinutesLate: 7},
+	}, nil)
+
+	w := performJSON(controller.RegisterHandler(), "GET", "/api/attendance/lessons/3?date=2024-09-02", nil, gin.Params{{Key: "id", Value: "3"}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var register Register
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &register))
+	assert.Len(t, register.Entries, 3)
+	assert.Equal(t, entity.AttendanceAbsent, register.Entries[2].Status)
+	assert.Equal(t, 7, register.Entries[1].MinutesLate)
+	assert.Equal(t, map[string]int{"present": 1, "late": 1, "absent": 1, "excused": 0}, register.Totals)
+}
+
+func TestAdjustHandler_ExcusesVisitor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	scannedAt := time.Date(2024, 9, 2, 8, 42, 0, 0, time.UTC)
+	lesson := newLesson(3, time.Monday, "08:30", "09:15")
+	mocks.timetable.On("FindLessonById", int64(3)).Return(lesson, nil)
+	mocks.timetable.On("IsClassMember", lesson.ClassId, int32(8)).Return(true, nil)
+	mocks.attendance.On("FindOne", int64(3), int32(8), "2024-09-02").Return(&entity.Attendance{
+		Status:      entity.AttendanceLate,
+		MinutesLate: 12,
+		ScannedAt:   &scannedAt,
+	}, nil)
+	mocks.attendance.On("Save", mock.MatchedBy(func(a *entity.Attendance) bool {
+		return a.Status == entity.AttendanceExcused && a.MinutesLate == 0 && a.ScannedAt == &scannedAt && a.Note == "dentist"
+	})).Return(nil)
+	mocks.timetable.On("FindClassMembers", lesson.ClassId).Return([]*entity.Visitor{{Id: 8}}, nil)
+	mocks.attendance.On("FindByLessonAndDate", int64(3), "2024-09-02").Return([]*entity.Attendance{
+		{VisitorId: 8, Status: entity.AttendanceExcused, Note: "dentist"},
+	}, nil)
+
+	request := AdjustRequest{Date: "2024-09-02", VisitorID: 8, Status: entity.AttendanceExcused, Note: "dentist"}
+	w := performJSON(controller.AdjustHandler(), "PATCH", "/api/attendance/lessons/3", request, gin.Params{{Key: "id", Value: "3"}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mocks.attendance.AssertExpectations(t)
+}
+
+func TestAdjustHandler_InvalidStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	request := AdjustRequest{Date: "2024-09-02", VisitorID: 8, Status: "sleeping"}
+	w := performJSON(controller.AdjustHandler(), "PATCH", "/api/attendance/lessons/3", request, gin.Params{{Key: "id", Value: "3"}})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mocks.attendance.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestTeacherLessonsHandler_UsesWeekdayOfDate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.timetable.On("FindLessonsByTeacher", int32(4), time.Tuesday).Return([]*entity.Lesson{newLesson(3, time.Tuesday, "08:30", "09:15")}, nil)
+
+	w := performJSON(controller.TeacherLessonsHandler(), "GET", "/api/attendance/teachers/4/lessons?date=2024-09-03", nil, gin.Params{{Key: "id", Value: "4"}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mocks.timetable.AssertExpectations(t)
+}
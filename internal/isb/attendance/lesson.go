@@ -0,0 +1,49 @@
+package attendance
+
+import (
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// Policy defines when room scans count for a lesson and when they are late.
+type Policy struct {
+	// EarlyScan allows scanning in before the period starts.
+	EarlyScan time.Duration
+	// LateAfter is the grace time after the period start before a scan is late.
+	LateAfter time.Duration
+}
+
+// CurrentLesson returns the lesson running at the given time, nil when the room is free.
+func CurrentLesson(lessons []*entity.Lesson, now time.Time, policy Policy) *entity.Lesson {
+	for _, lesson := range lessons {
+		if lesson.Period == nil || lesson.Weekday != now.Weekday() {
+			continue
+		}
+		start, err := lesson.Period.Start(now)
+		if err != nil {
+			continue
+		}
+		end, err := lesson.Period.End(now)
+		if err != nil {
+			continue
+		}
+		if !now.Before(start.Add(-policy.EarlyScan)) && now.Before(end) {
+			return lesson
+		}
+	}
+	return nil
+}
+
+// Classify returns the register status and minutes late for a scan in the lesson.
+func Classify(lesson *entity.Lesson, scannedAt time.Time, policy Policy) (string, int, error) {
+	start, err := lesson.Period.Start(scannedAt)
+	if err != nil {
+		return "", 0, err
+	}
+	late := scannedAt.Sub(start)
+	if late <= policy.LateAfter {
+		return entity.AttendancePresent, 0, nil
+	}
+	return entity.AttendanceLate, int(late.Minutes()), nil
+}
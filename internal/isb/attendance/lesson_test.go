@@ -0,0 +1,67 @@
+package attendance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPolicy = Policy{EarlyScan: 10 * time.Minute, LateAfter: 5 * time.Minute}
+
+func newLesson(id int64, weekday time.Weekday, startsAt, endsAt string) *entity.Lesson {
+	return &entity.Lesson{
+		Id:      id,
+		ClassId: id * 10,
+		Weekday: weekday,
+		Period:  &entity.Period{StartsAt: startsAt, EndsAt: endsAt},
+	}
+}
+
+func TestCurrentLessonWillFindRunningLesson(t *testing.T) {
+	// 2024-09-02 is a Monday
+	lessons := []*entity.Lesson{
+		newLesson(1, time.Monday, "08:30", "09:15"),
+		newLesson(2, time.Monday, "09:20", "10:05"),
+	}
+
+	assert.Equal(t, int64(1), CurrentLesson(lessons, time.Date(2024, 9, 2, 8, 25, 0, 0, time.UTC), testPolicy).Id)
+	assert.Equal(t, int64(1), CurrentLesson(lessons, time.Date(2024, 9, 2, 9, 0, 0, 0, time.UTC), testPolicy).Id)
+	assert.Equal(t, int64(2), CurrentLesson(lessons, time.Date(2024, 9, 2, 9, 15, 0, 0, time.UTC), testPolicy).Id)
+}
+
+func TestCurrentLessonOutsidePeriodsWillReturnNil(t *testing.T) {
+	lessons := []*entity.Lesson{newLesson(1, time.Monday, "08:30", "09:15")}
+
+	assert.Nil(t, CurrentLesson(lessons, time.Date(2024, 9, 2, 8, 19, 0, 0, time.UTC), testPolicy))
+	assert.Nil(t, CurrentLesson(lessons, time.Date(2024, 9, 2, 9, 15, 0, 0, time.UTC), testPolicy))
+	assert.Nil(t, CurrentLesson(lessons, time.Date(2024, 9, 3, 8, 40, 0, 0, time.UTC), testPolicy))
+}
+
+func TestClassifyWillApplyLateGrace(t *testing.T) {
+	lesson := newLesson(1, time.Monday, "08:30", "09:15")
+
+	status, minutes, err := Classify(lesson, time.Date(2024, 9, 2, 8, 25, 0, 0, time.UTC), testPolicy)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AttendancePresent, status)
+	assert.Equal(t, 0, minutes)
+
+	status, minutes, err = Classify(lesson, time.Date(2024, 9, 2, 8, 35, 0, 0, time.UTC), testPolicy)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AttendancePresent, status)
+	assert.Equal(t, 0, minutes)
+
+	status, minutes, err = Classify(lesson, time.Date(2024, 9, 2, 8, 42, 30, 0, time.UTC), testPolicy)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AttendanceLate, status)
+	assert.Equal(t, 12, minutes)
+}
+
+func TestClassifyWithInvalidPeriodWillReturnError(t *testing.T) {
+	lesson := newLesson(1, time.Monday, "8h30", "09:15")
+
+	_, _, err := Classify(lesson, time.Now(), testPolicy)
+
+	assert.Error(t, err)
+}
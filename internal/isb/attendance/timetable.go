@@ -0,0 +1,218 @@
+package attendance
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+type RoomRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type PeriodRequest struct {
+	Name     string `json:"name" binding:"required"`
+	StartsAt string `json:"starts_at" binding:"required"`
+	EndsAt   string `json:"ends_at" binding:"required"`
+}
+
+type ClassRequest struct {
+	Name      string `json:"name" binding:"required"`
+	TeacherID int32  `json:"teacher_id"`
+}
+
+type MembersRequest struct {
+	VisitorIDs []int32 `json:"visitor_ids"`
+}
+
+type LessonRequest struct {
+	ClassID  int64 `json:"class_id" binding:"required"`
+	PeriodID int64 `json:"period_id" binding:"required"`
+	RoomID   int64 `json:"room_id" binding:"required"`
+	// Weekday as in time.Weekday: 0 is Sunday, 1 is Monday.
+	Weekday int `json:"weekday"`
+}
+
+// TimetableController manages rooms, periods, classes with their rosters and lessons.
+type TimetableController struct {
+	TimetableRepository entity.TimetableRepository `container:"type"`
+}
+
+func (tc *TimetableController) CreateRoomHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request RoomRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		room := &entity.Room{Name: request.Name}
+		if err := tc.TimetableRepository.CreateRoom(room); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusCreated, room)
+	}
+}
+
+func (tc *TimetableController) RoomsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		rooms, err := tc.TimetableRepository.FindRooms()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, rooms)
+	}
+}
+
+func (tc *TimetableController) CreatePeriodHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request PeriodRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		period := &entity.Period{
+			Name:     request.Name,
+			StartsAt: request.StartsAt,
+			EndsAt:   request.EndsAt,
+		}
+		start, err := period.Start(time.Now())
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		end, err := period.End(time.Now())
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		if !end.After(start) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Period must end after it starts"))))
+			return
+		}
+		if err := tc.TimetableRepository.CreatePeriod(period); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusCreated, period)
+	}
+}
+
+func (tc *TimetableController) PeriodsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		periods, err := tc.TimetableRepository.FindPeriods()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, periods)
+	}
+}
+
+func (tc *TimetableController) CreateClassHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request ClassRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		class := &entity.SchoolClass{Name: request.Name, TeacherId: request.TeacherID}
+		if err := tc.TimetableRepository.CreateClass(class); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusCreated, class)
+	}
+}
+
+// SetMembersHandler replaces the roster of a class.
+func (tc *TimetableController) SetMembersHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request MembersRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		class, ok := tc.classFromParam(ctx)
+		if !ok {
+			return
+		}
+		if err := tc.TimetableRepository.SetClassMembers(class.Id, request.VisitorIDs); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		tc.respondWithMembers(ctx, class)
+	}
+}
+
+func (tc *TimetableController) MembersHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		class, ok := tc.classFromParam(ctx)
+		if !ok {
+			return
+		}
+		tc.respondWithMembers(ctx, class)
+	}
+}
+
+func (tc *TimetableController) CreateLessonHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request LessonRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		if request.Weekday < int(time.Sunday) || request.Weekday > int(time.Saturday) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid weekday"))))
+			return
+		}
+		lesson := &entity.Lesson{
+			ClassId:  request.ClassID,
+			PeriodId: request.PeriodID,
+			RoomId:   request.RoomID,
+			Weekday:  time.Weekday(request.Weekday),
+		}
+		if err := tc.TimetableRepository.CreateLesson(lesson); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusCreated, lesson)
+	}
+}
+
+func (tc *TimetableController) respondWithMembers(ctx *gin.Context, class *entity.SchoolClass) {
+	members, err := tc.TimetableRepository.FindClassMembers(class.Id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"class":   class,
+		"members": members,
+	})
+}
+
+func (tc *TimetableController) classFromParam(ctx *gin.Context) (*entity.SchoolClass, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid class id"))))
+		return nil, false
+	}
+	class, err := tc.TimetableRepository.FindClassById(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return nil, false
+	}
+	if class == nil {
+		ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Class not exists"))))
+		return nil, false
+	}
+	return class, true
+}
@@ -0,0 +1,139 @@
+package consequence
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+type ResolveRequest struct {
+	Status string `json:"status" binding:"required"`
+	Note   string `json:"note"`
+}
+
+type ConsequenceController struct {
+	Engine                    *Engine                          `container:"type"`
+	ConsequenceRepository     entity.ConsequenceRepository     `container:"type"`
+	ConsequenceRuleRepository entity.ConsequenceRuleRepository `container:"type"`
+	Now                       func() time.Time
+	Location                  *time.Location `container:"type"`
+}
+
+// ListHandler lists consequences by ?status, pending by default.
+func (cc *ConsequenceController) ListHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		consequences, err := cc.ConsequenceRepository.FindByStatus(ctx.Request.Context(), ctx.DefaultQuery("status", entity.ConsequencePending))
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, consequences)
+	}
+}
+
+// ResolveHandler marks a pending consequence as done or cancels it.
+func (cc *ConsequenceController) ResolveHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request ResolveRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		if request.Status != entity.ConsequenceDone && request.Status != entity.ConsequenceCancelled {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid status, expected done or cancelled"))))
+			return
+		}
+		id, ok := idFromParam(ctx, "Invalid consequence id")
+		if !ok {
+			return
+		}
+		consequence, err := cc.ConsequenceRepository.GetById(ctx.Request.Context(), id)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		if consequence == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Consequence not exists"))))
+			return
+		}
+		now := cc.now()
+		if err := cc.ConsequenceRepository.UpdateStatus(ctx.Request.Context(), id, request.Status, request.Note, now); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		consequence.Status = request.Status
+		consequence.Note = request.Note
+		consequence.ResolvedAt = &now
+		ctx.JSON(http.StatusOK, consequence)
+	}
+}
+
+// RulesHandler lists config and database rules together.
+func (cc *ConsequenceController) RulesHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		rules, err := cc.Engine.AllRules(ctx.Request.Context())
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, rules)
+	}
+}
+
+func (cc *ConsequenceController) CreateRuleHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var rule entity.ConsequenceRule
+		if err := ctx.ShouldBindJSON(&rule); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		if err := ValidateRule(&rule); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		if err := cc.ConsequenceRuleRepository.Store(ctx.Request.Context(), &rule); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusCreated, rule)
+	}
+}
+
+// DeleteRuleHandler removes a database rule, config rules change with the config.
+func (cc *ConsequenceController) DeleteRuleHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, ok := idFromParam(ctx, "Invalid rule id")
+		if !ok {
+			return
+		}
+		if err := cc.ConsequenceRuleRepository.Delete(ctx.Request.Context(), id); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "Rule deleted",
+		})
+	}
+}
+
+func (cc *ConsequenceController) now() time.Time {
+	if cc.Now != nil {
+		return util.In(cc.Now(), cc.Location)
+	}
+	return util.In(time.Now(), cc.Location)
+}
+
+func idFromParam(ctx *gin.Context, message string) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New(message))))
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,100 @@
+package consequence
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func perform(handler gin.HandlerFunc, method string, path string, payload any, params ...gin.Param) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	handler(c)
+	return w
+}
+
+func TestListHandler_DefaultsToPending(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	consequences := new(MockConsequenceRepository)
+	controller := &ConsequenceController{ConsequenceRepository: consequences}
+
+	consequences.On("FindByStatus", entity.ConsequencePending).Return([]*entity.Consequence{
+		{Id: 1, Rule: "3 lates a week", VisitorId: 7, Action: entity.ConsequenceDetention, Status: entity.ConsequencePending},
+	}, nil)
+
+	w := perform(controller.ListHandler(), "GET", "/api/consequences", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []*entity.Consequence
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+}
+
+func TestResolveHandler_Cancel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	consequences := new(MockConsequenceRepository)
+	now := time.Date(2024, 9, 6, 12, 0, 0, 0, time.UTC)
+	controller := &ConsequenceController{ConsequenceRepository: consequences, Now: func() time.Time { return now }}
+
+	consequences.On("GetById", int64(1)).Return(&entity.Consequence{Id: 1, Status: entity.ConsequencePending}, nil)
+	consequences.On("UpdateStatus", int64(1), entity.ConsequenceCancelled, "bus strike", now).Return(nil)
+
+	w := perform(controller.ResolveHandler(), "PATCH", "/api/consequences/1",
+		ResolveRequest{Status: entity.ConsequenceCancelled, Note: "bus strike"}, gin.Param{Key: "id", Value: "1"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	consequences.AssertExpectations(t)
+}
+
+func TestResolveHandler_InvalidStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	consequences := new(MockConsequenceRepository)
+	controller := &ConsequenceController{ConsequenceRepository: consequences}
+
+	w := perform(controller.ResolveHandler(), "PATCH", "/api/consequences/1",
+		ResolveRequest{Status: entity.ConsequencePending}, gin.Param{Key: "id", Value: "1"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRulesHandler_MergesConfigAndDatabase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rules := new(MockConsequenceRuleRepository)
+	controller := &ConsequenceController{Engine: &Engine{Rules: []*entity.ConsequenceRule{weekly}, RuleRepository: rules}}
+
+	rules.On("FindAll").Return([]*entity.ConsequenceRule{
+		{Id: 2, Name: "monthly", Threshold: 8, WindowDays: 30, Action: entity.ConsequenceNotify, Source: entity.RuleSourceDatabase},
+	}, nil)
+
+	w := perform(controller.RulesHandler(), "GET", "/api/consequence-rules", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []*entity.ConsequenceRule
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+	assert.Equal(t, "monthly", response[1].Name)
+}
+
+func TestCreateRuleHandler_ValidatesRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rules := new(MockConsequenceRuleRepository)
+	controller := &ConsequenceController{ConsequenceRuleRepository: rules}
+
+	w := perform(controller.CreateRuleHandler(), "POST", "/api/consequence-rules",
+		entity.ConsequenceRule{Name: "bad", Threshold: 0, WindowDays: 7, Action: entity.ConsequenceDetention})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	rules.AssertNotCalled(t, "Store", mock.Anything)
+}
@@ -0,0 +1,87 @@
+package consequence
+
+import (
+	"errors"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const (
+	// deliveryBuffer is how many notifications may wait for delivery before
+	// new ones fail.
+	deliveryBuffer = 256
+	// deliveryTimeout is how long a notification is waited for.
+	deliveryTimeout = 10 * time.Second
+)
+
+var (
+	ErrDeliveryQueueFull = errors.New("delivery queue is full")
+	ErrDeliveryTimeout   = errors.New("delivery timed out")
+)
+
+type delivery struct {
+	notifier     entity.Notifier
+	notification entity.Notification
+	done         func(err error)
+}
+
+// Deliveries delivers the notifications of consequences in the background,
+// a slow or unreachable webhook does not hold up the late sign-in which
+// raised the consequence. One queue serves all sites, containers are
+// rebuilt when a site changes.
+type Deliveries struct {
+	timeout time.Duration
+	queue   chan delivery
+}
+
+func NewDeliveries() *Deliveries {
+	return newDeliveries(deliveryBuffer, deliveryTimeout)
+}
+
+func newDeliveries(buffer int, timeout time.Duration) *Deliveries {
+	d := &Deliveries{
+		timeout: timeout,
+		queue:   make(chan delivery, buffer),
+	}
+	go d.run()
+	return d
+}
+
+// Send queues the notification, done is called with the outcome of its
+// delivery. A full queue fails it with ErrDeliveryQueueFull right away. A
+// nil Deliveries delivers the notification before it returns.
+func (d *Deliveries) Send(notifier entity.Notifier, notification entity.Notification, done func(err error)) {
+	if d == nil {
+		done(notifier.Notify(notification))
+		return
+	}
+	select {
+	case d.queue <- delivery{notifier: notifier, notification: notification, done: done}:
+	default:
+		done(ErrDeliveryQueueFull)
+	}
+}
+
+func (d *Deliveries) run() {
+	for delivery := range d.queue {
+		delivery.done(d.deliver(delivery))
+	}
+}
+
+// deliver fails with ErrDeliveryTimeout when the notifier does not return in
+// time, the notifier is left to finish in the background.
+func (d *Deliveries) deliver(delivery delivery) error {
+	result := make(chan error, 1)
+	go func() {
+		result <- delivery.notifier.Notify(delivery.notification)
+	}()
+	timer := time.NewTimer(d.timeout)
+	defer timer.Stop()
+	select {
+	case err := <-result:
+		return err
+	case <-timer.C:
+		return ErrDeliveryTimeout
+	}
+}
@@ -0,0 +1,62 @@
+package consequence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeliveries_ReportsTheOutcomeOfTheDelivery(t *testing.T) {
+	// Setup
+	deliveries := newDeliveries(1, time.Second)
+	notifier := new(MockNotifier)
+	notifier.On("Notify", mock.Anything).Return(errors.New("bad gateway"))
+	done := make(chan error, 1)
+
+	// Execute
+	deliveries.Send(notifier, entity.Notification{Type: "consequence"}, func(err error) { done <- err })
+
+	// Assert
+	assert.EqualError(t, <-done, "bad gateway")
+	notifier.AssertExpectations(t)
+}
+
+func TestDeliveries_FullQueueFailsRightAway(t *testing.T) {
+	// Setup
+	deliveries := newDeliveries(1, time.Second)
+	notifier := new(MockNotifier)
+	release := make(chan time.Time)
+	notifier.On("Notify", mock.Anything).Return(nil).WaitUntil(release)
+	done := make(chan error, 3)
+
+	// Execute
+	deliveries.Send(notifier, entity.Notification{}, func(err error) { done <- err })
+	// The worker picks the first one up, the second one waits in the queue.
+	assert.Eventually(t, func() bool { return len(deliveries.queue) == 0 }, time.Second, time.Millisecond)
+	deliveries.Send(notifier, entity.Notification{}, func(err error) { done <- err })
+	deliveries.Send(notifier, entity.Notification{}, func(err error) { done <- err })
+
+	// Assert
+	assert.ErrorIs(t, <-done, ErrDeliveryQueueFull)
+	close(release)
+	assert.NoError(t, <-done)
+	assert.NoError(t, <-done)
+}
+
+func TestDeliveries_NilDeliversRightAway(t *testing.T) {
+	// Setup
+	var deliveries *Deliveries
+	notifier := new(MockNotifier)
+	notifier.On("Notify", mock.Anything).Return(nil)
+	var delivered bool
+
+	// Execute
+	deliveries.Send(notifier, entity.Notification{}, func(err error) { delivered = err == nil })
+
+	// Assert
+	assert.True(t, delivered)
+}
@@ -0,0 +1,195 @@
+package consequence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// ParseRules reads rules from config, a JSON array such as
+// [{"name":"3 lates a week","threshold":3,"window_days":7,"action":"detention"}].
+func ParseRules(raw string) ([]*entity.ConsequenceRule, error) {
+	rules := []*entity.ConsequenceRule{}
+	if strings.TrimSpace(raw) == "" {
+		return rules, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil, fmt.Errorf("invalid consequence rules: %w", err)
+	}
+	for _, rule := range rules {
+		if err := ValidateRule(rule); err != nil {
+			return nil, fmt.Errorf("invalid consequence rule %q: %w", rule.Name, err)
+		}
+		rule.Source = entity.RuleSourceConfig
+	}
+	return rules, nil
+}
+
+func ValidateRule(rule *entity.ConsequenceRule) error {
+	switch {
+	case strings.TrimSpace(rule.Name) == "":
+		return errors.New("name is required")
+	case rule.Threshold <= 0:
+		return errors.New("threshold must be positive")
+	case rule.WindowDays <= 0:
+		return errors.New("window_days must be positive")
+	case !slices.Contains(entity.ConsequenceActions, rule.Action):
+		return errors.New("action must be one of: " + strings.Join(entity.ConsequenceActions, ", "))
+	case rule.Action == entity.ConsequenceWebhook && rule.WebhookURL == "":
+		return errors.New("webhook_url is required for webhook actions")
+	}
+	return nil
+}
+
+// Engine applies the behaviour policy after each late sign-in.
+type Engine struct {
+	Rules                 []*entity.ConsequenceRule // rules from config
+	RuleRepository        entity.ConsequenceRuleRepository
+	ConsequenceRepository entity.ConsequenceRepository
+	TardyRepository       entity.TardyRepository
+	Notifier              entity.Notifier
+	NewWebhook            func(url string) entity.Notifier
+	// Deliveries delivers the notifications, see act.
+	Deliveries *Deliveries
+}
+
+// AllRules returns the config rules followed by the database rules.
+func (e *Engine) AllRules(ctx context.Context) ([]*entity.ConsequenceRule, error) {
+	rules := slices.Clone(e.Rules)
+	if e.RuleRepository == nil {
+		return rules, nil
+	}
+	stored, err := e.RuleRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(rules, stored...), nil
+}
+
+// Evaluate raises a consequence for every rule whose threshold the student
+// reached again. Lates already answered by a consequence in the window do
+// not count twice, so the fourth late of a week does not repeat the
+// detention of the third. Notifications are delivered after Evaluate
+// returns, see act. A nil engine does nothing.
+func (e *Engine) Evaluate(ctx context.Context, visitor *entity.Visitor, at time.Time) ([]*entity.Consequence, error) {
+	raised := []*entity.Consequence{}
+	if e == nil || visitor == nil {
+		return raised, nil
+	}
+	rules, err := e.AllRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if !rule.AppliesTo(visitor) {
+			continue
+		}
+		since := WindowStart(rule, at)
+		lates, err := e.TardyRepository.CountByVisitorIdSince(ctx, visitor.Id, since)
+		if err != nil {
+			return nil, err
+		}
+		existing, err := e.ConsequenceRepository.CountByRuleAndVisitorIdSince(ctx, rule.Name, visitor.Id, since)
+		if err != nil {
+			return nil, err
+		}
+		if lates < rule.Threshold*(existing+1) {
+			continue
+		}
+		consequence := &entity.Consequence{
+			Rule:      rule.Name,
+			VisitorId: visitor.Id,
+			Visitor:   visitor,
+			Action:    rule.Action,
+			LateCount: lates,
+			Status:    entity.ConsequencePending,
+			CreatedAt: at,
+		}
+		notifier := e.notifier(rule, consequence)
+		if err := e.ConsequenceRepository.Store(ctx, consequence); err != nil {
+			return nil, err
+		}
+		if notifier != nil {
+			e.act(ctx, rule, consequence, notifier)
+		}
+		raised = append(raised, consequence)
+	}
+	return raised, nil
+}
+
+// WindowStart is the start of the first day counted by the rule.
+func WindowStart(rule *entity.ConsequenceRule, at time.Time) time.Time {
+	days := max(rule.WindowDays, 1)
+	return util.StartOfDay(at).AddDate(0, 0, -(days - 1))
+}
+
+// notifier returns the notifier of notify and webhook rules, detentions
+// stay pending until they are served. A rule without one fails the
+// consequence.
+func (e *Engine) notifier(rule *entity.ConsequenceRule, consequence *entity.Consequence) entity.Notifier {
+	var notifier entity.Notifier
+	switch rule.Action {
+	case entity.ConsequenceNotify:
+		notifier = e.Notifier
+	case entity.ConsequenceWebhook:
+		if e.NewWebhook != nil {
+			notifier = e.NewWebhook(rule.WebhookURL)
+		}
+	default:
+		return nil
+	}
+	if notifier == nil {
+		consequence.Status = entity.ConsequenceFailed
+		consequence.Note = "no notifier configured"
+	}
+	return notifier
+}
+
+// act hands the notification of a stored consequence to the Deliveries, the
+// consequence is done or failed once it is delivered. Failures are logged,
+// the sign-in does not wait for them.
+func (e *Engine) act(ctx context.Context, rule *entity.ConsequenceRule, consequence *entity.Consequence, notifier entity.Notifier) {
+	visitor := consequence.Visitor
+	notification := entity.Notification{
+		Type:    "consequence",
+		Subject: rule.Name,
+		Message: fmt.Sprintf(
+			"%s %s (grade %d) has been late %d times in %d days.",
+			visitor.Name,
+			visitor.Surname,
+			visitor.Grade,
+			consequence.LateCount,
+			rule.WindowDays,
+		),
+		Data: map[string]any{
+			"rule":       rule.Name,
+			"recipient":  rule.Recipient,
+			"visitor_id": visitor.Id,
+			"grade":      visitor.Grade,
+			"late_count": consequence.LateCount,
+		},
+		CreatedAt: consequence.CreatedAt,
+	}
+	// The request of the sign-in may be over by the time of the delivery.
+	ctx = context.WithoutCancel(ctx)
+	id := consequence.Id
+	e.Deliveries.Send(notifier, notification, func(err error) {
+		logger := logging.FromContext(ctx)
+		status, note := entity.ConsequenceDone, ""
+		if err != nil {
+			logger.Errorf("Error delivering consequence %q: %s", rule.Name, err.Error())
+			status, note = entity.ConsequenceFailed, err.Error()
+		}
+		if err := e.ConsequenceRepository.UpdateStatus(ctx, id, status, note, time.Now()); err != nil {
+			logger.Errorf("Error updating consequence %q: %s", rule.Name, err.Error())
+		}
+	})
+}
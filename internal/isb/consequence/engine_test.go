@@ -0,0 +1,255 @@
+package consequence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTardyRepository struct {
+	mock.Mock
+}
+
+func (m *MockTardyRepository) Save(ctx context.Context, tardy *entity.Tardy) error {
+	return m.Called(tardy).Error(0)
+}
+
+func (m *MockTardyRepository) GetByTrackId(ctx context.Context, trackId int64) (*entity.Tardy, error) {
+	args := m.Called(trackId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tardy), args.Error(1)
+}
+
+func (m *MockTardyRepository) FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.Tardy, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]*entity.Tardy), args.Error(1)
+}
+
+func (m *MockTardyRepository) CountByVisitorIdSince(ctx context.Context, visitorId int32, since time.Time) (int, error) {
+	args := m.Called(visitorId, since)
+	return args.Int(0), args.Error(1)
+}
+
+type MockConsequenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockConsequenceRepository) Store(ctx context.Context, consequence *entity.Consequence) error {
+	return m.Called(consequence).Error(0)
+}
+
+func (m *MockConsequenceRepository) GetById(ctx context.Context, id int64) (*entity.Consequence, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Consequence), args.Error(1)
+}
+
+func (m *MockConsequenceRepository) FindByStatus(ctx context.Context, status string) ([]*entity.Consequence, error) {
+	args := m.Called(status)
+	return args.Get(0).([]*entity.Consequence), args.Error(1)
+}
+
+func (m *MockConsequenceRepository) CountByRuleAndVisitorIdSince(ctx context.Context, rule string, visitorId int32, since time.Time) (int, error) {
+	args := m.Called(rule, visitorId, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockConsequenceRepository) UpdateStatus(ctx context.Context, id int64, status string, note string, at time.Time) error {
+	return m.Called(id, status, note, at).Error(0)
+}
+
+type MockConsequenceRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockConsequenceRuleRepository) FindAll(ctx context.Context) ([]*entity.ConsequenceRule, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.ConsequenceRule), args.Error(1)
+}
+
+func (m *MockConsequenceRuleRepository) Store(ctx context.Context, rule *entity.ConsequenceRule) error {
+	return m.Called(rule).Error(0)
+}
+
+func (m *MockConsequenceRuleRepository) Delete(ctx context.Context, id int64) error {
+	return m.Called(id).Error(0)
+}
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(notification entity.Notification) error {
+	return m.Called(notification).Error(0)
+}
+
+var (
+	// Thursday
+	lateAt  = time.Date(2024, 9, 5, 8, 45, 0, 0, time.UTC)
+	student = &entity.Visitor{Id: 7, Name: "Tom", Surname: "Doe", Grade: 8}
+	weekly  = &entity.ConsequenceRule{Name: "3 lates a week", Threshold: 3, WindowDays: 7, Action: entity.ConsequenceDetention}
+)
+
+func newTestEngine(rules ...*entity.ConsequenceRule) (*Engine, *MockTardyRepository, *MockConsequenceRepository, *MockNotifier) {
+	tardies := new(MockTardyRepository)
+	consequences := new(MockConsequenceRepository)
+	notifier := new(MockNotifier)
+	return &Engine{
+		Rules:                 rules,
+		ConsequenceRepository: consequences,
+		TardyRepository:       tardies,
+		Notifier:              notifier,
+	}, tardies, consequences, notifier
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(`[{"name":"3 lates a week","threshold":3,"window_days":7,"grades":[7,8],"action":"notify","recipient":"head of year"}]`)
+
+	assert.NoError(t, err)
+	assert.Len(t, rules, 1)
+	assert.Equal(t, []int{7, 8}, rules[0].Grades)
+	assert.Equal(t, entity.RuleSourceConfig, rules[0].Source)
+
+	rules, err = ParseRules("")
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	_, err = ParseRules(`[{"name":"broken","threshold":3,"window_days":7,"action":"webhook"}]`)
+	assert.ErrorContains(t, err, "webhook_url is required")
+}
+
+func TestEvaluate_ThresholdReachedCreatesPendingDetention(t *testing.T) {
+	engine, tardies, consequences, _ := newTestEngine(weekly)
+
+	since := time.Date(2024, 8, 30, 0, 0, 0, 0, time.UTC)
+	tardies.On("CountByVisitorIdSince", int32(7), since).Return(3, nil)
+	consequences.On("CountByRuleAndVisitorIdSince", "3 lates a week", int32(7), since).Return(0, nil)
+	consequences.On("Store", mock.MatchedBy(func(c *entity.Consequence) bool {
+		return c.Action == entity.ConsequenceDetention && c.Status == entity.ConsequencePending && c.LateCount == 3
+	})).Return(nil)
+
+	raised, err := engine.Evaluate(context.Background(), student, lateAt)
+
+	assert.NoError(t, err)
+	assert.Len(t, raised, 1)
+	consequences.AssertExpectations(t)
+}
+
+func TestEvaluate_LatesAlreadyAnsweredDoNotRepeat(t *testing.T) {
+	engine, tardies, consequences, _ := newTestEngine(weekly)
+
+	tardies.On("CountByVisitorIdSince", int32(7), mock.Anything).Return(4, nil)
+	consequences.On("CountByRuleAndVisitorIdSince", "3 lates a week", int32(7), mock.Anything).Return(1, nil)
+
+	raised, err := engine.Evaluate(context.Background(), student, lateAt)
+
+	assert.NoError(t, err)
+	assert.Empty(t, raised)
+	consequences.AssertNotCalled(t, "Store", mock.Anything)
+}
+
+func TestEvaluate_GradeFilter(t *testing.T) {
+	juniors := &entity.ConsequenceRule{Name: "juniors", Threshold: 1, WindowDays: 1, Grades: []int{5, 6}, Action: entity.ConsequenceDetention}
+	engine, tardies, _, _ := newTestEngine(juniors)
+
+	raised, err := engine.Evaluate(context.Background(), student, lateAt)
+
+	assert.NoError(t, err)
+	assert.Empty(t, raised)
+	tardies.AssertNotCalled(t, "CountByVisitorIdSince", mock.Anything, mock.Anything)
+}
+
+func TestEvaluate_NotifyHeadOfYear(t *testing.T) {
+	rule := &entity.ConsequenceRule{Name: "5 lates a month", Threshold: 5, WindowDays: 30, Action: entity.ConsequenceNotify, Recipient: "head of year 8"}
+	engine, tardies, consequences, notifier := newTestEngine()
+	rules := new(MockConsequenceRuleRepository)
+	engine.RuleRepository = rules
+
+	rules.On("FindAll").Return([]*entity.ConsequenceRule{rule}, nil)
+	tardies.On("CountByVisitorIdSince", int32(7), mock.Anything).Return(5, nil)
+	consequences.On("CountByRuleAndVisitorIdSince", "5 lates a month", int32(7), mock.Anything).Return(0, nil)
+	notifier.On("Notify", mock.MatchedBy(func(n entity.Notification) bool {
+		return n.Type == "consequence" && n.Data["recipient"] == "head of year 8" && n.Data["late_count"] == 5
+	})).Return(nil)
+	consequences.On("Store", mock.MatchedBy(func(c *entity.Consequence) bool {
+		return c.Status == entity.ConsequencePending
+	})).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*entity.Consequence).Id = 4
+	})
+	consequences.On("UpdateStatus", int64(4), entity.ConsequenceDone, "", mock.Anything).Return(nil)
+
+	raised, err := engine.Evaluate(context.Background(), student, lateAt)
+
+	assert.NoError(t, err)
+	assert.Len(t, raised, 1)
+	notifier.AssertExpectations(t)
+	consequences.AssertExpectations(t)
+}
+
+func TestEvaluate_FailedWebhookIsRecorded(t *testing.T) {
+	rule := &entity.ConsequenceRule{Name: "hook", Threshold: 1, WindowDays: 1, Action: entity.ConsequenceWebhook, WebhookURL: "https://example.test/hook"}
+	engine, tardies, consequences, _ := newTestEngine(rule)
+	webhook := new(MockNotifier)
+	var calledWith string
+	engine.NewWebhook = func(url string) entity.Notifier {
+		calledWith = url
+		return webhook
+	}
+
+	tardies.On("CountByVisitorIdSince", int32(7), mock.Anything).Return(1, nil)
+	consequences.On("CountByRuleAndVisitorIdSince", "hook", int32(7), mock.Anything).Return(0, nil)
+	webhook.On("Notify", mock.Anything).Return(errors.New("timeout"))
+	consequences.On("Store", mock.Anything).Return(nil)
+	consequences.On("UpdateStatus", int64(0), entity.ConsequenceFailed, "timeout", mock.Anything).Return(nil)
+
+	_, err := engine.Evaluate(context.Background(), student, lateAt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.test/hook", calledWith)
+	consequences.AssertExpectations(t)
+}
+
+func TestEvaluate_SlowWebhookDoesNotHoldUpTheSignIn(t *testing.T) {
+	rule := &entity.ConsequenceRule{Name: "hook", Threshold: 1, WindowDays: 1, Action: entity.ConsequenceWebhook, WebhookURL: "https://example.test/hook"}
+	engine, tardies, consequences, _ := newTestEngine(rule)
+	engine.Deliveries = newDeliveries(1, 50*time.Millisecond)
+	webhook := new(MockNotifier)
+	engine.NewWebhook = func(url string) entity.Notifier {
+		return webhook
+	}
+	updated := make(chan struct{})
+
+	tardies.On("CountByVisitorIdSince", int32(7), mock.Anything).Return(1, nil)
+	consequences.On("CountByRuleAndVisitorIdSince", "hook", int32(7), mock.Anything).Return(0, nil)
+	consequences.On("Store", mock.Anything).Return(nil)
+	webhook.On("Notify", mock.Anything).Return(nil).WaitUntil(time.After(time.Second))
+	consequences.On("UpdateStatus", int64(0), entity.ConsequenceFailed, ErrDeliveryTimeout.Error(), mock.Anything).Return(nil).Run(func(mock.Arguments) {
+		close(updated)
+	})
+
+	started := time.Now()
+	_, err := engine.Evaluate(context.Background(), student, lateAt)
+
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(started), 50*time.Millisecond)
+	<-updated
+	consequences.AssertExpectations(t)
+}
+
+func TestEvaluate_NilEngine(t *testing.T) {
+	var engine *Engine
+
+	raised, err := engine.Evaluate(context.Background(), student, lateAt)
+
+	assert.NoError(t, err)
+	assert.Empty(t, raised)
+}
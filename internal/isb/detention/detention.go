@@ -0,0 +1,520 @@
+package detention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	SessionFullCode = "detention_full"
+	// EscalationRule names the consequence raised for a missed detention.
+	EscalationRule = "Missed detention"
+
+	dateLayout = "2006-01-02"
+)
+
+type SessionRequest struct {
+	StartsAt     time.Time `json:"starts_at" binding:"required"`
+	EndsAt       time.Time `json:"ends_at" binding:"required"`
+	RoomID       int64     `json:"room_id" binding:"required"`
+	SupervisorID int32     `json:"supervisor_id" binding:"required"`
+	Capacity     int       `json:"capacity"`
+}
+
+type SessionResponse struct {
+	Session     *entity.DetentionSession      `json:"session"`
+	Assignments []*entity.DetentionAssignment `json:"assignments"`
+}
+
+// AssignRequest assigns either a student directly or a pending detention
+// raised by the consequence policy.
+type AssignRequest struct {
+	VisitorID     int32 `json:"visitor_id"`
+	ConsequenceID int64 `json:"consequence_id"`
+}
+
+type MarkRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+type ScanRequest struct {
+	DeviceID string `json:"device_id" binding:"required"`
+	VisitKey string `json:"visit_key" binding:"required"`
+}
+
+type DetentionController struct {
+	DetentionRepository   entity.DetentionRepository   `container:"type"`
+	ConsequenceRepository entity.ConsequenceRepository `container:"type"`
+	DeviceRepository      entity.DeviceRepository      `container:"type"`
+	VisitorRepository     entity.VisitorRepository     `container:"type"`
+	Notifier              entity.Notifier              `container:"type"`
+	Config                *config.Config               `container:"type"`
+	Now                   func() time.Time
+	Location              *time.Location `container:"type"`
+}
+
+func (dc *DetentionController) CreateSessionHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request SessionRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		if !request.EndsAt.After(request.StartsAt) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("ends_at must be after starts_at"))))
+			return
+		}
+		session := &entity.DetentionSession{
+			StartsAt:     request.StartsAt,
+			EndsAt:       request.EndsAt,
+			RoomId:       request.RoomID,
+			SupervisorId: request.SupervisorID,
+			Capacity:     request.Capacity,
+		}
+		if err := dc.DetentionRepository.CreateSession(session); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusCreated, session)
+	}
+}
+
+// ListSessionsHandler lists sessions starting between ?from and ?to
+// (inclusive dates), by default the coming week.
+func (dc *DetentionController) ListSessionsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		today := dc.now()
+		from, err := util.ParseDate(ctx.DefaultQuery("from", today.Format(dateLayout)), dc.Location)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid from date, expected YYYY-MM-DD"))))
+			return
+		}
+		to, err := util.ParseDate(ctx.DefaultQuery("to", from.AddDate(0, 0, 6).Format(dateLayout)), dc.Location)
+		if err != nil || to.Before(from) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid to date, expected YYYY-MM-DD not before from"))))
+			return
+		}
+		sessions, err := dc.DetentionRepository.FindSessionsBetween(from, to.AddDate(0, 0, 1))
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, sessions)
+	}
+}
+
+func (dc *DetentionController) SessionHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		session, ok := dc.sessionFromParam(ctx)
+		if !ok {
+			return
+		}
+		assignments, err := dc.DetentionRepository.FindAssignments(session.Id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, SessionResponse{
+			Session:     session,
+			Assignments: assignments,
+		})
+	}
+}
+
+func (dc *DetentionController) AssignHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request AssignRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		if request.VisitorID == 0 && request.ConsequenceID == 0 {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Either visitor_id or consequence_id is required"))))
+			return
+		}
+		session, ok := dc.openSessionFromParam(ctx)
+		if !ok {
+			return
+		}
+		if session.Full() {
+			ctx.JSON(http.StatusConflict, util.ExtendedFailureResponse{
+				Code:  SessionFullCode,
+				Error: "Detention session is full",
+			})
+			return
+		}
+
+		assignment := &entity.DetentionAssignment{
+			SessionId: session.Id,
+			VisitorId: request.VisitorID,
+			Status:    entity.DetentionAssigned,
+		}
+		if request.ConsequenceID > 0 {
+			consequence, err := dc.ConsequenceRepository.GetById(ctx.Request.Context(), request.ConsequenceID)
+			if err != nil {
+				ctx.JSON(util.ServerFailure(err))
+				return
+			}
+			if consequence == nil || consequence.Action != entity.ConsequenceDetention || consequence.Status != entity.ConsequencePending {
+				ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(errors.New("Consequence is not a pending detention"))))
+				return
+			}
+			assignment.VisitorId = consequence.VisitorId
+			assignment.ConsequenceId = consequence.Id
+		} else {
+			visitor, err := dc.VisitorRepository.FindById(ctx.Request.Context(), request.VisitorID)
+			if errors.Is(err, entity.ErrQueryTimeout) {
+				ctx.JSON(util.ServerFailure(err))
+				return
+			}
+			if err != nil || visitor == nil || visitor.Id == 0 {
+				ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor not exists"))))
+				return
+			}
+		}
+
+		existing, err := dc.DetentionRepository.FindAssignment(session.Id, assignment.VisitorId)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if existing != nil {
+			ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(errors.New("Student is already assigned to this session"))))
+			return
+		}
+		if err := dc.assign(ctx.Request.Context(), assignment); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusCreated, assignment)
+	}
+}
+
+// FillHandler assigns pending detentions from the consequence policy,
+// oldest first, until the session is full.
+func (dc *DetentionController) FillHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		session, ok := dc.openSessionFromParam(ctx)
+		if !ok {
+			return
+		}
+		pending, err := dc.ConsequenceRepository.FindByStatus(ctx.Request.Context(), entity.ConsequencePending)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		current, err := dc.DetentionRepository.FindAssignments(session.Id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		assignedVisitors := []int32{}
+		for _, assignment := range current {
+			assignedVisitors = append(assignedVisitors, assignment.VisitorId)
+		}
+
+		assigned := []*entity.DetentionAssignment{}
+		for _, consequence := range pending {
+			if session.Full() {
+				break
+			}
+			if consequence.Action != entity.ConsequenceDetention || slices.Contains(assignedVisitors, consequence.VisitorId) {
+				continue
+			}
+			assignment := &entity.DetentionAssignment{
+				SessionId:     session.Id,
+				VisitorId:     consequence.VisitorId,
+				Visitor:       consequence.Visitor,
+				ConsequenceId: consequence.Id,
+				Status:        entity.DetentionAssigned,
+			}
+			if err := dc.assign(ctx.Request.Context(), assignment); err != nil {
+				ctx.JSON(util.ServerFailure(err))
+				return
+			}
+			session.Assigned++
+			assignedVisitors = append(assignedVisitors, consequence.VisitorId)
+			assigned = append(assigned, assignment)
+		}
+		ctx.JSON(http.StatusOK, assigned)
+	}
+}
+
+// ScanHandler marks attendance when a student scans at the reader of the
+// detention room while a session is on.
+func (dc *DetentionController) ScanHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request ScanRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		device, err := dc.DeviceRepository.FindById(request.DeviceID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if device == nil || !device.InRoom() {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Device not exists or is not bound to a room"))))
+			return
+		}
+		details, err := dc.VisitorRepository.FindByKey(ctx.Request.Context(), request.VisitKey)
+		if errors.Is(err, entity.ErrQueryTimeout) {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		if err != nil || details == nil || details.Visitor == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor not exists"))))
+			return
+		}
+		sessions, err := dc.DetentionRepository.FindOpenSessionsByRoom(device.RoomId)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		now := dc.now()
+		session := CurrentSession(sessions, now, dc.earlyScan())
+		if session == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("No detention in progress"))))
+			return
+		}
+		assignment, err := dc.DetentionRepository.FindAssignment(session.Id, details.Visitor.Id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if assignment == nil {
+			ctx.JSON(http.StatusForbidden, util.NewFailureResponse(exception.Forbidden(errors.New("Student is not assigned to this detention"))))
+			return
+		}
+		if assignment.Status != entity.DetentionAttended {
+			if err := dc.mark(ctx.Request.Context(), assignment, entity.DetentionAttended, now); err != nil {
+				ctx.JSON(util.ServerFailure(err))
+				return
+			}
+		}
+		assignment.Visitor = details.Visitor
+		ctx.JSON(http.StatusOK, assignment)
+	}
+}
+
+// MarkHandler lets the supervisor correct attendance by hand.
+func (dc *DetentionController) MarkHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request MarkRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		if !slices.Contains(entity.DetentionStatuses, request.Status) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid status, expected one of: "+strings.Join(entity.DetentionStatuses, ", ")))))
+			return
+		}
+		session, ok := dc.sessionFromParam(ctx)
+		if !ok {
+			return
+		}
+		visitorId, err := strconv.ParseInt(ctx.Param("visitorId"), 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid visitor id"))))
+			return
+		}
+		assignment, err := dc.DetentionRepository.FindAssignment(session.Id, int32(visitorId))
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if assignment == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Student is not assigned to this detention"))))
+			return
+		}
+		if err := dc.mark(ctx.Request.Context(), assignment, request.Status, dc.now()); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, assignment)
+	}
+}
+
+// CloseHandler closes the session, students who did not turn up are marked
+// missed and escalated.
+func (dc *DetentionController) CloseHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		session, ok := dc.openSessionFromParam(ctx)
+		if !ok {
+			return
+		}
+		assignments, err := dc.DetentionRepository.FindAssignments(session.Id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		now := dc.now()
+		for _, assignment := range assignments {
+			if assignment.Status != entity.DetentionAssigned {
+				continue
+			}
+			if err := dc.mark(ctx.Request.Context(), assignment, entity.DetentionMissed, now); err != nil {
+				ctx.JSON(util.ServerFailure(err))
+				return
+			}
+			if err := dc.escalate(ctx, session, assignment, now); err != nil {
+				ctx.JSON(util.ServerFailure(err))
+				return
+			}
+		}
+		if err := dc.DetentionRepository.CloseSession(session.Id, now); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		session.ClosedAt = &now
+		ctx.JSON(http.StatusOK, SessionResponse{
+			Session:     session,
+			Assignments: assignments,
+		})
+	}
+}
+
+// CurrentSession returns the session on at the time, scans are accepted
+// from earlyScan before it starts until it ends.
+func CurrentSession(sessions []*entity.DetentionSession, at time.Time, earlyScan time.Duration) *entity.DetentionSession {
+	for _, session := range sessions {
+		if !at.Before(session.StartsAt.Add(-earlyScan)) && !at.After(session.EndsAt) {
+			return session
+		}
+	}
+	return nil
+}
+
+func (dc *DetentionController) assign(ctx context.Context, assignment *entity.DetentionAssignment) error {
+	if err := dc.DetentionRepository.Assign(assignment); err != nil {
+		return err
+	}
+	if assignment.ConsequenceId == 0 {
+		return nil
+	}
+	return dc.ConsequenceRepository.UpdateStatus(ctx, assignment.ConsequenceId, entity.ConsequenceScheduled, fmt.Sprintf("detention session %d", assignment.SessionId), dc.now())
+}
+
+// mark updates the assignment and the status of the consequence behind it.
+func (dc *DetentionController) mark(ctx context.Context, assignment *entity.DetentionAssignment, status string, now time.Time) error {
+	var attendedAt *time.Time
+	if status == entity.DetentionAttended {
+		attendedAt = &now
+	}
+	if err := dc.DetentionRepository.UpdateAssignment(assignment.Id, status, attendedAt); err != nil {
+		return err
+	}
+	assignment.Status = status
+	assignment.AttendedAt = attendedAt
+	if assignment.ConsequenceId == 0 {
+		return nil
+	}
+	consequenceStatus := map[string]string{
+		entity.DetentionAssigned: entity.ConsequenceScheduled,
+		entity.DetentionAttended: entity.ConsequenceDone,
+		entity.DetentionMissed:   entity.ConsequenceMissed,
+		entity.DetentionExcused:  entity.ConsequenceCancelled,
+	}[status]
+	return dc.ConsequenceRepository.UpdateStatus(ctx, assignment.ConsequenceId, consequenceStatus, fmt.Sprintf("detention session %d", assignment.SessionId), now)
+}
+
+// escalate raises a new pending detention and alerts staff.
+func (dc *DetentionController) escalate(ctx *gin.Context, session *entity.DetentionSession, assignment *entity.DetentionAssignment, now time.Time) error {
+	err := dc.ConsequenceRepository.Store(ctx.Request.Context(), &entity.Consequence{
+		Rule:      EscalationRule,
+		VisitorId: assignment.VisitorId,
+		Visitor:   assignment.Visitor,
+		Action:    entity.ConsequenceDetention,
+		Status:    entity.ConsequencePending,
+		Note:      fmt.Sprintf("missed detention session %d", session.Id),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return err
+	}
+	logger := logging.FromContext(ctx.Request.Context())
+	logger.Warnw("Detention missed, escalated",
+		"session_id", session.Id,
+		"visitor_id", assignment.VisitorId,
+	)
+	if dc.Notifier == nil {
+		return nil
+	}
+	name := ""
+	if assignment.Visitor != nil {
+		name = strings.TrimSpace(assignment.Visitor.Name + " " + assignment.Visitor.Surname)
+	}
+	err = dc.Notifier.Notify(entity.Notification{
+		Type:    "detention_missed",
+		Subject: "Detention missed",
+		Message: fmt.Sprintf("%s missed the detention on %s.", name, util.In(session.StartsAt, dc.Location).Format("2006-01-02 15:04")),
+		Data: map[string]any{
+			"session_id":     session.Id,
+			"visitor_id":     assignment.VisitorId,
+			"consequence_id": assignment.ConsequenceId,
+		},
+		CreatedAt: now,
+	})
+	if err != nil {
+		logger.Errorf("Error notifying staff about missed detention: %s", err.Error())
+	}
+	return nil
+}
+
+func (dc *DetentionController) sessionFromParam(ctx *gin.Context) (*entity.DetentionSession, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid session id"))))
+		return nil, false
+	}
+	session, err := dc.DetentionRepository.GetSessionById(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return nil, false
+	}
+	if session == nil {
+		ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Detention session not exists"))))
+		return nil, false
+	}
+	return session, true
+}
+
+func (dc *DetentionController) openSessionFromParam(ctx *gin.Context) (*entity.DetentionSession, bool) {
+	session, ok := dc.sessionFromParam(ctx)
+	if !ok {
+		return nil, false
+	}
+	if session.Closed() {
+		ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(errors.New("Detention session is closed"))))
+		return nil, false
+	}
+	return session, true
+}
+
+func (dc *DetentionController) earlyScan() time.Duration {
+	if dc.Config == nil {
+		return 0
+	}
+	return time.Duration(dc.Config.AttendanceEarlyScanMinutes) * time.Minute
+}
+
+func (dc *DetentionController) now() time.Time {
+	if dc.Now != nil {
+		return util.In(dc.Now(), dc.Location)
+	}
+	return util.In(time.Now(), dc.Location)
+}
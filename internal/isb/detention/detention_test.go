@@ -0,0 +1,402 @@
+package detention
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDetentionRepository struct {
+	mock.Mock
+}
+
+func (m *MockDetentionRepository) CreateSession(session *entity.DetentionSession) error {
+	return m.Called(session).Error(0)
+}
+
+func (m *MockDetentionRepository) GetSessionById(id int64) (*entity.DetentionSession, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DetentionSession), args.Error(1)
+}
+
+func (m *MockDetentionRepository) FindSessionsBetween(from time.Time, to time.Time) ([]*entity.DetentionSession, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]*entity.DetentionSession), args.Error(1)
+}
+
+func (m *MockDetentionRepository) FindOpenSessionsByRoom(roomId int64) ([]*entity.DetentionSession, error) {
+	args := m.Called(roomId)
+	return args.Get(0).([]*entity.DetentionSession), args.Error(1)
+}
+
+func (m *MockDetentionRepository) CloseSession(id int64, at time.Time) error {
+	return m.Called(id, at).Error(0)
+}
+
+func (m *MockDetentionRepository) Assign(assignment *entity.DetentionAssignment) error {
+	return m.Called(assignment).Error(0)
+}
+
+func (m *MockDetentionRepository) FindAssignments(sessionId int64) ([]*entity.DetentionAssignment, error) {
+	args := m.Called(sessionId)
+	return args.Get(0).([]*entity.DetentionAssignment), args.Error(1)
+}
+
+func (m *MockDetentionRepository) FindAssignment(sessionId int64, visitorId int32) (*entity.DetentionAssignment, error) {
+	args := m.Called(sessionId, visitorId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DetentionAssignment), args.Error(1)
+}
+
+func (m *MockDetentionRepository) UpdateAssignment(id int64, status string, attendedAt *time.Time) error {
+	return m.Called(id, status, attendedAt).Error(0)
+}
+
+type MockConsequenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockConsequenceRepository) Store(ctx context.Context, consequence *entity.Consequence) error {
+	return m.Called(consequence).Error(0)
+}
+
+func (m *MockConsequenceRepository) GetById(ctx context.Context, id int64) (*entity.Consequence, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Consequence), args.Error(1)
+}
+
+func (m *MockConsequenceRepository) FindByStatus(ctx context.Context, status string) ([]*entity.Consequence, error) {
+	args := m.Called(status)
+	return args.Get(0).([]*entity.Consequence), args.Error(1)
+}
+
+func (m *MockConsequenceRepository) CountByRuleAndVisitorIdSince(ctx context.Context, rule string, visitorId int32, since time.Time) (int, error) {
+	args := m.Called(rule, visitorId, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockConsequenceRepository) UpdateStatus(ctx context.Context, id int64, status string, note string, at time.Time) error {
+	return m.Called(id, status, note, at).Error(0)
+}
+
+type MockDeviceRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeviceRepository) FindById(id string) (*entity.Device, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Device), args.Error(1)
+}
+
+func (m *MockDeviceRepository) FindAll() ([]*entity.Device, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.Device), args.Error(1)
+}
+
+func (m *MockDeviceRepository) Save(device *entity.Device) error {
+	return m.Called(device).Error(0)
+}
+
+type MockVisitorRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorRepository) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Visitor), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
+	return m.Called(visitor, key).Error(0)
+}
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(notification entity.Notification) error {
+	return m.Called(notification).Error(0)
+}
+
+var (
+	now     = time.Date(2024, 9, 2, 12, 25, 0, 0, time.UTC)
+	student = &entity.Visitor{Id: 7, Name: "Tom", Surname: "Doe"}
+)
+
+type testController struct {
+	*DetentionController
+	detentions   *MockDetentionRepository
+	consequences *MockConsequenceRepository
+	devices      *MockDeviceRepository
+	visitors     *MockVisitorRepository
+	notifier     *MockNotifier
+}
+
+func newTestController() *testController {
+	tc := &testController{
+		detentions:   new(MockDetentionRepository),
+		consequences: new(MockConsequenceRepository),
+		devices:      new(MockDeviceRepository),
+		visitors:     new(MockVisitorRepository),
+		notifier:     new(MockNotifier),
+	}
+	tc.DetentionController = &DetentionController{
+		DetentionRepository:   tc.detentions,
+		ConsequenceRepository: tc.consequences,
+		DeviceRepository:      tc.devices,
+		VisitorRepository:     tc.visitors,
+		Notifier:              tc.notifier,
+		Config:                &config.Config{AttendanceEarlyScanMinutes: 10},
+		Now:                   func() time.Time { return now },
+	}
+	return tc
+}
+
+func lunchSession() *entity.DetentionSession {
+	return &entity.DetentionSession{
+		Id:       3,
+		StartsAt: time.Date(2024, 9, 2, 12, 30, 0, 0, time.UTC),
+		EndsAt:   time.Date(2024, 9, 2, 13, 0, 0, 0, time.UTC),
+		RoomId:   4,
+		Capacity: 2,
+	}
+}
+
+func performJSON(handler gin.HandlerFunc, path string, payload any, params ...gin.Param) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", path, bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	handler(c)
+	return w
+}
+
+func sessionParam() gin.Param {
+	return gin.Param{Key: "id", Value: "3"}
+}
+
+func TestCreateSessionHandler_RejectsEndBeforeStart(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tc := newTestController()
+
+	w := performJSON(tc.CreateSessionHandler(), "/api/detentions/sessions", map[string]any{
+		"starts_at":     "2024-09-02T13:00:00Z",
+		"ends_at":       "2024-09-02T12:30:00Z",
+		"room_id":       4,
+		"supervisor_id": 2,
+	})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	tc.detentions.AssertNotCalled(t, "CreateSession", mock.Anything)
+}
+
+func TestCreateSessionHandler_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tc := newTestController()
+	tc.detentions.On("CreateSession", mock.MatchedBy(func(s *entity.DetentionSession) bool {
+		return s.RoomId == 4 && s.SupervisorId == 2 && s.Capacity == 15
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*entity.DetentionSession).Id = 3
+	}).Return(nil)
+
+	w := performJSON(tc.CreateSessionHandler(), "/api/detentions/sessions", map[string]any{
+		"starts_at":     "2024-09-02T12:30:00Z",
+		"ends_at":       "2024-09-02T13:00:00Z",
+		"room_id":       4,
+		"supervisor_id": 2,
+		"capacity":      15,
+	})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":3`)
+	tc.detentions.AssertExpectations(t)
+}
+
+func TestAssignHandler_FromPendingConsequence(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tc := newTestController()
+	tc.detentions.On("GetSessionById", int64(3)).Return(lunchSession(), nil)
+	tc.consequences.On("GetById", int64(11)).Return(&entity.Consequence{
+		Id: 11, VisitorId: 7, Action: entity.ConsequenceDetention, Status: entity.ConsequencePending,
+	}, nil)
+	tc.detentions.On("FindAssignment", int64(3), int32(7)).Return(nil, nil)
+	tc.detentions.On("Assign", mock.MatchedBy(func(a *entity.DetentionAssignment) bool {
+		return a.VisitorId == 7 && a.ConsequenceId == 11 && a.Status == entity.DetentionAssigned
+	})).Return(nil)
+	tc.consequences.On("UpdateStatus", int64(11), entity.ConsequenceScheduled, "detention session 3", now).Return(nil)
+
+	w := performJSON(tc.AssignHandler(), "/api/detentions/sessions/3/assignments", AssignRequest{ConsequenceID: 11}, sessionParam())
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	tc.detentions.AssertExpectations(t)
+	tc.consequences.AssertExpectations(t)
+}
+
+func TestAssignHandler_RejectsFullSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tc := newTestController()
+	session := lunchSession()
+	session.Assigned = 2
+	tc.detentions.On("GetSessionById", int64(3)).Return(session, nil)
+
+	w := performJSON(tc.AssignHandler(), "/api/detentions/sessions/3/assignments", AssignRequest{VisitorID: 7}, sessionParam())
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response util.ExtendedFailureResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, SessionFullCode, response.Code)
+	tc.detentions.AssertNotCalled(t, "Assign", mock.Anything)
+}
+
+func TestFillHandler_AssignsPendingDetentionsUpToCapacity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tc := newTestController()
+	tc.detentions.On("GetSessionById", int64(3)).Return(lunchSession(), nil)
+	tc.consequences.On("FindByStatus", entity.ConsequencePending).Return([]*entity.Consequence{
+		{Id: 11, VisitorId: 7, Action: entity.ConsequenceDetention},
+		{Id: 12, VisitorId: 8, Action: entity.ConsequenceNotify},
+		{Id: 13, VisitorId: 9, Action: entity.ConsequenceDetention},
+		{Id: 14, VisitorId: 10, Action: entity.ConsequenceDetention},
+	}, nil)
+	tc.detentions.On("FindAssignments", int64(3)).Return([]*entity.DetentionAssignment{
+		{Id: 1, SessionId: 3, VisitorId: 9, Status: entity.DetentionAssigned},
+	}, nil)
+	tc.detentions.On("Assign", mock.Anything).Return(nil)
+	tc.consequences.On("UpdateStatus", mock.Anything, entity.ConsequenceScheduled, "detention session 3", now).Return(nil)
+
+	w := performJSON(tc.FillHandler(), "/api/detentions/sessions/3/fill", nil, sessionParam())
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var assigned []*entity.DetentionAssignment
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &assigned))
+	assert.Len(t, assigned, 2)
+	assert.Equal(t, int64(11), assigned[0].ConsequenceId)
+	assert.Equal(t, int64(14), assigned[1].ConsequenceId)
+	tc.consequences.AssertNumberOfCalls(t, "UpdateStatus", 2)
+}
+
+func TestScanHandler_MarksAttendanceBeforeStart(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tc := newTestController()
+	tc.devices.On("FindById", "room-4").Return(&entity.Device{Id: "room-4", RoomId: 4}, nil)
+	tc.visitors.On("FindByKey", "card").Return(&entity.VisitDetails{Visitor: student}, nil)
+	tc.detentions.On("FindOpenSessionsByRoom", int64(4)).Return([]*entity.DetentionSession{lunchSession()}, nil)
+	tc.detentions.On("FindAssignment", int64(3), int32(7)).Return(&entity.DetentionAssignment{
+		Id: 5, SessionId: 3, VisitorId: 7, ConsequenceId: 11, Status: entity.DetentionAssigned,
+	}, nil)
+	tc.detentions.On("UpdateAssignment", int64(5), entity.DetentionAttended, &now).Return(nil)
+	tc.consequences.On("UpdateStatus", int64(11), entity.ConsequenceDone, "detention session 3", now).Return(nil)
+
+	w := performJSON(tc.ScanHandler(), "/api/detentions/scan", ScanRequest{DeviceID: "room-4", VisitKey: "card"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"attended"`)
+	tc.detentions.AssertExpectations(t)
+	tc.consequences.AssertExpectations(t)
+}
+
+func TestScanHandler_RejectsStudentNotAssigned(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tc := newTestController()
+	tc.devices.On("FindById", "room-4").Return(&entity.Device{Id: "room-4", RoomId: 4}, nil)
+	tc.visitors.On("FindByKey", "card").Return(&entity.VisitDetails{Visitor: student}, nil)
+	tc.detentions.On("FindOpenSessionsByRoom", int64(4)).Return([]*entity.DetentionSession{lunchSession()}, nil)
+	tc.detentions.On("FindAssignment", int64(3), int32(7)).Return(nil, nil)
+
+	w := performJSON(tc.ScanHandler(), "/api/detentions/scan", ScanRequest{DeviceID: "room-4", VisitKey: "card"})
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	tc.detentions.AssertNotCalled(t, "UpdateAssignment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCloseHandler_EscalatesMissedDetention(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tc := newTestController()
+	tc.detentions.On("GetSessionById", int64(3)).Return(lunchSession(), nil)
+	tc.detentions.On("FindAssignments", int64(3)).Return([]*entity.DetentionAssignment{
+		{Id: 5, SessionId: 3, VisitorId: 7, Visitor: student, ConsequenceId: 11, Status: entity.DetentionAssigned},
+		{Id: 6, SessionId: 3, VisitorId: 8, Status: entity.DetentionAttended},
+	}, nil)
+	tc.detentions.On("UpdateAssignment", int64(5), entity.DetentionMissed, (*time.Time)(nil)).Return(nil)
+	tc.consequences.On("UpdateStatus", int64(11), entity.ConsequenceMissed, "detention session 3", now).Return(nil)
+	tc.consequences.On("Store", mock.MatchedBy(func(c *entity.Consequence) bool {
+		return c.Rule == EscalationRule && c.VisitorId == 7 && c.Action == entity.ConsequenceDetention && c.Status == entity.ConsequencePending
+	})).Return(nil)
+	tc.notifier.On("Notify", mock.MatchedBy(func(n entity.Notification) bool {
+		return n.Type == "detention_missed" && n.Message == "Tom Doe missed the detention on 2024-09-02 12:30."
+	})).Return(nil)
+	tc.detentions.On("CloseSession", int64(3), now).Return(nil)
+
+	w := performJSON(tc.CloseHandler(), "/api/detentions/sessions/3/close", nil, sessionParam())
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	tc.detentions.AssertExpectations(t)
+	tc.consequences.AssertExpectations(t)
+	tc.notifier.AssertExpectations(t)
+	tc.detentions.AssertNumberOfCalls(t, "UpdateAssignment", 1)
+}
+
+func TestCloseHandler_RejectsClosedSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tc := newTestController()
+	session := lunchSession()
+	session.ClosedAt = &now
+	tc.detentions.On("GetSessionById", int64(3)).Return(session, nil)
+
+	w := performJSON(tc.CloseHandler(), "/api/detentions/sessions/3/close", nil, sessionParam())
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	tc.detentions.AssertNotCalled(t, "CloseSession", mock.Anything, mock.Anything)
+}
+
+func TestCurrentSession(t *testing.T) {
+	sessions := []*entity.DetentionSession{lunchSession()}
+
+	assert.Nil(t, CurrentSession(sessions, time.Date(2024, 9, 2, 12, 15, 0, 0, time.UTC), 10*time.Minute))
+	assert.NotNil(t, CurrentSession(sessions, time.Date(2024, 9, 2, 12, 20, 0, 0, time.UTC), 10*time.Minute))
+	assert.NotNil(t, CurrentSession(sessions, time.Date(2024, 9, 2, 13, 0, 0, 0, time.UTC), 0))
+	assert.Nil(t, CurrentSession(sessions, time.Date(2024, 9, 2, 13, 1, 0, 0, time.UTC), 0))
+}
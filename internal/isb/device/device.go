@@ -0,0 +1,51 @@
+package device
+
+import (
+	"net/http"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+type SaveRequest struct {
+	Name   string `json:"name"`
+	RoomID int64  `json:"room_id"`
+}
+
+type DeviceController struct {
+	DeviceRepository entity.DeviceRepository `container:"type"`
+}
+
+func (dc *DeviceController) ListHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		devices, err := dc.DeviceRepository.FindAll()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, devices)
+	}
+}
+
+// SaveHandler registers a reader or binds it to a room, room_id 0 unbinds it.
+func (dc *DeviceController) SaveHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request SaveRequest
+		if err := ctx.Bind(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		device := &entity.Device{
+			Id:     ctx.Param("id"),
+			Name:   request.Name,
+			RoomId: request.RoomID,
+		}
+		if err := dc.DeviceRepository.Save(device); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, device)
+	}
+}
@@ -0,0 +1,74 @@
+package device
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDeviceRepository is a mock implementation of entity.DeviceRepository
+type MockDeviceRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeviceRepository) FindById(id string) (*entity.Device, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Device), args.Error(1)
+}
+
+func (m *MockDeviceRepository) FindAll() ([]*entity.Device, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Device), args.Error(1)
+}
+
+func (m *MockDeviceRepository) Save(device *entity.Device) error {
+	return m.Called(device).Error(0)
+}
+
+func TestSaveHandler_BindsDeviceToRoom(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := new(MockDeviceRepository)
+	controller := &DeviceController{DeviceRepository: mockRepo}
+
+	mockRepo.On("Save", &entity.Device{Id: "room-101", Name: "Room reader", RoomId: 101}).Return(nil)
+
+	body, _ := json.Marshal(SaveRequest{Name: "Room reader", RoomID: 101})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/api/devices/room-101", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: "room-101"}}
+	controller.SaveHandler()(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListHandler_RepositoryError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := new(MockDeviceRepository)
+	controller := &DeviceController{DeviceRepository: mockRepo}
+
+	mockRepo.On("FindAll").Return(nil, errors.New("database error"))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/devices", nil)
+	controller.ListHandler()(c)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,221 @@
+package dismissal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/buzyka/imlate/internal/isb/watchlist"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	NotAuthorizedCode = "pickup_not_authorized"
+
+	dismissalKey = "EARLY-DISMISSAL"
+	dateLayout   = "2006-01-02"
+)
+
+// Request identifies the student by card or id and the collecting adult by
+// a selected pickup person, a scanned card or, for unknown adults, a name.
+type Request struct {
+	VisitorID      int32  `json:"visitor_id"`
+	VisitKey       string `json:"visit_key"`
+	PickupPersonID int64  `json:"pickup_person_id"`
+	PickupKey      string `json:"pickup_key"`
+	CollectorName  string `json:"collector_name"`
+	Reason         string `json:"reason"`
+}
+
+type DismissalController struct {
+	DismissalRepository    entity.DismissalRepository    `container:"type"`
+	PickupPersonRepository entity.PickupPersonRepository `container:"type"`
+	VisitorRepository      entity.VisitorRepository      `container:"type"`
+	Tracking               *tracker.TrackingService      `container:"type"`
+	Notifier               entity.Notifier               `container:"type"`
+	Screener               *watchlist.Screener           `container:"type"`
+	Location               *time.Location                `container:"type"`
+}
+
+// DismissHandler signs a student out early when the collecting adult is
+// authorized and not on the safeguarding watchlist, otherwise records the
+// refusal and alerts staff.
+func (dc *DismissalController) DismissHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request Request
+		if err := ctx.Bind(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		if request.PickupPersonID == 0 && request.PickupKey == "" && request.CollectorName == "" {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Collecting adult is required"))))
+			return
+		}
+		visitor, visitKey, ok := dc.findVisitor(ctx, request)
+		if !ok {
+			return
+		}
+		person, err := dc.findAuthorizedPerson(visitor.Id, request)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+
+		dismissal := &entity.Dismissal{
+			VisitorId:     visitor.Id,
+			Visitor:       visitor,
+			CollectorName: request.CollectorName,
+			Reason:        request.Reason,
+			Status:        entity.DismissalRefused,
+		}
+		if person != nil {
+			dismissal.PickupPersonId = person.Id
+			dismissal.PickupPerson = person
+			dismissal.CollectorName = person.Name
+			dismissal.Status = entity.DismissalReleased
+		}
+		flagged, err := dc.Screener.ScreenName(ctx.Request.Context(), dismissal.CollectorName, watchlist.SourcePickup)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if flagged != nil {
+			dismissal.Status = entity.DismissalRefused
+		}
+		if _, err := dc.DismissalRepository.Store(dismissal); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+
+		if dismissal.Status == entity.DismissalRefused {
+			dc.alertRefusal(ctx, dismissal, request)
+			ctx.JSON(http.StatusForbidden, util.ExtendedFailureResponse{
+				Code:  NotAuthorizedCode,
+				Error: "Collecting adult is not authorized, staff have been alerted",
+			})
+			return
+		}
+
+		if err := dc.signOut(ctx.Request.Context(), visitor, visitKey); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, dismissal)
+	}
+}
+
+func (dc *DismissalController) ListHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		day, err := util.ParseDate(ctx.DefaultQuery("date", util.In(time.Now(), dc.Location).Format(dateLayout)), dc.Location)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid date, expected YYYY-MM-DD"))))
+			return
+		}
+		dismissals, err := dc.DismissalRepository.FindBetween(day, day.AddDate(0, 0, 1))
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, dismissals)
+	}
+}
+
+func (dc *DismissalController) findVisitor(ctx *gin.Context, request Request) (*entity.Visitor, string, bool) {
+	if request.VisitKey != "" {
+		details, err := dc.VisitorRepository.FindByKey(ctx.Request.Context(), request.VisitKey)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return nil, "", false
+		}
+		if details == nil || details.Visitor == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor not exists"))))
+			return nil, "", false
+		}
+		return details.Visitor, details.Key, true
+	}
+	visitor, err := dc.VisitorRepository.FindById(ctx.Request.Context(), request.VisitorID)
+	if err != nil {
+		ctx.JSON(util.ServerFailure(err))
+		return nil, "", false
+	}
+	if visitor == nil || visitor.Id == 0 {
+		ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor not exists"))))
+		return nil, "", false
+	}
+	return visitor, dismissalKey, true
+}
+
+func (dc *DismissalController) findAuthorizedPerson(visitorId int32, request Request) (*entity.PickupPerson, error) {
+	if request.PickupPersonID > 0 {
+		person, err := dc.PickupPersonRepository.FindById(request.PickupPersonID)
+		if err != nil || person == nil || person.VisitorId != visitorId {
+			return nil, err
+		}
+		return person, nil
+	}
+	if request.PickupKey != "" {
+		persons, err := dc.PickupPersonRepository.FindByKey(request.PickupKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, person := range persons {
+			if person.VisitorId == visitorId {
+				return person, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// signOut signs the student out when currently signed in.
+func (dc *DismissalController) signOut(ctx context.Context, visitor *entity.Visitor, visitKey string) error {
+	_, err := dc.Tracking.Track(ctx, tracker.Request{
+		VisitorID: visitor.Id,
+		VisitKey:  visitKey,
+		Direction: tracker.DirectionOut,
+	})
+	if errors.Is(err, tracker.ErrNotSignedIn) {
+		return nil
+	}
+	return err
+}
+
+func (dc *DismissalController) alertRefusal(ctx *gin.Context, dismissal *entity.Dismissal, request Request) {
+	logger := logging.FromContext(ctx.Request.Context())
+	logger.Warnw("Early dismissal refused, collecting adult not authorized",
+		"visitor_id", dismissal.VisitorId,
+		"collector_name", request.CollectorName,
+		"pickup_key", request.PickupKey,
+		"pickup_person_id", request.PickupPersonID,
+	)
+	if dc.Notifier == nil {
+		return
+	}
+	err := dc.Notifier.Notify(entity.Notification{
+		Type:    "pickup_refused",
+		Subject: "Unauthorized pickup attempt",
+		Message: fmt.Sprintf(
+			"%s %s was not released: the collecting adult is not authorized.",
+			dismissal.Visitor.Name,
+			dismissal.Visitor.Surname,
+		),
+		Data: map[string]any{
+			"visitor_id":       dismissal.VisitorId,
+			"dismissal_id":     dismissal.Id,
+			"collector_name":   request.CollectorName,
+			"pickup_key":       request.PickupKey,
+			"pickup_person_id": request.PickupPersonID,
+		},
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		logger.Errorf("Error notifying staff about refused pickup: %s", err.Error())
+	}
+}
@@ -0,0 +1,373 @@
+package dismissal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/buzyka/imlate/internal/isb/watchlist"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDismissalRepository is a mock implementation of entity.DismissalRepository
+type MockDismissalRepository struct {
+	mock.Mock
+}
+
+func (m *MockDismissalRepository) Store(dismissal *entity.Dismissal) (*entity.Dismissal, error) {
+	args := m.Called(dismissal)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Dismissal), args.Error(1)
+}
+
+func (m *MockDismissalRepository) FindBetween(from time.Time, to time.Time) ([]*entity.Dismissal, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]*entity.Dismissal), args.Error(1)
+}
+
+// MockPickupPersonRepository is a mock implementation of entity.PickupPersonRepository
+type MockPickupPersonRepository struct {
+	mock.Mock
+}
+
+func (m *MockPickupPersonRepository) FindById(id int64) (*entity.PickupPerson, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PickupPerson), args.Error(1)
+}
+
+func (m *MockPickupPersonRepository) FindByKey(key string) ([]*entity.PickupPerson, error) {
+	args := m.Called(key)
+	return args.Get(0).([]*entity.PickupPerson), args.Error(1)
+}
+
+func (m *MockPickupPersonRepository) FindByVisitorId(visitorId int32) ([]*entity.PickupPerson, error) {
+	args := m.Called(visitorId)
+	return args.Get(0).([]*entity.PickupPerson), args.Error(1)
+}
+
+func (m *MockPickupPersonRepository) Store(person *entity.PickupPerson) error {
+	return m.Called(person).Error(0)
+}
+
+func (m *MockPickupPersonRepository) Delete(id int64) error {
+	return m.Called(id).Error(0)
+}
+
+// MockVisitorRepository is a mock implementation of entity.VisitorRepository
+type MockVisitorRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorRepository) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Visitor), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
+	args := m.Called(visitor, key)
+	return args.Error(0)
+}
+
+// MockVisitorTrackRepository is a mock implementation of entity.VisitorTrackRepository
+type MockVisitorTrackRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorTrackRepository) Store(ctx context.Context, vt *entity.VisitTrack) (*entity.VisitTrack, error) {
+	args := m.Called(vt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) StoreBackdated(ctx context.Context, vt *entity.VisitTrack, until time.Time) (*entity.VisitTrack, error) {
+	args := m.Called(vt, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) GetById(ctx context.Context, id int64) (*entity.VisitTrack, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) GetByClientId(ctx context.Context, clientId string) (*entity.VisitTrack, error) {
+	args := m.Called(clientId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) CountEventsByVisitorIdSince(ctx context.Context, visitorId int32, date time.Time) (int, error) {
+	args := m.Called(visitorId, date)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) CountEventsByVisitorIdBetween(ctx context.Context, visitorId int32, from time.Time, to time.Time) (int, error) {
+	args := m.Called(visitorId, from, to)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) FindPresentVisitorsSince(ctx context.Context, date time.Time) ([]*entity.Visitor, error) {
+	args := m.Called(date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Visitor), args.Error(1)
+}
+
+// MockNotifier is a mock implementation of entity.Notifier
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(notification entity.Notification) error {
+	return m.Called(notification).Error(0)
+}
+
+type MockWatchlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatchlistRepository) FindActive(ctx context.Context) ([]*entity.WatchlistEntry, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WatchlistEntry), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) Store(ctx context.Context, entry *entity.WatchlistEntry) error {
+	return m.Called(entry).Error(0)
+}
+
+func (m *MockWatchlistRepository) Deactivate(ctx context.Context, id int64) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockWatchlistRepository) StoreHit(ctx context.Context, hit *entity.WatchlistHit) error {
+	return m.Called(hit).Error(0)
+}
+
+func (m *MockWatchlistRepository) FindHitsBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.WatchlistHit, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WatchlistHit), args.Error(1)
+}
+
+type testMocks struct {
+	dismissals *MockDismissalRepository
+	pickups    *MockPickupPersonRepository
+	visitors   *MockVisitorRepository
+	tracks     *MockVisitorTrackRepository
+	notifier   *MockNotifier
+}
+
+func newTestController() (*DismissalController, testMocks) {
+	mocks := testMocks{
+		dismissals: new(MockDismissalRepository),
+		pickups:    new(MockPickupPersonRepository),
+		visitors:   new(MockVisitorRepository),
+		tracks:     new(MockVisitorTrackRepository),
+		notifier:   new(MockNotifier),
+	}
+	return &DismissalController{
+		DismissalRepository:    mocks.dismissals,
+		PickupPersonRepository: mocks.pickups,
+		VisitorRepository:      mocks.visitors,
+		Tracking: &tracker.TrackingService{
+			VisitorRepository: mocks.visitors,
+			TrackRepository:   mocks.tracks,
+			Location:          time.UTC,
+		},
+		Notifier: mocks.notifier,
+	}, mocks
+}
+
+func performDismiss(controller *DismissalController, request Request) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(request)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/dismissals", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	controller.DismissHandler()(c)
+	return w
+}
+
+var student = &entity.Visitor{Id: 7, Name: "Tom", Surname: "Doe"}
+
+func TestDismissHandler_AuthorizedPersonSignsStudentOut(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mother := &entity.PickupPerson{Id: 3, VisitorId: 7, Name: "Jane Doe", Relationship: "mother"}
+	mocks.visitors.On("FindByKey", "KEY7").Return(&entity.VisitDetails{Visitor: student, Key: "KEY7"}, nil)
+	mocks.visitors.On("FindById", int32(7)).Return(student, nil)
+	mocks.pickups.On("FindById", int64(3)).Return(mother, nil)
+	mocks.dismissals.On("Store", mock.MatchedBy(func(d *entity.Dismissal) bool {
+		return d.Status == entity.DismissalReleased && d.PickupPersonId == 3 && d.CollectorName == "Jane Doe"
+	})).Return(&entity.Dismissal{}, nil)
+	mocks.tracks.On("CountEventsByVisitorIdSince", int32(7), mock.Anything).Return(1, nil)
+	mocks.tracks.On("Store", mock.MatchedBy(func(vt *entity.VisitTrack) bool {
+		return vt.VisitorId == 7 && vt.VisitKey == "KEY7" && !vt.SignedIn
+	})).Return(&entity.VisitTrack{}, nil)
+
+	w := performDismiss(controller, Request{VisitKey: "KEY7", PickupPersonID: 3, Reason: "dentist"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response entity.Dismissal
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, entity.DismissalReleased, response.Status)
+	assert.Equal(t, "dentist", response.Reason)
+	mocks.tracks.AssertExpectations(t)
+	mocks.notifier.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
+func TestDismissHandler_ScannedAdultCardMatchesStudent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.visitors.On("FindById", int32(7)).Return(student, nil)
+	mocks.pickups.On("FindByKey", "ADULT1").Return([]*entity.PickupPerson{
+		{Id: 2, VisitorId: 8, Name: "Jane Doe"},
+		{Id: 3, VisitorId: 7, Name: "Jane Doe"},
+	}, nil)
+	mocks.dismissals.On("Store", mock.MatchedBy(func(d *entity.Dismissal) bool {
+		return d.Status == entity.DismissalReleased && d.PickupPersonId == 3
+	})).Return(&entity.Dismissal{}, nil)
+	mocks.tracks.On("CountEventsByVisitorIdSince", int32(7), mock.Anything).Return(2, nil)
+
+	w := performDismiss(controller, Request{VisitorID: 7, PickupKey: "ADULT1"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	// Student already signed out, no extra track
+	mocks.tracks.AssertNotCalled(t, "Store", mock.Anything)
+}
+
+func TestDismissHandler_UnauthorizedAdultIsRefusedAndStaffAlerted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.visitors.On("FindByKey", "KEY7").Return(&entity.VisitDetails{Visitor: student, Key: "KEY7"}, nil)
+	mocks.dismissals.On("Store", mock.MatchedBy(func(d *entity.Dismissal) bool {
+		return d.Status == entity.DismissalRefused && d.CollectorName == "Mr Stranger" && d.PickupPersonId == 0
+	})).Return(&entity.Dismissal{}, nil)
+	mocks.notifier.On("Notify", mock.MatchedBy(func(n entity.Notification) bool {
+		return n.Type == "pickup_refused" && n.Data["collector_name"] == "Mr Stranger"
+	})).Return(nil)
+
+	w := performDismiss(controller, Request{VisitKey: "KEY7", CollectorName: "Mr Stranger"})
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var response util.ExtendedFailureResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, NotAuthorizedCode, response.Code)
+	mocks.notifier.AssertExpectations(t)
+	mocks.tracks.AssertNotCalled(t, "Store", mock.Anything)
+}
+
+func TestDismissHandler_PickupPersonOfAnotherStudentIsRefused(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.visitors.On("FindByKey", "KEY7").Return(&entity.VisitDetails{Visitor: student, Key: "KEY7"}, nil)
+	mocks.pickups.On("FindById", int64(2)).Return(&entity.PickupPerson{Id: 2, VisitorId: 8}, nil)
+	mocks.dismissals.On("Store", mock.MatchedBy(func(d *entity.Dismissal) bool {
+		return d.Status == entity.DismissalRefused
+	})).Return(&entity.Dismissal{}, nil)
+	mocks.notifier.On("Notify", mock.Anything).Return(nil)
+
+	w := performDismiss(controller, Request{VisitKey: "KEY7", PickupPersonID: 2})
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDismissHandler_CollectingAdultRequired(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	w := performDismiss(controller, Request{VisitKey: "KEY7"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mocks.dismissals.AssertNotCalled(t, "Store", mock.Anything)
+}
+
+func TestDismissHandler_UnknownStudent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.visitors.On("FindByKey", "NOPE").Return(&entity.VisitDetails{}, nil)
+
+	w := performDismiss(controller, Request{VisitKey: "NOPE", PickupPersonID: 3})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDismissHandler_WatchlistedAdultIsRefusedEvenWhenAuthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+	watchlistRepo := new(MockWatchlistRepository)
+	controller.Screener = &watchlist.Screener{Repository: watchlistRepo}
+
+	father := &entity.PickupPerson{Id: 4, VisitorId: 7, Name: "John Doe", Relationship: "father"}
+	mocks.visitors.On("FindByKey", "KEY7").Return(&entity.VisitDetails{Visitor: student, Key: "KEY7"}, nil)
+	mocks.pickups.On("FindById", int64(4)).Return(father, nil)
+	watchlistRepo.On("FindActive").Return([]*entity.WatchlistEntry{
+		{Id: 2, Name: "John", Surname: "Doe", Category: entity.WatchlistCustody},
+	}, nil)
+	watchlistRepo.On("StoreHit", mock.MatchedBy(func(hit *entity.WatchlistHit) bool {
+		return hit.EntryId == 2 && hit.Source == watchlist.SourcePickup
+	})).Return(nil)
+	mocks.dismissals.On("Store", mock.MatchedBy(func(d *entity.Dismissal) bool {
+		return d.Status == entity.DismissalRefused && d.PickupPersonId == 4
+	})).Return(&entity.Dismissal{}, nil)
+	mocks.notifier.On("Notify", mock.Anything).Return(nil)
+
+	w := performDismiss(controller, Request{VisitKey: "KEY7", PickupPersonID: 4})
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	watchlistRepo.AssertExpectations(t)
+	mocks.tracks.AssertNotCalled(t, "Store", mock.Anything)
+}
@@ -0,0 +1,99 @@
+package dismissal
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+type PickupPersonRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Relationship string `json:"relationship"`
+	Image        string `json:"image"`
+	Key          string `json:"key"`
+}
+
+// PickupController manages the adults authorized to collect a student.
+type PickupController struct {
+	PickupPersonRepository entity.PickupPersonRepository `container:"type"`
+	VisitorRepository      entity.VisitorRepository      `container:"type"`
+}
+
+func (pc *PickupController) ListHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		visitor, ok := pc.visitorFromParam(ctx)
+		if !ok {
+			return
+		}
+		persons, err := pc.PickupPersonRepository.FindByVisitorId(visitor.Id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, persons)
+	}
+}
+
+func (pc *PickupController) CreateHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request PickupPersonRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		visitor, ok := pc.visitorFromParam(ctx)
+		if !ok {
+			return
+		}
+		person := &entity.PickupPerson{
+			VisitorId:    visitor.Id,
+			Name:         request.Name,
+			Relationship: request.Relationship,
+			Image:        request.Image,
+			Key:          request.Key,
+		}
+		if err := pc.PickupPersonRepository.Store(person); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusCreated, person)
+	}
+}
+
+func (pc *PickupController) DeleteHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid pickup person id"))))
+			return
+		}
+		if err := pc.PickupPersonRepository.Delete(id); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+func (pc *PickupController) visitorFromParam(ctx *gin.Context) (*entity.Visitor, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid visitor id"))))
+		return nil, false
+	}
+	visitor, err := pc.VisitorRepository.FindById(ctx.Request.Context(), int32(id))
+	if err != nil {
+		ctx.JSON(util.ServerFailure(err))
+		return nil, false
+	}
+	if visitor == nil || visitor.Id == 0 {
+		ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor not exists"))))
+		return nil, false
+	}
+	return visitor, true
+}
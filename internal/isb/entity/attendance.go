@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+const (
+	AttendancePresent = "present"
+	AttendanceLate    = "late"
+	AttendanceAbsent  = "absent"
+	AttendanceExcused = "excused"
+)
+
+var AttendanceStatuses = []string{AttendancePresent, AttendanceLate, AttendanceAbsent, AttendanceExcused}
+
+// Attendance is the register mark of a visitor for a lesson on a date (YYYY-MM-DD).
+type Attendance struct {
+	Id          int64      `json:"id"`
+	LessonId    int64      `json:"lesson_id"`
+	VisitorId   int32      `json:"visitor_id"`
+	Date        string     `json:"date"`
+	Status      string     `json:"status"`
+	ScannedAt   *time.Time `json:"scanned_at"`
+	MinutesLate int        `json:"minutes_late"`
+	Note        string     `json:"note"`
+}
@@ -0,0 +1,8 @@
+package entity
+
+type AttendanceRepository interface {
+	FindOne(lessonId int64, visitorId int32, date string) (*Attendance, error)
+	FindByLessonAndDate(lessonId int64, date string) ([]*Attendance, error)
+	// Save inserts the mark or replaces the existing one for the same lesson, visitor and date.
+	Save(attendance *Attendance) error
+}
@@ -0,0 +1,12 @@
+package entity
+
+// MessageHandler receives the messages of a subscription.
+type MessageHandler func(topic string, payload []byte)
+
+// MessageBroker carries the scans of networked readers and the results sent
+// back to them. Topic filters may use the MQTT + and # wildcards.
+type MessageBroker interface {
+	Subscribe(filter string, handler MessageHandler) error
+	Publish(topic string, payload []byte) error
+	Close()
+}
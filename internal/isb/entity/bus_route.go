@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// BusRoute is a school bus run, DeviceId is the reader carried on the bus.
+type BusRoute struct {
+	Id       int64      `json:"id"`
+	Name     string     `json:"name"`
+	DeviceId string     `json:"device_id,omitempty"`
+	Stops    []*BusStop `json:"stops,omitempty"`
+	Riders   int        `json:"riders"`
+}
+
+type BusStop struct {
+	Id       int64  `json:"id"`
+	RouteId  int64  `json:"route_id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+	PickupAt string `json:"pickup_at,omitempty"`
+}
+
+// BusRider assigns a student to a route, a student rides one route.
+type BusRider struct {
+	Visitor  *Visitor `json:"visitor"`
+	RouteId  int64    `json:"route_id"`
+	StopId   int64    `json:"stop_id,omitempty"`
+	StopName string   `json:"stop_name,omitempty"`
+}
+
+// BusArrival records a bus reaching school, ExcusalId is the excusal
+// covering the late sign-ins of its riders.
+type BusArrival struct {
+	Id        int64     `json:"id"`
+	RouteId   int64     `json:"route_id"`
+	DeviceId  string    `json:"device_id,omitempty"`
+	ArrivedAt time.Time `json:"arrived_at"`
+	ExcusalId int64     `json:"excusal_id,omitempty"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// BusRouteRepository queries stop when ctx is done, a query running out of time
+// fails with a QueryTimeoutError.
+type BusRouteRepository interface {
+	RouteRoster
+	FindAll(ctx context.Context) ([]*BusRoute, error)
+	GetById(ctx context.Context, id int64) (*BusRoute, error)
+	FindByDeviceId(ctx context.Context, deviceId string) (*BusRoute, error)
+	// Store saves the route together with its stops.
+	Store(ctx context.Context, route *BusRoute) error
+	Delete(ctx context.Context, id int64) error
+	// AssignRider moves the student to the route, stopId may be 0.
+	AssignRider(ctx context.Context, routeId int64, visitorId int32, stopId int64) error
+	RemoveRider(ctx context.Context, routeId int64, visitorId int32) error
+	FindRiders(ctx context.Context, routeId int64) ([]*BusRider, error)
+	// FindRidersWithoutTrackSince returns riders who have not scanned since.
+	FindRidersWithoutTrackSince(ctx context.Context, routeId int64, since time.Time) ([]*BusRider, error)
+	FindRouteIdByVisitorId(ctx context.Context, visitorId int32) (int64, error)
+	StoreArrival(ctx context.Context, arrival *BusArrival) error
+	// FindLatestArrival returns the last arrival of the route since the time.
+	FindLatestArrival(ctx context.Context, routeId int64, since time.Time) (*BusArrival, error)
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	ConsequenceDetention = "detention"
+	ConsequenceNotify    = "notify"
+	ConsequenceWebhook   = "webhook"
+
+	ConsequencePending   = "pending"
+	ConsequenceDone      = "done"
+	ConsequenceCancelled = "cancelled"
+	ConsequenceFailed    = "failed"
+	ConsequenceScheduled = "scheduled" // detention assigned to a session
+	ConsequenceMissed    = "missed"
+
+	RuleSourceConfig   = "config"
+	RuleSourceDatabase = "database"
+)
+
+var ConsequenceActions = []string{ConsequenceDetention, ConsequenceNotify, ConsequenceWebhook}
+
+// ConsequenceRule triggers an action once a student reaches the threshold of
+// lates within the window, e.g. three lates in seven days.
+type ConsequenceRule struct {
+	Id         int64  `json:"id"`
+	Name       string `json:"name"`
+	Threshold  int    `json:"threshold"`
+	WindowDays int    `json:"window_days"`
+	Grades     []int  `json:"grades"` // empty applies to every grade
+	Action     string `json:"action"`
+	Recipient  string `json:"recipient"`   // who is notified, e.g. head of year
+	WebhookURL string `json:"webhook_url"` // target of webhook actions
+	Source     string `json:"source"`
+}
+
+func (r *ConsequenceRule) AppliesTo(visitor *Visitor) bool {
+	return len(r.Grades) == 0 || (visitor != nil && slices.Contains(r.Grades, visitor.Grade))
+}
+
+// Consequence is raised by a rule, detentions stay pending until served.
+type Consequence struct {
+	Id         int64      `json:"id"`
+	Rule       string     `json:"rule"`
+	VisitorId  int32      `json:"visitor_id"`
+	Visitor    *Visitor   `json:"visitor,omitempty"`
+	Action     string     `json:"action"`
+	LateCount  int        `json:"late_count"`
+	Status     string     `json:"status"`
+	Note       string     `json:"note"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// ConsequenceRuleRepository queries stop when ctx is done, a query running
+// out of time fails with a QueryTimeoutError.
+type ConsequenceRuleRepository interface {
+	FindAll(ctx context.Context) ([]*ConsequenceRule, error)
+	Store(ctx context.Context, rule *ConsequenceRule) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// ConsequenceRepository queries stop when ctx is done, a query running out
+// of time fails with a QueryTimeoutError.
+type ConsequenceRepository interface {
+	Store(ctx context.Context, consequence *Consequence) error
+	GetById(ctx context.Context, id int64) (*Consequence, error)
+	FindByStatus(ctx context.Context, status string) ([]*Consequence, error)
+	CountByRuleAndVisitorIdSince(ctx context.Context, rule string, visitorId int32, since time.Time) (int, error)
+	UpdateStatus(ctx context.Context, id int64, status string, note string, at time.Time) error
+}
@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+const (
+	DetentionAssigned = "assigned"
+	DetentionAttended = "attended"
+	DetentionMissed   = "missed"
+	DetentionExcused  = "excused"
+)
+
+var DetentionStatuses = []string{DetentionAssigned, DetentionAttended, DetentionMissed, DetentionExcused}
+
+// DetentionSession is a supervised detention in a room, closed once served.
+type DetentionSession struct {
+	Id           int64      `json:"id"`
+	StartsAt     time.Time  `json:"starts_at"`
+	EndsAt       time.Time  `json:"ends_at"`
+	RoomId       int64      `json:"room_id"`
+	SupervisorId int32      `json:"supervisor_id"`
+	Capacity     int        `json:"capacity"`
+	Assigned     int        `json:"assigned"`
+	ClosedAt     *time.Time `json:"closed_at"`
+}
+
+func (s *DetentionSession) Full() bool {
+	return s.Capacity > 0 && s.Assigned >= s.Capacity
+}
+
+func (s *DetentionSession) Closed() bool {
+	return s.ClosedAt != nil
+}
+
+// DetentionAssignment places a student in a session, ConsequenceId links
+// assignments made from the consequence policy.
+type DetentionAssignment struct {
+	Id            int64      `json:"id"`
+	SessionId     int64      `json:"session_id"`
+	VisitorId     int32      `json:"visitor_id"`
+	Visitor       *Visitor   `json:"visitor,omitempty"`
+	ConsequenceId int64      `json:"consequence_id"`
+	Status        string     `json:"status"`
+	AttendedAt    *time.Time `json:"attended_at"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type DetentionRepository interface {
+	CreateSession(session *DetentionSession) error
+	GetSessionById(id int64) (*DetentionSession, error)
+	FindSessionsBetween(from time.Time, to time.Time) ([]*DetentionSession, error)
+	// FindOpenSessionsByRoom returns sessions in the room not closed yet.
+	FindOpenSessionsByRoom(roomId int64) ([]*DetentionSession, error)
+	CloseSession(id int64, at time.Time) error
+	Assign(assignment *DetentionAssignment) error
+	FindAssignments(sessionId int64) ([]*DetentionAssignment, error)
+	FindAssignment(sessionId int64, visitorId int32) (*DetentionAssignment, error)
+	UpdateAssignment(id int64, status string, attendedAt *time.Time) error
+}
@@ -0,0 +1,13 @@
+package entity
+
+// Device is a card reader. Readers bound to a room record lesson attendance
+// instead of entrance tracking.
+type Device struct {
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+	RoomId int64  `json:"room_id"`
+}
+
+func (d *Device) InRoom() bool {
+	return d.RoomId > 0
+}
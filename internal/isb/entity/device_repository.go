@@ -0,0 +1,7 @@
+package entity
+
+type DeviceRepository interface {
+	FindById(id string) (*Device, error)
+	FindAll() ([]*Device, error)
+	Save(device *Device) error
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+const (
+	DismissalReleased = "released"
+	DismissalRefused  = "refused"
+)
+
+// PickupPerson is an adult authorized to collect a student.
+type PickupPerson struct {
+	Id           int64  `json:"id"`
+	VisitorId    int32  `json:"visitor_id"`
+	Name         string `json:"name"`
+	Relationship string `json:"relationship"`
+	Image        string `json:"image"`
+	Key          string `json:"key,omitempty"`
+}
+
+// Dismissal records a student leaving mid-day and who collected them.
+type Dismissal struct {
+	Id             int64         `json:"id"`
+	VisitorId      int32         `json:"visitor_id"`
+	Visitor        *Visitor      `json:"visitor,omitempty"`
+	PickupPersonId int64         `json:"pickup_person_id"`
+	PickupPerson   *PickupPerson `json:"pickup_person,omitempty"`
+	CollectorName  string        `json:"collector_name"`
+	Reason         string        `json:"reason"`
+	Status         string        `json:"status"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type PickupPersonRepository interface {
+	FindById(id int64) (*PickupPerson, error)
+	FindByKey(key string) ([]*PickupPerson, error)
+	FindByVisitorId(visitorId int32) ([]*PickupPerson, error)
+	Store(person *PickupPerson) error
+	Delete(id int64) error
+}
+
+type DismissalRepository interface {
+	Store(dismissal *Dismissal) (*Dismissal, error)
+	FindBetween(from time.Time, to time.Time) ([]*Dismissal, error)
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+type EvacuationSession struct {
+	Id        int64      `json:"id"`
+	Note      string     `json:"note"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+func (s *EvacuationSession) Active() bool {
+	return s.EndedAt == nil
+}
+
+// EvacuationEntry is a visitor who was signed in when the session started.
+type EvacuationEntry struct {
+	SessionId     int64      `json:"session_id"`
+	Visitor       *Visitor   `json:"visitor"`
+	AccountedAt   *time.Time `json:"accounted_at"`
+	AssemblyPoint string     `json:"assembly_point"`
+}
+
+func (e *EvacuationEntry) Accounted() bool {
+	return e.AccountedAt != nil
+}
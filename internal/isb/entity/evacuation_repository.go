@@ -0,0 +1,21 @@
+package entity
+
+import "errors"
+
+var ErrEvacuationEntryNotFound = errors.New("visitor is not part of the evacuation session")
+
+// ErrEvacuationActive is returned when a session is started while another
+// one of the site has not been ended.
+var ErrEvacuationActive = errors.New("evacuation already in progress")
+
+// ErrEvacuationEnded is returned when ending a session which already ended.
+var ErrEvacuationEnded = errors.New("evacuation already ended")
+
+type EvacuationRepository interface {
+	Start(note string, visitors []*Visitor) (*EvacuationSession, error)
+	GetById(id int64) (*EvacuationSession, error)
+	GetActive() (*EvacuationSession, error)
+	FindEntries(sessionId int64) ([]*EvacuationEntry, error)
+	MarkAccounted(sessionId int64, visitorId int32, assemblyPoint string) error
+	End(sessionId int64) error
+}
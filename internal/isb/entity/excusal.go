@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Excusal excuses late sign-ins in bulk, e.g. when a bus ran late. Every
+// selector which is set has to match, RouteId is resolved by a RouteRoster.
+type Excusal struct {
+	Id         int64      `json:"id"`
+	Reason     string     `json:"reason"`
+	From       time.Time  `json:"from"`
+	To         time.Time  `json:"to"`
+	RouteId    int64      `json:"route_id,omitempty"`
+	Grades     []int      `json:"grades,omitempty"`
+	VisitorIds []int32    `json:"visitor_ids,omitempty"`
+	Excused    int        `json:"excused"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+func (e *Excusal) Revoked() bool {
+	return e.RevokedAt != nil
+}
+
+// Selects reports whether the tardy falls in the window and matches the
+// grade and visitor selectors.
+func (e *Excusal) Selects(tardy *Tardy) bool {
+	if tardy.TrackedAt.Before(e.From) || !tardy.TrackedAt.Before(e.To) {
+		return false
+	}
+	if len(e.VisitorIds) > 0 && !slices.Contains(e.VisitorIds, tardy.VisitorId) {
+		return false
+	}
+	if len(e.Grades) > 0 && (tardy.Visitor == nil || !slices.Contains(e.Grades, tardy.Visitor.Grade)) {
+		return false
+	}
+	return true
+}
+
+// RouteRoster resolves the students riding a bus route.
+type RouteRoster interface {
+	FindVisitorIdsByRoute(ctx context.Context, routeId int64) ([]int32, error)
+}
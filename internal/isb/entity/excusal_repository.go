@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// ExcusalRepository queries stop when ctx is done, a query running out of time
+// fails with a QueryTimeoutError.
+type ExcusalRepository interface {
+	// Store saves the excusal and marks the given tardies as excused by it.
+	Store(ctx context.Context, excusal *Excusal, tardyIds []int64) error
+	// Excuse adds a tardy recorded after the excusal was made.
+	Excuse(ctx context.Context, id int64, tardyId int64) error
+	GetById(ctx context.Context, id int64) (*Excusal, error)
+	FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*Excusal, error)
+	// Revoke reverses the excusal, its tardies count as late again.
+	Revoke(ctx context.Context, id int64, at time.Time) error
+}
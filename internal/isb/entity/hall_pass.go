@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+const (
+	HallPassIssued   = "issued"
+	HallPassOut      = "out"
+	HallPassReturned = "returned"
+)
+
+// HallPass lets a student leave class for a destination for a limited time.
+// Passes issued for scan start at the first reader scan, others at issue.
+type HallPass struct {
+	Id          int64      `json:"id"`
+	VisitorId   int32      `json:"visitor_id"`
+	Visitor     *Visitor   `json:"visitor,omitempty"`
+	TeacherId   int32      `json:"teacher_id"`
+	Destination string     `json:"destination"`
+	MaxMinutes  int        `json:"max_minutes"`
+	IssuedAt    time.Time  `json:"issued_at"`
+	StartedAt   *time.Time `json:"started_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+	Overdue     bool       `json:"overdue"`
+}
+
+func (p *HallPass) Status() string {
+	switch {
+	case p.ClosedAt != nil:
+		return HallPassReturned
+	case p.StartedAt != nil:
+		return HallPassOut
+	default:
+		return HallPassIssued
+	}
+}
+
+func (p *HallPass) Open() bool {
+	return p.ClosedAt == nil
+}
+
+// Minutes is the time spent out of class, up to now for passes still open.
+func (p *HallPass) Minutes(now time.Time) int {
+	if p.StartedAt == nil {
+		return 0
+	}
+	end := now
+	if p.ClosedAt != nil {
+		end = *p.ClosedAt
+	}
+	return int(end.Sub(*p.StartedAt).Minutes())
+}
+
+// IsOverdue reports whether the student was, or still is, out longer than allowed.
+func (p *HallPass) IsOverdue(now time.Time) bool {
+	if p.StartedAt == nil {
+		return false
+	}
+	end := now
+	if p.ClosedAt != nil {
+		end = *p.ClosedAt
+	}
+	return end.Sub(*p.StartedAt) > time.Duration(p.MaxMinutes)*time.Minute
+}
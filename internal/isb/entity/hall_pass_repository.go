@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type HallPassRepository interface {
+	Store(pass *HallPass) error
+	GetById(id int64) (*HallPass, error)
+	FindOpenByVisitorId(visitorId int32) (*HallPass, error)
+	FindOpen() ([]*HallPass, error)
+	FindBetween(from time.Time, to time.Time) ([]*HallPass, error)
+	CountByVisitorIdSince(visitorId int32, since time.Time) (int, error)
+	Start(id int64, at time.Time) error
+	Close(id int64, at time.Time) error
+}
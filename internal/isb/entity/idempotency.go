@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// IdempotentRequest is a write request sent with an Idempotency-Key header
+// and, once it was handled, the response replayed to its retries.
+type IdempotentRequest struct {
+	Key string
+	// RequestHash identifies method, path and body, a key is bound to them.
+	RequestHash string
+	// StatusCode is 0 while the first request is still being handled.
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	ExpiresAt   time.Time
+}
+
+func (r *IdempotentRequest) Completed() bool {
+	return r.StatusCode != 0
+}
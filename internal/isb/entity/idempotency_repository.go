@@ -0,0 +1,12 @@
+package entity
+
+type IdempotencyRepository interface {
+	// Reserve stores the key of a request about to be handled, false when an
+	// unexpired request holds the key already.
+	Reserve(request *IdempotentRequest) (bool, error)
+	// GetByKey returns the unexpired request of the key, nil when there is none.
+	GetByKey(key string) (*IdempotentRequest, error)
+	Complete(request *IdempotentRequest) error
+	// Release frees the key of a request which failed, so a retry runs again.
+	Release(key string) error
+}
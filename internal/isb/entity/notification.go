@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// Notification is an alert delivered to staff through the configured channel.
+type Notification struct {
+	Type      string         `json:"type"`
+	Subject   string         `json:"subject"`
+	Message   string         `json:"message"`
+	Data      map[string]any `json:"data,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type Notifier interface {
+	Notify(notification Notification) error
+}
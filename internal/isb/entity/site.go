@@ -0,0 +1,33 @@
+package entity
+
+// Site is a campus of the school. Visitors have a home site, devices and
+// schedules belong to one, and a site may override the school day of the
+// configuration.
+type Site struct {
+	Id                int64  `json:"id"`
+	Code              string `json:"code"`
+	Name              string `json:"name"`
+	SchoolDayStartsAt string `json:"school_day_starts_at,omitempty"`
+	LateGraceMinutes  *int   `json:"late_grace_minutes,omitempty"`
+}
+
+// Equal reports whether both sites hold the same settings.
+func (s *Site) Equal(other *Site) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	if s.Id != other.Id || s.Code != other.Code || s.Name != other.Name || s.SchoolDayStartsAt != other.SchoolDayStartsAt {
+		return false
+	}
+	if s.LateGraceMinutes == nil || other.LateGraceMinutes == nil {
+		return s.LateGraceMinutes == other.LateGraceMinutes
+	}
+	return *s.LateGraceMinutes == *other.LateGraceMinutes
+}
+
+// ClosedDay is a day without school at the site, nobody is late on it.
+type ClosedDay struct {
+	SiteId int64  `json:"site_id"`
+	Date   string `json:"date"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type SiteRepository interface {
+	FindAll() ([]*Site, error)
+	GetById(id int64) (*Site, error)
+	GetByCode(code string) (*Site, error)
+	// Store creates the site or updates it when it has an id.
+	Store(site *Site) error
+	FindClosedDays(siteId int64, from time.Time, to time.Time) ([]*ClosedDay, error)
+	AddClosedDay(day *ClosedDay) error
+	RemoveClosedDay(siteId int64, date string) error
+	IsClosedOn(siteId int64, date time.Time) (bool, error)
+}
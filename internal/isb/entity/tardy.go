@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// Tardy records why a student signed in late and how late they were.
+type Tardy struct {
+	Id          int64     `json:"id"`
+	TrackId     int64     `json:"track_id"`
+	VisitorId   int32     `json:"visitor_id"`
+	Visitor     *Visitor  `json:"visitor,omitempty"`
+	Reason      string    `json:"reason"`
+	Note        string    `json:"note"`
+	MinutesLate int       `json:"minutes_late"`
+	TrackedAt   time.Time `json:"tracked_at"`
+	// ExcusalId is set while the tardy is excused in bulk, see Excusal.
+	ExcusalId     int64  `json:"excusal_id,omitempty"`
+	ExcusalReason string `json:"excusal_reason,omitempty"`
+}
+
+func (t *Tardy) Excused() bool {
+	return t.ExcusalId > 0
+}
+
+// SlipPrinter sends a rendered slip to a printer.
+type SlipPrinter interface {
+	Print(data []byte) error
+}
+
+// ErrPrinterNotConfigured is returned by printers when no slip printer is set up.
+var ErrPrinterNotConfigured = errors.New("slip printer is not configured")
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// TardyRepository queries stop when ctx is done, a query running out of time
+// fails with a QueryTimeoutError.
+type TardyRepository interface {
+	// Save stores the tardy at the site of its track, a track has at most
+	// one so saving again replaces the reason.
+	Save(ctx context.Context, tardy *Tardy) error
+	GetByTrackId(ctx context.Context, trackId int64) (*Tardy, error)
+	// FindBetween returns the tardies recorded at the site.
+	FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*Tardy, error)
+	// CountByVisitorIdSince counts the tardies which are not excused.
+	CountByVisitorIdSince(ctx context.Context, visitorId int32, since time.Time) (int, error)
+}
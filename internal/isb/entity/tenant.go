@@ -0,0 +1,21 @@
+package entity
+
+// Tenant is a school hosted on the instance. All records belong to one,
+// requests name it by hostname or API token.
+type Tenant struct {
+	Id       int64  `json:"id"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Hostname string `json:"hostname,omitempty"`
+	// Logo is the file name of the logo in the branding directory of the
+	// tenant, empty shows the default one.
+	Logo string `json:"logo,omitempty"`
+}
+
+// Equal reports whether both tenants hold the same settings.
+func (t *Tenant) Equal(other *Tenant) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return *t == *other
+}
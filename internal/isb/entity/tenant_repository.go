@@ -0,0 +1,13 @@
+package entity
+
+type TenantRepository interface {
+	FindAll() ([]*Tenant, error)
+	GetById(id int64) (*Tenant, error)
+	GetByCode(code string) (*Tenant, error)
+	GetByHostname(hostname string) (*Tenant, error)
+	GetByTokenHash(hash string) (*Tenant, error)
+	// Provision creates the tenant together with its first site.
+	Provision(tenant *Tenant, site *Site, tokenHash string) error
+	Update(tenant *Tenant) error
+	SetTokenHash(id int64, hash string) error
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrQueryTimeout matches every QueryTimeoutError with errors.Is.
+var ErrQueryTimeout = errors.New("database query timed out")
+
+// QueryTimeoutError is returned by repositories when a query does not finish
+// within its timeout or the deadline of the request. The request may be
+// retried, handlers answer it with 503.
+type QueryTimeoutError struct {
+	Query string
+	// Limit is the timeout of the query, zero when the request ran out of time.
+	Limit time.Duration
+	Err   error
+}
+
+func (e *QueryTimeoutError) Error() string {
+	if e.Limit > 0 {
+		return fmt.Sprintf("%s timed out after %s", e.Query, e.Limit)
+	}
+	return fmt.Sprintf("%s timed out", e.Query)
+}
+
+func (e *QueryTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+func (e *QueryTimeoutError) Is(target error) bool {
+	return target == ErrQueryTimeout
+}
+
+// Timeout lets callers which do not know the type tell it like a net.Error.
+func (e *QueryTimeoutError) Timeout() bool {
+	return true
+}
@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+type TimesheetRepository interface {
+	// FindStaffTracksBetween returns tracks of non-student visitors ordered by
+	// visitor and time, with the Visitor field populated.
+	FindStaffTracksBetween(from time.Time, to time.Time) ([]*VisitTrack, error)
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type Room struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// Period is a slot of the school day, times are "HH:MM" in school time.
+type Period struct {
+	Id       int64  `json:"id"`
+	Name     string `json:"name"`
+	StartsAt string `json:"starts_at"`
+	EndsAt   string `json:"ends_at"`
+}
+
+func (p *Period) Start(day time.Time) (time.Time, error) {
+	return timeOfDay(day, p.StartsAt)
+}
+
+func (p *Period) End(day time.Time) (time.Time, error) {
+	return timeOfDay(day, p.EndsAt)
+}
+
+type SchoolClass struct {
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	TeacherId int32  `json:"teacher_id"`
+}
+
+// Lesson is a timetable entry: a class taught in a room during a period on a weekday.
+type Lesson struct {
+	Id       int64        `json:"id"`
+	ClassId  int64        `json:"class_id"`
+	PeriodId int64        `json:"period_id"`
+	RoomId   int64        `json:"room_id"`
+	Weekday  time.Weekday `json:"weekday"`
+	Class    *SchoolClass `json:"class,omitempty"`
+	Period   *Period      `json:"period,omitempty"`
+	Room     *Room        `json:"room,omitempty"`
+}
+
+func timeOfDay(day time.Time, clock string) (time.Time, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time of day %q: %w", clock, err)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), parsed.Hour(), parsed.Minute(), 0, 0, day.Location()), nil
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type TimetableRepository interface {
+	CreateRoom(room *Room) error
+	FindRooms() ([]*Room, error)
+	CreatePeriod(period *Period) error
+	FindPeriods() ([]*Period, error)
+	CreateClass(class *SchoolClass) error
+	FindClassById(id int64) (*SchoolClass, error)
+	SetClassMembers(classId int64, visitorIds []int32) error
+	FindClassMembers(classId int64) ([]*Visitor, error)
+	IsClassMember(classId int64, visitorId int32) (bool, error)
+	CreateLesson(lesson *Lesson) error
+	FindLessonById(id int64) (*Lesson, error)
+	FindLessonsByRoom(roomId int64, weekday time.Weekday) ([]*Lesson, error)
+	FindLessonsByTeacher(teacherId int32, weekday time.Weekday) ([]*Lesson, error)
+}
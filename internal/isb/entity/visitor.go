@@ -6,6 +6,8 @@ type Visitor struct {
 	Surname string `json:"surname"`
 	Grade   int    `json:"grade"`
 	Image   string `json:"image"`	
+	// SiteId is the home site of the visitor.
+	SiteId  int64  `json:"site_id,omitempty"`
 }
 
 type VisitDetails struct {
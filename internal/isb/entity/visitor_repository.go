@@ -1,7 +1,19 @@
 package entity
 
+import (
+	"context"
+	"errors"
+)
+
+// ErrKeyAssigned is returned when a key of the tenant belongs to another
+// visitor already.
+var ErrKeyAssigned = errors.New("Key already assigned to another visitor")
+
+// VisitorRepository queries stop when ctx is done, a query running out of
+// time fails with a QueryTimeoutError.
 type VisitorRepository interface {
-	FindById(id int32) (*Visitor, error)
-	FindByKey(key string) (*VisitDetails, error)
-	AddKeyToVisitor(visitor *Visitor, key string) error
-}
\ No newline at end of file
+	FindById(ctx context.Context, id int32) (*Visitor, error)
+	FindByKey(ctx context.Context, key string) (*VisitDetails, error)
+	FindAllWithKeys(ctx context.Context) ([]*VisitDetails, error)
+	AddKeyToVisitor(ctx context.Context, visitor *Visitor, key string) error
+}
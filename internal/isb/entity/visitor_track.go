@@ -9,4 +9,6 @@ type VisitTrack struct {
 	Visitor *Visitor
 	CreatedAt time.Time `json:"created_at"`
 	SignedIn bool `json:"signed_in"`
-}  
\ No newline at end of file
+	// ClientId is the idempotency id an offline kiosk gives a queued scan.
+	ClientId string `json:"client_id,omitempty"`
+}  
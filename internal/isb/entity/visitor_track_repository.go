@@ -1,9 +1,21 @@
 package entity
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
+// VisitorTrackRepository queries stop when ctx is done, a query running out
+// of time fails with a QueryTimeoutError. Lookups of a missing track return
+// nil without an error.
 type VisitorTrackRepository interface {
-	Store(vt *VisitTrack) (*VisitTrack, error)
-	GetById(id int64) (*VisitTrack, error)
-	CountEventsByVisitorIdSince(visitorId int32, date time.Time) (int, error)
-}
\ No newline at end of file
+	Store(ctx context.Context, vt *VisitTrack) (*VisitTrack, error)
+	// StoreBackdated stores a track scanned before tracks stored already and
+	// flips the direction of the visitor's tracks after it and before until.
+	StoreBackdated(ctx context.Context, vt *VisitTrack, until time.Time) (*VisitTrack, error)
+	GetById(ctx context.Context, id int64) (*VisitTrack, error)
+	GetByClientId(ctx context.Context, clientId string) (*VisitTrack, error)
+	CountEventsByVisitorIdSince(ctx context.Context, visitorId int32, date time.Time) (int, error)
+	CountEventsByVisitorIdBetween(ctx context.Context, visitorId int32, from time.Time, to time.Time) (int, error)
+	FindPresentVisitorsSince(ctx context.Context, date time.Time) ([]*Visitor, error)
+}
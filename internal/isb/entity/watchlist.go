@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+const (
+	WatchlistNoAdmit = "no_admit"
+	WatchlistCustody = "custody"
+)
+
+var WatchlistCategories = []string{WatchlistNoAdmit, WatchlistCustody}
+
+// WatchlistEntry flags a person either linked to a visitor or identified by
+// name only, e.g. a guest or a parent with custody restrictions.
+type WatchlistEntry struct {
+	Id        int64     `json:"id"`
+	VisitorId int32     `json:"visitor_id"`
+	Name      string    `json:"name"`
+	Surname   string    `json:"surname"`
+	Category  string    `json:"category"`
+	Notes     string    `json:"notes"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WatchlistHit is logged every time a scan or a name matches an entry.
+type WatchlistHit struct {
+	Id        int64     `json:"id"`
+	EntryId   int64     `json:"entry_id"`
+	VisitorId int32     `json:"visitor_id"`
+	Source    string    `json:"source"`
+	Details   string    `json:"details"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// WatchlistRepository queries stop when ctx is done, a query running out of
+// time fails with a QueryTimeoutError.
+type WatchlistRepository interface {
+	FindActive(ctx context.Context) ([]*WatchlistEntry, error)
+	Store(ctx context.Context, entry *WatchlistEntry) error
+	Deactivate(ctx context.Context, id int64) error
+	StoreHit(ctx context.Context, hit *WatchlistHit) error
+	FindHitsBetween(ctx context.Context, from time.Time, to time.Time) ([]*WatchlistHit, error)
+}
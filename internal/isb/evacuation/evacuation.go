@@ -0,0 +1,273 @@
+package evacuation
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// ActiveCode is the error code of a start while an evacuation is in progress.
+const ActiveCode = "evacuation_active"
+
+type StartRequest struct {
+	Note string `json:"note"`
+}
+
+// CheckRequest marks a visitor as accounted for, either by scanned key or by id.
+type CheckRequest struct {
+	VisitorID     int32  `json:"visitor_id"`
+	VisitKey      string `json:"visit_key"`
+	AssemblyPoint string `json:"assembly_point"`
+}
+
+type Report struct {
+	Session     *entity.EvacuationSession `json:"session"`
+	Total       int                       `json:"total"`
+	Accounted   int                       `json:"accounted"`
+	Unaccounted []*entity.Visitor         `json:"unaccounted"`
+	Entries     []*entity.EvacuationEntry `json:"entries"`
+}
+
+type EvacuationController struct {
+	EvacuationRepository entity.EvacuationRepository   `container:"type"`
+	VisitorRepository    entity.VisitorRepository      `container:"type"`
+	TrackRepository      entity.VisitorTrackRepository `container:"type"`
+	Location             *time.Location                `container:"type"`
+}
+
+// StartHandler snapshots everyone currently signed in into a new session.
+func (ec *EvacuationController) StartHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request StartRequest
+		if ctx.Request.ContentLength > 0 {
+			if err := ctx.Bind(&request); err != nil {
+				ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+				return
+			}
+		}
+		active, err := ec.EvacuationRepository.GetActive()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if active != nil {
+			ec.respondActive(ctx)
+			return
+		}
+		present, err := ec.TrackRepository.FindPresentVisitorsSince(ctx.Request.Context(), util.StartOfDay(util.In(time.Now(), ec.Location)))
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		session, err := ec.EvacuationRepository.Start(request.Note, present)
+		if errors.Is(err, entity.ErrEvacuationActive) {
+			// Another start got in since the check above.
+			ec.respondActive(ctx)
+			return
+		}
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ec.respondWithReport(ctx, http.StatusCreated, session)
+	}
+}
+
+func (ec *EvacuationController) ActiveHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		session, err := ec.EvacuationRepository.GetActive()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if session == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("No evacuation in progress"))))
+			return
+		}
+		ec.respondWithReport(ctx, http.StatusOK, session)
+	}
+}
+
+func (ec *EvacuationController) ReportHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		session, ok := ec.sessionFromParam(ctx)
+		if !ok {
+			return
+		}
+		ec.respondWithReport(ctx, http.StatusOK, session)
+	}
+}
+
+// CheckHandler is used by marshals at assembly points.
+func (ec *EvacuationController) CheckHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request CheckRequest
+		if err := ctx.Bind(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		session, ok := ec.sessionFromParam(ctx)
+		if !ok {
+			return
+		}
+		if !session.Active() {
+			ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(errors.New("Evacuation already ended"))))
+			return
+		}
+
+		visitorId := request.VisitorID
+		if request.VisitKey != "" {
+			details, err := ec.VisitorRepository.FindByKey(ctx.Request.Context(), request.VisitKey)
+			if err != nil {
+				ctx.JSON(util.ServerFailure(err))
+				return
+			}
+			if details == nil || details.Visitor == nil {
+				ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor not exists"))))
+				return
+			}
+			visitorId = details.Visitor.Id
+		}
+		if visitorId == 0 {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("visitor_id or visit_key is required"))))
+			return
+		}
+
+		err := ec.EvacuationRepository.MarkAccounted(session.Id, visitorId, request.AssemblyPoint)
+		if errors.Is(err, entity.ErrEvacuationEntryNotFound) {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(err)))
+			return
+		}
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ec.respondWithReport(ctx, http.StatusOK, session)
+	}
+}
+
+func (ec *EvacuationController) EndHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		session, ok := ec.sessionFromParam(ctx)
+		if !ok {
+			return
+		}
+		if !session.Active() {
+			ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(errors.New("Evacuation already ended"))))
+			return
+		}
+		err := ec.EvacuationRepository.End(session.Id)
+		if errors.Is(err, entity.ErrEvacuationEnded) {
+			ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(errors.New("Evacuation already ended"))))
+			return
+		}
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		session, err = ec.EvacuationRepository.GetById(session.Id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ec.respondWithReport(ctx, http.StatusOK, session)
+	}
+}
+
+// respondActive rejects a start while another session is in progress.
+func (ec *EvacuationController) respondActive(ctx *gin.Context) {
+	ctx.JSON(http.StatusConflict, util.ExtendedFailureResponse{
+		Code:  ActiveCode,
+		Error: "Evacuation already in progress",
+	})
+}
+
+// ExportHandler writes the session as CSV for the drill report.
+func (ec *EvacuationController) ExportHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		session, ok := ec.sessionFromParam(ctx)
+		if !ok {
+			return
+		}
+		entries, err := ec.EvacuationRepository.FindEntries(session.Id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=evacuation-%d.csv", session.Id))
+		ctx.Header("Content-Type", "text/csv")
+		ctx.Status(http.StatusOK)
+		writer := csv.NewWriter(ctx.Writer)
+		_ = writer.Write([]string{"visitor_id", "name", "surname", "grade", "accounted", "accounted_at", "assembly_point"})
+		for _, entry := range entries {
+			accounted, accountedAt := "no", ""
+			if entry.Accounted() {
+				accounted = "yes"
+				accountedAt = util.In(*entry.AccountedAt, ec.Location).Format("2006-01-02 15:04:05")
+			}
+			_ = writer.Write([]string{
+				strconv.Itoa(int(entry.Visitor.Id)),
+				entry.Visitor.Name,
+				entry.Visitor.Surname,
+				strconv.Itoa(entry.Visitor.Grade),
+				accounted,
+				accountedAt,
+				entry.AssemblyPoint,
+			})
+		}
+		writer.Flush()
+	}
+}
+
+func (ec *EvacuationController) sessionFromParam(ctx *gin.Context) (*entity.EvacuationSession, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid evacuation id"))))
+		return nil, false
+	}
+	session, err := ec.EvacuationRepository.GetById(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return nil, false
+	}
+	if session == nil {
+		ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Evacuation not exists"))))
+		return nil, false
+	}
+	return session, true
+}
+
+func (ec *EvacuationController) respondWithReport(ctx *gin.Context, status int, session *entity.EvacuationSession) {
+	entries, err := ec.EvacuationRepository.FindEntries(session.Id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return
+	}
+	ctx.JSON(status, NewReport(session, entries))
+}
+
+func NewReport(session *entity.EvacuationSession, entries []*entity.EvacuationEntry) Report {
+	report := Report{
+		Session:     session,
+		Total:       len(entries),
+		Unaccounted: []*entity.Visitor{},
+		Entries:     entries,
+	}
+	for _, entry := range entries {
+		if entry.Accounted() {
+			report.Accounted++
+		} else {
+			report.Unaccounted = append(report.Unaccounted, entry.Visitor)
+		}
+	}
+	return report
+}
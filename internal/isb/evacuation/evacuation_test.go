@@ -0,0 +1,349 @@
+package evacuation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEvacuationRepository is a mock implementation of entity.EvacuationRepository
+type MockEvacuationRepository struct {
+	mock.Mock
+}
+
+func (m *MockEvacuationRepository) Start(note string, visitors []*entity.Visitor) (*entity.EvacuationSession, error) {
+	args := m.Called(note, visitors)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.EvacuationSession), args.Error(1)
+}
+
+func (m *MockEvacuationRepository) GetById(id int64) (*entity.EvacuationSession, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.EvacuationSession), args.Error(1)
+}
+
+func (m *MockEvacuationRepository) GetActive() (*entity.EvacuationSession, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.EvacuationSession), args.Error(1)
+}
+
+func (m *MockEvacuationRepository) FindEntries(sessionId int64) ([]*entity.EvacuationEntry, error) {
+	args := m.Called(sessionId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.EvacuationEntry), args.Error(1)
+}
+
+func (m *MockEvacuationRepository) MarkAccounted(sessionId int64, visitorId int32, assemblyPoint string) error {
+	args := m.Called(sessionId, visitorId, assemblyPoint)
+	return args.Error(0)
+}
+
+func (m *MockEvacuationRepository) End(sessionId int64) error {
+	args := m.Called(sessionId)
+	return args.Error(0)
+}
+
+// MockVisitorRepository is a mock implementation of entity.VisitorRepository
+type MockVisitorRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorRepository) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Visitor), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
+	args := m.Called(visitor, key)
+	return args.Error(0)
+}
+
+// MockVisitorTrackRepository is a mock implementation of entity.VisitorTrackRepository
+type MockVisitorTrackRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorTrackRepository) Store(ctx context.Context, vt *entity.VisitTrack) (*entity.VisitTrack, error) {
+	args := m.Called(vt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) StoreBackdated(ctx context.Context, vt *entity.VisitTrack, until time.Time) (*entity.VisitTrack, error) {
+	args := m.Called(vt, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) GetById(ctx context.Context, id int64) (*entity.VisitTrack, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) GetByClientId(ctx context.Context, clientId string) (*entity.VisitTrack, error) {
+	args := m.Called(clientId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) CountEventsByVisitorIdSince(ctx context.Context, visitorId int32, date time.Time) (int, error) {
+	args := m.Called(visitorId, date)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) CountEventsByVisitorIdBetween(ctx context.Context, visitorId int32, from time.Time, to time.Time) (int, error) {
+	args := m.Called(visitorId, from, to)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) FindPresentVisitorsSince(ctx context.Context, date time.Time) ([]*entity.Visitor, error) {
+	args := m.Called(date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Visitor), args.Error(1)
+}
+
+func newTestController() (*EvacuationController, *MockEvacuationRepository, *MockVisitorRepository, *MockVisitorTrackRepository) {
+	evacuationRepo := new(MockEvacuationRepository)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	return &EvacuationController{
+		EvacuationRepository: evacuationRepo,
+		VisitorRepository:    visitorRepo,
+		TrackRepository:      trackRepo,
+	}, evacuationRepo, visitorRepo, trackRepo
+}
+
+func newTestContext(method, target, body string, params gin.Params) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	return c, w
+}
+
+func TestStartHandler_SnapshotsPresentVisitors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, evacuationRepo, _, trackRepo := newTestController()
+
+	present := []*entity.Visitor{{Id: 1, Name: "John"}, {Id: 2, Name: "Jane"}}
+	session := &entity.EvacuationSession{Id: 3, StartedAt: time.Now()}
+	entries := []*entity.EvacuationEntry{
+		{SessionId: 3, Visitor: present[0]},
+		{SessionId: 3, Visitor: present[1]},
+	}
+	evacuationRepo.On("GetActive").Return(nil, nil)
+	trackRepo.On("FindPresentVisitorsSince", mock.Anything).Return(present, nil)
+	evacuationRepo.On("Start", "fire drill", present).Return(session, nil)
+	evacuationRepo.On("FindEntries", int64(3)).Return(entries, nil)
+
+	c, w := newTestContext("POST", "/api/evacuation", `{"note":"fire drill"}`, nil)
+	controller.StartHandler()(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var report Report
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 0, report.Accounted)
+	assert.Len(t, report.Unaccounted, 2)
+	evacuationRepo.AssertExpectations(t)
+	trackRepo.AssertExpectations(t)
+}
+
+func TestStartHandler_ActiveSessionConflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, evacuationRepo, _, trackRepo := newTestController()
+
+	evacuationRepo.On("GetActive").Return(&entity.EvacuationSession{Id: 3}, nil)
+
+	c, w := newTestContext("POST", "/api/evacuation", "", nil)
+	controller.StartHandler()(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response util.ExtendedFailureResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, util.ExtendedFailureResponse{Code: ActiveCode, Error: "Evacuation already in progress"}, response)
+	trackRepo.AssertNotCalled(t, "FindPresentVisitorsSince", mock.Anything)
+}
+
+func TestStartHandler_ConcurrentStartConflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, evacuationRepo, _, trackRepo := newTestController()
+
+	present := []*entity.Visitor{{Id: 1, Name: "John"}}
+	evacuationRepo.On("GetActive").Return(nil, nil)
+	trackRepo.On("FindPresentVisitorsSince", mock.Anything).Return(present, nil)
+	evacuationRepo.On("Start", "", present).Return(nil, entity.ErrEvacuationActive)
+
+	c, w := newTestContext("POST", "/api/evacuation", "", nil)
+	controller.StartHandler()(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), ActiveCode)
+	evacuationRepo.AssertExpectations(t)
+}
+
+func TestCheckHandler_ByScannedKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, evacuationRepo, visitorRepo, _ := newTestController()
+
+	accountedAt := time.Now()
+	visitor := &entity.Visitor{Id: 1, Name: "John"}
+	evacuationRepo.On("GetById", int64(3)).Return(&entity.EvacuationSession{Id: 3}, nil)
+	visitorRepo.On("FindByKey", "KEY123").Return(&entity.VisitDetails{Visitor: visitor, Key: "KEY123"}, nil)
+	evacuationRepo.On("MarkAccounted", int64(3), int32(1), "Field A").Return(nil)
+	evacuationRepo.On("FindEntries", int64(3)).Return([]*entity.EvacuationEntry{
+		{SessionId: 3, Visitor: visitor, AccountedAt: &accountedAt, AssemblyPoint: "Field A"},
+		{SessionId: 3, Visitor: &entity.Visitor{Id: 2}},
+	}, nil)
+
+	c, w := newTestContext("POST", "/api/evacuation/3/check", `{"visit_key":"KEY123","assembly_point":"Field A"}`, gin.Params{{Key: "id", Value: "3"}})
+	controller.CheckHandler()(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var report Report
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, 1, report.Accounted)
+	assert.Len(t, report.Unaccounted, 1)
+	assert.Equal(t, int32(2), report.Unaccounted[0].Id)
+	evacuationRepo.AssertExpectations(t)
+	visitorRepo.AssertExpectations(t)
+}
+
+func TestCheckHandler_VisitorNotInSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, evacuationRepo, _, _ := newTestController()
+
+	evacuationRepo.On("GetById", int64(3)).Return(&entity.EvacuationSession{Id: 3}, nil)
+	evacuationRepo.On("MarkAccounted", int64(3), int32(9), "").Return(entity.ErrEvacuationEntryNotFound)
+
+	c, w := newTestContext("POST", "/api/evacuation/3/check", `{"visitor_id":9}`, gin.Params{{Key: "id", Value: "3"}})
+	controller.CheckHandler()(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCheckHandler_EndedSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, evacuationRepo, _, _ := newTestController()
+
+	endedAt := time.Now()
+	evacuationRepo.On("GetById", int64(3)).Return(&entity.EvacuationSession{Id: 3, EndedAt: &endedAt}, nil)
+
+	c, w := newTestContext("POST", "/api/evacuation/3/check", `{"visitor_id":1}`, gin.Params{{Key: "id", Value: "3"}})
+	controller.CheckHandler()(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	evacuationRepo.AssertNotCalled(t, "MarkAccounted", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEndHandler_EndedSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, evacuationRepo, _, _ := newTestController()
+
+	endedAt := time.Now()
+	evacuationRepo.On("GetById", int64(3)).Return(&entity.EvacuationSession{Id: 3, EndedAt: &endedAt}, nil)
+
+	c, w := newTestContext("POST", "/api/evacuation/3/end", "", gin.Params{{Key: "id", Value: "3"}})
+	controller.EndHandler()(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	evacuationRepo.AssertNotCalled(t, "End", mock.Anything)
+}
+
+func TestEndHandler_EndedConcurrently(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, evacuationRepo, _, _ := newTestController()
+
+	evacuationRepo.On("GetById", int64(3)).Return(&entity.EvacuationSession{Id: 3}, nil)
+	evacuationRepo.On("End", int64(3)).Return(entity.ErrEvacuationEnded)
+
+	c, w := newTestContext("POST", "/api/evacuation/3/end", "", gin.Params{{Key: "id", Value: "3"}})
+	controller.EndHandler()(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	evacuationRepo.AssertExpectations(t)
+}
+
+func TestExportHandler_WritesCsv(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, evacuationRepo, _, _ := newTestController()
+
+	accountedAt := time.Date(2024, 9, 2, 10, 5, 0, 0, time.UTC)
+	evacuationRepo.On("GetById", int64(3)).Return(&entity.EvacuationSession{Id: 3}, nil)
+	evacuationRepo.On("FindEntries", int64(3)).Return([]*entity.EvacuationEntry{
+		{SessionId: 3, Visitor: &entity.Visitor{Id: 1, Name: "John", Surname: "Doe", Grade: 5}, AccountedAt: &accountedAt, AssemblyPoint: "Field A"},
+		{SessionId: 3, Visitor: &entity.Visitor{Id: 2, Name: "Jane", Surname: "Smith", Grade: 6}},
+	}, nil)
+
+	c, w := newTestContext("GET", "/api/evacuation/3/export", "", gin.Params{{Key: "id", Value: "3"}})
+	controller.ExportHandler()(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "1,John,Doe,5,yes,2024-09-02 10:05:00,Field A", lines[1])
+	assert.Equal(t, "2,Jane,Smith,6,no,,", lines[2])
+}
+
+func TestReportHandler_InvalidId(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, _, _, _ := newTestController()
+
+	c, w := newTestContext("GET", "/api/evacuation/abc", "", gin.Params{{Key: "id", Value: "abc"}})
+	controller.ReportHandler()(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
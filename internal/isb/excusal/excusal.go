@@ -0,0 +1,185 @@
+package excusal
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type ExcusalRequest struct {
+	Reason     string    `json:"reason" binding:"required"`
+	From       time.Time `json:"from" binding:"required"`
+	To         time.Time `json:"to" binding:"required"`
+	RouteID    int64     `json:"route_id"`
+	Grades     []int     `json:"grades"`
+	VisitorIDs []int32   `json:"visitor_ids"`
+}
+
+type ExcusalResponse struct {
+	Excusal *entity.Excusal `json:"excusal"`
+	Tardies []*entity.Tardy `json:"tardies"`
+}
+
+type ExcusalController struct {
+	ExcusalRepository entity.ExcusalRepository `container:"type"`
+	TardyRepository   entity.TardyRepository   `container:"type"`
+	Routes            entity.RouteRoster       `container:"type"`
+	Now               func() time.Time
+	Location          *time.Location `container:"type"`
+}
+
+// CreateHandler excuses the late sign-ins in the window which match every
+// given selector.
+func (ec *ExcusalController) CreateHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request ExcusalRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		request.Reason = strings.TrimSpace(request.Reason)
+		if request.Reason == "" || !request.To.After(request.From) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("A reason and a window with to after from are required"))))
+			return
+		}
+		if request.RouteID == 0 && len(request.Grades) == 0 && len(request.VisitorIDs) == 0 {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Select students by route_id, grades or visitor_ids"))))
+			return
+		}
+
+		excusal := &entity.Excusal{
+			Reason:     request.Reason,
+			From:       request.From,
+			To:         request.To,
+			RouteId:    request.RouteID,
+			Grades:     request.Grades,
+			VisitorIds: request.VisitorIDs,
+			CreatedAt:  ec.now(),
+		}
+		var riders []int32
+		if request.RouteID > 0 {
+			if ec.Routes == nil {
+				ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Bus routes are not configured"))))
+				return
+			}
+			var err error
+			if riders, err = ec.Routes.FindVisitorIdsByRoute(ctx.Request.Context(), request.RouteID); err != nil {
+				ctx.JSON(util.ServerFailure(err))
+				return
+			}
+		}
+
+		tardies, err := ec.TardyRepository.FindBetween(ctx.Request.Context(), request.From, request.To)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		matched := []*entity.Tardy{}
+		tardyIds := []int64{}
+		for _, tardy := range tardies {
+			if tardy.Excused() || !excusal.Selects(tardy) {
+				continue
+			}
+			if request.RouteID > 0 && !slices.Contains(riders, tardy.VisitorId) {
+				continue
+			}
+			matched = append(matched, tardy)
+			tardyIds = append(tardyIds, tardy.Id)
+		}
+		if len(matched) == 0 {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("No late sign-ins match the selection"))))
+			return
+		}
+		if err := ec.ExcusalRepository.Store(ctx.Request.Context(), excusal, tardyIds); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		for _, tardy := range matched {
+			tardy.ExcusalId = excusal.Id
+			tardy.ExcusalReason = excusal.Reason
+		}
+		logging.FromContext(ctx.Request.Context()).Infow("Late sign-ins excused",
+			"excusal_id", excusal.Id,
+			"reason", excusal.Reason,
+			"excused", excusal.Excused,
+		)
+		ctx.JSON(http.StatusCreated, ExcusalResponse{
+			Excusal: excusal,
+			Tardies: matched,
+		})
+	}
+}
+
+// ListHandler lists excusals overlapping ?from and ?to (inclusive dates),
+// by default today.
+func (ec *ExcusalController) ListHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		today := ec.now().Format(dateLayout)
+		from, err := util.ParseDate(ctx.DefaultQuery("from", today), ec.Location)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid from date, expected YYYY-MM-DD"))))
+			return
+		}
+		to, err := util.ParseDate(ctx.DefaultQuery("to", from.Format(dateLayout)), ec.Location)
+		if err != nil || to.Before(from) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid to date, expected YYYY-MM-DD not before from"))))
+			return
+		}
+		excusals, err := ec.ExcusalRepository.FindBetween(ctx.Request.Context(), from, to.AddDate(0, 0, 1))
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, excusals)
+	}
+}
+
+// RevokeHandler reverses an excusal, its sign-ins count as late again.
+func (ec *ExcusalController) RevokeHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid excusal id"))))
+			return
+		}
+		excusal, err := ec.ExcusalRepository.GetById(ctx.Request.Context(), id)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		if excusal == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Excusal not exists"))))
+			return
+		}
+		if excusal.Revoked() {
+			ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(errors.New("Excusal is already revoked"))))
+			return
+		}
+		now := ec.now()
+		if err := ec.ExcusalRepository.Revoke(ctx.Request.Context(), id, now); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		excusal.RevokedAt = &now
+		logging.FromContext(ctx.Request.Context()).Infow("Excusal revoked", "excusal_id", id)
+		ctx.JSON(http.StatusOK, excusal)
+	}
+}
+
+func (ec *ExcusalController) now() time.Time {
+	if ec.Now != nil {
+		return util.In(ec.Now(), ec.Location)
+	}
+	return util.In(time.Now(), ec.Location)
+}
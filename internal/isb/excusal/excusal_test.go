@@ -0,0 +1,233 @@
+package excusal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockExcusalRepository struct {
+	mock.Mock
+}
+
+func (m *MockExcusalRepository) Store(ctx context.Context, excusal *entity.Excusal, tardyIds []int64) error {
+	return m.Called(excusal, tardyIds).Error(0)
+}
+
+func (m *MockExcusalRepository) Excuse(ctx context.Context, id int64, tardyId int64) error {
+	return m.Called(id, tardyId).Error(0)
+}
+
+func (m *MockExcusalRepository) GetById(ctx context.Context, id int64) (*entity.Excusal, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Excusal), args.Error(1)
+}
+
+func (m *MockExcusalRepository) FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.Excusal, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]*entity.Excusal), args.Error(1)
+}
+
+func (m *MockExcusalRepository) Revoke(ctx context.Context, id int64, at time.Time) error {
+	return m.Called(id, at).Error(0)
+}
+
+type MockTardyRepository struct {
+	mock.Mock
+}
+
+func (m *MockTardyRepository) Save(ctx context.Context, tardy *entity.Tardy) error {
+	return m.Called(tardy).Error(0)
+}
+
+func (m *MockTardyRepository) GetByTrackId(ctx context.Context, trackId int64) (*entity.Tardy, error) {
+	args := m.Called(trackId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tardy), args.Error(1)
+}
+
+func (m *MockTardyRepository) FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.Tardy, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]*entity.Tardy), args.Error(1)
+}
+
+func (m *MockTardyRepository) CountByVisitorIdSince(ctx context.Context, visitorId int32, since time.Time) (int, error) {
+	args := m.Called(visitorId, since)
+	return args.Int(0), args.Error(1)
+}
+
+type MockRouteRoster struct {
+	mock.Mock
+}
+
+func (m *MockRouteRoster) FindVisitorIdsByRoute(ctx context.Context, routeId int64) ([]int32, error) {
+	args := m.Called(routeId)
+	return args.Get(0).([]int32), args.Error(1)
+}
+
+var (
+	now  = time.Date(2024, 9, 2, 9, 30, 0, 0, time.UTC)
+	from = time.Date(2024, 9, 2, 8, 30, 0, 0, time.UTC)
+	to   = time.Date(2024, 9, 2, 9, 15, 0, 0, time.UTC)
+)
+
+func morningTardies() []*entity.Tardy {
+	return []*entity.Tardy{
+		{Id: 1, VisitorId: 7, Visitor: &entity.Visitor{Id: 7, Grade: 7}, TrackedAt: from.Add(10 * time.Minute)},
+		{Id: 2, VisitorId: 8, Visitor: &entity.Visitor{Id: 8, Grade: 8}, TrackedAt: from.Add(12 * time.Minute)},
+		{Id: 3, VisitorId: 9, Visitor: &entity.Visitor{Id: 9, Grade: 7}, TrackedAt: from.Add(15 * time.Minute), ExcusalId: 2},
+		{Id: 4, VisitorId: 10, Visitor: &entity.Visitor{Id: 10, Grade: 7}, TrackedAt: from.Add(20 * time.Minute)},
+	}
+}
+
+func newTestController() (*ExcusalController, *MockExcusalRepository, *MockTardyRepository) {
+	excusals := new(MockExcusalRepository)
+	tardies := new(MockTardyRepository)
+	return &ExcusalController{
+		ExcusalRepository: excusals,
+		TardyRepository:   tardies,
+		Now:               func() time.Time { return now },
+	}, excusals, tardies
+}
+
+func perform(handler gin.HandlerFunc, method string, path string, payload any, params ...gin.Param) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	handler(c)
+	return w
+}
+
+func TestCreateHandler_ExcusesMatchingGrade(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, excusals, tardies := newTestController()
+	tardies.On("FindBetween", from, to).Return(morningTardies(), nil)
+	excusals.On("Store", mock.MatchedBy(func(e *entity.Excusal) bool {
+		return e.Reason == "Bus 12 late" && e.CreatedAt.Equal(now)
+	}), []int64{1, 4}).Run(func(args mock.Arguments) {
+		excusal := args.Get(0).(*entity.Excusal)
+		excusal.Id = 5
+		excusal.Excused = 2
+	}).Return(nil)
+
+	w := perform(controller.CreateHandler(), "POST", "/api/excusals", ExcusalRequest{
+		Reason: "Bus 12 late",
+		From:   from,
+		To:     to,
+		Grades: []int{7},
+	})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response ExcusalResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Excusal.Excused)
+	assert.Len(t, response.Tardies, 2)
+	assert.Equal(t, int64(5), response.Tardies[0].ExcusalId)
+	excusals.AssertExpectations(t)
+}
+
+func TestCreateHandler_RouteAndListMustBothMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, excusals, tardies := newTestController()
+	routes := new(MockRouteRoster)
+	controller.Routes = routes
+	routes.On("FindVisitorIdsByRoute", int64(12)).Return([]int32{7, 8}, nil)
+	tardies.On("FindBetween", from, to).Return(morningTardies(), nil)
+	excusals.On("Store", mock.Anything, []int64{2}).Return(nil)
+
+	w := perform(controller.CreateHandler(), "POST", "/api/excusals", ExcusalRequest{
+		Reason:     "Bus 12 late",
+		From:       from,
+		To:         to,
+		RouteID:    12,
+		VisitorIDs: []int32{8, 10},
+	})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	excusals.AssertExpectations(t)
+}
+
+func TestCreateHandler_RouteWithoutRoster(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, _, tardies := newTestController()
+
+	w := perform(controller.CreateHandler(), "POST", "/api/excusals", ExcusalRequest{
+		Reason:  "Bus 12 late",
+		From:    from,
+		To:      to,
+		RouteID: 12,
+	})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	tardies.AssertNotCalled(t, "FindBetween", mock.Anything, mock.Anything)
+}
+
+func TestCreateHandler_RequiresSelector(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, _, _ := newTestController()
+
+	w := perform(controller.CreateHandler(), "POST", "/api/excusals", ExcusalRequest{
+		Reason: "Bus 12 late",
+		From:   from,
+		To:     to,
+	})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateHandler_NothingToExcuse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, excusals, tardies := newTestController()
+	tardies.On("FindBetween", from, to).Return(morningTardies(), nil)
+
+	w := perform(controller.CreateHandler(), "POST", "/api/excusals", ExcusalRequest{
+		Reason:     "Assembly",
+		From:       from,
+		To:         to,
+		VisitorIDs: []int32{9},
+	})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	excusals.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestRevokeHandler_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, excusals, _ := newTestController()
+	excusals.On("GetById", int64(5)).Return(&entity.Excusal{Id: 5, Reason: "Bus 12 late"}, nil)
+	excusals.On("Revoke", int64(5), now).Return(nil)
+
+	w := perform(controller.RevokeHandler(), "DELETE", "/api/excusals/5", nil, gin.Param{Key: "id", Value: "5"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"revoked_at":"2024-09-02T09:30:00Z"`)
+	excusals.AssertExpectations(t)
+}
+
+func TestRevokeHandler_AlreadyRevoked(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, excusals, _ := newTestController()
+	excusals.On("GetById", int64(5)).Return(&entity.Excusal{Id: 5, RevokedAt: &now}, nil)
+
+	w := perform(controller.RevokeHandler(), "DELETE", "/api/excusals/5", nil, gin.Param{Key: "id", Value: "5"})
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	excusals.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,219 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	imlatev1 "github.com/buzyka/imlate/api/imlate/v1"
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/site"
+	"github.com/buzyka/imlate/internal/isb/tenant"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// SiteMetadata names the site of a call by code or id, see site.Header.
+const SiteMetadata = "x-site"
+
+// Tracker tracks a scan the way the kiosk does, see
+// tracker.TrackingService.FindAndTrack.
+type Tracker interface {
+	FindAndTrack(ctx context.Context, request tracker.Request) (tracker.TrackResponse, error)
+}
+
+// Finder looks up the visitor of a key, see visitor.VisitorService.Lookup.
+type Finder interface {
+	Lookup(ctx context.Context, key string) (*entity.VisitDetails, error)
+}
+
+// Server serves the Tracking service of api/imlate/v1/tracking.proto with
+// the services of the call's tenant and site, the same the HTTP requests
+// are served by.
+type Server struct {
+	imlatev1.UnimplementedTrackingServer
+
+	Tenants       entity.TenantRepository
+	DefaultTenant string
+	Sites         func(t *entity.Tenant) entity.SiteRepository
+	DefaultSite   string
+	Trackers      func(t *entity.Tenant, s *entity.Site) Tracker
+	Finders       func(t *entity.Tenant, s *entity.Site) Finder
+	Feed          *tracker.Feed
+	Logger        *zap.SugaredLogger
+}
+
+func (srv *Server) LookupVisitor(ctx context.Context, request *imlatev1.LookupVisitorRequest) (*imlatev1.LookupVisitorResponse, error) {
+	ctx, t, s, err := srv.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if request.GetVisitKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "visit_key is required")
+	}
+	visit, err := srv.Finders(t, s).Lookup(ctx, request.GetVisitKey())
+	switch {
+	case errors.Is(err, tracker.ErrVisitorNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, entity.ErrQueryTimeout):
+		return nil, status.Error(codes.Unavailable, err.Error())
+	case err != nil:
+		srv.Logger.Errorf("Error looking up visitor: %s", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &imlatev1.LookupVisitorResponse{
+		Visitor:  toVisitor(visit.Visitor),
+		VisitKey: visit.Key,
+	}, nil
+}
+
+func (srv *Server) Track(ctx context.Context, request *imlatev1.TrackRequest) (*imlatev1.TrackResponse, error) {
+	ctx, t, s, err := srv.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if request.GetVisitKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "visit_key is required")
+	}
+	if len(request.GetClientId()) > tracker.MaxScanIdLength {
+		return nil, status.Error(codes.InvalidArgument, "client_id must have at most 64 characters")
+	}
+	response, err := srv.Trackers(t, s).FindAndTrack(ctx, tracker.Request{
+		VisitKey:  request.GetVisitKey(),
+		SignedIn:  true,
+		Direction: request.GetDirection(),
+		ClientId:  request.GetClientId(),
+	})
+	switch {
+	case errors.Is(err, tracker.ErrVisitorNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, tracker.ErrAlreadySignedIn):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, entity.ErrQueryTimeout):
+		return nil, status.Error(codes.Unavailable, err.Error())
+	case err != nil:
+		srv.Logger.Errorf("Error tracking scan: %s", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return toTrackResponse(response), nil
+}
+
+// SubscribeTracks streams the tracks of the site until the client goes away,
+// the response headers are sent once the subscription is made. A client
+// which does not keep up misses tracks, see tracker.Feed.
+func (srv *Server) SubscribeTracks(_ *imlatev1.SubscribeTracksRequest, stream imlatev1.Tracking_SubscribeTracksServer) error {
+	ctx, _, s, err := srv.scope(stream.Context())
+	if err != nil {
+		return err
+	}
+	events, cancel := srv.Feed.Subscribe(s.Id)
+	defer cancel()
+	// The headers tell the client that no track is missed from now on.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-events:
+			if err := stream.Send(&imlatev1.TrackEvent{
+				VisitKey: event.VisitKey,
+				Track:    toTrackResponse(event.Track),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// scope resolves the tenant and site of the call the way the tenant and site
+// middlewares resolve those of a request, and stores them in the context.
+func (srv *Server) scope(ctx context.Context) (context.Context, *entity.Tenant, *entity.Site, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	t, err := srv.tenant(md)
+	if err != nil {
+		return ctx, nil, nil, err
+	}
+	ref := first(md, SiteMetadata)
+	if ref == "" {
+		ref = srv.DefaultSite
+	}
+	resolver := &site.Resolver{Repository: srv.Sites(t), DefaultCode: srv.DefaultSite}
+	s, err := resolver.Find(ref)
+	if err != nil {
+		srv.Logger.Errorf("Error resolving site: %s", err.Error())
+		return ctx, nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	if s == nil {
+		return ctx, nil, nil, status.Error(codes.NotFound, "Site not found")
+	}
+	ctx = tenant.NewContext(site.NewContext(ctx, s), t)
+	ctx = logging.NewContext(ctx, srv.Logger.With("tenant", t.Code, "site", s.Code))
+	return ctx, t, s, nil
+}
+
+// tenant resolves the tenant of the call with tenant.Resolver, the host of
+// the call is its :authority.
+func (srv *Server) tenant(md metadata.MD) (*entity.Tenant, error) {
+	resolver := &tenant.Resolver{Repository: srv.Tenants, DefaultCode: srv.DefaultTenant}
+	t, err := resolver.Find(tenant.Credentials{Token: bearerToken(md), Host: first(md, ":authority")})
+	if errors.Is(err, tenant.ErrTokenRequired) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		srv.Logger.Errorf("Error resolving tenant: %s", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if t == nil {
+		return nil, status.Error(codes.Unauthenticated, "Invalid API token")
+	}
+	return t, nil
+}
+
+func bearerToken(md metadata.MD) string {
+	header := first(md, "authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(header[7:])
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func toVisitor(visitor *entity.Visitor) *imlatev1.Visitor {
+	if visitor == nil {
+		return nil
+	}
+	return &imlatev1.Visitor{
+		Id:      visitor.Id,
+		Name:    visitor.Name,
+		Surname: visitor.Surname,
+		Grade:   int32(visitor.Grade),
+		Image:   visitor.Image,
+		SiteId:  visitor.SiteId,
+	}
+}
+
+func toTrackResponse(response tracker.TrackResponse) *imlatev1.TrackResponse {
+	return &imlatev1.TrackResponse{
+		TrackId:     response.TrackId,
+		Visitor:     toVisitor(response.Visitor),
+		TrackType:   response.TrackType,
+		TrackDate:   response.TrackDate,
+		Status:      response.Status,
+		Late:        response.Late,
+		MinutesLate: int32(response.MinutesLate),
+		LateReasons: response.LateReasons,
+		Excused:     response.Excused,
+	}
+}
@@ -0,0 +1,300 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	imlatev1 "github.com/buzyka/imlate/api/imlate/v1"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/site"
+	"github.com/buzyka/imlate/internal/isb/tenant"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/buzyka/imlate/internal/isb/visitor"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var (
+	defaultTenant = &entity.Tenant{Id: 1, Code: "default"}
+	oakTenant     = &entity.Tenant{Id: 2, Code: "oak", Hostname: "oak.example.org"}
+	mainSite      = &entity.Site{Id: 1, Code: "main"}
+	northSite     = &entity.Site{Id: 2, Code: "north"}
+	oakSite       = &entity.Site{Id: 3, Code: "main"}
+)
+
+var (
+	_ Tracker = (*tracker.TrackingService)(nil)
+	_ Finder  = (*visitor.VisitorService)(nil)
+)
+
+type fakeTenants struct {
+	entity.TenantRepository
+}
+
+func (r *fakeTenants) GetByCode(code string) (*entity.Tenant, error) {
+	if code == defaultTenant.Code {
+		return defaultTenant, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeTenants) GetByHostname(hostname string) (*entity.Tenant, error) {
+	if hostname == oakTenant.Hostname {
+		return oakTenant, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeTenants) GetByTokenHash(hash string) (*entity.Tenant, error) {
+	if hash == tenant.HashToken("oak-token") {
+		return oakTenant, nil
+	}
+	return nil, nil
+}
+
+type fakeSites struct {
+	entity.SiteRepository
+	sites []*entity.Site
+}
+
+func (r *fakeSites) GetByCode(code string) (*entity.Site, error) {
+	for _, s := range r.sites {
+		if s.Code == code {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+// fakeTracker tracks KEY123 and records the request and the site and tenant
+// of the context.
+type fakeTracker struct {
+	err     error
+	request tracker.Request
+	tenant  *entity.Tenant
+	site    *entity.Site
+}
+
+func (f *fakeTracker) FindAndTrack(ctx context.Context, request tracker.Request) (tracker.TrackResponse, error) {
+	f.request = request
+	f.tenant = tenant.FromContext(ctx)
+	f.site = site.FromContext(ctx)
+	if f.err != nil {
+		return tracker.TrackResponse{}, f.err
+	}
+	if request.VisitKey != "KEY123" {
+		return tracker.TrackResponse{}, tracker.ErrVisitorNotFound
+	}
+	return tracker.TrackResponse{
+		TrackId:     9,
+		Visitor:     &entity.Visitor{Id: 7, Name: "Jane", Surname: "Doe", Grade: 5},
+		TrackType:   "sign-in",
+		TrackDate:   "2025-03-03 08:10:00",
+		Late:        true,
+		MinutesLate: 10,
+		LateReasons: []string{"Bus"},
+	}, nil
+}
+
+type fakeFinder struct{}
+
+func (fakeFinder) Lookup(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	if key != "KEY123" {
+		return nil, tracker.ErrVisitorNotFound
+	}
+	return &entity.VisitDetails{Visitor: &entity.Visitor{Id: 7, Name: "Jane"}, Key: key}, nil
+}
+
+func newClient(t *testing.T, srv *Server, options ...grpc.DialOption) imlatev1.TrackingClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	imlatev1.RegisterTrackingServer(server, srv)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	options = append(options,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.NewClient("passthrough:///bufnet", options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return imlatev1.NewTrackingClient(conn)
+}
+
+func newServer(trackers *fakeTracker, feed *tracker.Feed) *Server {
+	return &Server{
+		Tenants:       &fakeTenants{},
+		DefaultTenant: defaultTenant.Code,
+		Sites: func(t *entity.Tenant) entity.SiteRepository {
+			if t.Id == oakTenant.Id {
+				return &fakeSites{sites: []*entity.Site{oakSite}}
+			}
+			return &fakeSites{sites: []*entity.Site{mainSite, northSite}}
+		},
+		DefaultSite: mainSite.Code,
+		Trackers: func(t *entity.Tenant, s *entity.Site) Tracker {
+			return trackers
+		},
+		Finders: func(t *entity.Tenant, s *entity.Site) Finder {
+			return fakeFinder{}
+		},
+		Feed:   feed,
+		Logger: zap.NewNop().Sugar(),
+	}
+}
+
+func TestTrack_DefaultTenantAndSite(t *testing.T) {
+	// Setup
+	trackers := &fakeTracker{}
+	client := newClient(t, newServer(trackers, tracker.NewFeed()))
+
+	// Execute
+	response, err := client.Track(context.Background(), &imlatev1.TrackRequest{VisitKey: "KEY123", Direction: "in"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), response.GetTrackId())
+	assert.Equal(t, "Jane", response.GetVisitor().GetName())
+	assert.Equal(t, int32(5), response.GetVisitor().GetGrade())
+	assert.Equal(t, "sign-in", response.GetTrackType())
+	assert.True(t, response.GetLate())
+	assert.Equal(t, int32(10), response.GetMinutesLate())
+	assert.Equal(t, []string{"Bus"}, response.GetLateReasons())
+	assert.Equal(t, defaultTenant, trackers.tenant)
+	assert.Equal(t, mainSite, trackers.site)
+}
+
+func TestTrack_TenantOfTokenAndSiteOfMetadata(t *testing.T) {
+	// Setup
+	trackers := &fakeTracker{}
+	client := newClient(t, newServer(trackers, tracker.NewFeed()))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer oak-token", SiteMetadata, "main")
+
+	// Execute
+	_, err := client.Track(ctx, &imlatev1.TrackRequest{VisitKey: "KEY123"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, oakTenant, trackers.tenant)
+	assert.Equal(t, oakSite, trackers.site)
+}
+
+func TestTrack_PassesClientId(t *testing.T) {
+	// Setup
+	trackers := &fakeTracker{}
+	client := newClient(t, newServer(trackers, tracker.NewFeed()))
+
+	// Execute
+	_, err := client.Track(context.Background(), &imlatev1.TrackRequest{VisitKey: "KEY123", Direction: "in", ClientId: "scan-1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, tracker.Request{VisitKey: "KEY123", SignedIn: true, Direction: "in", ClientId: "scan-1"}, trackers.request)
+}
+
+func TestTrack_HostnameOfAnotherTenant(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata []string
+		code     codes.Code
+		tenant   *entity.Tenant
+	}{
+		{name: "without token", code: codes.Unauthenticated},
+		{name: "with its token", metadata: []string{"authorization", "Bearer oak-token"}, code: codes.OK, tenant: oakTenant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trackers := &fakeTracker{}
+			client := newClient(t, newServer(trackers, tracker.NewFeed()), grpc.WithAuthority("oak.example.org:443"))
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.metadata...)
+
+			_, err := client.Track(ctx, &imlatev1.TrackRequest{VisitKey: "KEY123"})
+
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.tenant, trackers.tenant)
+		})
+	}
+}
+
+func TestTrack_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata []string
+		key      string
+		clientId string
+		err      error
+		code     codes.Code
+	}{
+		{name: "invalid token", metadata: []string{"authorization", "Bearer wrong"}, key: "KEY123", code: codes.Unauthenticated},
+		{name: "unknown site", metadata: []string{SiteMetadata, "south"}, key: "KEY123", code: codes.NotFound},
+		{name: "missing key", code: codes.InvalidArgument},
+		{name: "long client id", key: "KEY123", clientId: strings.Repeat("x", 65), code: codes.InvalidArgument},
+		{name: "unknown key", key: "UNKNOWN", code: codes.NotFound},
+		{name: "anti-passback", key: "KEY123", err: tracker.ErrAlreadySignedIn, code: codes.FailedPrecondition},
+		{name: "query timeout", key: "KEY123", err: &entity.QueryTimeoutError{Query: "Counting tracks", Err: context.DeadlineExceeded}, code: codes.Unavailable},
+		{name: "failure", key: "KEY123", err: errors.New("db down"), code: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, newServer(&fakeTracker{err: tt.err}, tracker.NewFeed()))
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.metadata...)
+
+			_, err := client.Track(ctx, &imlatev1.TrackRequest{VisitKey: tt.key, ClientId: tt.clientId})
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestLookupVisitor(t *testing.T) {
+	// Setup
+	client := newClient(t, newServer(&fakeTracker{}, tracker.NewFeed()))
+
+	// Execute
+	response, err := client.LookupVisitor(context.Background(), &imlatev1.LookupVisitorRequest{VisitKey: "KEY123"})
+	_, notFound := client.LookupVisitor(context.Background(), &imlatev1.LookupVisitorRequest{VisitKey: "UNKNOWN"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), response.GetVisitor().GetId())
+	assert.Equal(t, "KEY123", response.GetVisitKey())
+	assert.Equal(t, codes.NotFound, status.Code(notFound))
+}
+
+func TestSubscribeTracks_StreamsTracksOfTheSite(t *testing.T) {
+	// Setup
+	feed := tracker.NewFeed()
+	client := newClient(t, newServer(&fakeTracker{}, feed))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.SubscribeTracks(metadata.AppendToOutgoingContext(ctx, SiteMetadata, "north"), &imlatev1.SubscribeTracksRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Header()
+	assert.NoError(t, err)
+
+	// Execute
+	feed.Publish(tracker.TrackEvent{SiteId: mainSite.Id, VisitKey: "OTHER", Track: tracker.TrackResponse{TrackId: 1}})
+	feed.Publish(tracker.TrackEvent{SiteId: northSite.Id, VisitKey: "KEY123", Track: tracker.TrackResponse{TrackId: 2, TrackType: "sign-out"}})
+	event, err := stream.Recv()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "KEY123", event.GetVisitKey())
+	assert.Equal(t, int64(2), event.GetTrack().GetTrackId())
+	assert.Equal(t, "sign-out", event.GetTrack().GetTrackType())
+}
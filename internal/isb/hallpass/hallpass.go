@@ -0,0 +1,268 @@
+package hallpass
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DailyLimitCode = "hall_pass_limit"
+	OpenPassCode   = "hall_pass_open"
+
+	dateLayout = "2006-01-02"
+)
+
+type IssueRequest struct {
+	VisitorID   int32  `json:"visitor_id" binding:"required"`
+	TeacherID   int32  `json:"teacher_id" binding:"required"`
+	Destination string `json:"destination" binding:"required"`
+	MaxMinutes  int    `json:"max_minutes"`
+	// StartOnScan leaves the pass waiting until the student scans out at a reader.
+	StartOnScan bool `json:"start_on_scan"`
+}
+
+type ScanRequest struct {
+	VisitKey string `json:"visit_key" binding:"required"`
+}
+
+type HallPassController struct {
+	HallPassRepository entity.HallPassRepository `container:"type"`
+	VisitorRepository  entity.VisitorRepository  `container:"type"`
+	Config             *config.Config            `container:"type"`
+	Now                func() time.Time
+	Location           *time.Location `container:"type"`
+}
+
+// IssueHandler issues a pass unless the student is already out or has used
+// up the daily limit.
+func (hc *HallPassController) IssueHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request IssueRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		visitor, err := hc.VisitorRepository.FindById(ctx.Request.Context(), request.VisitorID)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		if visitor == nil || visitor.Id == 0 {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor not exists"))))
+			return
+		}
+
+		open, err := hc.HallPassRepository.FindOpenByVisitorId(visitor.Id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if open != nil {
+			ctx.JSON(http.StatusConflict, util.ExtendedFailureResponse{
+				Code:  OpenPassCode,
+				Error: "Student already has an open hall pass",
+			})
+			return
+		}
+
+		now := hc.now()
+		if limit := hc.dailyLimit(); limit > 0 {
+			count, err := hc.HallPassRepository.CountByVisitorIdSince(visitor.Id, util.StartOfDay(now))
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+				return
+			}
+			if count >= limit {
+				ctx.JSON(http.StatusConflict, util.ExtendedFailureResponse{
+					Code:  DailyLimitCode,
+					Error: "Daily hall pass limit reached",
+				})
+				return
+			}
+		}
+
+		pass := &entity.HallPass{
+			VisitorId:   visitor.Id,
+			Visitor:     visitor,
+			TeacherId:   request.TeacherID,
+			Destination: strings.TrimSpace(request.Destination),
+			MaxMinutes:  request.MaxMinutes,
+			IssuedAt:    now,
+		}
+		if pass.MaxMinutes <= 0 {
+			pass.MaxMinutes = hc.defaultMaxMinutes()
+		}
+		if !request.StartOnScan {
+			pass.StartedAt = &now
+		}
+		if err := hc.HallPassRepository.Store(pass); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusCreated, pass)
+	}
+}
+
+// ScanHandler starts a waiting pass or, when the student is already out,
+// closes it on their return.
+func (hc *HallPassController) ScanHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request ScanRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		details, err := hc.VisitorRepository.FindByKey(ctx.Request.Context(), request.VisitKey)
+		if errors.Is(err, entity.ErrQueryTimeout) {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		if err != nil || details == nil || details.Visitor == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor not exists"))))
+			return
+		}
+		pass, err := hc.HallPassRepository.FindOpenByVisitorId(details.Visitor.Id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if pass == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("No open hall pass"))))
+			return
+		}
+
+		now := hc.now()
+		if pass.StartedAt == nil {
+			err = hc.HallPassRepository.Start(pass.Id, now)
+			pass.StartedAt = &now
+		} else {
+			err = hc.HallPassRepository.Close(pass.Id, now)
+			pass.ClosedAt = &now
+		}
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		hc.respondWithPass(ctx, pass, now)
+	}
+}
+
+// CloseHandler lets the teacher close a pass when the student is back
+// without scanning.
+func (hc *HallPassController) CloseHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid hall pass id"))))
+			return
+		}
+		pass, err := hc.HallPassRepository.GetById(id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if pass == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Hall pass not exists"))))
+			return
+		}
+		now := hc.now()
+		if pass.Open() {
+			if err := hc.HallPassRepository.Close(pass.Id, now); err != nil {
+				ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+				return
+			}
+			pass.ClosedAt = &now
+		}
+		hc.respondWithPass(ctx, pass, now)
+	}
+}
+
+// ActiveHandler lists open passes, ?overdue=true keeps only overdue ones.
+func (hc *HallPassController) ActiveHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		passes, err := hc.HallPassRepository.FindOpen()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		now := hc.now()
+		onlyOverdue := ctx.Query("overdue") == "true"
+		result := []*entity.HallPass{}
+		for _, pass := range passes {
+			pass.Overdue = pass.IsOverdue(now)
+			if onlyOverdue && !pass.Overdue {
+				continue
+			}
+			result = append(result, pass)
+		}
+		ctx.JSON(http.StatusOK, result)
+	}
+}
+
+// ReportHandler summarises pass usage between ?from and ?to (inclusive
+// dates), by default for the current day.
+func (hc *HallPassController) ReportHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		today := hc.now().Format(dateLayout)
+		from, err := util.ParseDate(ctx.DefaultQuery("from", today), hc.Location)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid from date, expected YYYY-MM-DD"))))
+			return
+		}
+		to, err := util.ParseDate(ctx.DefaultQuery("to", from.Format(dateLayout)), hc.Location)
+		if err != nil || to.Before(from) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid to date, expected YYYY-MM-DD not before from"))))
+			return
+		}
+		passes, err := hc.HallPassRepository.FindBetween(from, to.AddDate(0, 0, 1))
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, NewReport(from.Format(dateLayout), to.Format(dateLayout), passes, hc.now()))
+	}
+}
+
+func (hc *HallPassController) respondWithPass(ctx *gin.Context, pass *entity.HallPass, now time.Time) {
+	pass.Overdue = pass.IsOverdue(now)
+	if pass.Overdue && !pass.Open() {
+		logging.FromContext(ctx.Request.Context()).Warnw("Hall pass returned overdue",
+			"hall_pass_id", pass.Id,
+			"visitor_id", pass.VisitorId,
+			"minutes", pass.Minutes(now),
+			"max_minutes", pass.MaxMinutes,
+		)
+	}
+	ctx.JSON(http.StatusOK, pass)
+}
+
+func (hc *HallPassController) dailyLimit() int {
+	if hc.Config == nil {
+		return 0
+	}
+	return hc.Config.HallPassDailyLimit
+}
+
+func (hc *HallPassController) defaultMaxMinutes() int {
+	if hc.Config == nil || hc.Config.HallPassMaxMinutes <= 0 {
+		return 10
+	}
+	return hc.Config.HallPassMaxMinutes
+}
+
+func (hc *HallPassController) now() time.Time {
+	if hc.Now != nil {
+		return util.In(hc.Now(), hc.Location)
+	}
+	return util.In(time.Now(), hc.Location)
+}
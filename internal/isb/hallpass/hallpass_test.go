@@ -0,0 +1,259 @@
+package hallpass
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHallPassRepository struct {
+	mock.Mock
+}
+
+func (m *MockHallPassRepository) Store(pass *entity.HallPass) error {
+	return m.Called(pass).Error(0)
+}
+
+func (m *MockHallPassRepository) GetById(id int64) (*entity.HallPass, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.HallPass), args.Error(1)
+}
+
+func (m *MockHallPassRepository) FindOpenByVisitorId(visitorId int32) (*entity.HallPass, error) {
+	args := m.Called(visitorId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.HallPass), args.Error(1)
+}
+
+func (m *MockHallPassRepository) FindOpen() ([]*entity.HallPass, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.HallPass), args.Error(1)
+}
+
+func (m *MockHallPassRepository) FindBetween(from time.Time, to time.Time) ([]*entity.HallPass, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]*entity.HallPass), args.Error(1)
+}
+
+func (m *MockHallPassRepository) CountByVisitorIdSince(visitorId int32, since time.Time) (int, error) {
+	args := m.Called(visitorId, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockHallPassRepository) Start(id int64, at time.Time) error {
+	return m.Called(id, at).Error(0)
+}
+
+func (m *MockHallPassRepository) Close(id int64, at time.Time) error {
+	return m.Called(id, at).Error(0)
+}
+
+type MockVisitorRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorRepository) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Visitor), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
+	return m.Called(visitor, key).Error(0)
+}
+
+var (
+	now     = time.Date(2024, 9, 2, 10, 30, 0, 0, time.UTC)
+	student = &entity.Visitor{Id: 7, Name: "Tom", Surname: "Doe"}
+)
+
+func newTestController() (*HallPassController, *MockHallPassRepository, *MockVisitorRepository) {
+	passes := new(MockHallPassRepository)
+	visitors := new(MockVisitorRepository)
+	return &HallPassController{
+		HallPassRepository: passes,
+		VisitorRepository:  visitors,
+		Config:             &config.Config{HallPassDailyLimit: 2, HallPassMaxMinutes: 10},
+		Now:                func() time.Time { return now },
+	}, passes, visitors
+}
+
+func performJSON(handler gin.HandlerFunc, path string, payload any, params ...gin.Param) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", path, bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	handler(c)
+	return w
+}
+
+func TestIssueHandler_StartsPassWithDefaultDuration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, passes, visitors := newTestController()
+
+	visitors.On("FindById", int32(7)).Return(student, nil)
+	passes.On("FindOpenByVisitorId", int32(7)).Return(nil, nil)
+	passes.On("CountByVisitorIdSince", int32(7), util.StartOfDay(now)).Return(1, nil)
+	passes.On("Store", mock.MatchedBy(func(p *entity.HallPass) bool {
+		return p.MaxMinutes == 10 && p.Destination == "nurse" && p.StartedAt != nil && p.IssuedAt.Equal(now)
+	})).Return(nil)
+
+	w := performJSON(controller.IssueHandler(), "/api/hall-passes", IssueRequest{VisitorID: 7, TeacherID: 2, Destination: "nurse"})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	passes.AssertExpectations(t)
+}
+
+func TestIssueHandler_StartOnScanLeavesPassWaiting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, passes, visitors := newTestController()
+
+	visitors.On("FindById", int32(7)).Return(student, nil)
+	passes.On("FindOpenByVisitorId", int32(7)).Return(nil, nil)
+	passes.On("CountByVisitorIdSince", int32(7), mock.Anything).Return(0, nil)
+	passes.On("Store", mock.MatchedBy(func(p *entity.HallPass) bool {
+		return p.MaxMinutes == 5 && p.StartedAt == nil
+	})).Return(nil)
+
+	w := performJSON(controller.IssueHandler(), "/api/hall-passes", IssueRequest{VisitorID: 7, TeacherID: 2, Destination: "toilet", MaxMinutes: 5, StartOnScan: true})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	passes.AssertExpectations(t)
+}
+
+func TestIssueHandler_DailyLimitReached(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, passes, visitors := newTestController()
+
+	visitors.On("FindById", int32(7)).Return(student, nil)
+	passes.On("FindOpenByVisitorId", int32(7)).Return(nil, nil)
+	passes.On("CountByVisitorIdSince", int32(7), mock.Anything).Return(2, nil)
+
+	w := performJSON(controller.IssueHandler(), "/api/hall-passes", IssueRequest{VisitorID: 7, TeacherID: 2, Destination: "toilet"})
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response util.ExtendedFailureResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, DailyLimitCode, response.Code)
+	passes.AssertNotCalled(t, "Store", mock.Anything)
+}
+
+func TestIssueHandler_OpenPassExists(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, passes, visitors := newTestController()
+
+	visitors.On("FindById", int32(7)).Return(student, nil)
+	passes.On("FindOpenByVisitorId", int32(7)).Return(&entity.HallPass{Id: 3, VisitorId: 7}, nil)
+
+	w := performJSON(controller.IssueHandler(), "/api/hall-passes", IssueRequest{VisitorID: 7, TeacherID: 2, Destination: "toilet"})
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), OpenPassCode)
+}
+
+func TestScanHandler_StartsWaitingPass(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, passes, visitors := newTestController()
+
+	visitors.On("FindByKey", "KEY7").Return(&entity.VisitDetails{Visitor: student, Key: "KEY7"}, nil)
+	passes.On("FindOpenByVisitorId", int32(7)).Return(&entity.HallPass{Id: 3, VisitorId: 7, MaxMinutes: 5}, nil)
+	passes.On("Start", int64(3), now).Return(nil)
+
+	w := performJSON(controller.ScanHandler(), "/api/hall-passes/scan", ScanRequest{VisitKey: "KEY7"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response entity.HallPass
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, entity.HallPassOut, response.Status())
+	passes.AssertExpectations(t)
+}
+
+func TestScanHandler_ClosesPassAndFlagsOverdue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, passes, visitors := newTestController()
+
+	startedAt := now.Add(-12 * time.Minute)
+	visitors.On("FindByKey", "KEY7").Return(&entity.VisitDetails{Visitor: student, Key: "KEY7"}, nil)
+	passes.On("FindOpenByVisitorId", int32(7)).Return(&entity.HallPass{Id: 3, VisitorId: 7, MaxMinutes: 10, StartedAt: &startedAt}, nil)
+	passes.On("Close", int64(3), now).Return(nil)
+
+	w := performJSON(controller.ScanHandler(), "/api/hall-passes/scan", ScanRequest{VisitKey: "KEY7"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response entity.HallPass
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, entity.HallPassReturned, response.Status())
+	assert.True(t, response.Overdue)
+}
+
+func TestScanHandler_NoOpenPass(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, passes, visitors := newTestController()
+
+	visitors.On("FindByKey", "KEY7").Return(&entity.VisitDetails{Visitor: student, Key: "KEY7"}, nil)
+	passes.On("FindOpenByVisitorId", int32(7)).Return(nil, nil)
+
+	w := performJSON(controller.ScanHandler(), "/api/hall-passes/scan", ScanRequest{VisitKey: "KEY7"})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestActiveHandler_FiltersOverdue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, passes, _ := newTestController()
+
+	late := now.Add(-20 * time.Minute)
+	recent := now.Add(-2 * time.Minute)
+	passes.On("FindOpen").Return([]*entity.HallPass{
+		{Id: 1, VisitorId: 7, MaxMinutes: 10, StartedAt: &late},
+		{Id: 2, VisitorId: 8, MaxMinutes: 10, StartedAt: &recent},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/hall-passes/active?overdue=true", nil)
+	controller.ActiveHandler()(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []*entity.HallPass
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, int64(1), response[0].Id)
+	assert.True(t, response[0].Overdue)
+}
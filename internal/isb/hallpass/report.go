@@ -0,0 +1,58 @@
+package hallpass
+
+import (
+	"sort"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// Usage summarises the passes of one student in the report period.
+type Usage struct {
+	Visitor      *entity.Visitor `json:"visitor"`
+	Passes       int             `json:"passes"`
+	TotalMinutes int             `json:"total_minutes"`
+	Overdue      int             `json:"overdue"`
+	Destinations map[string]int  `json:"destinations"`
+}
+
+type Report struct {
+	From         string         `json:"from"`
+	To           string         `json:"to"`
+	Passes       int            `json:"passes"`
+	Overdue      int            `json:"overdue"`
+	Destinations map[string]int `json:"destinations"`
+	Students     []*Usage       `json:"students"`
+}
+
+// NewReport groups passes per student, busiest students first.
+func NewReport(from string, to string, passes []*entity.HallPass, now time.Time) Report {
+	report := Report{
+		From:         from,
+		To:           to,
+		Destinations: map[string]int{},
+		Students:     []*Usage{},
+	}
+	byVisitor := map[int32]*Usage{}
+	for _, pass := range passes {
+		usage, ok := byVisitor[pass.VisitorId]
+		if !ok {
+			usage = &Usage{Visitor: pass.Visitor, Destinations: map[string]int{}}
+			byVisitor[pass.VisitorId] = usage
+			report.Students = append(report.Students, usage)
+		}
+		usage.Passes++
+		usage.TotalMinutes += pass.Minutes(now)
+		usage.Destinations[pass.Destination]++
+		report.Passes++
+		report.Destinations[pass.Destination]++
+		if pass.IsOverdue(now) {
+			usage.Overdue++
+			report.Overdue++
+		}
+	}
+	sort.SliceStable(report.Students, func(i, j int) bool {
+		return report.Students[i].Passes > report.Students[j].Passes
+	})
+	return report
+}
@@ -0,0 +1,47 @@
+package hallpass
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func at(hour, minute int) *time.Time {
+	t := time.Date(2024, 9, 2, hour, minute, 0, 0, time.UTC)
+	return &t
+}
+
+func TestNewReport_GroupsPassesPerStudent(t *testing.T) {
+	tom := &entity.Visitor{Id: 7, Name: "Tom"}
+	ann := &entity.Visitor{Id: 8, Name: "Ann"}
+	passes := []*entity.HallPass{
+		{VisitorId: 8, Visitor: ann, Destination: "nurse", MaxMinutes: 10, StartedAt: at(9, 0), ClosedAt: at(9, 5)},
+		{VisitorId: 7, Visitor: tom, Destination: "toilet", MaxMinutes: 5, StartedAt: at(10, 0), ClosedAt: at(10, 12)},
+		{VisitorId: 7, Visitor: tom, Destination: "toilet", MaxMinutes: 5, StartedAt: at(13, 0)},
+	}
+
+	report := NewReport("2024-09-02", "2024-09-02", passes, *at(13, 3))
+
+	assert.Equal(t, 3, report.Passes)
+	assert.Equal(t, 1, report.Overdue)
+	assert.Equal(t, map[string]int{"nurse": 1, "toilet": 2}, report.Destinations)
+	assert.Len(t, report.Students, 2)
+	assert.Equal(t, tom, report.Students[0].Visitor)
+	assert.Equal(t, 2, report.Students[0].Passes)
+	assert.Equal(t, 15, report.Students[0].TotalMinutes)
+	assert.Equal(t, 1, report.Students[0].Overdue)
+	assert.Equal(t, 5, report.Students[1].TotalMinutes)
+}
+
+func TestNewReport_WaitingPassIsNeverOverdue(t *testing.T) {
+	passes := []*entity.HallPass{
+		{VisitorId: 7, Visitor: &entity.Visitor{Id: 7}, Destination: "office", MaxMinutes: 5},
+	}
+
+	report := NewReport("2024-09-02", "2024-09-02", passes, *at(15, 0))
+
+	assert.Equal(t, 0, report.Overdue)
+	assert.Equal(t, 0, report.Students[0].TotalMinutes)
+}
@@ -0,0 +1,192 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+
+	KeyReusedCode  = "idempotency_key_reused"
+	InProgressCode = "idempotency_key_in_progress"
+
+	maxKeyLength = 255
+	// maxBodyBytes bounds the body kept in memory to hash the request.
+	maxBodyBytes = 1 << 20
+)
+
+// Guard makes write requests carrying an Idempotency-Key header safe to
+// retry: the first response is stored for the configured period and
+// replayed to every retry with the same key.
+type Guard struct {
+	Repository entity.IdempotencyRepository `container:"type"`
+	Config     *config.Config               `container:"type"`
+	Now        func() time.Time
+}
+
+func (g *Guard) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(Header)
+		if key == "" || !isWrite(ctx.Request.Method) || g.ttl() <= 0 {
+			ctx.Next()
+			return
+		}
+		if len(key) > maxKeyLength {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Idempotency-Key must not exceed 255 characters"))))
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodyBytes))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, util.NewFailureResponse(exception.Validation(errors.New("Request body must not exceed 1 MiB"))))
+			return
+		}
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		request := &entity.IdempotentRequest{
+			Key:         key,
+			RequestHash: requestHash(ctx.Request, body),
+			ExpiresAt:   g.now().Add(g.ttl()),
+		}
+		reserved, err := g.Repository.Reserve(request)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if !reserved {
+			g.replay(ctx, request)
+			return
+		}
+
+		// A handler which panics answers no response to replay, the key is
+		// released for the retry and the panic left to the recovery.
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				g.release(ctx, key)
+				panic(recovered)
+			}
+		}()
+
+		recorder := &bodyRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			g.release(ctx, key)
+			return
+		}
+		request.StatusCode = recorder.Status()
+		request.ContentType = recorder.Header().Get("Content-Type")
+		request.Body = recorder.body.Bytes()
+		if err := g.Repository.Complete(request); err != nil {
+			logging.FromContext(ctx.Request.Context()).Errorf("Error storing idempotent response: %s", err.Error())
+		}
+	}
+}
+
+// release forgets the key of a request which failed, a retry runs it again.
+func (g *Guard) release(ctx *gin.Context, key string) {
+	if err := g.Repository.Release(key); err != nil {
+		logging.FromContext(ctx.Request.Context()).Errorf("Error releasing idempotency key: %s", err.Error())
+	}
+}
+
+// replay answers a retry with the stored response, a key sent with another
+// request or while the first one is still running is a conflict.
+func (g *Guard) replay(ctx *gin.Context, request *entity.IdempotentRequest) {
+	stored, err := g.Repository.GetByKey(request.Key)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return
+	}
+	if stored != nil && stored.RequestHash != request.RequestHash {
+		ctx.AbortWithStatusJSON(http.StatusConflict, util.ExtendedFailureResponse{
+			Code:  KeyReusedCode,
+			Error: "Idempotency-Key was used with a different request",
+		})
+		return
+	}
+	if stored == nil || !stored.Completed() {
+		ctx.AbortWithStatusJSON(http.StatusConflict, util.ExtendedFailureResponse{
+			Code:  InProgressCode,
+			Error: "A request with this Idempotency-Key is in progress",
+		})
+		return
+	}
+	ctx.Header(ReplayedHeader, "true")
+	ctx.Data(stored.StatusCode, stored.ContentType, stored.Body)
+	ctx.Abort()
+}
+
+func (g *Guard) ttl() time.Duration {
+	if g.Config == nil {
+		return 0
+	}
+	return time.Duration(g.Config.IdempotencyKeyTTLHours) * time.Hour
+}
+
+func (g *Guard) now() time.Time {
+	if g.Now != nil {
+		return g.Now()
+	}
+	return time.Now()
+}
+
+func isWrite(method string) bool {
+	return method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+}
+
+// requestHash identifies the request by its method, route and body.
+func requestHash(request *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(request.Method + " " + route(request.URL.Path) + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// route returns the path of the request without the API prefix, the
+// deprecated aliases and the paths of /api/v1 they stand for are the same
+// request, see cmd/app.
+func route(path string) string {
+	for _, prefix := range []string{"/api/v1/", "/api/"} {
+		if strings.HasPrefix(path, prefix) {
+			return "/" + strings.TrimPrefix(path, prefix)
+		}
+	}
+	return path
+}
+
+// bodyRecorder keeps a copy of the response written by the handlers.
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
@@ -0,0 +1,227 @@
+package idempotency
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) Reserve(request *entity.IdempotentRequest) (bool, error) {
+	args := m.Called(request)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockIdempotencyRepository) GetByKey(key string) (*entity.IdempotentRequest, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.IdempotentRequest), args.Error(1)
+}
+
+func (m *MockIdempotencyRepository) Complete(request *entity.IdempotentRequest) error {
+	return m.Called(request).Error(0)
+}
+
+func (m *MockIdempotencyRepository) Release(key string) error {
+	return m.Called(key).Error(0)
+}
+
+// newRouter serves a write endpoint which counts how often it really ran.
+func newRouter(guard *Guard, status int, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(guard.Middleware())
+	r.POST("/find-and-track", func(ctx *gin.Context) {
+		*calls++
+		ctx.JSON(status, gin.H{"track_id": *calls})
+	})
+	return r
+}
+
+func send(r *gin.Engine, key string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/find-and-track", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(Header, key)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func newGuard(repo *MockIdempotencyRepository) *Guard {
+	return &Guard{
+		Repository: repo,
+		Config:     &config.Config{IdempotencyKeyTTLHours: 24},
+	}
+}
+
+func TestMiddleware_StoresFirstResponse(t *testing.T) {
+	repo := new(MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(newGuard(repo), http.StatusOK, &calls)
+
+	repo.On("Reserve", mock.MatchedBy(func(request *entity.IdempotentRequest) bool {
+		return request.Key == "key-1" && request.RequestHash != "" && !request.Completed()
+	})).Return(true, nil)
+	repo.On("Complete", mock.MatchedBy(func(request *entity.IdempotentRequest) bool {
+		return request.StatusCode == http.StatusOK && string(request.Body) == `{"track_id":1}` &&
+			request.ContentType == "application/json; charset=utf-8"
+	})).Return(nil)
+
+	w := send(r, "key-1", `{"visit_key":"KEY123"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, calls)
+	repo.AssertExpectations(t)
+}
+
+func TestMiddleware_ReplaysStoredResponse(t *testing.T) {
+	repo := new(MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(newGuard(repo), http.StatusOK, &calls)
+
+	var first *entity.IdempotentRequest
+	repo.On("Reserve", mock.Anything).Return(true, nil).Once()
+	repo.On("Complete", mock.Anything).Run(func(args mock.Arguments) {
+		first = args.Get(0).(*entity.IdempotentRequest)
+	}).Return(nil)
+
+	original := send(r, "key-1", `{"visit_key":"KEY123"}`)
+
+	repo.On("Reserve", mock.Anything).Return(false, nil).Once()
+	repo.On("GetByKey", "key-1").Return(first, nil)
+	retry := send(r, "key-1", `{"visit_key":"KEY123"}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusOK, retry.Code)
+	assert.Equal(t, original.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(ReplayedHeader))
+}
+
+func TestMiddleware_KeyReusedWithDifferentBody(t *testing.T) {
+	repo := new(MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(newGuard(repo), http.StatusOK, &calls)
+
+	repo.On("Reserve", mock.Anything).Return(false, nil)
+	repo.On("GetByKey", "key-1").Return(&entity.IdempotentRequest{Key: "key-1", RequestHash: "other", StatusCode: http.StatusOK}, nil)
+
+	w := send(r, "key-1", `{"visit_key":"KEY999"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), KeyReusedCode)
+	assert.Equal(t, 0, calls)
+}
+
+func TestMiddleware_FirstRequestInProgress(t *testing.T) {
+	repo := new(MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(newGuard(repo), http.StatusOK, &calls)
+
+	repo.On("Reserve", mock.Anything).Return(false, nil)
+	hash := requestHash(httptest.NewRequest("POST", "/find-and-track", nil), []byte(`{}`))
+	repo.On("GetByKey", "key-1").Return(&entity.IdempotentRequest{Key: "key-1", RequestHash: hash}, nil)
+
+	w := send(r, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), InProgressCode)
+	assert.Equal(t, 0, calls)
+}
+
+func TestMiddleware_ServerErrorReleasesKey(t *testing.T) {
+	repo := new(MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(newGuard(repo), http.StatusInternalServerError, &calls)
+
+	repo.On("Reserve", mock.Anything).Return(true, nil)
+	repo.On("Release", "key-1").Return(nil)
+
+	w := send(r, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "Complete", mock.Anything)
+}
+
+func TestMiddleware_PanicReleasesKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockIdempotencyRepository)
+	r := gin.New()
+	r.Use(gin.Recovery(), newGuard(repo).Middleware())
+	r.POST("/find-and-track", func(ctx *gin.Context) {
+		panic("handler failed")
+	})
+
+	repo.On("Reserve", mock.Anything).Return(true, nil)
+	repo.On("Release", "key-1").Return(nil)
+
+	w := send(r, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "Complete", mock.Anything)
+}
+
+func TestMiddleware_OversizedBody(t *testing.T) {
+	repo := new(MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(newGuard(repo), http.StatusOK, &calls)
+
+	w := send(r, "key-1", `{"visit_key":"`+strings.Repeat("x", maxBodyBytes)+`"}`)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, 0, calls)
+	repo.AssertNotCalled(t, "Reserve", mock.Anything)
+}
+
+func TestRequestHash_DeprecatedAliasMatchesVersionedPath(t *testing.T) {
+	body := []byte(`{"visit_key":"KEY123"}`)
+	hash := func(path string) string {
+		return requestHash(httptest.NewRequest("POST", path, nil), body)
+	}
+
+	assert.Equal(t, hash("/api/v1/find-and-track"), hash("/find-and-track"))
+	assert.Equal(t, hash("/api/v1/scans/batch"), hash("/api/scans/batch"))
+	assert.NotEqual(t, hash("/api/v1/find-and-track"), hash("/api/v1/track"))
+}
+
+func TestMiddleware_WithoutKeyPassesThrough(t *testing.T) {
+	repo := new(MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(newGuard(repo), http.StatusOK, &calls)
+
+	send(r, "", `{}`)
+	send(r, "", `{}`)
+
+	assert.Equal(t, 2, calls)
+	repo.AssertNotCalled(t, "Reserve", mock.Anything)
+}
+
+func TestMiddleware_RepositoryError(t *testing.T) {
+	repo := new(MockIdempotencyRepository)
+	calls := 0
+	r := newRouter(newGuard(repo), http.StatusOK, &calls)
+
+	repo.On("Reserve", mock.Anything).Return(false, errors.New("db down"))
+
+	w := send(r, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, 0, calls)
+}
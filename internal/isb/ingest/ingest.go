@@ -0,0 +1,117 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/site"
+	"github.com/buzyka/imlate/internal/isb/tenant"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"go.uber.org/zap"
+)
+
+const (
+	InvalidPayloadCode = "invalid_payload"
+	UnknownReaderCode  = "unknown_reader"
+	NotFoundCode       = "not_found"
+	ErrorCode          = "error"
+)
+
+// Tracker tracks a scan the way the kiosk does, see
+// tracker.TrackingService.FindAndTrack.
+type Tracker interface {
+	FindAndTrack(ctx context.Context, request tracker.Request) (tracker.TrackResponse, error)
+}
+
+// Result is published back to the reader for every scan, the response the
+// kiosk gets or the code of the failure.
+type Result struct {
+	Device   string `json:"device,omitempty"`
+	VisitKey string `json:"visit_key,omitempty"`
+	*tracker.TrackResponse
+	Code  string `json:"code,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Subscriber feeds the scans networked readers publish to a message broker
+// into tracking and publishes the result to the reader's result topic.
+//
+// The tenant and site of a scan are those of its topic, readers send no
+// credential of their own. The ACLs of the broker must let every reader
+// publish to its own scan topic and read its own result topic only, else
+// one reader tracks scans for any tenant.
+type Subscriber struct {
+	Broker  entity.MessageBroker
+	Scans   Template
+	Results Template
+	Mapping Mapping
+	// Resolve returns the tenant and site of the codes in a topic.
+	Resolve func(tenantCode string, siteCode string) (*entity.Tenant, *entity.Site, error)
+	// Trackers returns the tracker serving the site.
+	Trackers func(t *entity.Tenant, s *entity.Site) Tracker
+	Logger   *zap.SugaredLogger
+}
+
+func (sub *Subscriber) Start() error {
+	return sub.Broker.Subscribe(sub.Scans.Filter(), sub.handle)
+}
+
+func (sub *Subscriber) handle(topic string, payload []byte) {
+	reader, ok := sub.Scans.Match(topic)
+	if !ok {
+		sub.Logger.Warnw("Ignoring message on unexpected topic", "topic", topic)
+		return
+	}
+	result := sub.track(reader, payload)
+	sub.publish(reader, result)
+}
+
+func (sub *Subscriber) track(reader Reader, payload []byte) Result {
+	result := Result{Device: reader.Device}
+	request, err := sub.Mapping.Scan(payload)
+	if err != nil {
+		return failed(result, InvalidPayloadCode, err)
+	}
+	result.VisitKey = request.VisitKey
+
+	t, s, err := sub.Resolve(reader.Tenant, reader.Site)
+	if err != nil {
+		sub.Logger.Warnw("Scan of unknown reader", "tenant", reader.Tenant, "site", reader.Site, "device", reader.Device, "error", err.Error())
+		return failed(result, UnknownReaderCode, err)
+	}
+
+	ctx := tenant.NewContext(site.NewContext(context.Background(), s), t)
+	ctx = logging.NewContext(ctx, sub.Logger.With("tenant", t.Code, "site", s.Code, "device", reader.Device))
+	response, err := sub.Trackers(t, s).FindAndTrack(ctx, request)
+	switch {
+	case errors.Is(err, tracker.ErrVisitorNotFound):
+		return failed(result, NotFoundCode, err)
+	case errors.Is(err, tracker.ErrAlreadySignedIn):
+		return failed(result, tracker.AntiPassbackCode, err)
+	case err != nil:
+		sub.Logger.Errorf("Error tracking reader scan: %s", err.Error())
+		return failed(result, ErrorCode, err)
+	}
+	result.TrackResponse = &response
+	return result
+}
+
+func (sub *Subscriber) publish(reader Reader, result Result) {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		sub.Logger.Errorf("Error encoding reader result: %s", err.Error())
+		return
+	}
+	if err := sub.Broker.Publish(sub.Results.Topic(reader), payload); err != nil {
+		sub.Logger.Errorf("Error publishing reader result: %s", err.Error())
+	}
+}
+
+func failed(result Result, code string, err error) Result {
+	result.Code = code
+	result.Error = err.Error()
+	return result
+}
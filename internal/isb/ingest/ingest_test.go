@@ -0,0 +1,174 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/site"
+	"github.com/buzyka/imlate/internal/isb/tenant"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// The kiosk tracker is what readers feed.
+var _ Tracker = (*tracker.TrackingService)(nil)
+
+// memoryBroker delivers published messages to matching subscriptions.
+type memoryBroker struct {
+	subscriptions map[string]entity.MessageHandler
+	published     map[string][]byte
+}
+
+func newMemoryBroker() *memoryBroker {
+	return &memoryBroker{
+		subscriptions: map[string]entity.MessageHandler{},
+		published:     map[string][]byte{},
+	}
+}
+
+func (b *memoryBroker) Subscribe(filter string, handler entity.MessageHandler) error {
+	b.subscriptions[filter] = handler
+	return nil
+}
+
+func (b *memoryBroker) Publish(topic string, payload []byte) error {
+	b.published[topic] = payload
+	for filter, handler := range b.subscriptions {
+		if matches(filter, topic) {
+			handler(topic, payload)
+		}
+	}
+	return nil
+}
+
+func (b *memoryBroker) Close() {}
+
+func matches(filter string, topic string) bool {
+	filterParts := strings.Split(filter, "/")
+	topicParts := strings.Split(topic, "/")
+	for i, part := range filterParts {
+		if part == "#" {
+			return true
+		}
+		if i >= len(topicParts) || (part != "+" && part != topicParts[i]) {
+			return false
+		}
+	}
+	return len(filterParts) == len(topicParts)
+}
+
+type fakeTracker struct {
+	requests []tracker.Request
+	sites    []string
+	response tracker.TrackResponse
+	err      error
+}
+
+func (f *fakeTracker) FindAndTrack(ctx context.Context, request tracker.Request) (tracker.TrackResponse, error) {
+	f.requests = append(f.requests, request)
+	f.sites = append(f.sites, tenant.FromContext(ctx).Code+"/"+site.FromContext(ctx).Code)
+	return f.response, f.err
+}
+
+var (
+	oak   = &entity.Tenant{Id: 2, Code: "oak", Name: "Oak school"}
+	north = &entity.Site{Id: 3, Code: "north", Name: "North campus"}
+)
+
+func newSubscriber(t *testing.T, broker *memoryBroker, tr *fakeTracker) *Subscriber {
+	scans, err := ParseTemplate("imlate/{tenant}/{site}/{device}/scan")
+	assert.NoError(t, err)
+	results, err := ParseTemplate("imlate/{tenant}/{site}/{device}/result")
+	assert.NoError(t, err)
+	sub := &Subscriber{
+		Broker:  broker,
+		Scans:   scans,
+		Results: results,
+		Mapping: Mapping{KeyField: "card_id", DirectionField: "direction"},
+		Resolve: func(tenantCode string, siteCode string) (*entity.Tenant, *entity.Site, error) {
+			if tenantCode == oak.Code && siteCode == north.Code {
+				return oak, north, nil
+			}
+			return nil, nil, errors.New("unknown tenant or site")
+		},
+		Trackers: func(t *entity.Tenant, s *entity.Site) Tracker {
+			return tr
+		},
+		Logger: zap.NewNop().Sugar(),
+	}
+	assert.NoError(t, sub.Start())
+	return sub
+}
+
+func resultOn(t *testing.T, broker *memoryBroker, topic string) map[string]any {
+	var result map[string]any
+	assert.NoError(t, json.Unmarshal(broker.published[topic], &result))
+	return result
+}
+
+func TestSubscriber_TracksScanAndPublishesResult(t *testing.T) {
+	broker := newMemoryBroker()
+	tr := &fakeTracker{response: tracker.TrackResponse{TrackId: 10, TrackType: "sign-in", Visitor: &entity.Visitor{Id: 1, Name: "John"}}}
+	newSubscriber(t, broker, tr)
+
+	assert.NoError(t, broker.Publish("imlate/oak/north/door-1/scan", []byte(`{"card_id": "ABC123", "direction": "in"}`)))
+
+	assert.Equal(t, []tracker.Request{{VisitKey: "ABC123", SignedIn: true, Direction: tracker.DirectionIn}}, tr.requests)
+	assert.Equal(t, []string{"oak/north"}, tr.sites)
+	result := resultOn(t, broker, "imlate/oak/north/door-1/result")
+	assert.Equal(t, "door-1", result["device"])
+	assert.Equal(t, "ABC123", result["visit_key"])
+	assert.Equal(t, "sign-in", result["track_type"])
+	assert.Equal(t, float64(10), result["track_id"])
+	assert.Nil(t, result["code"])
+}
+
+func TestSubscriber_ReportsRejectedScans(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		code string
+	}{
+		"unknown card":  {tracker.ErrVisitorNotFound, NotFoundCode},
+		"anti-passback": {tracker.ErrAlreadySignedIn, tracker.AntiPassbackCode},
+		"failure":       {errors.New("db down"), ErrorCode},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			broker := newMemoryBroker()
+			newSubscriber(t, broker, &fakeTracker{err: c.err})
+
+			assert.NoError(t, broker.Publish("imlate/oak/north/door-1/scan", []byte("ABC123")))
+
+			result := resultOn(t, broker, "imlate/oak/north/door-1/result")
+			assert.Equal(t, c.code, result["code"])
+			assert.Nil(t, result["track_type"])
+		})
+	}
+}
+
+func TestSubscriber_UnknownReader(t *testing.T) {
+	broker := newMemoryBroker()
+	tr := &fakeTracker{}
+	newSubscriber(t, broker, tr)
+
+	assert.NoError(t, broker.Publish("imlate/elm/main/door-1/scan", []byte("ABC123")))
+
+	assert.Empty(t, tr.requests)
+	assert.Equal(t, UnknownReaderCode, resultOn(t, broker, "imlate/elm/main/door-1/result")["code"])
+}
+
+func TestSubscriber_InvalidPayload(t *testing.T) {
+	broker := newMemoryBroker()
+	tr := &fakeTracker{}
+	newSubscriber(t, broker, tr)
+
+	assert.NoError(t, broker.Publish("imlate/oak/north/door-1/scan", []byte(`{"card": "ABC123"}`)))
+
+	assert.Empty(t, tr.requests)
+	assert.Equal(t, InvalidPayloadCode, resultOn(t, broker, "imlate/oak/north/door-1/result")["code"])
+}
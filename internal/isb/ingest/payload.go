@@ -0,0 +1,68 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/buzyka/imlate/internal/isb/tracker"
+)
+
+// Mapping names the fields of a JSON scan payload, dotted paths reach into
+// nested objects. Payloads which are not JSON objects are the card id itself.
+type Mapping struct {
+	KeyField       string
+	DirectionField string
+}
+
+var ErrEmptyPayload = errors.New("payload holds no card id")
+
+// Scan maps a payload to the request the kiosk would have sent.
+func (m Mapping) Scan(payload []byte) (tracker.Request, error) {
+	payload = bytes.TrimSpace(payload)
+	if len(payload) == 0 {
+		return tracker.Request{}, ErrEmptyPayload
+	}
+	if payload[0] != '{' {
+		return tracker.Request{VisitKey: string(payload), SignedIn: true}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return tracker.Request{}, fmt.Errorf("invalid JSON payload: %w", err)
+	}
+	key := lookup(fields, m.KeyField)
+	if key == "" {
+		return tracker.Request{}, fmt.Errorf("%w: field %q is missing", ErrEmptyPayload, m.KeyField)
+	}
+	return tracker.Request{
+		VisitKey:  key,
+		SignedIn:  true,
+		Direction: lookup(fields, m.DirectionField),
+	}, nil
+}
+
+func lookup(fields map[string]any, path string) string {
+	if path == "" {
+		return ""
+	}
+	var value any = fields
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return ""
+		}
+		value = object[name]
+	}
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
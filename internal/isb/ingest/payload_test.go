@@ -0,0 +1,37 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMappingScan_RawPayloadIsTheCardId(t *testing.T) {
+	request, err := Mapping{KeyField: "card_id"}.Scan([]byte(" ABC123\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, tracker.Request{VisitKey: "ABC123", SignedIn: true}, request)
+}
+
+func TestMappingScan_NestedFields(t *testing.T) {
+	mapping := Mapping{KeyField: "read.card", DirectionField: "read.direction"}
+
+	request, err := mapping.Scan([]byte(`{"read": {"card": 4211, "direction": "in"}}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "4211", request.VisitKey)
+	assert.Equal(t, tracker.DirectionIn, request.Direction)
+}
+
+func TestMappingScan_MissingKey(t *testing.T) {
+	_, err := Mapping{KeyField: "card_id"}.Scan([]byte(`{"card": "ABC123"}`))
+
+	assert.ErrorIs(t, err, ErrEmptyPayload)
+}
+
+func TestMappingScan_InvalidJSON(t *testing.T) {
+	_, err := Mapping{KeyField: "card_id"}.Scan([]byte(`{"card_id": `))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+package ingest
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	tenantSegment = "{tenant}"
+	siteSegment   = "{site}"
+	deviceSegment = "{device}"
+)
+
+// Reader identifies a networked reader by the topic it publishes to. Empty
+// tenant and site codes name the defaults.
+type Reader struct {
+	Tenant string
+	Site   string
+	Device string
+}
+
+// Template is a topic whose {tenant}, {site} and {device} segments name the
+// reader, e.g. "imlate/{tenant}/{site}/{device}/scan".
+type Template struct {
+	segments []string
+}
+
+func ParseTemplate(pattern string) (Template, error) {
+	segments := strings.Split(pattern, "/")
+	for _, segment := range segments {
+		switch {
+		case segment == "+" || segment == "#":
+			return Template{}, fmt.Errorf("topic %q: use {tenant}, {site} or {device} instead of wildcards", pattern)
+		case strings.ContainsAny(segment, "{}") && !isPlaceholder(segment):
+			return Template{}, fmt.Errorf("topic %q: unknown placeholder %q", pattern, segment)
+		}
+	}
+	return Template{segments: segments}, nil
+}
+
+// Filter is the subscription of the template, placeholders match any segment.
+func (t Template) Filter() string {
+	filter := make([]string, len(t.segments))
+	for i, segment := range t.segments {
+		filter[i] = segment
+		if isPlaceholder(segment) {
+			filter[i] = "+"
+		}
+	}
+	return strings.Join(filter, "/")
+}
+
+// Match reads the reader from a topic of the template.
+func (t Template) Match(topic string) (Reader, bool) {
+	parts := strings.Split(topic, "/")
+	if len(parts) != len(t.segments) {
+		return Reader{}, false
+	}
+	reader := Reader{}
+	for i, segment := range t.segments {
+		switch segment {
+		case tenantSegment:
+			reader.Tenant = parts[i]
+		case siteSegment:
+			reader.Site = parts[i]
+		case deviceSegment:
+			reader.Device = parts[i]
+		default:
+			if parts[i] != segment {
+				return Reader{}, false
+			}
+		}
+	}
+	return reader, true
+}
+
+// Topic is the topic of the template for the reader.
+func (t Template) Topic(reader Reader) string {
+	topic := make([]string, len(t.segments))
+	for i, segment := range t.segments {
+		switch segment {
+		case tenantSegment:
+			topic[i] = reader.Tenant
+		case siteSegment:
+			topic[i] = reader.Site
+		case deviceSegment:
+			topic[i] = reader.Device
+		default:
+			topic[i] = segment
+		}
+	}
+	return strings.Join(topic, "/")
+}
+
+func isPlaceholder(segment string) bool {
+	return segment == tenantSegment || segment == siteSegment || segment == deviceSegment
+}
@@ -0,0 +1,47 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTemplate_FilterAndMatch(t *testing.T) {
+	template, err := ParseTemplate("imlate/{tenant}/{site}/{device}/scan")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "imlate/+/+/+/scan", template.Filter())
+
+	reader, ok := template.Match("imlate/oak/north/door-1/scan")
+	assert.True(t, ok)
+	assert.Equal(t, Reader{Tenant: "oak", Site: "north", Device: "door-1"}, reader)
+
+	_, ok = template.Match("imlate/oak/north/door-1/result")
+	assert.False(t, ok)
+	_, ok = template.Match("imlate/oak/door-1/scan")
+	assert.False(t, ok)
+}
+
+func TestParseTemplate_DeviceOnly(t *testing.T) {
+	template, err := ParseTemplate("readers/{device}")
+	assert.NoError(t, err)
+
+	reader, ok := template.Match("readers/gate")
+	assert.True(t, ok)
+	assert.Equal(t, Reader{Device: "gate"}, reader)
+}
+
+func TestParseTemplate_RejectsWildcardsAndUnknownPlaceholders(t *testing.T) {
+	_, err := ParseTemplate("imlate/+/scan")
+	assert.Error(t, err)
+
+	_, err = ParseTemplate("imlate/{room}/scan")
+	assert.Error(t, err)
+}
+
+func TestTemplate_Topic(t *testing.T) {
+	template, err := ParseTemplate("imlate/{tenant}/{site}/{device}/result")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "imlate/oak/north/door-1/result", template.Topic(Reader{Tenant: "oak", Site: "north", Device: "door-1"}))
+}
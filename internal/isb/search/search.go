@@ -1,29 +1,32 @@
 package search
 
 import (
+	"errors"
 	"net/http"
 
-	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/buzyka/imlate/internal/isb/visitor"
 	"github.com/gin-gonic/gin"
 )
 
+// ErrNotFound is returned for keys of no visitor, see visitor.VisitorService.
+var ErrNotFound = tracker.ErrVisitorNotFound
+
 type SearchController struct {
-	StudentRepository entity.VisitorRepository `container:"type"`
+	Visitors *visitor.VisitorService `container:"type"`
 }
 
 func (sc SearchController) SearchHandler() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		id := ctx.Param("id")
-		visit, err := sc.StudentRepository.FindByKey(id)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
-			})
+		visit, err := sc.Visitors.Lookup(ctx.Request.Context(), id)
+		if errors.Is(err, ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
 			return
 		}
-
-		if visit == nil || visit.Visitor == nil {
-			ctx.Status(http.StatusNotFound)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
 			return
 		}
 		ctx.JSON(http.StatusOK, visit)
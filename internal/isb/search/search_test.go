@@ -1,13 +1,16 @@
 package search
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/buzyka/imlate/internal/infrastructure/apispec"
 	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/visitor"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,7 +21,7 @@ type MockVisitorRepository struct {
 	mock.Mock
 }
 
-func (m *MockVisitorRepository) FindByKey(key string) (*entity.VisitDetails, error) {
+func (m *MockVisitorRepository) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
 	args := m.Called(key)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -26,7 +29,15 @@ func (m *MockVisitorRepository) FindByKey(key string) (*entity.VisitDetails, err
 	return args.Get(0).(*entity.VisitDetails), args.Error(1)
 }
 
-func (m *MockVisitorRepository) FindById(id int32) (*entity.Visitor, error) {
+func (m *MockVisitorRepository) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -34,18 +45,33 @@ func (m *MockVisitorRepository) FindById(id int32) (*entity.Visitor, error) {
 	return args.Get(0).(*entity.Visitor), args.Error(1)
 }
 
-func (m *MockVisitorRepository) AddKeyToVisitor(visitor *entity.Visitor, key string) error {
+func (m *MockVisitorRepository) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
 	args := m.Called(visitor, key)
 	return args.Error(0)
 }
 
+// serve runs the handler for the key and checks the request and the response
+// against api/openapi.yaml.
+func serve(t *testing.T, handler gin.HandlerFunc, req *http.Request, key string) (*httptest.ResponseRecorder, *gin.Context) {
+	validator, err := apispec.NewValidator()
+	assert.NoError(t, err)
+	assert.NoError(t, validator.ValidateRequest(req), "request does not match api/openapi.yaml")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: key}}
+	c.Request = req
+	handler(c)
+	assert.NoError(t, validator.ValidateResponse(req, c.Writer.Status(), w.Header(), w.Body.Bytes()), "response does not match api/openapi.yaml")
+	return w, c
+}
+
 func TestSearchHandler_Success(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
 	mockRepo := new(MockVisitorRepository)
-	controller := SearchController{
-		StudentRepository: mockRepo,
-	}
+	controller := SearchController{Visitors: &visitor.VisitorService{
+		VisitorRepository: mockRepo,
+	}}
 
 	visitor := &entity.Visitor{
 		Id:      1,
@@ -63,15 +89,10 @@ func TestSearchHandler_Success(t *testing.T) {
 	mockRepo.On("FindByKey", "TEST123").Return(visitDetails, nil)
 
 	// Create test request
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Params = gin.Params{gin.Param{Key: "id", Value: "TEST123"}}
-	req, _ := http.NewRequest(http.MethodGet, "/search/TEST123", nil)
-	c.Request = req
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search/TEST123", nil)
 
 	// Execute
-	handler := controller.SearchHandler()
-	handler(c)
+	w, _ := serve(t, controller.SearchHandler(), req, "TEST123")
 
 	// Assert
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -91,9 +112,9 @@ func TestSearchHandler_VisitorNotFound(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
 	mockRepo := new(MockVisitorRepository)
-	controller := SearchController{
-		StudentRepository: mockRepo,
-	}
+	controller := SearchController{Visitors: &visitor.VisitorService{
+		VisitorRepository: mockRepo,
+	}}
 
 	// Return an empty VisitDetails with explicit nil Visitor
 	visitDetails := &entity.VisitDetails{
@@ -107,15 +128,10 @@ func TestSearchHandler_VisitorNotFound(t *testing.T) {
 	mockRepo.On("FindByKey", "NOTFOUND").Return(visitDetails, nil)
 
 	// Create test request
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Params = gin.Params{gin.Param{Key: "id", Value: "NOTFOUND"}}
-	req, _ := http.NewRequest(http.MethodGet, "/search/NOTFOUND", nil)
-	c.Request = req
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search/NOTFOUND", nil)
 
 	// Execute
-	handler := controller.SearchHandler()
-	handler(c)
+	w, c := serve(t, controller.SearchHandler(), req, "NOTFOUND")
 
 	// Assert
 	t.Logf("Response code: %d, body: '%s', mock called: %v", w.Code, w.Body.String(), mockRepo.AssertNumberOfCalls(t, "FindByKey", 1))
@@ -129,23 +145,18 @@ func TestSearchHandler_InternalError(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
 	mockRepo := new(MockVisitorRepository)
-	controller := SearchController{
-		StudentRepository: mockRepo,
-	}
+	controller := SearchController{Visitors: &visitor.VisitorService{
+		VisitorRepository: mockRepo,
+	}}
 
 	expectedError := errors.New("database connection error")
 	mockRepo.On("FindByKey", "ERROR123").Return(nil, expectedError)
 
 	// Create test request
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Params = gin.Params{gin.Param{Key: "id", Value: "ERROR123"}}
-	req, _ := http.NewRequest(http.MethodGet, "/search/ERROR123", nil)
-	c.Request = req
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search/ERROR123", nil)
 
 	// Execute
-	handler := controller.SearchHandler()
-	handler(c)
+	w, _ := serve(t, controller.SearchHandler(), req, "ERROR123")
 
 	// Assert
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -162,9 +173,9 @@ func TestSearchHandler_EmptyKey(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
 	mockRepo := new(MockVisitorRepository)
-	controller := SearchController{
-		StudentRepository: mockRepo,
-	}
+	controller := SearchController{Visitors: &visitor.VisitorService{
+		VisitorRepository: mockRepo,
+	}}
 
 	visitDetails := &entity.VisitDetails{
 		Visitor: nil,
@@ -193,9 +204,9 @@ func TestSearchHandler_NilVisitorInDetails(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
 	mockRepo := new(MockVisitorRepository)
-	controller := SearchController{
-		StudentRepository: mockRepo,
-	}
+	controller := SearchController{Visitors: &visitor.VisitorService{
+		VisitorRepository: mockRepo,
+	}}
 
 	visitDetails := &entity.VisitDetails{
 		Visitor: nil,
@@ -204,15 +215,10 @@ func TestSearchHandler_NilVisitorInDetails(t *testing.T) {
 	mockRepo.On("FindByKey", "KEY123").Return(visitDetails, nil)
 
 	// Create test request
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Params = gin.Params{gin.Param{Key: "id", Value: "KEY123"}}
-	req, _ := http.NewRequest(http.MethodGet, "/search/KEY123", nil)
-	c.Request = req
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search/KEY123", nil)
 
 	// Execute
-	handler := controller.SearchHandler()
-	handler(c)
+	w, c := serve(t, controller.SearchHandler(), req, "KEY123")
 
 	// Assert
 	assert.Equal(t, http.StatusNotFound, c.Writer.Status())
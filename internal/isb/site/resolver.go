@@ -0,0 +1,84 @@
+package site
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// Header names the site of a request by code or id, kiosks and admin pages
+// of other sites than the default one send it. The site query parameter is
+// accepted too for links and plain browsers.
+const Header = "X-Site"
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, site *entity.Site) context.Context {
+	return context.WithValue(ctx, contextKey{}, site)
+}
+
+// FromContext returns the site resolved for the request, nil outside of one.
+func FromContext(ctx context.Context) *entity.Site {
+	site, _ := ctx.Value(contextKey{}).(*entity.Site)
+	return site
+}
+
+// Config returns the configuration with the school day of the site applied.
+func Config(cfg *config.Config, site *entity.Site) *config.Config {
+	siteCfg := *cfg
+	if site == nil {
+		return &siteCfg
+	}
+	if site.SchoolDayStartsAt != "" {
+		siteCfg.SchoolDayStartsAt = site.SchoolDayStartsAt
+	}
+	if site.LateGraceMinutes != nil {
+		siteCfg.LateGraceMinutes = *site.LateGraceMinutes
+	}
+	return &siteCfg
+}
+
+type Resolver struct {
+	Repository  entity.SiteRepository
+	DefaultCode string
+}
+
+// Middleware resolves the site of the request and stores it in the request
+// context, unknown sites are rejected.
+func (r *Resolver) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ref := ctx.GetHeader(Header)
+		if ref == "" {
+			ref = ctx.Query("site")
+		}
+		if ref == "" {
+			ref = r.DefaultCode
+		}
+		site, err := r.Find(ref)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if site == nil {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Site not found"))))
+			return
+		}
+		ctx.Request = ctx.Request.WithContext(NewContext(ctx.Request.Context(), site))
+		ctx.Next()
+	}
+}
+
+// Find returns the site of a code or id, nil for unknown ones.
+func (r *Resolver) Find(ref string) (*entity.Site, error) {
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		return r.Repository.GetById(id)
+	}
+	return r.Repository.GetByCode(ref)
+}
@@ -0,0 +1,125 @@
+package site
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSiteRepository struct {
+	mock.Mock
+}
+
+func (m *MockSiteRepository) FindAll() ([]*entity.Site, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.Site), args.Error(1)
+}
+
+func (m *MockSiteRepository) GetById(id int64) (*entity.Site, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Site), args.Error(1)
+}
+
+func (m *MockSiteRepository) GetByCode(code string) (*entity.Site, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Site), args.Error(1)
+}
+
+func (m *MockSiteRepository) Store(site *entity.Site) error {
+	return m.Called(site).Error(0)
+}
+
+func (m *MockSiteRepository) FindClosedDays(siteId int64, from time.Time, to time.Time) ([]*entity.ClosedDay, error) {
+	args := m.Called(siteId, from, to)
+	return args.Get(0).([]*entity.ClosedDay), args.Error(1)
+}
+
+func (m *MockSiteRepository) AddClosedDay(day *entity.ClosedDay) error {
+	return m.Called(day).Error(0)
+}
+
+func (m *MockSiteRepository) RemoveClosedDay(siteId int64, date string) error {
+	return m.Called(siteId, date).Error(0)
+}
+
+func (m *MockSiteRepository) IsClosedOn(siteId int64, date time.Time) (bool, error) {
+	args := m.Called(siteId, date)
+	return args.Bool(0), args.Error(1)
+}
+
+var (
+	mainSite  = &entity.Site{Id: 1, Code: "main", Name: "Main site"}
+	northSite = &entity.Site{Id: 2, Code: "north", Name: "North campus"}
+)
+
+func performResolve(resolver *Resolver, request *http.Request) (*httptest.ResponseRecorder, *entity.Site) {
+	var resolved *entity.Site
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+	router.GET("/api/site", resolver.Middleware(), func(ctx *gin.Context) {
+		resolved = FromContext(ctx.Request.Context())
+		ctx.Status(http.StatusOK)
+	})
+	router.ServeHTTP(w, request)
+	return w, resolved
+}
+
+func TestMiddleware_FallsBackToDefaultSite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockSiteRepository)
+	repo.On("GetByCode", "main").Return(mainSite, nil)
+
+	w, resolved := performResolve(&Resolver{Repository: repo, DefaultCode: "main"}, httptest.NewRequest("GET", "/api/site", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, mainSite, resolved)
+}
+
+func TestMiddleware_ResolvesHeaderById(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockSiteRepository)
+	repo.On("GetById", int64(2)).Return(northSite, nil)
+
+	request := httptest.NewRequest("GET", "/api/site?site=main", nil)
+	request.Header.Set(Header, "2")
+	w, resolved := performResolve(&Resolver{Repository: repo, DefaultCode: "main"}, request)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, northSite, resolved)
+}
+
+func TestMiddleware_UnknownSite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockSiteRepository)
+	repo.On("GetByCode", "south").Return(nil, nil)
+
+	w, resolved := performResolve(&Resolver{Repository: repo, DefaultCode: "main"}, httptest.NewRequest("GET", "/api/site?site=south", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Nil(t, resolved)
+}
+
+func TestConfig_AppliesSiteSchoolDay(t *testing.T) {
+	grace := 5
+	cfg := &config.Config{SchoolDayStartsAt: "08:30", LateGraceMinutes: 0}
+
+	siteCfg := Config(cfg, &entity.Site{Id: 2, SchoolDayStartsAt: "08:15", LateGraceMinutes: &grace})
+
+	assert.Equal(t, "08:15", siteCfg.SchoolDayStartsAt)
+	assert.Equal(t, 5, siteCfg.LateGraceMinutes)
+	assert.Equal(t, "08:30", cfg.SchoolDayStartsAt)
+	assert.Equal(t, "08:30", Config(cfg, mainSite).SchoolDayStartsAt)
+}
@@ -0,0 +1,220 @@
+package site
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+type SiteRequest struct {
+	Code              string `json:"code" binding:"required"`
+	Name              string `json:"name" binding:"required"`
+	SchoolDayStartsAt string `json:"school_day_starts_at"`
+	LateGraceMinutes  *int   `json:"late_grace_minutes"`
+}
+
+type ClosedDayRequest struct {
+	Reason string `json:"reason"`
+}
+
+type SiteController struct {
+	SiteRepository entity.SiteRepository `container:"type"`
+	Location       *time.Location        `container:"type"`
+}
+
+func (sc *SiteController) ListHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sites, err := sc.SiteRepository.FindAll()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, sites)
+	}
+}
+
+// CurrentHandler returns the site the request was resolved to, kiosks use it
+// for the name on screen.
+func (sc *SiteController) CurrentHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, FromContext(ctx.Request.Context()))
+	}
+}
+
+func (sc *SiteController) CreateHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		site, ok := bindSite(ctx)
+		if !ok {
+			return
+		}
+		sc.store(ctx, site, http.StatusCreated)
+	}
+}
+
+func (sc *SiteController) UpdateHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		existing, ok := sc.siteFromParam(ctx)
+		if !ok {
+			return
+		}
+		site, ok := bindSite(ctx)
+		if !ok {
+			return
+		}
+		site.Id = existing.Id
+		sc.store(ctx, site, http.StatusOK)
+	}
+}
+
+// ClosedDaysHandler lists the days without school at the site, from and to
+// default to the current year.
+func (sc *SiteController) ClosedDaysHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		site, ok := sc.siteFromParam(ctx)
+		if !ok {
+			return
+		}
+		today := util.In(time.Now(), sc.Location)
+		from, to := time.Date(today.Year(), 1, 1, 0, 0, 0, 0, today.Location()), time.Date(today.Year()+1, 1, 1, 0, 0, 0, 0, today.Location())
+		if raw := ctx.Query("from"); raw != "" {
+			parsed, err := util.ParseDate(raw, sc.Location)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid from date, expected YYYY-MM-DD"))))
+				return
+			}
+			from = parsed
+		}
+		if raw := ctx.Query("to"); raw != "" {
+			parsed, err := util.ParseDate(raw, sc.Location)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid to date, expected YYYY-MM-DD"))))
+				return
+			}
+			to = parsed.AddDate(0, 0, 1)
+		}
+		days, err := sc.SiteRepository.FindClosedDays(site.Id, from, to)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, days)
+	}
+}
+
+func (sc *SiteController) AddClosedDayHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		site, ok := sc.siteFromParam(ctx)
+		if !ok {
+			return
+		}
+		date, ok := dateFromParam(ctx)
+		if !ok {
+			return
+		}
+		var request ClosedDayRequest
+		if ctx.Request.ContentLength > 0 {
+			if err := ctx.ShouldBindJSON(&request); err != nil {
+				ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+				return
+			}
+		}
+		day := &entity.ClosedDay{SiteId: site.Id, Date: date, Reason: request.Reason}
+		if err := sc.SiteRepository.AddClosedDay(day); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, day)
+	}
+}
+
+func (sc *SiteController) RemoveClosedDayHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		site, ok := sc.siteFromParam(ctx)
+		if !ok {
+			return
+		}
+		date, ok := dateFromParam(ctx)
+		if !ok {
+			return
+		}
+		if err := sc.SiteRepository.RemoveClosedDay(site.Id, date); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+func (sc *SiteController) store(ctx *gin.Context, site *entity.Site, status int) {
+	existing, err := sc.SiteRepository.GetByCode(site.Code)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return
+	}
+	if existing != nil && existing.Id != site.Id {
+		ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(errors.New("Site code already in use"))))
+		return
+	}
+	if err := sc.SiteRepository.Store(site); err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return
+	}
+	ctx.JSON(status, site)
+}
+
+func (sc *SiteController) siteFromParam(ctx *gin.Context) (*entity.Site, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid site id"))))
+		return nil, false
+	}
+	site, err := sc.SiteRepository.GetById(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return nil, false
+	}
+	if site == nil {
+		ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Site not found"))))
+		return nil, false
+	}
+	return site, true
+}
+
+func bindSite(ctx *gin.Context) (*entity.Site, bool) {
+	var request SiteRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+		return nil, false
+	}
+	if request.SchoolDayStartsAt != "" {
+		if _, err := time.Parse("15:04", request.SchoolDayStartsAt); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid school_day_starts_at, expected HH:MM"))))
+			return nil, false
+		}
+	}
+	if request.LateGraceMinutes != nil && *request.LateGraceMinutes < 0 {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid late_grace_minutes"))))
+		return nil, false
+	}
+	return &entity.Site{
+		Code:              request.Code,
+		Name:              request.Name,
+		SchoolDayStartsAt: request.SchoolDayStartsAt,
+		LateGraceMinutes:  request.LateGraceMinutes,
+	}, true
+}
+
+func dateFromParam(ctx *gin.Context) (string, bool) {
+	date := ctx.Param("date")
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid date, expected YYYY-MM-DD"))))
+		return "", false
+	}
+	return date, true
+}
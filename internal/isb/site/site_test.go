@@ -0,0 +1,95 @@
+package site
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func perform(handler gin.HandlerFunc, method string, path string, payload any, params ...gin.Param) *httptest.ResponseRecorder {
+	var body []byte
+	if payload != nil {
+		body, _ = json.Marshal(payload)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	handler(c)
+	return w
+}
+
+func TestCreateHandler_StoresSite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockSiteRepository)
+	controller := &SiteController{SiteRepository: repo}
+
+	repo.On("GetByCode", "north").Return(nil, nil)
+	repo.On("Store", mock.MatchedBy(func(site *entity.Site) bool {
+		return site.Code == "north" && site.SchoolDayStartsAt == "08:15"
+	})).Return(nil)
+
+	w := perform(controller.CreateHandler(), "POST", "/api/sites", SiteRequest{Code: "north", Name: "North campus", SchoolDayStartsAt: "08:15"})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateHandler_CodeInUse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockSiteRepository)
+	controller := &SiteController{SiteRepository: repo}
+
+	repo.On("GetByCode", "north").Return(northSite, nil)
+
+	w := perform(controller.CreateHandler(), "POST", "/api/sites", SiteRequest{Code: "north", Name: "North campus"})
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	repo.AssertNotCalled(t, "Store", mock.Anything)
+}
+
+func TestCreateHandler_InvalidSchoolDay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockSiteRepository)
+	controller := &SiteController{SiteRepository: repo}
+
+	w := perform(controller.CreateHandler(), "POST", "/api/sites", SiteRequest{Code: "north", Name: "North campus", SchoolDayStartsAt: "8am"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAddClosedDayHandler_StoresDay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockSiteRepository)
+	controller := &SiteController{SiteRepository: repo}
+
+	repo.On("GetById", int64(2)).Return(northSite, nil)
+	repo.On("AddClosedDay", &entity.ClosedDay{SiteId: 2, Date: "2024-12-23", Reason: "Snow day"}).Return(nil)
+
+	w := perform(controller.AddClosedDayHandler(), "PUT", "/api/sites/2/closed-days/2024-12-23", ClosedDayRequest{Reason: "Snow day"},
+		gin.Param{Key: "id", Value: "2"}, gin.Param{Key: "date", Value: "2024-12-23"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	repo.AssertExpectations(t)
+}
+
+func TestAddClosedDayHandler_InvalidDate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockSiteRepository)
+	controller := &SiteController{SiteRepository: repo}
+
+	repo.On("GetById", int64(2)).Return(northSite, nil)
+
+	w := perform(controller.AddClosedDayHandler(), "PUT", "/api/sites/2/closed-days/23-12-2024", nil,
+		gin.Param{Key: "id", Value: "2"}, gin.Param{Key: "date", Value: "23-12-2024"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
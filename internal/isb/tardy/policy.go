@@ -0,0 +1,39 @@
+package tardy
+
+import (
+	"math"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+)
+
+const clockLayout = "15:04"
+
+// Policy decides whether a sign-in is late.
+type Policy struct {
+	StartsAt  string // "HH:MM", empty disables late classification
+	LateAfter time.Duration
+}
+
+func NewPolicy(cfg *config.Config) Policy {
+	if cfg == nil {
+		return Policy{}
+	}
+	return Policy{
+		StartsAt:  cfg.SchoolDayStartsAt,
+		LateAfter: time.Duration(cfg.LateGraceMinutes) * time.Minute,
+	}
+}
+
+// MinutesLate counts from the start of the school day, 0 means on time.
+func (p Policy) MinutesLate(signedInAt time.Time) int {
+	clock, err := time.Parse(clockLayout, p.StartsAt)
+	if err != nil {
+		return 0
+	}
+	start := time.Date(signedInAt.Year(), signedInAt.Month(), signedInAt.Day(), clock.Hour(), clock.Minute(), 0, 0, signedInAt.Location())
+	if !signedInAt.After(start.Add(p.LateAfter)) {
+		return 0
+	}
+	return int(math.Max(1, math.Floor(signedInAt.Sub(start).Minutes())))
+}
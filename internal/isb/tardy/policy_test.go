@@ -0,0 +1,32 @@
+package tardy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinutesLate(t *testing.T) {
+	policy := NewPolicy(&config.Config{SchoolDayStartsAt: "08:30", LateGraceMinutes: 5})
+	day := func(hour, minute, second int) time.Time {
+		return time.Date(2024, 9, 2, hour, minute, second, 0, time.UTC)
+	}
+
+	assert.Equal(t, 0, policy.MinutesLate(day(8, 20, 0)))
+	assert.Equal(t, 0, policy.MinutesLate(day(8, 35, 0)), "within the grace")
+	assert.Equal(t, 5, policy.MinutesLate(day(8, 35, 30)), "counted from the start of the day")
+	assert.Equal(t, 72, policy.MinutesLate(day(9, 42, 0)))
+}
+
+func TestMinutesLate_WithoutGraceIsAtLeastOneMinute(t *testing.T) {
+	policy := Policy{StartsAt: "08:30"}
+
+	assert.Equal(t, 1, policy.MinutesLate(time.Date(2024, 9, 2, 8, 30, 20, 0, time.UTC)))
+}
+
+func TestMinutesLate_DisabledWithoutStart(t *testing.T) {
+	assert.Equal(t, 0, NewPolicy(nil).MinutesLate(time.Date(2024, 9, 2, 11, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 0, Policy{StartsAt: "soon"}.MinutesLate(time.Date(2024, 9, 2, 11, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,61 @@
+package tardy
+
+import (
+	"sort"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// Lateness summarises the late sign-ins of one student in the report period.
+type Lateness struct {
+	Visitor     *entity.Visitor `json:"visitor"`
+	Late        int             `json:"late"`
+	Excused     int             `json:"excused"`
+	MinutesLate int             `json:"minutes_late"`
+}
+
+type Report struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Late     int             `json:"late"`
+	Excused  int             `json:"excused"`
+	Reasons  map[string]int  `json:"reasons"`
+	Students []*Lateness     `json:"students"`
+	Tardies  []*entity.Tardy `json:"tardies"`
+}
+
+// NewReport groups tardies per student, excused ones are counted apart and
+// add no minutes, most late students first.
+func NewReport(from string, to string, tardies []*entity.Tardy) Report {
+	report := Report{
+		From:     from,
+		To:       to,
+		Reasons:  map[string]int{},
+		Students: []*Lateness{},
+		Tardies:  tardies,
+	}
+	byVisitor := map[int32]*Lateness{}
+	for _, tardy := range tardies {
+		lateness, ok := byVisitor[tardy.VisitorId]
+		if !ok {
+			lateness = &Lateness{Visitor: tardy.Visitor}
+			byVisitor[tardy.VisitorId] = lateness
+			report.Students = append(report.Students, lateness)
+		}
+		if tardy.Excused() {
+			lateness.Excused++
+			report.Excused++
+			continue
+		}
+		lateness.Late++
+		lateness.MinutesLate += tardy.MinutesLate
+		report.Late++
+		if tardy.Reason != "" {
+			report.Reasons[tardy.Reason]++
+		}
+	}
+	sort.SliceStable(report.Students, func(i, j int) bool {
+		return report.Students[i].Late > report.Students[j].Late
+	})
+	return report
+}
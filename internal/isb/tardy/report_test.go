@@ -0,0 +1,31 @@
+package tardy
+
+import (
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReport_CountsExcusedApart(t *testing.T) {
+	tom := &entity.Visitor{Id: 7, Name: "Tom"}
+	ann := &entity.Visitor{Id: 8, Name: "Ann"}
+	tardies := []*entity.Tardy{
+		{Id: 1, VisitorId: 7, Visitor: tom, Reason: "bus", MinutesLate: 20, ExcusalId: 4},
+		{Id: 2, VisitorId: 8, Visitor: ann, Reason: "overslept", MinutesLate: 5},
+		{Id: 3, VisitorId: 8, Visitor: ann, Reason: "", MinutesLate: 3},
+		{Id: 4, VisitorId: 7, Visitor: tom, Reason: "bus", MinutesLate: 10},
+	}
+
+	report := NewReport("2024-09-02", "2024-09-06", tardies)
+
+	assert.Equal(t, 3, report.Late)
+	assert.Equal(t, 1, report.Excused)
+	assert.Equal(t, map[string]int{"bus": 1, "overslept": 1}, report.Reasons)
+	assert.Len(t, report.Students, 2)
+	assert.Equal(t, ann, report.Students[0].Visitor)
+	assert.Equal(t, 8, report.Students[0].MinutesLate)
+	assert.Equal(t, 1, report.Students[1].Late)
+	assert.Equal(t, 1, report.Students[1].Excused)
+	assert.Equal(t, 10, report.Students[1].MinutesLate)
+}
@@ -0,0 +1,136 @@
+package tardy
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	slipTitle      = "Tardy slip"
+	slipTimeLayout = "2006-01-02 15:04"
+)
+
+// Slip is the printable proof of a late sign-in with its reason.
+type Slip struct {
+	Name        string
+	Time        string
+	Reason      string
+	Note        string
+	MinutesLate int
+}
+
+// NewSlip shows the sign-in time in the location, the school's.
+func NewSlip(tardy *entity.Tardy, loc *time.Location) Slip {
+	slip := Slip{
+		Time:        util.In(tardy.TrackedAt, loc).Format(slipTimeLayout),
+		Reason:      tardy.Reason,
+		Note:        tardy.Note,
+		MinutesLate: tardy.MinutesLate,
+	}
+	if tardy.Visitor != nil {
+		slip.Name = strings.TrimSpace(tardy.Visitor.Name + " " + tardy.Visitor.Surname)
+	}
+	return slip
+}
+
+func (s Slip) lines() []string {
+	lines := []string{
+		"Name: " + s.Name,
+		"Time: " + s.Time,
+		fmt.Sprintf("Minutes late: %d", s.MinutesLate),
+		"Reason: " + s.Reason,
+	}
+	if s.Note != "" {
+		lines = append(lines, "Note: "+s.Note)
+	}
+	return lines
+}
+
+var slipTemplate = template.Must(template.New("slip").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>{{.Title}}</title>
+    <style>
+        body { font-family: sans-serif; width: 72mm; margin: 4mm; }
+        h1 { font-size: 18px; text-align: center; }
+        p { margin: 4px 0; font-size: 14px; }
+    </style>
+</head>
+<body onload="window.print()">
+    <h1>{{.Title}}</h1>
+    {{range .Lines}}<p>{{.}}</p>
+    {{end}}
+</body>
+</html>
+`))
+
+func (s Slip) RenderHTML(w io.Writer) error {
+	return slipTemplate.Execute(w, map[string]any{
+		"Title": slipTitle,
+		"Lines": s.lines(),
+	})
+}
+
+// RenderPDF writes the slip on a receipt sized page.
+func (s Slip) RenderPDF(w io.Writer) error {
+	pdf := fpdf.NewCustom(&fpdf.InitType{
+		UnitStr: "mm",
+		Size:    fpdf.SizeType{Wd: 80, Ht: 100},
+	})
+	pdf.SetMargins(5, 5, 5)
+	pdf.AddPage()
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 10, slipTitle, "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 11)
+	for _, line := range s.lines() {
+		pdf.MultiCell(0, 6, translate(line), "", "L", false)
+	}
+	return pdf.Output(w)
+}
+
+// ESC/POS commands understood by common thermal receipt printers.
+var (
+	escInit       = []byte{0x1b, 0x40}
+	escCenter     = []byte{0x1b, 0x61, 0x01}
+	escLeft       = []byte{0x1b, 0x61, 0x00}
+	escDouble     = []byte{0x1b, 0x21, 0x30}
+	escNormal     = []byte{0x1b, 0x21, 0x00}
+	escFeedAndCut = []byte{0x1d, 0x56, 0x42, 0x03}
+)
+
+// EscPos renders the slip as an ESC/POS byte stream. Printers use a single
+// byte code page so characters outside ASCII are replaced.
+func (s Slip) EscPos() []byte {
+	var buf bytes.Buffer
+	buf.Write(escInit)
+	buf.Write(escCenter)
+	buf.Write(escDouble)
+	buf.WriteString(slipTitle + "\n")
+	buf.Write(escNormal)
+	buf.Write(escLeft)
+	buf.WriteString("\n")
+	for _, line := range s.lines() {
+		buf.WriteString(asciiOnly(line) + "\n")
+	}
+	buf.Write(escFeedAndCut)
+	return buf.Bytes()
+}
+
+func asciiOnly(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 127 {
+			return '?'
+		}
+		return r
+	}, text)
+}
@@ -0,0 +1,60 @@
+package tardy
+
+import (
+	"bytes"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSlip() Slip {
+	return NewSlip(&entity.Tardy{
+		TrackId:     10,
+		Visitor:     &entity.Visitor{Id: 7, Name: "Zoë", Surname: "Doe"},
+		Reason:      "bus",
+		Note:        "<late bus 12>",
+		MinutesLate: 12,
+		TrackedAt:   time.Date(2024, 9, 2, 8, 42, 10, 0, time.UTC),
+	}, nil)
+}
+
+func TestSlip_RenderHTMLEscapesContent(t *testing.T) {
+	var buf bytes.Buffer
+
+	assert.NoError(t, newTestSlip().RenderHTML(&buf))
+
+	html := buf.String()
+	assert.Contains(t, html, "Name: Zoë Doe")
+	assert.Contains(t, html, "Time: 2024-09-02 08:42")
+	assert.Contains(t, html, "Reason: bus")
+	assert.Contains(t, html, "&lt;late bus 12&gt;")
+}
+
+func TestSlip_RenderPDF(t *testing.T) {
+	var buf bytes.Buffer
+
+	assert.NoError(t, newTestSlip().RenderPDF(&buf))
+
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
+
+func TestSlip_EscPos(t *testing.T) {
+	data := newTestSlip().EscPos()
+
+	assert.True(t, bytes.HasPrefix(data, escInit))
+	assert.True(t, bytes.HasSuffix(data, escFeedAndCut))
+	assert.Contains(t, string(data), "Name: Zo? Doe\n")
+	assert.Contains(t, string(data), "Minutes late: 12\n")
+}
+
+func TestNewSlip_ShowsTimeInSchoolZone(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	slip := NewSlip(&entity.Tardy{TrackedAt: time.Date(2024, 9, 2, 6, 42, 10, 0, time.UTC)}, loc)
+
+	assert.Equal(t, "2024-09-02 08:42", slip.Time)
+}
@@ -0,0 +1,237 @@
+package tardy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	FormatHTML   = "html"
+	FormatPDF    = "pdf"
+	FormatEscPos = "escpos"
+
+	dateLayout = "2006-01-02"
+)
+
+type ReasonRequest struct {
+	Reason string `json:"reason" binding:"required"`
+	Note   string `json:"note"`
+}
+
+type ReasonResponse struct {
+	Tardy   *entity.Tardy `json:"tardy"`
+	Printed bool          `json:"printed"`
+	SlipURL string        `json:"slip_url"`
+}
+
+type TardyController struct {
+	TardyRepository   entity.TardyRepository        `container:"type"`
+	TrackRepository   entity.VisitorTrackRepository `container:"type"`
+	VisitorRepository entity.VisitorRepository      `container:"type"`
+	Printer           entity.SlipPrinter            `container:"type"`
+	Config            *config.Config                `container:"type"`
+	Now               func() time.Time
+	Location          *time.Location `container:"type"`
+}
+
+// ReasonsHandler lists the late reasons the kiosk offers.
+func (tc *TardyController) ReasonsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, tc.reasons())
+	}
+}
+
+// ReasonHandler attaches the reason picked at the kiosk to a late sign-in
+// and prints the slip when a printer is configured.
+func (tc *TardyController) ReasonHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request ReasonRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		request.Reason = strings.TrimSpace(request.Reason)
+		if reasons := tc.reasons(); len(reasons) > 0 && !slices.Contains(reasons, request.Reason) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid reason, expected one of: "+strings.Join(reasons, ", ")))))
+			return
+		}
+		trackId, ok := trackIdFromParam(ctx)
+		if !ok {
+			return
+		}
+		track, err := tc.TrackRepository.GetById(ctx.Request.Context(), trackId)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		if track == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Track not exists"))))
+			return
+		}
+		minutesLate := NewPolicy(tc.Config).MinutesLate(util.In(track.CreatedAt, tc.Location))
+		if minutesLate == 0 {
+			ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(errors.New("Track is not a late sign-in"))))
+			return
+		}
+		visitor, err := tc.VisitorRepository.FindById(ctx.Request.Context(), track.VisitorId)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+
+		tardy := &entity.Tardy{
+			TrackId:     int64(track.Id),
+			VisitorId:   track.VisitorId,
+			Visitor:     visitor,
+			Reason:      request.Reason,
+			Note:        request.Note,
+			MinutesLate: minutesLate,
+			TrackedAt:   track.CreatedAt,
+		}
+		if err := tc.TardyRepository.Save(ctx.Request.Context(), tardy); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+
+		response := ReasonResponse{
+			Tardy:   tardy,
+			SlipURL: fmt.Sprintf("/api/tracks/%d/tardy-slip", track.Id),
+		}
+		if tc.Printer != nil {
+			err := tc.Printer.Print(NewSlip(tardy, tc.Location).EscPos())
+			if err != nil && !errors.Is(err, entity.ErrPrinterNotConfigured) {
+				logging.FromContext(ctx.Request.Context()).Errorf("Error printing tardy slip: %s", err.Error())
+			}
+			response.Printed = err == nil
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
+
+// ReportHandler reports late sign-ins between ?from and ?to (inclusive
+// dates), by default today.
+func (tc *TardyController) ReportHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		today := tc.now().Format(dateLayout)
+		from, err := util.ParseDate(ctx.DefaultQuery("from", today), tc.Location)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid from date, expected YYYY-MM-DD"))))
+			return
+		}
+		to, err := util.ParseDate(ctx.DefaultQuery("to", from.Format(dateLayout)), tc.Location)
+		if err != nil || to.Before(from) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid to date, expected YYYY-MM-DD not before from"))))
+			return
+		}
+		tardies, err := tc.TardyRepository.FindBetween(ctx.Request.Context(), from, to.AddDate(0, 0, 1))
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, NewReport(from.Format(dateLayout), to.Format(dateLayout), tardies))
+	}
+}
+
+// SlipHandler renders the slip, ?format=html (default), pdf or escpos.
+func (tc *TardyController) SlipHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tardy, ok := tc.tardyFromParam(ctx)
+		if !ok {
+			return
+		}
+		slip := NewSlip(tardy, tc.Location)
+		switch ctx.DefaultQuery("format", FormatHTML) {
+		case FormatHTML:
+			ctx.Header("Content-Type", "text/html; charset=utf-8")
+			ctx.Status(http.StatusOK)
+			_ = slip.RenderHTML(ctx.Writer)
+		case FormatPDF:
+			ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=tardy-slip-%d.pdf", tardy.TrackId))
+			ctx.Header("Content-Type", "application/pdf")
+			ctx.Status(http.StatusOK)
+			_ = slip.RenderPDF(ctx.Writer)
+		case FormatEscPos:
+			ctx.Data(http.StatusOK, "application/octet-stream", slip.EscPos())
+		default:
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid format, expected html, pdf or escpos"))))
+		}
+	}
+}
+
+// PrintHandler reprints the slip on the configured printer.
+func (tc *TardyController) PrintHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tardy, ok := tc.tardyFromParam(ctx)
+		if !ok {
+			return
+		}
+		if tc.Printer == nil {
+			ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(entity.ErrPrinterNotConfigured)))
+			return
+		}
+		err := tc.Printer.Print(NewSlip(tardy, tc.Location).EscPos())
+		if errors.Is(err, entity.ErrPrinterNotConfigured) {
+			ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(err)))
+			return
+		}
+		if err != nil {
+			ctx.JSON(http.StatusBadGateway, util.NewFailureResponse(exception.ExternalServiceError(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "Slip printed",
+		})
+	}
+}
+
+func (tc *TardyController) tardyFromParam(ctx *gin.Context) (*entity.Tardy, bool) {
+	trackId, ok := trackIdFromParam(ctx)
+	if !ok {
+		return nil, false
+	}
+	tardy, err := tc.TardyRepository.GetByTrackId(ctx.Request.Context(), trackId)
+	if err != nil {
+		ctx.JSON(util.ServerFailure(err))
+		return nil, false
+	}
+	if tardy == nil {
+		ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("No late reason recorded for this track"))))
+		return nil, false
+	}
+	return tardy, true
+}
+
+func (tc *TardyController) now() time.Time {
+	if tc.Now != nil {
+		return util.In(tc.Now(), tc.Location)
+	}
+	return util.In(time.Now(), tc.Location)
+}
+
+func (tc *TardyController) reasons() []string {
+	if tc.Config == nil {
+		return []string{}
+	}
+	return tc.Config.LateReasons
+}
+
+func trackIdFromParam(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid track id"))))
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,336 @@
+package tardy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTardyRepository struct {
+	mock.Mock
+}
+
+func (m *MockTardyRepository) Save(ctx context.Context, tardy *entity.Tardy) error {
+	return m.Called(tardy).Error(0)
+}
+
+func (m *MockTardyRepository) GetByTrackId(ctx context.Context, trackId int64) (*entity.Tardy, error) {
+	args := m.Called(trackId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tardy), args.Error(1)
+}
+
+func (m *MockTardyRepository) FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.Tardy, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]*entity.Tardy), args.Error(1)
+}
+
+func (m *MockTardyRepository) CountByVisitorIdSince(ctx context.Context, visitorId int32, since time.Time) (int, error) {
+	args := m.Called(visitorId, since)
+	return args.Int(0), args.Error(1)
+}
+
+type MockVisitorTrackRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorTrackRepository) Store(ctx context.Context, vt *entity.VisitTrack) (*entity.VisitTrack, error) {
+	args := m.Called(vt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) StoreBackdated(ctx context.Context, vt *entity.VisitTrack, until time.Time) (*entity.VisitTrack, error) {
+	args := m.Called(vt, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) GetById(ctx context.Context, id int64) (*entity.VisitTrack, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) GetByClientId(ctx context.Context, clientId string) (*entity.VisitTrack, error) {
+	args := m.Called(clientId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) CountEventsByVisitorIdSince(ctx context.Context, visitorId int32, date time.Time) (int, error) {
+	args := m.Called(visitorId, date)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) CountEventsByVisitorIdBetween(ctx context.Context, visitorId int32, from time.Time, to time.Time) (int, error) {
+	args := m.Called(visitorId, from, to)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) FindPresentVisitorsSince(ctx context.Context, date time.Time) ([]*entity.Visitor, error) {
+	args := m.Called(date)
+	return args.Get(0).([]*entity.Visitor), args.Error(1)
+}
+
+type MockVisitorRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorRepository) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Visitor), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
+	return m.Called(visitor, key).Error(0)
+}
+
+type MockPrinter struct {
+	mock.Mock
+}
+
+func (m *MockPrinter) Print(data []byte) error {
+	return m.Called(data).Error(0)
+}
+
+type testMocks struct {
+	tardies  *MockTardyRepository
+	tracks   *MockVisitorTrackRepository
+	visitors *MockVisitorRepository
+	printer  *MockPrinter
+}
+
+func newTestController() (*TardyController, testMocks) {
+	mocks := testMocks{
+		tardies:  new(MockTardyRepository),
+		tracks:   new(MockVisitorTrackRepository),
+		visitors: new(MockVisitorRepository),
+		printer:  new(MockPrinter),
+	}
+	return &TardyController{
+		TardyRepository:   mocks.tardies,
+		TrackRepository:   mocks.tracks,
+		VisitorRepository: mocks.visitors,
+		Printer:           mocks.printer,
+		Config: &config.Config{
+			SchoolDayStartsAt: "08:30",
+			LateReasons:       []string{"bus", "appointment", "overslept"},
+		},
+	}, mocks
+}
+
+func perform(handler gin.HandlerFunc, method string, path string, payload any) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: "10"}}
+	handler(c)
+	return w
+}
+
+var (
+	student   = &entity.Visitor{Id: 7, Name: "Tom", Surname: "Doe"}
+	lateTrack = &entity.VisitTrack{Id: 10, VisitorId: 7, SignedIn: true, CreatedAt: time.Date(2024, 9, 2, 8, 42, 0, 0, time.UTC)}
+)
+
+func TestReasonHandler_StoresTardyAndPrintsSlip(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.tracks.On("GetById", int64(10)).Return(lateTrack, nil)
+	mocks.visitors.On("FindById", int32(7)).Return(student, nil)
+	mocks.tardies.On("Save", mock.MatchedBy(func(tardy *entity.Tardy) bool {
+		return tardy.TrackId == 10 && tardy.Reason == "bus" && tardy.MinutesLate == 12
+	})).Return(nil)
+	mocks.printer.On("Print", mock.MatchedBy(func(data []byte) bool {
+		return bytes.Contains(data, []byte("Reason: bus"))
+	})).Return(nil)
+
+	w := perform(controller.ReasonHandler(), "POST", "/api/tracks/10/late-reason", ReasonRequest{Reason: "bus"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ReasonResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Printed)
+	assert.Equal(t, "/api/tracks/10/tardy-slip", response.SlipURL)
+	mocks.tardies.AssertExpectations(t)
+	mocks.printer.AssertExpectations(t)
+}
+
+func TestReasonHandler_PrinterNotConfiguredFallsBackToSlipURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.tracks.On("GetById", int64(10)).Return(lateTrack, nil)
+	mocks.visitors.On("FindById", int32(7)).Return(student, nil)
+	mocks.tardies.On("Save", mock.Anything).Return(nil)
+	mocks.printer.On("Print", mock.Anything).Return(entity.ErrPrinterNotConfigured)
+
+	w := perform(controller.ReasonHandler(), "POST", "/api/tracks/10/late-reason", ReasonRequest{Reason: "overslept"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ReasonResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.False(t, response.Printed)
+}
+
+func TestReasonHandler_UnknownReason(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	w := perform(controller.ReasonHandler(), "POST", "/api/tracks/10/late-reason", ReasonRequest{Reason: "aliens"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mocks.tardies.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestReasonHandler_OnTimeTrackIsRejected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	onTime := &entity.VisitTrack{Id: 10, VisitorId: 7, CreatedAt: time.Date(2024, 9, 2, 8, 10, 0, 0, time.UTC)}
+	mocks.tracks.On("GetById", int64(10)).Return(onTime, nil)
+
+	w := perform(controller.ReasonHandler(), "POST", "/api/tracks/10/late-reason", ReasonRequest{Reason: "bus"})
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mocks.tardies.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestSlipHandler_Formats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.tardies.On("GetByTrackId", int64(10)).Return(&entity.Tardy{
+		TrackId:   10,
+		Visitor:   student,
+		Reason:    "bus",
+		TrackedAt: lateTrack.CreatedAt,
+	}, nil)
+
+	html := perform(controller.SlipHandler(), "GET", "/api/tracks/10/tardy-slip", nil)
+	assert.Equal(t, http.StatusOK, html.Code)
+	assert.Contains(t, html.Body.String(), "Name: Tom Doe")
+
+	pdf := perform(controller.SlipHandler(), "GET", "/api/tracks/10/tardy-slip?format=pdf", nil)
+	assert.Equal(t, "application/pdf", pdf.Header().Get("Content-Type"))
+
+	escpos := perform(controller.SlipHandler(), "GET", "/api/tracks/10/tardy-slip?format=escpos", nil)
+	assert.Equal(t, "application/octet-stream", escpos.Header().Get("Content-Type"))
+	assert.True(t, bytes.HasPrefix(escpos.Body.Bytes(), escInit))
+
+	invalid := perform(controller.SlipHandler(), "GET", "/api/tracks/10/tardy-slip?format=docx", nil)
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+}
+
+func TestPrintHandler_PrinterFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.tardies.On("GetByTrackId", int64(10)).Return(&entity.Tardy{TrackId: 10, Visitor: student}, nil)
+	mocks.printer.On("Print", mock.Anything).Return(errors.New("connection refused"))
+
+	w := perform(controller.PrintHandler(), "POST", "/api/tracks/10/tardy-slip/print", nil)
+
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+}
+
+func TestReportHandler_DayOfDSTChangeInSchoolZone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	controller, mocks := newTestController()
+	controller.Location = newYork
+
+	// March 10th 2024 is 23 hours long in New York
+	mocks.tardies.On("FindBetween", mock.MatchedBy(func(from time.Time) bool {
+		return from.Equal(time.Date(2024, 3, 10, 5, 0, 0, 0, time.UTC))
+	}), mock.MatchedBy(func(to time.Time) bool {
+		return to.Equal(time.Date(2024, 3, 11, 4, 0, 0, 0, time.UTC))
+	})).Return([]*entity.Tardy{}, nil)
+
+	w := perform(controller.ReportHandler(), "GET", "/api/tardies?from=2024-03-10", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mocks.tardies.AssertExpectations(t)
+}
+
+func TestReportHandler_QueryTimeoutReturnsServiceUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, mocks := newTestController()
+
+	mocks.tardies.On("FindBetween", mock.Anything, mock.Anything).Return([]*entity.Tardy(nil), &entity.QueryTimeoutError{
+		Query: "Finding tardies",
+		Limit: time.Second,
+		Err:   context.DeadlineExceeded,
+	})
+
+	w := perform(controller.ReportHandler(), "GET", "/api/tardies?from=2024-09-02", nil)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "Finding tardies timed out after 1s")
+}
+
+func TestReasonHandler_LatenessInSchoolZone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+	controller, mocks := newTestController()
+	controller.Location = berlin
+
+	// 08:42 UTC is 10:42 in Berlin, 132 minutes after the school day starts
+	mocks.tracks.On("GetById", int64(10)).Return(lateTrack, nil)
+	mocks.visitors.On("FindById", int32(7)).Return(student, nil)
+	mocks.tardies.On("Save", mock.MatchedBy(func(tardy *entity.Tardy) bool {
+		return tardy.MinutesLate == 132
+	})).Return(nil)
+	mocks.printer.On("Print", mock.Anything).Return(nil)
+
+	w := perform(controller.ReasonHandler(), "POST", "/api/tracks/10/late-reason", ReasonRequest{Reason: "bus"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mocks.tardies.AssertExpectations(t)
+}
@@ -0,0 +1,42 @@
+package tenant
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultLogo is shown by tenants without a logo of their own.
+const DefaultLogo = "website/assets/img/ISBLogo.jpg"
+
+// BrandingController serves the name and logo of the tenant to the kiosk
+// and admin pages.
+type BrandingController struct {
+	Tenant *entity.Tenant `container:"type"`
+	Config *config.Config `container:"type"`
+}
+
+func (bc *BrandingController) BrandingHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{
+			"name":     bc.Tenant.Name,
+			"logo_url": "/branding/logo",
+		})
+	}
+}
+
+func (bc *BrandingController) LogoHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.File(bc.logoPath())
+	}
+}
+
+func (bc *BrandingController) logoPath() string {
+	if bc.Tenant == nil || bc.Tenant.Logo == "" {
+		return DefaultLogo
+	}
+	return filepath.Join(bc.Config.BrandingPath, bc.Tenant.Code, filepath.Base(bc.Tenant.Logo))
+}
@@ -0,0 +1,178 @@
+package tenant
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+type contextKey struct{}
+
+var errDefaultTenantNotFound = errors.New("default tenant not found")
+
+// ErrTokenRequired rejects requests to the hostname of a tenant other than
+// the default one from neither the holder of its API token nor a kiosk
+// enrolled for it, the Host header is set by clients.
+var ErrTokenRequired = errors.New("API token required, or a kiosk enrolled for the tenant")
+
+// KioskCookie holds the API token of the tenant in the browser of a kiosk,
+// its pages send no Authorization header. See EnrollHandler.
+const KioskCookie = "imlate_kiosk"
+
+// kioskCookieMaxAge keeps a kiosk enrolled for a year, rotating the API token
+// of the tenant ends it earlier.
+const kioskCookieMaxAge = 365 * 24 * 60 * 60
+
+func NewContext(ctx context.Context, tenant *entity.Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenant)
+}
+
+// FromContext returns the tenant resolved for the request, nil outside of one.
+func FromContext(ctx context.Context) *entity.Tenant {
+	tenant, _ := ctx.Value(contextKey{}).(*entity.Tenant)
+	return tenant
+}
+
+// NewToken returns a random API token, only its hash is stored.
+func NewToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// BearerToken returns the token of the Authorization header, empty without one.
+func BearerToken(ctx *gin.Context) string {
+	header := ctx.GetHeader("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(header[7:])
+}
+
+type Resolver struct {
+	Repository  entity.TenantRepository
+	DefaultCode string
+}
+
+// Credentials are what a request or call names its tenant by.
+type Credentials struct {
+	// Token is the API token, empty without one.
+	Token string
+	// Host is the host the request was sent to, the port is ignored.
+	Host string
+	// Kiosk is the API token a kiosk is enrolled with, see KioskCookie.
+	Kiosk string
+}
+
+// Middleware resolves the tenant of the request and stores it in the request
+// context, see Find.
+func (r *Resolver) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		kiosk, _ := ctx.Cookie(KioskCookie)
+		tenant, err := r.Find(Credentials{Token: BearerToken(ctx), Host: ctx.Request.Host, Kiosk: kiosk})
+		if errors.Is(err, ErrTokenRequired) {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, util.NewFailureResponse(exception.Unauthorized(err)))
+			return
+		}
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if tenant == nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, util.NewFailureResponse(exception.Unauthorized(errors.New("Invalid API token"))))
+			return
+		}
+		ctx.Request = ctx.Request.WithContext(NewContext(ctx.Request.Context(), tenant))
+		ctx.Next()
+	}
+}
+
+// Find returns the tenant of the credentials, nil for an unknown token. An
+// API token names the tenant, credentials without one go to the tenant of
+// their host, else to the default tenant. The host of another tenant fails
+// with ErrTokenRequired unless the kiosk is enrolled for it. The gRPC calls
+// are resolved by it as well.
+func (r *Resolver) Find(credentials Credentials) (*entity.Tenant, error) {
+	if credentials.Token != "" {
+		return r.Repository.GetByTokenHash(HashToken(credentials.Token))
+	}
+	host := credentials.Host
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	if host != "" {
+		tenant, err := r.Repository.GetByHostname(strings.ToLower(host))
+		if err != nil {
+			return nil, err
+		}
+		if tenant != nil && tenant.Code != r.DefaultCode {
+			return r.kiosk(credentials.Kiosk, tenant)
+		}
+		if tenant != nil {
+			return tenant, nil
+		}
+	}
+	tenant, err := r.Repository.GetByCode(r.DefaultCode)
+	if err == nil && tenant == nil {
+		return nil, errDefaultTenantNotFound
+	}
+	return tenant, err
+}
+
+// kiosk serves the tenant of the host to a kiosk enrolled with its API
+// token, the token of another tenant does not do.
+func (r *Resolver) kiosk(token string, tenant *entity.Tenant) (*entity.Tenant, error) {
+	if token == "" {
+		return nil, ErrTokenRequired
+	}
+	enrolled, err := r.Repository.GetByTokenHash(HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if enrolled == nil || enrolled.Id != tenant.Id {
+		return nil, ErrTokenRequired
+	}
+	return tenant, nil
+}
+
+// EnrollHandler enrolls the browser of a kiosk for the tenant of the API
+// token of the request: the token is kept in the KioskCookie, which is only
+// sent to the host which set it. Enrolling again replaces the tenant.
+func EnrollHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := BearerToken(ctx)
+		if token == "" {
+			ctx.JSON(http.StatusUnauthorized, util.NewFailureResponse(exception.Unauthorized(errors.New("API token required"))))
+			return
+		}
+		ctx.SetSameSite(http.SameSiteStrictMode)
+		ctx.SetCookie(KioskCookie, token, kioskCookieMaxAge, "/", "", ctx.Request.TLS != nil, true)
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// UnenrollHandler removes the kiosk cookie of the browser.
+func UnenrollHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.SetSameSite(http.SameSiteStrictMode)
+		ctx.SetCookie(KioskCookie, "", -1, "/", "", ctx.Request.TLS != nil, true)
+		ctx.Status(http.StatusNoContent)
+	}
+}
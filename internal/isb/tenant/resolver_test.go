@@ -0,0 +1,220 @@
+package tenant
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTenantRepository struct {
+	mock.Mock
+}
+
+func (m *MockTenantRepository) FindAll() ([]*entity.Tenant, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) GetById(id int64) (*entity.Tenant, error) {
+	return m.tenant(m.Called(id))
+}
+
+func (m *MockTenantRepository) GetByCode(code string) (*entity.Tenant, error) {
+	return m.tenant(m.Called(code))
+}
+
+func (m *MockTenantRepository) GetByHostname(hostname string) (*entity.Tenant, error) {
+	return m.tenant(m.Called(hostname))
+}
+
+func (m *MockTenantRepository) GetByTokenHash(hash string) (*entity.Tenant, error) {
+	return m.tenant(m.Called(hash))
+}
+
+func (m *MockTenantRepository) Provision(tenant *entity.Tenant, site *entity.Site, tokenHash string) error {
+	return m.Called(tenant, site, tokenHash).Error(0)
+}
+
+func (m *MockTenantRepository) Update(tenant *entity.Tenant) error {
+	return m.Called(tenant).Error(0)
+}
+
+func (m *MockTenantRepository) SetTokenHash(id int64, hash string) error {
+	return m.Called(id, hash).Error(0)
+}
+
+func (m *MockTenantRepository) tenant(args mock.Arguments) (*entity.Tenant, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tenant), args.Error(1)
+}
+
+var (
+	defaultTenant   = &entity.Tenant{Id: 1, Code: "default", Name: "Default school"}
+	riversideTenant = &entity.Tenant{Id: 2, Code: "riverside", Name: "Riverside School", Hostname: "riverside.example.org"}
+)
+
+func performResolve(resolver *Resolver, request *http.Request) (*httptest.ResponseRecorder, *entity.Tenant) {
+	var resolved *entity.Tenant
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+	router.GET("/api/site", resolver.Middleware(), func(ctx *gin.Context) {
+		resolved = FromContext(ctx.Request.Context())
+		ctx.Status(http.StatusOK)
+	})
+	router.ServeHTTP(w, request)
+	return w, resolved
+}
+
+func TestMiddleware_ResolvesHostnameOfDefaultTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockTenantRepository)
+	defaultWithHost := &entity.Tenant{Id: 1, Code: "default", Name: "Default school", Hostname: "school.example.org"}
+	repo.On("GetByHostname", "school.example.org").Return(defaultWithHost, nil)
+
+	request := httptest.NewRequest("GET", "/api/site", nil)
+	request.Host = "School.example.org:8080"
+	w, resolved := performResolve(&Resolver{Repository: repo, DefaultCode: "default"}, request)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, defaultWithHost, resolved)
+}
+
+func TestMiddleware_HostnameOfAnotherTenantRequiresToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockTenantRepository)
+	repo.On("GetByHostname", "riverside.example.org").Return(riversideTenant, nil)
+
+	request := httptest.NewRequest("GET", "/api/site", nil)
+	request.Host = "Riverside.example.org:8080"
+	w, resolved := performResolve(&Resolver{Repository: repo, DefaultCode: "default"}, request)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "API token required")
+	assert.Nil(t, resolved)
+	repo.AssertNotCalled(t, "GetByCode", mock.Anything)
+}
+
+func TestMiddleware_HostnameOfAnotherTenantWithItsToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockTenantRepository)
+	repo.On("GetByTokenHash", HashToken("secret")).Return(riversideTenant, nil)
+
+	request := httptest.NewRequest("GET", "/api/site", nil)
+	request.Host = "riverside.example.org"
+	request.Header.Set("Authorization", "Bearer secret")
+	w, resolved := performResolve(&Resolver{Repository: repo, DefaultCode: "default"}, request)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, riversideTenant, resolved)
+}
+
+func TestMiddleware_FallsBackToDefaultTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockTenantRepository)
+	repo.On("GetByHostname", "localhost").Return(nil, nil)
+	repo.On("GetByCode", "default").Return(defaultTenant, nil)
+
+	request := httptest.NewRequest("GET", "/api/site", nil)
+	request.Host = "localhost:8080"
+	w, resolved := performResolve(&Resolver{Repository: repo, DefaultCode: "default"}, request)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, defaultTenant, resolved)
+}
+
+func TestMiddleware_TokenWinsOverHostname(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockTenantRepository)
+	repo.On("GetByTokenHash", HashToken("secret")).Return(riversideTenant, nil)
+
+	request := httptest.NewRequest("GET", "/api/site", nil)
+	request.Host = "localhost"
+	request.Header.Set("Authorization", "Bearer secret")
+	w, resolved := performResolve(&Resolver{Repository: repo, DefaultCode: "default"}, request)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, riversideTenant, resolved)
+	repo.AssertNotCalled(t, "GetByHostname", mock.Anything)
+}
+
+func TestMiddleware_UnknownToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockTenantRepository)
+	repo.On("GetByTokenHash", HashToken("wrong")).Return(nil, nil)
+
+	request := httptest.NewRequest("GET", "/api/site", nil)
+	request.Header.Set("Authorization", "Bearer wrong")
+	w, resolved := performResolve(&Resolver{Repository: repo, DefaultCode: "default"}, request)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Nil(t, resolved)
+}
+
+func TestMiddleware_HostnameOfAnotherTenantFromEnrolledKiosk(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockTenantRepository)
+	repo.On("GetByHostname", "riverside.example.org").Return(riversideTenant, nil)
+	repo.On("GetByTokenHash", HashToken("secret")).Return(riversideTenant, nil)
+
+	request := httptest.NewRequest("GET", "/api/site", nil)
+	request.Host = "riverside.example.org"
+	request.AddCookie(&http.Cookie{Name: KioskCookie, Value: "secret"})
+	w, resolved := performResolve(&Resolver{Repository: repo, DefaultCode: "default"}, request)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, riversideTenant, resolved)
+}
+
+func TestMiddleware_KioskEnrolledForAnotherTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockTenantRepository)
+	repo.On("GetByHostname", "riverside.example.org").Return(riversideTenant, nil)
+	repo.On("GetByTokenHash", HashToken("default-secret")).Return(defaultTenant, nil)
+
+	request := httptest.NewRequest("GET", "/api/site", nil)
+	request.Host = "riverside.example.org"
+	request.AddCookie(&http.Cookie{Name: KioskCookie, Value: "default-secret"})
+	w, resolved := performResolve(&Resolver{Repository: repo, DefaultCode: "default"}, request)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Nil(t, resolved)
+}
+
+func TestEnrollHandler_StoresTokenInKioskCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+	router.POST("/api/kiosk", EnrollHandler())
+
+	request := httptest.NewRequest("POST", "/api/kiosk", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	router.ServeHTTP(w, request)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	cookies := w.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, KioskCookie, cookies[0].Name)
+		assert.Equal(t, "secret", cookies[0].Value)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+	}
+}
+
+func TestEnrollHandler_RequiresToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+	router.POST("/api/kiosk", EnrollHandler())
+
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/kiosk", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, w.Result().Cookies())
+}
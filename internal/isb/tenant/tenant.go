@@ -0,0 +1,225 @@
+package tenant
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// codePattern keeps tenant codes usable as directory names for branding.
+var codePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+var logoExtensions = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true}
+
+type TenantRequest struct {
+	Code     string `json:"code" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Hostname string `json:"hostname"`
+}
+
+// ProvisionResponse carries the API token of a new tenant, it is not shown
+// again.
+type ProvisionResponse struct {
+	Tenant   *entity.Tenant `json:"tenant"`
+	Site     *entity.Site   `json:"site"`
+	ApiToken string         `json:"api_token"`
+}
+
+// SuperAdmin guards the provisioning API with the configured bearer token,
+// without one the API is disabled.
+func SuperAdmin(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if token == "" {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, util.NewFailureResponse(exception.Forbidden(errors.New("Tenant provisioning is disabled"))))
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(BearerToken(ctx)), []byte(token)) != 1 {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, util.NewFailureResponse(exception.Unauthorized(errors.New("Invalid super admin token"))))
+			return
+		}
+		ctx.Next()
+	}
+}
+
+type TenantController struct {
+	TenantRepository entity.TenantRepository `container:"type"`
+	Config           *config.Config          `container:"type"`
+}
+
+func (tc *TenantController) ListHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tenants, err := tc.TenantRepository.FindAll()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, tenants)
+	}
+}
+
+// CreateHandler provisions a tenant with its first site and returns its API
+// token.
+func (tc *TenantController) CreateHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tenant, ok := bindTenant(ctx)
+		if !ok || !tc.available(ctx, tenant) {
+			return
+		}
+		token, err := NewToken()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		site := &entity.Site{Code: tc.Config.DefaultSite, Name: "Main site"}
+		if err := tc.TenantRepository.Provision(tenant, site, HashToken(token)); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusCreated, ProvisionResponse{Tenant: tenant, Site: site, ApiToken: token})
+	}
+}
+
+func (tc *TenantController) UpdateHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		existing, ok := tc.tenantFromParam(ctx)
+		if !ok {
+			return
+		}
+		tenant, ok := bindTenant(ctx)
+		if !ok {
+			return
+		}
+		tenant.Id = existing.Id
+		tenant.Logo = existing.Logo
+		if !tc.available(ctx, tenant) {
+			return
+		}
+		if err := tc.TenantRepository.Update(tenant); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, tenant)
+	}
+}
+
+// RotateTokenHandler replaces the API token of the tenant, the old one stops
+// working at once.
+func (tc *TenantController) RotateTokenHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tenant, ok := tc.tenantFromParam(ctx)
+		if !ok {
+			return
+		}
+		token, err := NewToken()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if err := tc.TenantRepository.SetTokenHash(tenant.Id, HashToken(token)); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"api_token": token,
+		})
+	}
+}
+
+// LogoHandler stores the uploaded logo file in the branding directory of the
+// tenant.
+func (tc *TenantController) LogoHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tenant, ok := tc.tenantFromParam(ctx)
+		if !ok {
+			return
+		}
+		file, err := ctx.FormFile("logo")
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Logo file is required"))))
+			return
+		}
+		ext := strings.ToLower(filepath.Ext(file.Filename))
+		if !logoExtensions[ext] {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Logo must be a png, jpg, gif or svg image"))))
+			return
+		}
+		dir := filepath.Join(tc.Config.BrandingPath, tenant.Code)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		tenant.Logo = "logo" + ext
+		if err := ctx.SaveUploadedFile(file, filepath.Join(dir, tenant.Logo)); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		if err := tc.TenantRepository.Update(tenant); err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, tenant)
+	}
+}
+
+// available rejects codes and hostnames another tenant already uses.
+func (tc *TenantController) available(ctx *gin.Context, tenant *entity.Tenant) bool {
+	existing, err := tc.TenantRepository.GetByCode(tenant.Code)
+	if err == nil && (existing == nil || existing.Id == tenant.Id) && tenant.Hostname != "" {
+		existing, err = tc.TenantRepository.GetByHostname(tenant.Hostname)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return false
+	}
+	if existing != nil && existing.Id != tenant.Id {
+		ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(errors.New("Tenant code or hostname already in use"))))
+		return false
+	}
+	return true
+}
+
+func (tc *TenantController) tenantFromParam(ctx *gin.Context) (*entity.Tenant, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid tenant id"))))
+		return nil, false
+	}
+	tenant, err := tc.TenantRepository.GetById(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+		return nil, false
+	}
+	if tenant == nil {
+		ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Tenant not found"))))
+		return nil, false
+	}
+	return tenant, true
+}
+
+func bindTenant(ctx *gin.Context) (*entity.Tenant, bool) {
+	var request TenantRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+		return nil, false
+	}
+	if !codePattern.MatchString(request.Code) {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid code, use lowercase letters, digits and dashes"))))
+		return nil, false
+	}
+	return &entity.Tenant{
+		Code:     request.Code,
+		Name:     request.Name,
+		Hostname: strings.ToLower(request.Hostname),
+	}, true
+}
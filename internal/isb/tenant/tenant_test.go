@@ -0,0 +1,114 @@
+package tenant
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func perform(handler gin.HandlerFunc, method string, path string, payload any, params ...gin.Param) *httptest.ResponseRecorder {
+	var body []byte
+	if payload != nil {
+		body, _ = json.Marshal(payload)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	handler(c)
+	return w
+}
+
+func TestCreateHandler_ProvisionsTenantWithToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockTenantRepository)
+	controller := &TenantController{TenantRepository: repo, Config: &config.Config{DefaultSite: "main"}}
+
+	repo.On("GetByCode", "riverside").Return(nil, nil)
+	repo.On("GetByHostname", "riverside.example.org").Return(nil, nil)
+	repo.On("Provision", mock.MatchedBy(func(tenant *entity.Tenant) bool {
+		return tenant.Code == "riverside" && tenant.Hostname == "riverside.example.org"
+	}), mock.MatchedBy(func(site *entity.Site) bool {
+		return site.Code == "main"
+	}), mock.AnythingOfType("string")).Return(nil)
+
+	w := perform(controller.CreateHandler(), "POST", "/api/admin/tenants", TenantRequest{Code: "riverside", Name: "Riverside School", Hostname: "Riverside.example.org"})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response ProvisionResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.ApiToken, 64)
+	hash := repo.Calls[len(repo.Calls)-1].Arguments.String(2)
+	assert.Equal(t, HashToken(response.ApiToken), hash)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateHandler_HostnameInUse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockTenantRepository)
+	controller := &TenantController{TenantRepository: repo, Config: &config.Config{DefaultSite: "main"}}
+
+	repo.On("GetByCode", "riverside").Return(nil, nil)
+	repo.On("GetByHostname", "riverside.example.org").Return(defaultTenant, nil)
+
+	w := perform(controller.CreateHandler(), "POST", "/api/admin/tenants", TenantRequest{Code: "riverside", Name: "Riverside School", Hostname: "riverside.example.org"})
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	repo.AssertNotCalled(t, "Provision", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateHandler_InvalidCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockTenantRepository)
+	controller := &TenantController{TenantRepository: repo, Config: &config.Config{}}
+
+	w := perform(controller.CreateHandler(), "POST", "/api/admin/tenants", TenantRequest{Code: "../etc", Name: "Bad"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSuperAdmin_RejectsWrongToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+	router.GET("/api/admin/tenants", SuperAdmin("secret"), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	request := httptest.NewRequest("GET", "/api/admin/tenants", nil)
+	request.Header.Set("Authorization", "Bearer wrong")
+	router.ServeHTTP(w, request)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestSuperAdmin_DisabledWithoutToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+	router.GET("/api/admin/tenants", SuperAdmin(""), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	request := httptest.NewRequest("GET", "/api/admin/tenants", nil)
+	request.Header.Set("Authorization", "Bearer ")
+	router.ServeHTTP(w, request)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestBrandingController_LogoPath(t *testing.T) {
+	cfg := &config.Config{BrandingPath: "branding"}
+
+	assert.Equal(t, DefaultLogo, (&BrandingController{Tenant: defaultTenant, Config: cfg}).logoPath())
+	assert.Equal(t, "branding/riverside/logo.png", (&BrandingController{Tenant: &entity.Tenant{Code: "riverside", Logo: "logo.png"}, Config: cfg}).logoPath())
+}
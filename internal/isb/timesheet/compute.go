@@ -0,0 +1,192 @@
+package timesheet
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const (
+	// FlagMissingSignOut marks a sign-in without a matching sign-out on the same day.
+	FlagMissingSignOut = "missing_sign_out"
+	// FlagMissingSignIn marks a sign-out without a sign-in before it on the same day.
+	FlagMissingSignIn = "missing_sign_in"
+
+	dateLayout = "2006-01-02"
+)
+
+// Policy holds the contracted hours used for overtime calculation.
+type Policy struct {
+	ContractedHoursPerDay  float64
+	ContractedHoursPerWeek float64
+	// Location is the zone days are counted in, that of the tracks without one.
+	Location *time.Location
+}
+
+// Interval is a sign-in and the sign-out after it. Either is nil when it is
+// missing, the interval is flagged and counts no hours then.
+type Interval struct {
+	SignIn  *time.Time `json:"sign_in"`
+	SignOut *time.Time `json:"sign_out"`
+	Hours   float64    `json:"hours"`
+	Flags   []string   `json:"flags"`
+}
+
+type Day struct {
+	Date      string      `json:"date"`
+	Intervals []*Interval `json:"intervals"`
+	Hours     float64     `json:"hours"`
+	Overtime  float64     `json:"overtime"`
+	Flags     []string    `json:"flags"`
+
+	worked time.Duration
+}
+
+type Week struct {
+	Year     int     `json:"year"`
+	Week     int     `json:"week"`
+	Hours    float64 `json:"hours"`
+	Overtime float64 `json:"overtime"`
+
+	worked time.Duration
+}
+
+type Timesheet struct {
+	Visitor  *entity.Visitor `json:"visitor"`
+	Days     []*Day          `json:"days"`
+	Weeks    []*Week         `json:"weeks"`
+	Hours    float64         `json:"hours"`
+	Overtime float64         `json:"overtime"`
+}
+
+// Compute groups tracks per visitor and day and pairs each sign-in with the
+// sign-out after it into a work interval. A sign-in followed by another one
+// or ending the day is flagged as missing its sign-out, a sign-out without a
+// sign-in before it as missing its sign-in.
+func Compute(tracks []*entity.VisitTrack, policy Policy) []*Timesheet {
+	byVisitor := map[int32][]*entity.VisitTrack{}
+	visitors := map[int32]*entity.Visitor{}
+	order := []int32{}
+	for _, track := range tracks {
+		if _, ok := byVisitor[track.VisitorId]; !ok {
+			order = append(order, track.VisitorId)
+			visitors[track.VisitorId] = track.Visitor
+			if track.Visitor == nil {
+				visitors[track.VisitorId] = &entity.Visitor{Id: track.VisitorId}
+			}
+		}
+		byVisitor[track.VisitorId] = append(byVisitor[track.VisitorId], track)
+	}
+
+	timesheets := make([]*Timesheet, 0, len(order))
+	for _, visitorId := range order {
+		timesheets = append(timesheets, computeForVisitor(visitors[visitorId], byVisitor[visitorId], policy))
+	}
+	return timesheets
+}
+
+func computeForVisitor(visitor *entity.Visitor, tracks []*entity.VisitTrack, policy Policy) *Timesheet {
+	sort.SliceStable(tracks, func(i, j int) bool {
+		return tracks[i].CreatedAt.Before(tracks[j].CreatedAt)
+	})
+
+	timesheet := &Timesheet{
+		Visitor: visitor,
+		Days:    []*Day{},
+		Weeks:   []*Week{},
+	}
+	var day *Day
+	var week *Week
+	var open *Interval
+	for _, track := range tracks {
+		at := util.In(track.CreatedAt, policy.Location)
+		date := at.Format(dateLayout)
+		if day == nil || day.Date != date {
+			closeDay(day, open, policy)
+			open = nil
+			day = &Day{Date: date, Intervals: []*Interval{}, Flags: []string{}}
+			timesheet.Days = append(timesheet.Days, day)
+
+			year, weekNumber := at.ISOWeek()
+			if week == nil || week.Year != year || week.Week != weekNumber {
+				week = &Week{Year: year, Week: weekNumber}
+				timesheet.Weeks = append(timesheet.Weeks, week)
+			}
+		}
+		if track.SignedIn {
+			flagOpen(day, open)
+			signIn := at
+			open = &Interval{SignIn: &signIn, Flags: []string{}}
+			day.Intervals = append(day.Intervals, open)
+			continue
+		}
+		signOut := at
+		if open == nil {
+			day.Intervals = append(day.Intervals, &Interval{SignOut: &signOut, Flags: []string{FlagMissingSignIn}})
+			flag(day, FlagMissingSignIn)
+			continue
+		}
+		open.SignOut = &signOut
+		worked := signOut.Sub(*open.SignIn)
+		open.Hours = hours(worked)
+		day.worked += worked
+		week.worked += worked
+		open = nil
+	}
+	closeDay(day, open, policy)
+
+	var total, overtime time.Duration
+	for _, w := range timesheet.Weeks {
+		w.Hours = hours(w.worked)
+		w.Overtime = hours(excess(w.worked, policy.ContractedHoursPerWeek))
+		total += w.worked
+		overtime += excess(w.worked, policy.ContractedHoursPerWeek)
+	}
+	timesheet.Hours = hours(total)
+	timesheet.Overtime = hours(overtime)
+	return timesheet
+}
+
+func closeDay(day *Day, open *Interval, policy Policy) {
+	if day == nil {
+		return
+	}
+	flagOpen(day, open)
+	day.Hours = hours(day.worked)
+	day.Overtime = hours(excess(day.worked, policy.ContractedHoursPerDay))
+}
+
+// flagOpen flags an interval left without a sign-out.
+func flagOpen(day *Day, open *Interval) {
+	if open == nil {
+		return
+	}
+	open.Flags = append(open.Flags, FlagMissingSignOut)
+	flag(day, FlagMissingSignOut)
+}
+
+// flag adds the flag to the day once.
+func flag(day *Day, name string) {
+	if !slices.Contains(day.Flags, name) {
+		day.Flags = append(day.Flags, name)
+	}
+}
+
+func excess(worked time.Duration, contractedHours float64) time.Duration {
+	if contractedHours <= 0 {
+		return 0
+	}
+	contracted := time.Duration(contractedHours * float64(time.Hour))
+	if worked <= contracted {
+		return 0
+	}
+	return worked - contracted
+}
+
+func hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
@@ -0,0 +1,230 @@
+package timesheet
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPolicy = Policy{ContractedHoursPerDay: 8, ContractedHoursPerWeek: 40}
+
+func newTrack(visitor *entity.Visitor, createdAt string, signedIn bool) *entity.VisitTrack {
+	t, _ := time.Parse("2006-01-02 15:04", createdAt)
+	return &entity.VisitTrack{VisitorId: visitor.Id, Visitor: visitor, CreatedAt: t, SignedIn: signedIn}
+}
+
+func signIn(visitor *entity.Visitor, createdAt string) *entity.VisitTrack {
+	return newTrack(visitor, createdAt, true)
+}
+
+func signOut(visitor *entity.Visitor, createdAt string) *entity.VisitTrack {
+	return newTrack(visitor, createdAt, false)
+}
+
+func TestComputeWillPairEventsIntoIntervals(t *testing.T) {
+	staff := &entity.Visitor{Id: 1, Name: "Anna"}
+	tracks := []*entity.VisitTrack{
+		signIn(staff, "2024-09-02 08:00"),
+		signOut(staff, "2024-09-02 12:00"),
+		signIn(staff, "2024-09-02 12:30"),
+		signOut(staff, "2024-09-02 17:00"),
+	}
+
+	timesheets := Compute(tracks, testPolicy)
+
+	assert.Len(t, timesheets, 1)
+	day := timesheets[0].Days[0]
+	assert.Equal(t, "2024-09-02", day.Date)
+	assert.Len(t, day.Intervals, 2)
+	assert.Equal(t, 4.0, day.Intervals[0].Hours)
+	assert.Equal(t, 4.5, day.Intervals[1].Hours)
+	assert.Equal(t, 8.5, day.Hours)
+	assert.Equal(t, 0.5, day.Overtime)
+	assert.Empty(t, day.Flags)
+	assert.Equal(t, 8.5, timesheets[0].Hours)
+	assert.Equal(t, 0.0, timesheets[0].Overtime)
+}
+
+func TestComputeWillFlagMissingSignOut(t *testing.T) {
+	staff := &entity.Visitor{Id: 1}
+	tracks := []*entity.VisitTrack{
+		signIn(staff, "2024-09-02 08:00"),
+		signOut(staff, "2024-09-02 16:00"),
+		signIn(staff, "2024-09-02 18:00"),
+		signIn(staff, "2024-09-03 08:00"),
+		signOut(staff, "2024-09-03 15:00"),
+	}
+
+	timesheets := Compute(tracks, testPolicy)
+
+	days := timesheets[0].Days
+	assert.Len(t, days, 2)
+	assert.Equal(t, []string{FlagMissingSignOut}, days[0].Flags)
+	assert.Nil(t, days[0].Intervals[1].SignOut)
+	assert.Equal(t, []string{FlagMissingSignOut}, days[0].Intervals[1].Flags)
+	assert.Equal(t, 8.0, days[0].Hours)
+	assert.Empty(t, days[1].Flags)
+	assert.Equal(t, 7.0, days[1].Hours)
+}
+
+func TestComputeWillTotalWeeksAndOvertime(t *testing.T) {
+	staff := &entity.Visitor{Id: 1}
+	tracks := []*entity.VisitTrack{}
+	// Monday to Friday, 9 hours a day, then Monday of the next week.
+	for _, date := range []string{"2024-09-02", "2024-09-03", "2024-09-04", "2024-09-05", "2024-09-06", "2024-09-09"} {
+		tracks = append(tracks, signIn(staff, date+" 08:00"), signOut(staff, date+" 17:00"))
+	}
+
+	timesheets := Compute(tracks, testPolicy)
+
+	weeks := timesheets[0].Weeks
+	assert.Len(t, weeks, 2)
+	assert.Equal(t, 36, weeks[0].Week)
+	assert.Equal(t, 45.0, weeks[0].Hours)
+	assert.Equal(t, 5.0, weeks[0].Overtime)
+	assert.Equal(t, 37, weeks[1].Week)
+	assert.Equal(t, 9.0, weeks[1].Hours)
+	assert.Equal(t, 0.0, weeks[1].Overtime)
+	assert.Equal(t, 54.0, timesheets[0].Hours)
+	assert.Equal(t, 5.0, timesheets[0].Overtime)
+}
+
+func TestComputeWillSeparateStaffMembers(t *testing.T) {
+	anna := &entity.Visitor{Id: 1, Name: "Anna"}
+	bob := &entity.Visitor{Id: 2, Name: "Bob"}
+	tracks := []*entity.VisitTrack{
+		signIn(anna, "2024-09-02 08:00"),
+		signIn(bob, "2024-09-02 09:00"),
+		signOut(anna, "2024-09-02 10:00"),
+		signOut(bob, "2024-09-02 10:00"),
+	}
+
+	timesheets := Compute(tracks, testPolicy)
+
+	assert.Len(t, timesheets, 2)
+	assert.Equal(t, "Anna", timesheets[0].Visitor.Name)
+	assert.Equal(t, 2.0, timesheets[0].Hours)
+	assert.Equal(t, "Bob", timesheets[1].Visitor.Name)
+	assert.Equal(t, 1.0, timesheets[1].Hours)
+}
+
+func TestComputeWithoutContractedHoursHasNoOvertime(t *testing.T) {
+	staff := &entity.Visitor{Id: 1}
+	tracks := []*entity.VisitTrack{
+		signIn(staff, "2024-09-02 06:00"),
+		signOut(staff, "2024-09-02 20:00"),
+	}
+
+	timesheets := Compute(tracks, Policy{})
+
+	assert.Equal(t, 0.0, timesheets[0].Days[0].Overtime)
+	assert.Equal(t, 0.0, timesheets[0].Overtime)
+}
+
+func TestComputeWillCountDaysInSchoolZone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+	staff := &entity.Visitor{Id: 1}
+	// Stored in UTC, 23:30 on September 1st is 08:30 on September 2nd in Tokyo
+	tracks := []*entity.VisitTrack{
+		signIn(staff, "2024-09-01 23:30"),
+		signOut(staff, "2024-09-02 08:30"),
+	}
+
+	timesheets := Compute(tracks, Policy{Location: tokyo})
+
+	assert.Len(t, timesheets[0].Days, 1)
+	day := timesheets[0].Days[0]
+	assert.Equal(t, "2024-09-02", day.Date)
+	assert.Equal(t, 9.0, day.Hours)
+	assert.Equal(t, 8, day.Intervals[0].SignIn.Hour())
+	assert.Empty(t, day.Flags)
+}
+
+func TestComputeWillPairBySignedIn(t *testing.T) {
+	staff := &entity.Visitor{Id: 1}
+	tests := []struct {
+		name      string
+		tracks    []*entity.VisitTrack
+		intervals int
+		hours     float64
+		flags     []string
+	}{
+		{
+			name: "day starts with a sign-out",
+			tracks: []*entity.VisitTrack{
+				signOut(staff, "2024-09-02 07:00"),
+				signIn(staff, "2024-09-02 08:00"),
+				signOut(staff, "2024-09-02 16:00"),
+			},
+			intervals: 2,
+			hours:     8,
+			flags:     []string{FlagMissingSignIn},
+		},
+		{
+			name: "two sign-ins in a row",
+			tracks: []*entity.VisitTrack{
+				signIn(staff, "2024-09-02 08:00"),
+				signIn(staff, "2024-09-02 09:00"),
+				signOut(staff, "2024-09-02 17:00"),
+			},
+			intervals: 2,
+			hours:     8,
+			flags:     []string{FlagMissingSignOut},
+		},
+		{
+			name: "two sign-outs in a row",
+			tracks: []*entity.VisitTrack{
+				signIn(staff, "2024-09-02 08:00"),
+				signOut(staff, "2024-09-02 12:00"),
+				signOut(staff, "2024-09-02 17:00"),
+			},
+			intervals: 2,
+			hours:     4,
+			flags:     []string{FlagMissingSignIn},
+		},
+		{
+			name: "both anomalies flagged once",
+			tracks: []*entity.VisitTrack{
+				signOut(staff, "2024-09-02 07:00"),
+				signIn(staff, "2024-09-02 08:00"),
+				signIn(staff, "2024-09-02 09:00"),
+				signOut(staff, "2024-09-02 10:00"),
+				signIn(staff, "2024-09-02 11:00"),
+			},
+			intervals: 4,
+			hours:     1,
+			flags:     []string{FlagMissingSignIn, FlagMissingSignOut},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			timesheets := Compute(tc.tracks, testPolicy)
+
+			day := timesheets[0].Days[0]
+			assert.Len(t, day.Intervals, tc.intervals)
+			assert.Equal(t, tc.hours, day.Hours)
+			assert.Equal(t, tc.flags, day.Flags)
+		})
+	}
+}
+
+func TestComputeWillKeepSignOutWithoutSignIn(t *testing.T) {
+	staff := &entity.Visitor{Id: 1}
+	tracks := []*entity.VisitTrack{
+		signOut(staff, "2024-09-02 07:00"),
+	}
+
+	timesheets := Compute(tracks, testPolicy)
+
+	interval := timesheets[0].Days[0].Intervals[0]
+	assert.Nil(t, interval.SignIn)
+	if assert.NotNil(t, interval.SignOut) {
+		assert.Equal(t, 7, interval.SignOut.Hour())
+	}
+	assert.Equal(t, []string{FlagMissingSignIn}, interval.Flags)
+	assert.Equal(t, 0.0, interval.Hours)
+}
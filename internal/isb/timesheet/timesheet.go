@@ -0,0 +1,141 @@
+package timesheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+type Response struct {
+	From       string       `json:"from"`
+	To         string       `json:"to"`
+	Timesheets []*Timesheet `json:"timesheets"`
+}
+
+type TimesheetController struct {
+	TimesheetRepository entity.TimesheetRepository `container:"type"`
+	Config              *config.Config             `container:"type"`
+	Location            *time.Location             `container:"type"`
+}
+
+func (tc *TimesheetController) TimesheetHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		from, to, ok := periodFromQuery(ctx, tc.Location)
+		if !ok {
+			return
+		}
+		timesheets, err := tc.compute(from, to)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, Response{
+			From:       from.Format(dateLayout),
+			To:         to.AddDate(0, 0, -1).Format(dateLayout),
+			Timesheets: timesheets,
+		})
+	}
+}
+
+// ExportHandler writes one row per staff member and day for the pay period.
+func (tc *TimesheetController) ExportHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		from, to, ok := periodFromQuery(ctx, tc.Location)
+		if !ok {
+			return
+		}
+		timesheets, err := tc.compute(from, to)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+
+		fileName := fmt.Sprintf("timesheet-%s-%s.csv", from.Format(dateLayout), to.AddDate(0, 0, -1).Format(dateLayout))
+		ctx.Header("Content-Disposition", "attachment; filename="+fileName)
+		ctx.Header("Content-Type", "text/csv")
+		ctx.Status(http.StatusOK)
+		writer := csv.NewWriter(ctx.Writer)
+		_ = writer.Write([]string{"staff_id", "name", "surname", "date", "first_in", "last_out", "hours", "overtime", "flags"})
+		for _, timesheet := range timesheets {
+			for _, day := range timesheet.Days {
+				firstIn, lastOut := "", ""
+				if len(day.Intervals) > 0 {
+					if first := day.Intervals[0]; first.SignIn != nil {
+						firstIn = first.SignIn.Format("15:04:05")
+					}
+					if last := day.Intervals[len(day.Intervals)-1]; last.SignOut != nil {
+						lastOut = last.SignOut.Format("15:04:05")
+					}
+				}
+				_ = writer.Write([]string{
+					strconv.Itoa(int(timesheet.Visitor.Id)),
+					timesheet.Visitor.Name,
+					timesheet.Visitor.Surname,
+					day.Date,
+					firstIn,
+					lastOut,
+					strconv.FormatFloat(day.Hours, 'f', 2, 64),
+					strconv.FormatFloat(day.Overtime, 'f', 2, 64),
+					strings.Join(day.Flags, ";"),
+				})
+			}
+		}
+		writer.Flush()
+	}
+}
+
+func (tc *TimesheetController) compute(from time.Time, to time.Time) ([]*Timesheet, error) {
+	tracks, err := tc.TimesheetRepository.FindStaffTracksBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return Compute(tracks, tc.policy()), nil
+}
+
+func (tc *TimesheetController) policy() Policy {
+	if tc.Config == nil {
+		return Policy{Location: tc.Location}
+	}
+	return Policy{
+		ContractedHoursPerDay:  tc.Config.StaffContractedHoursPerDay,
+		ContractedHoursPerWeek: tc.Config.StaffContractedHoursPerWeek,
+		Location:               tc.Location,
+	}
+}
+
+// periodFromQuery reads the inclusive from/to dates, defaulting to the current
+// week, and returns the half-open [from, to) range.
+func periodFromQuery(ctx *gin.Context, loc *time.Location) (time.Time, time.Time, bool) {
+	today := util.StartOfDay(util.In(time.Now(), loc))
+	from := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	to := from.AddDate(0, 0, 6)
+
+	var err error
+	if value := ctx.Query("from"); value != "" {
+		if from, err = util.ParseDate(value, loc); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid from date, expected YYYY-MM-DD"))))
+			return from, to, false
+		}
+	}
+	if value := ctx.Query("to"); value != "" {
+		if to, err = util.ParseDate(value, loc); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid to date, expected YYYY-MM-DD"))))
+			return from, to, false
+		}
+	}
+	if to.Before(from) {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid period, to is before from"))))
+		return from, to, false
+	}
+	return from, to.AddDate(0, 0, 1), true
+}
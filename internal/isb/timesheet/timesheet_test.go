@@ -0,0 +1,125 @@
+package timesheet
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTimesheetRepository is a mock implementation of entity.TimesheetRepository
+type MockTimesheetRepository struct {
+	mock.Mock
+}
+
+func (m *MockTimesheetRepository) FindStaffTracksBetween(from time.Time, to time.Time) ([]*entity.VisitTrack, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitTrack), args.Error(1)
+}
+
+func performRequest(handler gin.HandlerFunc, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", target, nil)
+	handler(c)
+	return w
+}
+
+func TestTimesheetHandler_UsesRequestedPeriod(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := new(MockTimesheetRepository)
+	controller := &TimesheetController{
+		TimesheetRepository: mockRepo,
+		Config:              &config.Config{StaffContractedHoursPerDay: 8, StaffContractedHoursPerWeek: 40},
+	}
+
+	staff := &entity.Visitor{Id: 1, Name: "Anna"}
+	from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2024, 10, 1, 0, 0, 0, 0, time.Local)
+	mockRepo.On("FindStaffTracksBetween", from, to).Return([]*entity.VisitTrack{
+		signIn(staff, "2024-09-02 08:00"),
+		signOut(staff, "2024-09-02 17:00"),
+	}, nil)
+
+	w := performRequest(controller.TimesheetHandler(), "/api/timesheets?from=2024-09-01&to=2024-09-30")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response Response
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "2024-09-01", response.From)
+	assert.Equal(t, "2024-09-30", response.To)
+	assert.Len(t, response.Timesheets, 1)
+	assert.Equal(t, 9.0, response.Timesheets[0].Hours)
+	assert.Equal(t, 1.0, response.Timesheets[0].Days[0].Overtime)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTimesheetHandler_InvalidDate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := new(MockTimesheetRepository)
+	controller := &TimesheetController{TimesheetRepository: mockRepo}
+
+	w := performRequest(controller.TimesheetHandler(), "/api/timesheets?from=yesterday")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRepo.AssertNotCalled(t, "FindStaffTracksBetween", mock.Anything, mock.Anything)
+}
+
+func TestTimesheetHandler_ToBeforeFrom(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := new(MockTimesheetRepository)
+	controller := &TimesheetController{TimesheetRepository: mockRepo}
+
+	w := performRequest(controller.TimesheetHandler(), "/api/timesheets?from=2024-09-10&to=2024-09-01")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTimesheetHandler_RepositoryError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := new(MockTimesheetRepository)
+	controller := &TimesheetController{TimesheetRepository: mockRepo}
+
+	mockRepo.On("FindStaffTracksBetween", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+	w := performRequest(controller.TimesheetHandler(), "/api/timesheets")
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestExportHandler_WritesPayrollCsv(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := new(MockTimesheetRepository)
+	controller := &TimesheetController{
+		TimesheetRepository: mockRepo,
+		Config:              &config.Config{StaffContractedHoursPerDay: 8, StaffContractedHoursPerWeek: 40},
+	}
+
+	staff := &entity.Visitor{Id: 1, Name: "Anna", Surname: "Smith"}
+	mockRepo.On("FindStaffTracksBetween", mock.Anything, mock.Anything).Return([]*entity.VisitTrack{
+		signIn(staff, "2024-09-02 08:00"),
+		signOut(staff, "2024-09-02 17:30"),
+		signIn(staff, "2024-09-03 08:00"),
+	}, nil)
+
+	w := performRequest(controller.ExportHandler(), "/api/timesheets/export?from=2024-09-01&to=2024-09-30")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "timesheet-2024-09-01-2024-09-30.csv")
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "1,Anna,Smith,2024-09-02,08:00:00,17:30:00,9.50,1.50,", lines[1])
+	assert.Equal(t, "1,Anna,Smith,2024-09-03,08:00:00,,0.00,0.00,missing_sign_out", lines[2])
+}
@@ -0,0 +1,89 @@
+package tracker
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// MaxBatchScans is the number of queued scans a kiosk may sync at once.
+	MaxBatchScans = 500
+
+	ScanTracked   = "tracked"
+	ScanDuplicate = "duplicate"
+	ScanDebounced = "debounced"
+	ScanNotFound  = "not_found"
+	ScanInvalid   = "invalid"
+	// ScanFailed is the only status the kiosk retries, all others are final.
+	ScanFailed = "failed"
+
+	// maxClientSkew is how far ahead of the server a kiosk clock may be.
+	maxClientSkew = 5 * time.Minute
+	// MaxScanIdLength bounds the ids clients give their scans.
+	MaxScanIdLength = 64
+)
+
+// Scan is a scan queued by a kiosk while it could not reach the server.
+type Scan struct {
+	// Id is generated by the kiosk, a scan synced twice is stored once.
+	Id        string    `json:"id"`
+	VisitKey  string    `json:"visit_key"`
+	ScannedAt time.Time `json:"scanned_at"`
+}
+
+type BatchRequest struct {
+	Scans []Scan `json:"scans"`
+}
+
+type ScanResult struct {
+	Id     string         `json:"id"`
+	Status string         `json:"status"`
+	Error  string         `json:"error,omitempty"`
+	Track  *TrackResponse `json:"track,omitempty"`
+}
+
+type BatchResponse struct {
+	Results []ScanResult `json:"results"`
+}
+
+// BatchHandler syncs the scans an offline kiosk queued, see
+// TrackingService.Sync.
+func (tc *TrackerController) BatchHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request BatchRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		results, err := tc.Service.Sync(ctx.Request.Context(), request.Scans)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		ctx.JSON(http.StatusOK, BatchResponse{Results: results})
+	}
+}
+
+func validateScan(scan Scan) string {
+	switch {
+	case scan.Id == "" || len(scan.Id) > MaxScanIdLength:
+		return "Scan id must have between 1 and 64 characters"
+	case scan.VisitKey == "":
+		return "Visit key is required"
+	case scan.ScannedAt.IsZero():
+		return "Scan time is required"
+	case scan.ScannedAt.After(time.Now().Add(maxClientSkew)):
+		return "Scan time is in the future"
+	}
+	return ""
+}
+
+func failedScan(result ScanResult) ScanResult {
+	result.Status = ScanFailed
+	result.Error = "Scan could not be stored, retry later"
+	return result
+}
@@ -0,0 +1,212 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/apispec"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newBatchRequest(request BatchRequest) *http.Request {
+	body, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/api/v1/scans/batch", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func performBatch(t *testing.T, controller *TrackerController, request BatchRequest) *httptest.ResponseRecorder {
+	return serve(t, controller.BatchHandler(), newBatchRequest(request))
+}
+
+func decodeBatch(t *testing.T, w *httptest.ResponseRecorder) BatchResponse {
+	var response BatchResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func storedScan(id int, clientId string, at time.Time) *entity.VisitTrack {
+	return &entity.VisitTrack{
+		Id:        id,
+		VisitorId: 1,
+		VisitKey:  "KEY123",
+		Visitor:   newTestVisitDetails().Visitor,
+		ClientId:  clientId,
+		CreatedAt: at,
+	}
+}
+
+func withClientId(clientId string) any {
+	return mock.MatchedBy(func(track *entity.VisitTrack) bool {
+		return track.ClientId == clientId
+	})
+}
+
+func TestBatchHandler_OrdersLateArrivingScansIntoTheDay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+	}}
+
+	morning := time.Date(2024, 9, 2, 8, 10, 0, 0, time.UTC)
+	afternoon := time.Date(2024, 9, 2, 15, 30, 0, 0, time.UTC)
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("GetByClientId", mock.Anything).Return(nil, nil)
+	trackRepo.On("StoreBackdated", withClientId("scan-in"), mock.Anything).Return(storedScan(10, "scan-in", morning), nil).Once()
+	trackRepo.On("StoreBackdated", withClientId("scan-out"), mock.Anything).Return(storedScan(11, "scan-out", afternoon), nil).Once()
+	trackRepo.On("CountEventsByVisitorIdBetween", int32(1), mock.Anything, morning).Return(0, nil).Once()
+	trackRepo.On("CountEventsByVisitorIdBetween", int32(1), mock.Anything, afternoon).Return(1, nil).Once()
+
+	// The kiosk sends its queue newest first
+	w := performBatch(t, controller, BatchRequest{Scans: []Scan{
+		{Id: "scan-out", VisitKey: "KEY123", ScannedAt: afternoon},
+		{Id: "scan-in", VisitKey: "KEY123", ScannedAt: morning},
+	}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	response := decodeBatch(t, w)
+	assert.Len(t, response.Results, 2)
+	assert.Equal(t, "scan-in", response.Results[0].Id)
+	assert.Equal(t, ScanTracked, response.Results[0].Status)
+	assert.Equal(t, "sign-in", response.Results[0].Track.TrackType)
+	assert.Equal(t, "scan-out", response.Results[1].Id)
+	assert.Equal(t, "sign-out", response.Results[1].Track.TrackType)
+	trackRepo.AssertExpectations(t)
+}
+
+func TestBatchHandler_SyncedScanIsNotStoredAgain(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+	}}
+
+	scannedAt := time.Date(2024, 9, 2, 8, 10, 0, 0, time.UTC)
+	trackRepo.On("GetByClientId", "scan-in").Return(storedScan(10, "scan-in", scannedAt), nil)
+
+	w := performBatch(t, controller, BatchRequest{Scans: []Scan{
+		{Id: "scan-in", VisitKey: "KEY123", ScannedAt: scannedAt},
+	}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	response := decodeBatch(t, w)
+	assert.Equal(t, ScanDuplicate, response.Results[0].Status)
+	assert.Equal(t, int64(10), response.Results[0].Track.TrackId)
+	trackRepo.AssertNotCalled(t, "StoreBackdated", mock.Anything, mock.Anything)
+	visitorRepo.AssertNotCalled(t, "FindByKey", mock.Anything)
+}
+
+func TestBatchHandler_ConcurrentSyncIsDuplicate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+	}}
+
+	scannedAt := time.Date(2024, 9, 2, 8, 10, 0, 0, time.UTC)
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("GetByClientId", "scan-in").Return(nil, nil).Once()
+	trackRepo.On("CountEventsByVisitorIdBetween", int32(1), mock.Anything, scannedAt).Return(0, nil)
+	trackRepo.On("StoreBackdated", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+	trackRepo.On("GetByClientId", "scan-in").Return(storedScan(10, "scan-in", scannedAt), nil).Once()
+
+	w := performBatch(t, controller, BatchRequest{Scans: []Scan{
+		{Id: "scan-in", VisitKey: "KEY123", ScannedAt: scannedAt},
+	}})
+
+	response := decodeBatch(t, w)
+	assert.Equal(t, ScanDuplicate, response.Results[0].Status)
+	trackRepo.AssertExpectations(t)
+}
+
+func TestBatchHandler_ReportsEachScan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+	}}
+
+	scannedAt := time.Date(2024, 9, 2, 8, 10, 0, 0, time.UTC)
+	trackRepo.On("GetByClientId", mock.Anything).Return(nil, nil)
+	visitorRepo.On("FindByKey", "UNKNOWN").Return(&entity.VisitDetails{}, nil)
+	visitorRepo.On("FindByKey", "BROKEN").Return(nil, assert.AnError)
+
+	w := performBatch(t, controller, BatchRequest{Scans: []Scan{
+		{Id: "", VisitKey: "KEY123", ScannedAt: scannedAt},
+		{Id: "scan-2", VisitKey: "UNKNOWN", ScannedAt: scannedAt.Add(time.Minute)},
+		{Id: "scan-3", VisitKey: "BROKEN", ScannedAt: scannedAt.Add(2 * time.Minute)},
+		{Id: "scan-4", VisitKey: "KEY123", ScannedAt: time.Now().Add(time.Hour)},
+	}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	response := decodeBatch(t, w)
+	statuses := []string{}
+	for _, result := range response.Results {
+		statuses = append(statuses, result.Status)
+	}
+	assert.Equal(t, []string{ScanInvalid, ScanNotFound, ScanFailed, ScanInvalid}, statuses)
+	assert.Equal(t, "Scan time is in the future", response.Results[3].Error)
+	trackRepo.AssertNotCalled(t, "StoreBackdated", mock.Anything, mock.Anything)
+}
+
+func TestBatchHandler_DebouncesRepeatedScans(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+		Debouncer:         NewDebouncer(time.Minute),
+	}}
+
+	scannedAt := time.Date(2024, 9, 2, 8, 10, 0, 0, time.UTC)
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("GetByClientId", mock.Anything).Return(nil, nil)
+	repeatedAt := scannedAt.Add(10 * time.Second)
+	trackRepo.On("StoreBackdated", withClientId("scan-1"), mock.Anything).Return(storedScan(10, "scan-1", scannedAt), nil).Once()
+	trackRepo.On("CountEventsByVisitorIdBetween", int32(1), scannedAt.Add(-time.Minute), scannedAt.Add(time.Minute)).Return(0, nil).Once()
+	trackRepo.On("CountEventsByVisitorIdBetween", int32(1), mock.Anything, scannedAt).Return(0, nil).Once()
+	trackRepo.On("CountEventsByVisitorIdBetween", int32(1), repeatedAt.Add(-time.Minute), repeatedAt.Add(time.Minute)).Return(1, nil).Once()
+
+	w := performBatch(t, controller, BatchRequest{Scans: []Scan{
+		{Id: "scan-1", VisitKey: "KEY123", ScannedAt: scannedAt},
+		{Id: "scan-2", VisitKey: "KEY123", ScannedAt: repeatedAt},
+	}})
+
+	response := decodeBatch(t, w)
+	assert.Equal(t, ScanTracked, response.Results[0].Status)
+	assert.Equal(t, ScanDebounced, response.Results[1].Status)
+	trackRepo.AssertNumberOfCalls(t, "StoreBackdated", 1)
+}
+
+func TestBatchHandler_RejectsOversizedBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := &TrackerController{Service: &TrackingService{}}
+
+	request := BatchRequest{Scans: make([]Scan, MaxBatchScans+1)}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = newBatchRequest(request)
+	controller.BatchHandler()(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	// api/openapi.yaml caps batches as well
+	validator, _ := apispec.NewValidator()
+	assert.Error(t, validator.ValidateRequest(newBatchRequest(request)))
+}
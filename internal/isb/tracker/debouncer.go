@@ -0,0 +1,104 @@
+package tracker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// debounceEntry is the last scan of a visitor. pending is open while the
+// scan is still being tracked.
+type debounceEntry struct {
+	response  TrackResponse
+	scannedAt time.Time
+	pending   chan struct{}
+}
+
+// Debouncer remembers the last tracking result per visitor so that repeated
+// scans within the window return the previous result instead of a new track.
+// Scans of a visitor are tracked one at a time, a scan arriving while
+// another one is tracked waits for it, so concurrent scans can not both pass
+// the debounce or the anti-passback check.
+type Debouncer struct {
+	Window time.Duration
+	Now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[int32]*debounceEntry
+}
+
+func NewDebouncer(window time.Duration) *Debouncer {
+	return &Debouncer{
+		Window:  window,
+		Now:     time.Now,
+		entries: make(map[int32]*debounceEntry),
+	}
+}
+
+func (d *Debouncer) Enabled() bool {
+	return d != nil && d.Window > 0
+}
+
+// Claim reserves the scan of the visitor. It returns the remembered response
+// and true when the visitor was scanned within the debounce window, waiting
+// for a scan still being tracked. Otherwise the caller tracks the scan and
+// must Release the visitor afterwards. Waiting stops with the error of ctx
+// when it is done, the visitor is not claimed then and must not be released.
+func (d *Debouncer) Claim(ctx context.Context, visitorId int32) (TrackResponse, bool, error) {
+	if d == nil {
+		return TrackResponse{}, false, nil
+	}
+	for {
+		d.mu.Lock()
+		if d.entries == nil {
+			d.entries = make(map[int32]*debounceEntry)
+		}
+		entry, ok := d.entries[visitorId]
+		if ok && entry.pending != nil {
+			pending := entry.pending
+			d.mu.Unlock()
+			select {
+			case <-pending:
+				continue
+			case <-ctx.Done():
+				return TrackResponse{}, false, ctx.Err()
+			}
+		}
+		if ok && d.Enabled() && d.Now().Sub(entry.scannedAt) < d.Window {
+			d.mu.Unlock()
+			return entry.response, true, nil
+		}
+		d.entries[visitorId] = &debounceEntry{pending: make(chan struct{})}
+		d.mu.Unlock()
+		return TrackResponse{}, false, nil
+	}
+}
+
+// Release ends the scan of the visitor claimed by Claim. The response of a
+// tracked scan is remembered for the window, a failed scan is forgotten so
+// the next one is tracked.
+func (d *Debouncer) Release(visitorId int32, response TrackResponse, tracked bool) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entry, ok := d.entries[visitorId]
+	if !ok || entry.pending == nil {
+		return
+	}
+	now := d.Now()
+	for id, other := range d.entries {
+		if other.pending == nil && now.Sub(other.scannedAt) >= d.Window {
+			delete(d.entries, id)
+		}
+	}
+	close(entry.pending)
+	entry.pending = nil
+	if !tracked || !d.Enabled() {
+		delete(d.entries, visitorId)
+		return
+	}
+	entry.response = response
+	entry.scannedAt = now
+}
@@ -0,0 +1,182 @@
+package tracker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebouncerWithinWindowWillReturnPreviousResponse(t *testing.T) {
+	now := time.Date(2024, 9, 2, 8, 0, 0, 0, time.UTC)
+	d := NewDebouncer(5 * time.Second)
+	d.Now = func() time.Time { return now }
+	response := TrackResponse{TrackType: "sign-in", TrackDate: "2024-09-02 08:00:00"}
+
+	d.Claim(context.Background(), 1)
+	d.Release(1, response, true)
+	now = now.Add(2 * time.Second)
+	previous, ok, _ := d.Claim(context.Background(), 1)
+
+	assert.True(t, ok)
+	assert.Equal(t, response, previous)
+}
+
+func TestDebouncerAfterWindowWillForgetResponse(t *testing.T) {
+	now := time.Date(2024, 9, 2, 8, 0, 0, 0, time.UTC)
+	d := NewDebouncer(5 * time.Second)
+	d.Now = func() time.Time { return now }
+
+	d.Claim(context.Background(), 1)
+	d.Release(1, TrackResponse{TrackType: "sign-in"}, true)
+	now = now.Add(5 * time.Second)
+	_, ok, _ := d.Claim(context.Background(), 1)
+
+	assert.False(t, ok)
+}
+
+func TestDebouncerWillTrackVisitorsSeparately(t *testing.T) {
+	d := NewDebouncer(time.Minute)
+
+	d.Claim(context.Background(), 1)
+	d.Release(1, TrackResponse{TrackType: "sign-in"}, true)
+	_, ok, _ := d.Claim(context.Background(), 2)
+
+	assert.False(t, ok)
+}
+
+func TestDebouncerWithZeroWindowIsDisabled(t *testing.T) {
+	d := NewDebouncer(0)
+
+	d.Claim(context.Background(), 1)
+	d.Release(1, TrackResponse{TrackType: "sign-in"}, true)
+	_, ok, _ := d.Claim(context.Background(), 1)
+
+	assert.False(t, d.Enabled())
+	assert.False(t, ok)
+}
+
+func TestNilDebouncerIsDisabled(t *testing.T) {
+	var d *Debouncer
+
+	d.Claim(context.Background(), 1)
+	d.Release(1, TrackResponse{TrackType: "sign-in"}, true)
+	_, ok, _ := d.Claim(context.Background(), 1)
+
+	assert.False(t, ok)
+}
+
+func TestDebouncerFailedScanIsForgotten(t *testing.T) {
+	d := NewDebouncer(time.Minute)
+
+	d.Claim(context.Background(), 1)
+	d.Release(1, TrackResponse{}, false)
+	_, ok, _ := d.Claim(context.Background(), 1)
+
+	assert.False(t, ok)
+}
+
+func TestDebouncerConcurrentScansWaitForTheFirst(t *testing.T) {
+	d := NewDebouncer(time.Minute)
+	response := TrackResponse{TrackId: 1, TrackType: "sign-in"}
+	tracked := 0
+	responses := make(chan TrackResponse, 20)
+	wg := sync.WaitGroup{}
+
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if previous, ok, _ := d.Claim(context.Background(), 1); ok {
+				responses <- previous
+				return
+			}
+			tracked++
+			time.Sleep(10 * time.Millisecond)
+			d.Release(1, response, true)
+			responses <- response
+		}()
+	}
+	wg.Wait()
+	close(responses)
+
+	assert.Equal(t, 1, tracked)
+	for previous := range responses {
+		assert.Equal(t, response, previous)
+	}
+}
+
+func TestDebouncerWithZeroWindowTracksScansOneAtATime(t *testing.T) {
+	d := NewDebouncer(0)
+	tracking, overlapped := 0, false
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, ok, _ := d.Claim(context.Background(), 1)
+			assert.False(t, ok)
+			mu.Lock()
+			tracking++
+			overlapped = overlapped || tracking > 1
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			tracking--
+			mu.Unlock()
+			d.Release(1, TrackResponse{}, true)
+		}()
+	}
+	wg.Wait()
+
+	assert.False(t, overlapped)
+}
+
+func TestDebouncerCancelledWaitDoesNotClaim(t *testing.T) {
+	d := NewDebouncer(time.Minute)
+	response := TrackResponse{TrackId: 1, TrackType: "sign-in"}
+	d.Claim(context.Background(), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, ok, err := d.Claim(ctx, 1)
+
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// The first scan still holds the claim, the cancelled one released nothing.
+	d.Release(1, response, true)
+	previous, ok, err := d.Claim(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, response, previous)
+}
+
+func TestDebouncerWaitStopsWhenContextIsDone(t *testing.T) {
+	d := NewDebouncer(time.Minute)
+	d.Claim(context.Background(), 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		_, _, err := d.Claim(ctx, 1)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		t.Fatal("Claim kept waiting after the context was done")
+	}
+	d.Release(1, TrackResponse{}, false)
+	_, ok, err := d.Claim(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
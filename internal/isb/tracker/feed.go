@@ -0,0 +1,63 @@
+package tracker
+
+import (
+	"sync"
+)
+
+// feedBuffer is how many events a subscriber may fall behind before it
+// misses some.
+const feedBuffer = 64
+
+// TrackEvent is a track made at a site, see Feed.
+type TrackEvent struct {
+	SiteId   int64
+	VisitKey string
+	Track    TrackResponse
+}
+
+// Feed hands the tracks of every site to the subscribers of the site, the
+// gRPC stream of track events for one. Subscribers which do not keep up miss
+// events instead of holding up tracking.
+type Feed struct {
+	mu          sync.Mutex
+	subscribers map[chan TrackEvent]int64
+}
+
+func NewFeed() *Feed {
+	return &Feed{
+		subscribers: make(map[chan TrackEvent]int64),
+	}
+}
+
+// Subscribe returns the events of the site until cancel is called.
+func (f *Feed) Subscribe(siteId int64) (events <-chan TrackEvent, cancel func()) {
+	ch := make(chan TrackEvent, feedBuffer)
+	f.mu.Lock()
+	f.subscribers[ch] = siteId
+	f.mu.Unlock()
+	return ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.subscribers[ch]; ok {
+			delete(f.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (f *Feed) Publish(event TrackEvent) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch, siteId := range f.subscribers {
+		if siteId != event.SiteId {
+			continue
+		}
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
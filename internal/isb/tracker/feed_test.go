@@ -0,0 +1,44 @@
+package tracker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedWillDeliverEventsOfTheSite(t *testing.T) {
+	feed := NewFeed()
+	north, cancelNorth := feed.Subscribe(1)
+	defer cancelNorth()
+	south, cancelSouth := feed.Subscribe(2)
+	defer cancelSouth()
+
+	feed.Publish(TrackEvent{SiteId: 1, VisitKey: "KEY123", Track: TrackResponse{TrackId: 7}})
+
+	assert.Equal(t, TrackEvent{SiteId: 1, VisitKey: "KEY123", Track: TrackResponse{TrackId: 7}}, <-north)
+	assert.Empty(t, south)
+}
+
+func TestFeedWillDropEventsOfSlowSubscribers(t *testing.T) {
+	feed := NewFeed()
+	events, cancel := feed.Subscribe(1)
+
+	for i := 0; i < feedBuffer+10; i++ {
+		feed.Publish(TrackEvent{SiteId: 1, Track: TrackResponse{TrackId: int64(i)}})
+	}
+	cancel()
+	cancel()
+
+	received := 0
+	for range events {
+		received++
+	}
+	assert.Equal(t, feedBuffer, received)
+}
+
+func TestFeedWithoutSubscribersWillNotBlock(t *testing.T) {
+	var feed *Feed
+
+	feed.Publish(TrackEvent{SiteId: 1})
+	NewFeed().Publish(TrackEvent{SiteId: 1})
+}
@@ -0,0 +1,394 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/consequence"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/tardy"
+	"github.com/buzyka/imlate/internal/isb/transport"
+	"github.com/buzyka/imlate/internal/isb/watchlist"
+)
+
+// ErrInvalidBatch rejects a batch of no scans or of more than MaxBatchScans.
+var ErrInvalidBatch = errors.New("A batch holds between 1 and 500 scans")
+
+// TrackingService tracks the scans of a site: it looks up the visitor of the
+// key, stores the track, classifies it as a sign-in or sign-out, records a
+// late first sign-in and publishes the track to the feed. The kiosk
+// handlers, the networked readers and the gRPC service share it.
+type TrackingService struct {
+	VisitorRepository entity.VisitorRepository      `container:"type"`
+	TrackRepository   entity.VisitorTrackRepository `container:"type"`
+	Config            *config.Config                `container:"type"`
+	Debouncer         *Debouncer                    `container:"type"`
+	Screener          *watchlist.Screener           `container:"type"`
+	TardyRepository   entity.TardyRepository        `container:"type"`
+	Consequences      *consequence.Engine           `container:"type"`
+	Arrivals          *transport.Arrivals           `container:"type"`
+	Site              *entity.Site                  `container:"type"`
+	SiteRepository    entity.SiteRepository         `container:"type"`
+	// Feed gets every track, subscribers follow the tracks of the site.
+	Feed *Feed `container:"type"`
+	// Location is the zone of the school, days start at midnight in it.
+	Location *time.Location `container:"type"`
+	// Now is the time of a scan, the current time when nil.
+	Now func() time.Time
+}
+
+// Track tracks the visitor of the request by id the way FindAndTrack tracks
+// a scan, the manual tracking page and early dismissals use it. SignedIn
+// tells a sign-in from a sign-out unless the request has a Direction:
+// DirectionIn signs the visitor in, subject to anti-passback, DirectionOut
+// signs the visitor out and fails with ErrNotSignedIn unless the visitor is
+// signed in. ErrVisitorNotFound rejects unknown visitors. Queries stop when
+// ctx is done, entity.ErrQueryTimeout matches those which ran out of time.
+func (s *TrackingService) Track(ctx context.Context, request Request) (TrackResponse, error) {
+	visitor, err := s.VisitorRepository.FindById(ctx, request.VisitorID)
+	if errors.Is(err, entity.ErrQueryTimeout) {
+		return TrackResponse{}, err
+	}
+	// Repositories answer an unknown id with an empty visitor, not nil.
+	if err != nil || visitor == nil || visitor.Id == 0 {
+		return TrackResponse{}, ErrVisitorNotFound
+	}
+	switch request.Direction {
+	case DirectionIn:
+		request.SignedIn = true
+	case DirectionOut:
+		request.SignedIn = false
+	}
+	return s.track(ctx, request, visitor, func(signedIn bool) (bool, error) {
+		if request.Direction == DirectionOut && !signedIn {
+			return false, ErrNotSignedIn
+		}
+		return request.SignedIn, nil
+	})
+}
+
+// FindAndTrack tracks a scan of a visit key, ErrVisitorNotFound and
+// ErrAlreadySignedIn reject the scan. A scan whose client id is stored
+// already, e.g. one the kiosk queued after its response was lost, is
+// answered with the stored track.
+func (s *TrackingService) FindAndTrack(ctx context.Context, request Request) (TrackResponse, error) {
+	visitDetails, err := s.VisitorRepository.FindByKey(ctx, request.VisitKey)
+	if errors.Is(err, entity.ErrQueryTimeout) {
+		return TrackResponse{}, err
+	}
+	if err != nil || visitDetails == nil || visitDetails.Visitor == nil {
+		return TrackResponse{}, ErrVisitorNotFound
+	}
+	// A scan signs the visitor in or out, whichever the visitor is not.
+	return s.track(ctx, request, visitDetails.Visitor, func(signedIn bool) (bool, error) {
+		return !signedIn, nil
+	})
+}
+
+// track stores a track of the visitor unless the visitor was tracked within
+// the debounce window or the client id of the request is tracked already.
+// classify tells from whether the visitor is signed in whether the track is
+// a sign-in, a visitor whose events can not be counted is taken as signed
+// out.
+func (s *TrackingService) track(ctx context.Context, request Request, visitor *entity.Visitor, classify func(signedIn bool) (bool, error)) (TrackResponse, error) {
+	track := &entity.VisitTrack{
+		VisitorId: visitor.Id,
+		VisitKey:  request.VisitKey,
+		Visitor:   visitor,
+		ClientId:  request.ClientId,
+	}
+
+	// Scans of the visitor wait for this one, released even on a panic.
+	previous, ok, err := s.Debouncer.Claim(ctx, track.VisitorId)
+	if err != nil {
+		return TrackResponse{}, err
+	}
+	if ok {
+		return previous, nil
+	}
+	visitorId := track.VisitorId
+	response, tracked := TrackResponse{}, false
+	defer func() {
+		s.Debouncer.Release(visitorId, response, tracked)
+	}()
+
+	repeated, err := s.storedScan(ctx, request.ClientId)
+	if err != nil {
+		return TrackResponse{}, err
+	}
+	if repeated != nil {
+		return s.repeatedScan(repeated, track.Visitor), nil
+	}
+
+	// The track is classified by the visitor's events that day before it is
+	// stored, the track keeps the direction so timesheets can pair them.
+	scannedAt := util.In(s.now(), s.Location)
+	before, countErr := s.TrackRepository.CountEventsByVisitorIdSince(ctx, track.VisitorId, util.StartOfDay(scannedAt))
+	signedIn := countErr == nil && before%2 == 1
+	if s.antiPassbackEnabled() && request.Direction == DirectionIn {
+		if countErr != nil {
+			return TrackResponse{}, countErr
+		}
+		if signedIn {
+			logging.FromContext(ctx).Warnw(
+				"Anti-passback: sign-in rejected, visitor already signed in",
+				"visitor_id", track.VisitorId,
+				"visit_key", track.VisitKey,
+			)
+			return TrackResponse{}, ErrAlreadySignedIn
+		}
+	}
+	if request.Direction == DirectionOut && countErr != nil {
+		return TrackResponse{}, countErr
+	}
+	signIn, err := classify(signedIn)
+	if err != nil {
+		return TrackResponse{}, err
+	}
+	track.SignedIn = signIn
+	track.CreatedAt = scannedAt
+
+	track, err = s.TrackRepository.Store(ctx, track)
+	if err != nil {
+		// The same scan may have been synced concurrently.
+		if synced, lookupErr := s.storedScan(ctx, request.ClientId); lookupErr == nil && synced != nil {
+			return s.repeatedScan(synced, visitor), nil
+		}
+		return TrackResponse{}, err
+	}
+	// The day, the lateness and the shown time are those of the school.
+	track.CreatedAt = util.In(track.CreatedAt, s.Location)
+
+	response, tracked = s.respond(ctx, track, signIn, before+1), true
+	return response, nil
+}
+
+// Sync stores the scans an offline kiosk queued, at the time they were
+// made. Scans are processed oldest first and each one is classified by the
+// visitor's events up to it, so a late-arriving scan takes its place in the
+// day and the presence derived from it: the directions of the visitor's
+// later tracks that day are flipped along with storing it. Tardies already
+// recorded for later scans are kept. Scans whose id is stored already and
+// scans within the debounce window of a stored track of the visitor are
+// not stored again. Anti-passback is not applied, the visitor already went
+// through the door. ErrInvalidBatch rejects the whole batch, a failure of a
+// single scan is reported in its result.
+func (s *TrackingService) Sync(ctx context.Context, scans []Scan) ([]ScanResult, error) {
+	if len(scans) == 0 || len(scans) > MaxBatchScans {
+		return nil, ErrInvalidBatch
+	}
+	sort.SliceStable(scans, func(i, j int) bool {
+		return scans[i].ScannedAt.Before(scans[j].ScannedAt)
+	})
+	results := make([]ScanResult, 0, len(scans))
+	for _, scan := range scans {
+		results = append(results, s.syncScan(ctx, scan))
+	}
+	return results, nil
+}
+
+// syncScan stores a single queued scan.
+func (s *TrackingService) syncScan(ctx context.Context, scan Scan) ScanResult {
+	result := ScanResult{Id: scan.Id}
+	if message := validateScan(scan); message != "" {
+		result.Status = ScanInvalid
+		result.Error = message
+		return result
+	}
+	logger := logging.FromContext(ctx)
+
+	stored, err := s.TrackRepository.GetByClientId(ctx, scan.Id)
+	if err != nil {
+		logger.Errorf("Error looking up synced scan: %s", err.Error())
+		return failedScan(result)
+	}
+	if stored != nil {
+		return s.duplicateScan(result, stored)
+	}
+
+	visitDetails, err := s.VisitorRepository.FindByKey(ctx, scan.VisitKey)
+	if err != nil {
+		logger.Errorf("Error finding visitor of synced scan: %s", err.Error())
+		return failedScan(result)
+	}
+	if visitDetails == nil || visitDetails.Visitor == nil {
+		result.Status = ScanNotFound
+		result.Error = ErrVisitorNotFound.Error()
+		return result
+	}
+	visitor := visitDetails.Visitor
+
+	// Tracks stored online or by earlier batches count, not only the scans
+	// of this batch.
+	if s.Debouncer.Enabled() {
+		nearby, err := s.TrackRepository.CountEventsByVisitorIdBetween(ctx, visitor.Id, scan.ScannedAt.Add(-s.Debouncer.Window), scan.ScannedAt.Add(s.Debouncer.Window))
+		if err != nil {
+			logger.Errorf("Error looking up tracks near synced scan: %s", err.Error())
+			return failedScan(result)
+		}
+		if nearby > 0 {
+			result.Status = ScanDebounced
+			return result
+		}
+	}
+
+	scannedAt := util.In(scan.ScannedAt, s.Location)
+	before, countErr := s.TrackRepository.CountEventsByVisitorIdBetween(ctx, visitor.Id, util.StartOfDay(scannedAt), scannedAt)
+	signIn := countErr != nil || before%2 == 0
+	track, err := s.TrackRepository.StoreBackdated(ctx, &entity.VisitTrack{
+		VisitorId: visitor.Id,
+		VisitKey:  scan.VisitKey,
+		Visitor:   visitor,
+		SignedIn:  signIn,
+		ClientId:  scan.Id,
+		CreatedAt: scan.ScannedAt,
+	}, util.StartOfNextDay(scannedAt))
+	if err != nil {
+		// The same scan may have been synced concurrently by a retry.
+		if stored, lookupErr := s.TrackRepository.GetByClientId(ctx, scan.Id); lookupErr == nil && stored != nil {
+			return s.duplicateScan(result, stored)
+		}
+		logger.Errorf("Error storing synced scan: %s", err.Error())
+		return failedScan(result)
+	}
+	track.CreatedAt = util.In(track.CreatedAt, s.Location)
+
+	response := s.respond(ctx, track, signIn, before+1)
+	result.Status = ScanTracked
+	result.Track = &response
+	return result
+}
+
+// storedScan returns the track stored for the client id of a scan, nil for
+// scans without one.
+func (s *TrackingService) storedScan(ctx context.Context, clientId string) (*entity.VisitTrack, error) {
+	if clientId == "" {
+		return nil, nil
+	}
+	return s.TrackRepository.GetByClientId(ctx, clientId)
+}
+
+// repeatedScan answers a scan tracked already with its stored track.
+func (s *TrackingService) repeatedScan(stored *entity.VisitTrack, visitor *entity.Visitor) TrackResponse {
+	eType := "sign-in"
+	if !stored.SignedIn {
+		eType = "sign-out"
+	}
+	return TrackResponse{
+		TrackId:   int64(stored.Id),
+		Visitor:   visitor,
+		TrackType: eType,
+		TrackDate: util.In(stored.CreatedAt, s.Location).Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (s *TrackingService) duplicateScan(result ScanResult, stored *entity.VisitTrack) ScanResult {
+	result.Status = ScanDuplicate
+	result.Track = &TrackResponse{
+		TrackId:   int64(stored.Id),
+		TrackDate: util.In(stored.CreatedAt, s.Location).Format("2006-01-02 15:04:05"),
+	}
+	return result
+}
+
+// respond answers a stored sign-in or sign-out, eCount is the number of the
+// visitor's events that day up to and including it. It records a first late
+// sign-in, screens the visitor and publishes the track to the feed.
+func (s *TrackingService) respond(ctx context.Context, track *entity.VisitTrack, signIn bool, eCount int) TrackResponse {
+	eType := "sign-in"
+	if !signIn {
+		eType = "sign-out"
+	}
+
+	response := TrackResponse{
+		TrackId:   int64(track.Id),
+		Visitor:   track.Visitor,
+		TrackType: eType,
+		TrackDate: track.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if eType == "sign-in" && eCount == 1 && s.Config != nil && !s.closedOn(ctx, track.CreatedAt) {
+		if minutesLate := tardy.NewPolicy(s.Config).MinutesLate(track.CreatedAt); minutesLate > 0 {
+			response.Late = true
+			response.MinutesLate = minutesLate
+			response.LateReasons = s.Config.LateReasons
+			if s.recordLate(ctx, track, minutesLate) {
+				response.Excused = true
+				response.LateReasons = nil
+			}
+		}
+	}
+	entry, err := s.Screener.ScreenVisitor(ctx, track.Visitor, watchlist.SourceScan)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Error screening visitor against watchlist: %s", err.Error())
+	}
+	if entry != nil {
+		response.Status = watchlist.AlertStatus
+	}
+	if s.Site != nil {
+		s.Feed.Publish(TrackEvent{SiteId: s.Site.Id, VisitKey: track.VisitKey, Track: response})
+	}
+	return response
+}
+
+// recordLate stores the tardy, the kiosk adds the reason later, excuses it
+// when the student's bus arrived late and applies the consequence rules.
+// Failures are logged, the sign-in itself stands. Returns whether the
+// tardy was excused.
+func (s *TrackingService) recordLate(ctx context.Context, track *entity.VisitTrack, minutesLate int) bool {
+	if s.TardyRepository == nil {
+		return false
+	}
+	logger := logging.FromContext(ctx)
+	lateness := &entity.Tardy{
+		TrackId:     int64(track.Id),
+		VisitorId:   track.VisitorId,
+		Visitor:     track.Visitor,
+		MinutesLate: minutesLate,
+		TrackedAt:   track.CreatedAt,
+	}
+	if err := s.TardyRepository.Save(ctx, lateness); err != nil {
+		logger.Errorf("Error recording late sign-in: %s", err.Error())
+		return false
+	}
+	excused, err := s.Arrivals.ExcuseLate(ctx, lateness)
+	if err != nil {
+		logger.Errorf("Error checking bus arrival: %s", err.Error())
+	}
+	if excused {
+		return true
+	}
+	if _, err := s.Consequences.Evaluate(ctx, track.Visitor, track.CreatedAt); err != nil {
+		logger.Errorf("Error evaluating consequence rules: %s", err.Error())
+	}
+	return false
+}
+
+// closedOn reports whether the site has no school that day, nobody is late
+// then. Calendar failures are logged and the day counts as a school day.
+func (s *TrackingService) closedOn(ctx context.Context, at time.Time) bool {
+	if s.Site == nil || s.SiteRepository == nil {
+		return false
+	}
+	closed, err := s.SiteRepository.IsClosedOn(s.Site.Id, at)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Error checking site calendar: %s", err.Error())
+		return false
+	}
+	return closed
+}
+
+func (s *TrackingService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *TrackingService) antiPassbackEnabled() bool {
+	return s.Config != nil && s.Config.AntiPassback
+}
@@ -0,0 +1,358 @@
+package tracker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/repository"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryTardies is an entity.TardyRepository, a track has at most one.
+type memoryTardies struct {
+	tardies map[int64]*entity.Tardy
+}
+
+func (r *memoryTardies) Save(ctx context.Context, tardy *entity.Tardy) error {
+	if r.tardies == nil {
+		r.tardies = map[int64]*entity.Tardy{}
+	}
+	tardy.Id = tardy.TrackId
+	r.tardies[tardy.TrackId] = tardy
+	return nil
+}
+
+func (r *memoryTardies) GetByTrackId(ctx context.Context, trackId int64) (*entity.Tardy, error) {
+	return r.tardies[trackId], nil
+}
+
+func (r *memoryTardies) FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.Tardy, error) {
+	found := []*entity.Tardy{}
+	for _, tardy := range r.tardies {
+		if !tardy.TrackedAt.Before(from) && tardy.TrackedAt.Before(to) {
+			found = append(found, tardy)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryTardies) CountByVisitorIdSince(ctx context.Context, visitorId int32, since time.Time) (int, error) {
+	count := 0
+	for _, tardy := range r.tardies {
+		if tardy.VisitorId == visitorId && !tardy.TrackedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+var jane = &entity.Visitor{Id: 7, Name: "Jane", Surname: "Doe", Grade: 5, SiteId: 3}
+
+func newTrackingService() *TrackingService {
+	memory := repository.NewMemoryStore()
+	scope := repository.Scope{TenantId: 1, SiteId: 3}
+	_ = memory.Seed(scope, repository.MemorySeed{Visitors: []repository.SeedVisitor{{Visitor: *jane, Keys: []string{"KEY123"}}}})
+	return &TrackingService{
+		VisitorRepository: &repository.MemoryVisitor{Memory: memory, Scope: scope},
+		TrackRepository:   &repository.MemoryVisitorTrack{Memory: memory, Scope: scope},
+		Site:              &entity.Site{Id: 3, Code: "north"},
+		Feed:              NewFeed(),
+		Location:          time.UTC,
+	}
+}
+
+// trackCount returns the number of tracks stored for jane.
+func trackCount(service *TrackingService) int {
+	count, _ := service.TrackRepository.CountEventsByVisitorIdSince(context.Background(), jane.Id, time.Time{})
+	return count
+}
+
+func TestTrackingService_FindAndTrackSignsInAndOut(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	events, cancel := service.Feed.Subscribe(3)
+	defer cancel()
+
+	// Execute
+	first, firstErr := service.FindAndTrack(context.Background(), Request{VisitKey: "KEY123", SignedIn: true})
+	second, secondErr := service.FindAndTrack(context.Background(), Request{VisitKey: "KEY123", SignedIn: true})
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, "sign-in", first.TrackType)
+	assert.Equal(t, "sign-out", second.TrackType)
+	assert.Equal(t, jane, first.Visitor)
+	assert.Equal(t, TrackEvent{SiteId: 3, VisitKey: "KEY123", Track: first}, <-events)
+	assert.Equal(t, TrackEvent{SiteId: 3, VisitKey: "KEY123", Track: second}, <-events)
+}
+
+func TestTrackingService_FindAndTrackUnknownKey(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+
+	// Execute
+	_, err := service.FindAndTrack(context.Background(), Request{VisitKey: "UNKNOWN"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrVisitorNotFound)
+	assert.Equal(t, 0, trackCount(service))
+}
+
+func TestTrackingService_FindAndTrackDebouncesRepeatedScans(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	service.Debouncer = NewDebouncer(time.Minute)
+
+	// Execute
+	first, _ := service.FindAndTrack(context.Background(), Request{VisitKey: "KEY123"})
+	second, err := service.FindAndTrack(context.Background(), Request{VisitKey: "KEY123"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, trackCount(service))
+}
+
+func TestTrackingService_AntiPassback(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	service.Config = &config.Config{AntiPassback: true}
+	ctx := context.Background()
+
+	// Execute
+	_, signInErr := service.FindAndTrack(ctx, Request{VisitKey: "KEY123", Direction: DirectionIn})
+	_, passbackErr := service.FindAndTrack(ctx, Request{VisitKey: "KEY123", Direction: DirectionIn})
+	signOut, signOutErr := service.FindAndTrack(ctx, Request{VisitKey: "KEY123", Direction: DirectionOut})
+
+	// Assert
+	assert.NoError(t, signInErr)
+	assert.ErrorIs(t, passbackErr, ErrAlreadySignedIn)
+	assert.NoError(t, signOutErr)
+	assert.Equal(t, "sign-out", signOut.TrackType)
+}
+
+func TestTrackingService_FindAndTrackRecordsLateSignIn(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	service.Config = &config.Config{SchoolDayStartsAt: "08:30", LateReasons: []string{"Bus"}}
+	tardies := &memoryTardies{}
+	service.TardyRepository = tardies
+	service.Now = func() time.Time {
+		return time.Date(2025, 3, 3, 8, 50, 0, 0, time.UTC)
+	}
+
+	// Execute
+	response, err := service.FindAndTrack(context.Background(), Request{VisitKey: "KEY123"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Late)
+	assert.Equal(t, 20, response.MinutesLate)
+	assert.Equal(t, []string{"Bus"}, response.LateReasons)
+	if assert.Contains(t, tardies.tardies, response.TrackId) {
+		assert.Equal(t, 20, tardies.tardies[response.TrackId].MinutesLate)
+		assert.Equal(t, jane.Id, tardies.tardies[response.TrackId].VisitorId)
+	}
+}
+
+func TestTrackingService_Track(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	service.Location, _ = time.LoadLocation("Europe/London")
+
+	// Execute
+	track, err := service.Track(context.Background(), Request{VisitorID: jane.Id, VisitKey: "KEY123", SignedIn: true})
+	_, unknownErr := service.Track(context.Background(), Request{VisitorID: 99})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, jane, track.Visitor)
+	assert.Equal(t, "sign-in", track.TrackType)
+	assert.Equal(t, util.In(time.Now(), service.Location).Format("2006-01-02"), track.TrackDate[:10])
+	assert.ErrorIs(t, unknownErr, ErrVisitorNotFound)
+	assert.Equal(t, 1, trackCount(service))
+}
+
+func TestTrackingService_TrackSignsOutOnlyWhenSignedIn(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	events, cancel := service.Feed.Subscribe(3)
+	defer cancel()
+	ctx := context.Background()
+
+	// Execute
+	_, notSignedInErr := service.Track(ctx, Request{VisitorID: jane.Id, VisitKey: "KEY123", Direction: DirectionOut})
+	_, signInErr := service.FindAndTrack(ctx, Request{VisitKey: "KEY123"})
+	<-events
+	signOut, signOutErr := service.Track(ctx, Request{VisitorID: jane.Id, VisitKey: "EARLY-DISMISSAL", Direction: DirectionOut})
+
+	// Assert
+	assert.ErrorIs(t, notSignedInErr, ErrNotSignedIn)
+	assert.NoError(t, signInErr)
+	assert.NoError(t, signOutErr)
+	assert.Equal(t, "sign-out", signOut.TrackType)
+	assert.Equal(t, TrackEvent{SiteId: 3, VisitKey: "EARLY-DISMISSAL", Track: signOut}, <-events)
+	assert.Equal(t, 2, trackCount(service))
+}
+
+func TestTrackingService_SyncOrdersScansIntoTheDay(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	morning := util.StartOfDay(time.Now().UTC()).AddDate(0, 0, -1).Add(8 * time.Hour)
+	scans := []Scan{
+		{Id: "scan-2", VisitKey: "KEY123", ScannedAt: morning.Add(time.Hour)},
+		{Id: "scan-1", VisitKey: "KEY123", ScannedAt: morning},
+		{Id: "scan-3", VisitKey: "UNKNOWN", ScannedAt: morning},
+		{Id: "", VisitKey: "KEY123", ScannedAt: morning},
+	}
+
+	// Execute
+	results, err := service.Sync(context.Background(), scans)
+	retried, retryErr := service.Sync(context.Background(), []Scan{{Id: "scan-1", VisitKey: "KEY123", ScannedAt: morning}})
+
+	// Assert
+	assert.NoError(t, err)
+	byId := map[string]ScanResult{}
+	for _, result := range results {
+		byId[result.Id] = result
+	}
+	assert.Equal(t, ScanTracked, byId["scan-1"].Status)
+	assert.Equal(t, ScanTracked, byId["scan-2"].Status)
+	assert.Equal(t, ScanNotFound, byId["scan-3"].Status)
+	assert.Equal(t, ScanInvalid, byId[""].Status)
+	if assert.NotNil(t, byId["scan-1"].Track) && assert.NotNil(t, byId["scan-2"].Track) {
+		assert.Equal(t, "sign-in", byId["scan-1"].Track.TrackType)
+		assert.Equal(t, "sign-out", byId["scan-2"].Track.TrackType)
+	}
+	assert.NoError(t, retryErr)
+	assert.Equal(t, ScanDuplicate, retried[0].Status)
+	assert.Equal(t, 2, trackCount(service))
+}
+
+func TestTrackingService_SyncRejectsInvalidBatch(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+
+	// Execute
+	_, emptyErr := service.Sync(context.Background(), nil)
+	_, oversizedErr := service.Sync(context.Background(), make([]Scan, MaxBatchScans+1))
+
+	// Assert
+	assert.ErrorIs(t, emptyErr, ErrInvalidBatch)
+	assert.ErrorIs(t, oversizedErr, ErrInvalidBatch)
+}
+
+func TestTrackingService_FindAndTrackConcurrentScans(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	service.Config = &config.Config{AntiPassback: true}
+	service.Debouncer = NewDebouncer(0)
+	wg := sync.WaitGroup{}
+
+	// Execute
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = service.FindAndTrack(context.Background(), Request{VisitKey: "KEY123", Direction: DirectionIn})
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, 1, trackCount(service))
+}
+
+func TestTrackingService_FindAndTrackStoresDirection(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	ctx := context.Background()
+
+	// Execute
+	first, _ := service.FindAndTrack(ctx, Request{VisitKey: "KEY123", SignedIn: true})
+	second, _ := service.FindAndTrack(ctx, Request{VisitKey: "KEY123", SignedIn: true})
+	signIn, signInErr := service.TrackRepository.GetById(ctx, first.TrackId)
+	signOut, signOutErr := service.TrackRepository.GetById(ctx, second.TrackId)
+
+	// Assert
+	assert.NoError(t, signInErr)
+	assert.NoError(t, signOutErr)
+	if assert.NotNil(t, signIn) && assert.NotNil(t, signOut) {
+		assert.True(t, signIn.SignedIn)
+		assert.False(t, signOut.SignedIn)
+	}
+}
+
+func TestTrackingService_FindAndTrackRepeatedClientId(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	ctx := context.Background()
+
+	// Execute
+	first, firstErr := service.FindAndTrack(ctx, Request{VisitKey: "KEY123", ClientId: "scan-1"})
+	again, againErr := service.FindAndTrack(ctx, Request{VisitKey: "KEY123", ClientId: "scan-1"})
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, againErr)
+	assert.Equal(t, first.TrackId, again.TrackId)
+	assert.Equal(t, "sign-in", again.TrackType)
+	assert.Equal(t, jane, again.Visitor)
+	assert.Equal(t, 1, trackCount(service))
+}
+
+func TestTrackingService_SyncOfOnlineScanIsDuplicate(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	scannedAt := util.StartOfDay(time.Now().UTC()).Add(8 * time.Hour)
+	service.Now = func() time.Time { return scannedAt }
+	ctx := context.Background()
+	online, err := service.FindAndTrack(ctx, Request{VisitKey: "KEY123", ClientId: "scan-1"})
+	assert.NoError(t, err)
+
+	// Execute
+	results, syncErr := service.Sync(ctx, []Scan{{Id: "scan-1", VisitKey: "KEY123", ScannedAt: scannedAt}})
+
+	// Assert
+	assert.NoError(t, syncErr)
+	assert.Equal(t, ScanDuplicate, results[0].Status)
+	if assert.NotNil(t, results[0].Track) {
+		assert.Equal(t, online.TrackId, results[0].Track.TrackId)
+	}
+	assert.Equal(t, 1, trackCount(service))
+}
+
+func TestTrackingService_SyncDebouncesAgainstStoredTracks(t *testing.T) {
+	// Setup
+	service := newTrackingService()
+	service.Debouncer = NewDebouncer(time.Minute)
+	scannedAt := util.StartOfDay(time.Now().UTC()).Add(8 * time.Hour)
+	service.Now = func() time.Time { return scannedAt }
+	ctx := context.Background()
+	_, err := service.FindAndTrack(ctx, Request{VisitKey: "KEY123", ClientId: "online"})
+	assert.NoError(t, err)
+	_, err = service.Sync(ctx, []Scan{{Id: "earlier-batch", VisitKey: "KEY123", ScannedAt: scannedAt.Add(time.Hour)}})
+	assert.NoError(t, err)
+
+	// Execute
+	results, syncErr := service.Sync(ctx, []Scan{
+		{Id: "before-online", VisitKey: "KEY123", ScannedAt: scannedAt.Add(-10 * time.Second)},
+		{Id: "after-batch", VisitKey: "KEY123", ScannedAt: scannedAt.Add(time.Hour + 10*time.Second)},
+		{Id: "later", VisitKey: "KEY123", ScannedAt: scannedAt.Add(2 * time.Hour)},
+	})
+
+	// Assert
+	assert.NoError(t, syncErr)
+	statuses := []string{}
+	for _, result := range results {
+		statuses = append(statuses, result.Status)
+	}
+	assert.Equal(t, []string{ScanDebounced, ScanDebounced, ScanTracked}, statuses)
+	assert.Equal(t, 3, trackCount(service))
+}
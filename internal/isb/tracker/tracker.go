@@ -1,30 +1,60 @@
 package tracker
 
 import (
+	"errors"
 	"net/http"
-	"time"
 
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
 	"github.com/buzyka/imlate/internal/isb/entity"
 	"github.com/gin-gonic/gin"
 )
 
+var (
+	ErrVisitorNotFound = errors.New("Visitor not exists")
+	ErrAlreadySignedIn = errors.New("Visitor already signed in")
+	ErrNotSignedIn     = errors.New("Visitor not signed in")
+)
+
+const (
+	DirectionIn  = "in"
+	DirectionOut = "out"
+
+	AntiPassbackCode = "anti_passback"
+)
+
 
 
 type Request struct {
 	VisitorID int32 `json:"visitor_id"`
 	VisitKey  string `json:"visit_key"`
 	SignedIn  bool `json:"signed_in"`
+	// Direction of the reader which produced the scan: "in" for entrances,
+	// "out" for exits, empty when the reader is used for both.
+	Direction string `json:"direction"`
+	// ClientId is the id the kiosk gave the scan, the one it syncs the scan
+	// with when it is queued, see Scan.
+	ClientId string `json:"client_id"`
 }
 
+// TrackerController serves tracking over HTTP, see TrackingService.
 type TrackerController struct {
-	VisitorRepository entity.VisitorRepository `container:"type"`
-	TrackRepository entity.VisitorTrackRepository `container:"type"`
+	Service *TrackingService `container:"type"`
 }
 
 type TrackResponse struct {
+	TrackId int64 `json:"track_id"`
 	Visitor *entity.Visitor `json:"visitor"`
 	TrackType string `json:"track_type"`
 	TrackDate string `json:"track_date"`
+	// Status is set to "attention" on a safeguarding watchlist match.
+	Status string `json:"status,omitempty"`
+	// Late sign-ins carry the reasons the kiosk offers for a tardy slip.
+	Late bool `json:"late"`
+	MinutesLate int `json:"minutes_late,omitempty"`
+	LateReasons []string `json:"late_reasons,omitempty"`
+	// Excused is set when the late sign-in is covered by a bus arrival.
+	Excused bool `json:"excused,omitempty"`
 }
 
 func (tc *TrackerController) TrackHandler() gin.HandlerFunc {
@@ -32,28 +62,16 @@ func (tc *TrackerController) TrackHandler() gin.HandlerFunc {
 		var Request Request
 		err := ctx.Bind(&Request);
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
 			return
 		}
-		track := &entity.VisitTrack{
-			VisitorId: Request.VisitorID,
-			VisitKey:  Request.VisitKey,
-			SignedIn:  Request.SignedIn,
-		}
-		track.Visitor, err = tc.VisitorRepository.FindById(Request.VisitorID)
-		if err != nil {
-			ctx.JSON(http.StatusNotFound, gin.H{
-				"error": "Visitor not exists",
-			})
+		track, err := tc.Service.Track(ctx.Request.Context(), Request)
+		switch {
+		case errors.Is(err, ErrVisitorNotFound):
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(err)))
 			return
-		}
-		track, err = tc.TrackRepository.Store(track)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
-			})
+		case err != nil:
+			ctx.JSON(util.ServerFailure(err))
 			return
 		}
 		ctx.JSON(http.StatusOK, gin.H{
@@ -61,7 +79,7 @@ func (tc *TrackerController) TrackHandler() gin.HandlerFunc {
 			"id": Request.VisitorID,
 			"vk": Request.VisitKey,
 			"tr": Request.SignedIn,
-			"cr": track.CreatedAt.Format("2006-01-02 15:04:05"),	
+			"cr": track.TrackDate,
 		})
 	}
 }
@@ -71,53 +89,26 @@ func (tc *TrackerController) FindAndTrackHandler() gin.HandlerFunc {
 		var Request Request
 		err := ctx.Bind(&Request);
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
 			return
 		}
-		track := &entity.VisitTrack{
-			VisitKey: Request.VisitKey,
-			SignedIn: Request.SignedIn,
-		}
-		visitDetails, err := tc.VisitorRepository.FindByKey(Request.VisitKey)
-		if err != nil || visitDetails.Visitor == nil {
-			ctx.JSON(http.StatusNotFound, gin.H{
-				"error": "Visitor not exists",
-			})
+		if len(Request.ClientId) > MaxScanIdLength {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Client id must have at most 64 characters"))))
 			return
 		}
-		track.Visitor = visitDetails.Visitor
-		track.VisitorId = visitDetails.Visitor.Id
-		track, err = tc.TrackRepository.Store(track)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
+		response, err := tc.Service.FindAndTrack(ctx.Request.Context(), Request)
+		switch {
+		case errors.Is(err, ErrVisitorNotFound):
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(err)))
+		case errors.Is(err, ErrAlreadySignedIn):
+			ctx.JSON(http.StatusConflict, util.ExtendedFailureResponse{
+				Code:  AntiPassbackCode,
+				Error: err.Error(),
 			})
-			return
+		case err != nil:
+			ctx.JSON(util.ServerFailure(err))
+		default:
+			ctx.JSON(http.StatusOK, response)
 		}
-	
-
-		eType := "sign-in"
-		startDate := time.Date(track.CreatedAt.Year(), track.CreatedAt.Month(), track.CreatedAt.Day(), 0, 0, 0, 0, time.Local)
-		eCount, err := tc.TrackRepository.CountEventsByVisitorIdSince(track.VisitorId, startDate)
-		if err == nil {
-			if eCount % 2 == 0 {
-				eType = "sign-out"
-			}
-		}
-
-		response := TrackResponse{
-			Visitor: track.Visitor,
-			TrackType: eType,
-			TrackDate: track.CreatedAt.Format("2006-01-02 15:04:05"),
-		}
-		ctx.JSON(http.StatusOK, response)
 	}
 }
-
-type FakeTrackerService struct {}
-
-func (fts FakeTrackerService) Track(id string) error {
-	return nil
-}
\ No newline at end of file
@@ -0,0 +1,659 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/buzyka/imlate/internal/config"
+	"github.com/buzyka/imlate/internal/infrastructure/apispec"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/watchlist"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockVisitorRepository is a mock implementation of entity.VisitorRepository
+type MockVisitorRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorRepository) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Visitor), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
+	args := m.Called(visitor, key)
+	return args.Error(0)
+}
+
+// MockVisitorTrackRepository is a mock implementation of entity.VisitorTrackRepository
+type MockVisitorTrackRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorTrackRepository) Store(ctx context.Context, vt *entity.VisitTrack) (*entity.VisitTrack, error) {
+	args := m.Called(vt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) StoreBackdated(ctx context.Context, vt *entity.VisitTrack, until time.Time) (*entity.VisitTrack, error) {
+	args := m.Called(vt, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) GetById(ctx context.Context, id int64) (*entity.VisitTrack, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) GetByClientId(ctx context.Context, clientId string) (*entity.VisitTrack, error) {
+	args := m.Called(clientId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitTrack), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) CountEventsByVisitorIdSince(ctx context.Context, visitorId int32, date time.Time) (int, error) {
+	args := m.Called(visitorId, date)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) CountEventsByVisitorIdBetween(ctx context.Context, visitorId int32, from time.Time, to time.Time) (int, error) {
+	args := m.Called(visitorId, from, to)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockVisitorTrackRepository) FindPresentVisitorsSince(ctx context.Context, date time.Time) ([]*entity.Visitor, error) {
+	args := m.Called(date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Visitor), args.Error(1)
+}
+
+type MockWatchlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatchlistRepository) FindActive(ctx context.Context) ([]*entity.WatchlistEntry, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WatchlistEntry), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) Store(ctx context.Context, entry *entity.WatchlistEntry) error {
+	return m.Called(entry).Error(0)
+}
+
+func (m *MockWatchlistRepository) Deactivate(ctx context.Context, id int64) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockWatchlistRepository) StoreHit(ctx context.Context, hit *entity.WatchlistHit) error {
+	return m.Called(hit).Error(0)
+}
+
+func (m *MockWatchlistRepository) FindHitsBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.WatchlistHit, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WatchlistHit), args.Error(1)
+}
+
+type MockTardyRepository struct {
+	mock.Mock
+}
+
+func (m *MockTardyRepository) Save(ctx context.Context, tardy *entity.Tardy) error {
+	return m.Called(tardy).Error(0)
+}
+
+func (m *MockTardyRepository) GetByTrackId(ctx context.Context, trackId int64) (*entity.Tardy, error) {
+	args := m.Called(trackId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tardy), args.Error(1)
+}
+
+func (m *MockTardyRepository) FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.Tardy, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]*entity.Tardy), args.Error(1)
+}
+
+func (m *MockTardyRepository) CountByVisitorIdSince(ctx context.Context, visitorId int32, since time.Time) (int, error) {
+	args := m.Called(visitorId, since)
+	return args.Int(0), args.Error(1)
+}
+
+type MockSiteRepository struct {
+	mock.Mock
+}
+
+func (m *MockSiteRepository) FindAll() ([]*entity.Site, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.Site), args.Error(1)
+}
+
+func (m *MockSiteRepository) GetById(id int64) (*entity.Site, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Site), args.Error(1)
+}
+
+func (m *MockSiteRepository) GetByCode(code string) (*entity.Site, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Site), args.Error(1)
+}
+
+func (m *MockSiteRepository) Store(site *entity.Site) error {
+	return m.Called(site).Error(0)
+}
+
+func (m *MockSiteRepository) FindClosedDays(siteId int64, from time.Time, to time.Time) ([]*entity.ClosedDay, error) {
+	args := m.Called(siteId, from, to)
+	return args.Get(0).([]*entity.ClosedDay), args.Error(1)
+}
+
+func (m *MockSiteRepository) AddClosedDay(day *entity.ClosedDay) error {
+	return m.Called(day).Error(0)
+}
+
+func (m *MockSiteRepository) RemoveClosedDay(siteId int64, date string) error {
+	return m.Called(siteId, date).Error(0)
+}
+
+func (m *MockSiteRepository) IsClosedOn(siteId int64, date time.Time) (bool, error) {
+	args := m.Called(siteId, date)
+	return args.Bool(0), args.Error(1)
+}
+
+// serve runs the handler and checks the request and the response against
+// api/openapi.yaml.
+func serve(t *testing.T, handler gin.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	validator, err := apispec.NewValidator()
+	assert.NoError(t, err)
+	assert.NoError(t, validator.ValidateRequest(req), "request does not match api/openapi.yaml")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	handler(c)
+	assert.NoError(t, validator.ValidateResponse(req, w.Code, w.Header(), w.Body.Bytes()), "response does not match api/openapi.yaml")
+	return w
+}
+
+func performFindAndTrack(t *testing.T, controller *TrackerController, request Request) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/api/v1/find-and-track", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	return serve(t, controller.FindAndTrackHandler(), req)
+}
+
+func newTestVisitDetails() *entity.VisitDetails {
+	return &entity.VisitDetails{
+		Visitor: &entity.Visitor{Id: 1, Name: "John", Surname: "Doe", Grade: 10},
+		Key:     "KEY123",
+	}
+}
+
+// The SQL visitor repository answers an unknown id with an empty visitor
+// instead of nil.
+func TestTrackHandler_EmptyVisitorIsNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+	}}
+
+	visitorRepo.On("FindById", int32(99)).Return(&entity.Visitor{}, nil)
+
+	body, _ := json.Marshal(Request{VisitorID: 99, VisitKey: "KEY123", SignedIn: true})
+	req := httptest.NewRequest("POST", "/api/v1/track", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := serve(t, controller.TrackHandler(), req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	trackRepo.AssertNotCalled(t, "Store", mock.Anything)
+}
+
+func TestFindAndTrackHandler_RepeatedScanWithinDebounceWindowReturnsPreviousResult(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+		Debouncer:         NewDebouncer(time.Minute),
+	}}
+
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("Store", mock.Anything).Return(&entity.VisitTrack{
+		Id:        10,
+		VisitorId: 1,
+		Visitor:   newTestVisitDetails().Visitor,
+		CreatedAt: time.Now(),
+	}, nil).Once()
+	trackRepo.On("CountEventsByVisitorIdSince", int32(1), mock.Anything).Return(0, nil).Once()
+
+	first := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true})
+	second := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true})
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.JSONEq(t, first.Body.String(), second.Body.String())
+	var response TrackResponse
+	assert.NoError(t, json.Unmarshal(second.Body.Bytes(), &response))
+	assert.Equal(t, "sign-in", response.TrackType)
+	trackRepo.AssertNumberOfCalls(t, "Store", 1)
+	trackRepo.AssertExpectations(t)
+}
+
+func TestFindAndTrackHandler_RejectsLongClientId(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	controller := &TrackerController{Service: &TrackingService{VisitorRepository: visitorRepo}}
+
+	request := Request{VisitKey: "KEY123", ClientId: strings.Repeat("x", 65)}
+	body, _ := json.Marshal(request)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/find-and-track", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	controller.FindAndTrackHandler()(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	visitorRepo.AssertNotCalled(t, "FindByKey", mock.Anything)
+	// api/openapi.yaml caps client ids as well
+	validator, _ := apispec.NewValidator()
+	req := httptest.NewRequest("POST", "/api/v1/find-and-track", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	assert.ErrorContains(t, validator.ValidateRequest(req), "maximum string length is 64")
+}
+
+func TestFindAndTrackHandler_AntiPassbackRejectsSignInWhenAlreadyIn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+		Config:            &config.Config{AntiPassback: true},
+	}}
+
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("CountEventsByVisitorIdSince", int32(1), mock.Anything).Return(1, nil)
+
+	w := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true, Direction: DirectionIn})
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response util.ExtendedFailureResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, AntiPassbackCode, response.Code)
+	trackRepo.AssertNotCalled(t, "Store", mock.Anything)
+}
+
+func TestFindAndTrackHandler_AntiPassbackAllowsSignInWhenOut(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+		Config:            &config.Config{AntiPassback: true},
+	}}
+
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("CountEventsByVisitorIdSince", int32(1), mock.Anything).Return(2, nil).Once()
+	trackRepo.On("Store", mock.Anything).Return(&entity.VisitTrack{
+		Id:        11,
+		VisitorId: 1,
+		Visitor:   newTestVisitDetails().Visitor,
+		CreatedAt: time.Now(),
+	}, nil)
+
+	w := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true, Direction: DirectionIn})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TrackResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "sign-in", response.TrackType)
+	trackRepo.AssertExpectations(t)
+}
+
+func TestFindAndTrackHandler_AntiPassbackIgnoredWithoutDirection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+		Config:            &config.Config{AntiPassback: true},
+	}}
+
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("Store", mock.Anything).Return(&entity.VisitTrack{
+		Id:        12,
+		VisitorId: 1,
+		Visitor:   newTestVisitDetails().Visitor,
+		CreatedAt: time.Now(),
+	}, nil)
+	trackRepo.On("CountEventsByVisitorIdSince", int32(1), mock.Anything).Return(1, nil).Once()
+
+	w := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TrackResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "sign-out", response.TrackType)
+	trackRepo.AssertExpectations(t)
+}
+
+func TestFindAndTrackHandler_WatchlistMatchReturnsDiscreetStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	watchlistRepo := new(MockWatchlistRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+		Screener:          &watchlist.Screener{Repository: watchlistRepo},
+	}}
+
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("Store", mock.Anything).Return(&entity.VisitTrack{
+		Id:        13,
+		VisitorId: 1,
+		Visitor:   newTestVisitDetails().Visitor,
+		CreatedAt: time.Now(),
+	}, nil)
+	trackRepo.On("CountEventsByVisitorIdSince", int32(1), mock.Anything).Return(0, nil)
+	watchlistRepo.On("FindActive").Return([]*entity.WatchlistEntry{
+		{Id: 5, VisitorId: 1, Category: entity.WatchlistNoAdmit},
+	}, nil)
+	watchlistRepo.On("StoreHit", mock.MatchedBy(func(hit *entity.WatchlistHit) bool {
+		return hit.EntryId == 5 && hit.VisitorId == 1 && hit.Source == watchlist.SourceScan
+	})).Return(nil)
+
+	w := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TrackResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, watchlist.AlertStatus, response.Status)
+	assert.Equal(t, "sign-in", response.TrackType)
+	assert.NotContains(t, w.Body.String(), entity.WatchlistNoAdmit)
+	watchlistRepo.AssertExpectations(t)
+}
+
+func TestFindAndTrackHandler_LateFirstSignInOffersReasons(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	tardyRepo := new(MockTardyRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+		TardyRepository:   tardyRepo,
+		Config: &config.Config{
+			SchoolDayStartsAt: "08:30",
+			LateReasons:       []string{"bus", "overslept"},
+		},
+	}}
+
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("Store", mock.Anything).Return(&entity.VisitTrack{
+		Id:        14,
+		VisitorId: 1,
+		Visitor:   newTestVisitDetails().Visitor,
+		CreatedAt: time.Date(2024, 9, 2, 8, 50, 0, 0, time.Local),
+	}, nil)
+	trackRepo.On("CountEventsByVisitorIdSince", int32(1), mock.Anything).Return(0, nil)
+	tardyRepo.On("Save", mock.MatchedBy(func(tardy *entity.Tardy) bool {
+		return tardy.TrackId == 14 && tardy.MinutesLate == 20 && tardy.Reason == ""
+	})).Return(nil)
+
+	w := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	tardyRepo.AssertExpectations(t)
+	var response TrackResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(14), response.TrackId)
+	assert.True(t, response.Late)
+	assert.Equal(t, 20, response.MinutesLate)
+	assert.Equal(t, []string{"bus", "overslept"}, response.LateReasons)
+}
+
+func TestFindAndTrackHandler_LaterSignInIsNotLate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+		Config:            &config.Config{SchoolDayStartsAt: "08:30"},
+	}}
+
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("Store", mock.Anything).Return(&entity.VisitTrack{
+		Id:        15,
+		VisitorId: 1,
+		Visitor:   newTestVisitDetails().Visitor,
+		CreatedAt: time.Date(2024, 9, 2, 13, 5, 0, 0, time.Local),
+	}, nil)
+	// Back from lunch: two events earlier that day
+	trackRepo.On("CountEventsByVisitorIdSince", int32(1), mock.Anything).Return(2, nil)
+
+	w := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true})
+
+	var response TrackResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "sign-in", response.TrackType)
+	assert.False(t, response.Late)
+}
+
+func TestFindAndTrackHandler_ClosedDayIsNotLate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	tardyRepo := new(MockTardyRepository)
+	siteRepo := new(MockSiteRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+		TardyRepository:   tardyRepo,
+		Config:            &config.Config{SchoolDayStartsAt: "08:30"},
+		Site:              &entity.Site{Id: 2, Code: "north"},
+		SiteRepository:    siteRepo,
+	}}
+
+	signedInAt := time.Date(2024, 12, 23, 9, 40, 0, 0, time.Local)
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("Store", mock.Anything).Return(&entity.VisitTrack{
+		Id:        16,
+		VisitorId: 1,
+		Visitor:   newTestVisitDetails().Visitor,
+		CreatedAt: signedInAt,
+	}, nil)
+	trackRepo.On("CountEventsByVisitorIdSince", int32(1), mock.Anything).Return(0, nil)
+	siteRepo.On("IsClosedOn", int64(2), signedInAt).Return(true, nil)
+
+	w := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true})
+
+	var response TrackResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "sign-in", response.TrackType)
+	assert.False(t, response.Late)
+	tardyRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestFindAndTrackHandler_CountsDayInSchoolZone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+		Location:          berlin,
+		Now:               func() time.Time { return time.Date(2024, 9, 1, 22, 30, 0, 0, time.UTC) },
+	}}
+
+	// 22:30 UTC on September 1st is half past midnight on September 2nd in Berlin
+	visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+	trackRepo.On("Store", mock.Anything).Return(&entity.VisitTrack{
+		Id:        17,
+		VisitorId: 1,
+		Visitor:   newTestVisitDetails().Visitor,
+		CreatedAt: time.Date(2024, 9, 1, 22, 30, 0, 0, time.UTC),
+	}, nil)
+	trackRepo.On("CountEventsByVisitorIdSince", int32(1), mock.MatchedBy(func(since time.Time) bool {
+		return since.Equal(time.Date(2024, 9, 1, 22, 0, 0, 0, time.UTC))
+	})).Return(0, nil)
+
+	w := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	trackRepo.AssertExpectations(t)
+	var response TrackResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "2024-09-02 00:30:00", response.TrackDate)
+}
+
+func TestFindAndTrackHandler_LatenessAcrossDSTChanges(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+	tests := []struct {
+		name       string
+		signedInAt time.Time
+		dayStart   time.Time
+	}{
+		{
+			name:       "spring forward",
+			signedInAt: time.Date(2024, 3, 31, 6, 45, 0, 0, time.UTC),
+			dayStart:   time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "fall back",
+			signedInAt: time.Date(2024, 10, 27, 7, 45, 0, 0, time.UTC),
+			dayStart:   time.Date(2024, 10, 26, 22, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			visitorRepo := new(MockVisitorRepository)
+			trackRepo := new(MockVisitorTrackRepository)
+			tardyRepo := new(MockTardyRepository)
+			controller := &TrackerController{Service: &TrackingService{
+				VisitorRepository: visitorRepo,
+				TrackRepository:   trackRepo,
+				TardyRepository:   tardyRepo,
+				Config:            &config.Config{SchoolDayStartsAt: "08:30"},
+				Location:          berlin,
+				Now:               func() time.Time { return tc.signedInAt },
+			}}
+
+			// Both sign-ins are at 08:45 on the wall clock of the school
+			visitorRepo.On("FindByKey", "KEY123").Return(newTestVisitDetails(), nil)
+			trackRepo.On("Store", mock.Anything).Return(&entity.VisitTrack{
+				Id:        18,
+				VisitorId: 1,
+				Visitor:   newTestVisitDetails().Visitor,
+				CreatedAt: tc.signedInAt,
+			}, nil)
+			trackRepo.On("CountEventsByVisitorIdSince", int32(1), mock.MatchedBy(func(since time.Time) bool {
+				return since.Equal(tc.dayStart)
+			})).Return(0, nil)
+			tardyRepo.On("Save", mock.MatchedBy(func(tardy *entity.Tardy) bool {
+				return tardy.MinutesLate == 15 && tardy.TrackedAt.Equal(tc.signedInAt)
+			})).Return(nil)
+
+			w := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true})
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			trackRepo.AssertExpectations(t)
+			tardyRepo.AssertExpectations(t)
+			var response TrackResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.True(t, response.Late)
+			assert.Equal(t, 15, response.MinutesLate)
+		})
+	}
+}
+
+func TestFindAndTrackHandler_QueryTimeoutReturnsServiceUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	visitorRepo := new(MockVisitorRepository)
+	trackRepo := new(MockVisitorTrackRepository)
+	controller := &TrackerController{Service: &TrackingService{
+		VisitorRepository: visitorRepo,
+		TrackRepository:   trackRepo,
+	}}
+
+	visitorRepo.On("FindByKey", "KEY123").Return(nil, &entity.QueryTimeoutError{
+		Query: "Finding visitor by key",
+		Limit: time.Second,
+		Err:   context.DeadlineExceeded,
+	})
+
+	w := performFindAndTrack(t, controller, Request{VisitKey: "KEY123", SignedIn: true})
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var response util.ExtendedFailureResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, exception.TimeoutType, response.Code)
+	assert.Equal(t, "Finding visitor by key timed out after 1s", response.Error)
+	trackRepo.AssertNotCalled(t, "Store", mock.Anything)
+}
@@ -0,0 +1,104 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+// ErrAlreadyArrived is returned when the route already arrived that day.
+var ErrAlreadyArrived = errors.New("bus arrival already recorded today")
+
+// Arrivals records buses reaching school. Each arrival excuses the riders'
+// late sign-ins from the start of the day until Grace after the arrival.
+type Arrivals struct {
+	Routes   entity.BusRouteRepository
+	Excusals entity.ExcusalRepository
+	Tardies  entity.TardyRepository
+	Grace    time.Duration
+	// Location is the zone of the school, days start at midnight in it.
+	Location *time.Location
+}
+
+// Record stores the arrival and excuses riders who already signed in late.
+func (a *Arrivals) Record(ctx context.Context, route *entity.BusRoute, deviceId string, at time.Time) (*entity.BusArrival, *entity.Excusal, error) {
+	at = util.In(at, a.Location)
+	dayStart := util.StartOfDay(at)
+	previous, err := a.Routes.FindLatestArrival(ctx, route.Id, dayStart)
+	if err != nil {
+		return nil, nil, err
+	}
+	if previous != nil {
+		return previous, nil, ErrAlreadyArrived
+	}
+
+	excusal := &entity.Excusal{
+		Reason:    fmt.Sprintf("Bus %s arrived at %s", route.Name, at.Format("15:04")),
+		From:      dayStart,
+		To:        at.Add(a.Grace),
+		RouteId:   route.Id,
+		CreatedAt: at,
+	}
+	riders, err := a.Routes.FindVisitorIdsByRoute(ctx, route.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	tardies, err := a.Tardies.FindBetween(ctx, excusal.From, excusal.To)
+	if err != nil {
+		return nil, nil, err
+	}
+	tardyIds := []int64{}
+	for _, tardy := range tardies {
+		if !tardy.Excused() && slices.Contains(riders, tardy.VisitorId) {
+			tardyIds = append(tardyIds, tardy.Id)
+		}
+	}
+	if err := a.Excusals.Store(ctx, excusal, tardyIds); err != nil {
+		return nil, nil, err
+	}
+
+	arrival := &entity.BusArrival{
+		RouteId:   route.Id,
+		DeviceId:  deviceId,
+		ArrivedAt: at,
+		ExcusalId: excusal.Id,
+	}
+	if err := a.Routes.StoreArrival(ctx, arrival); err != nil {
+		return nil, nil, err
+	}
+	return arrival, excusal, nil
+}
+
+// ExcuseLate excuses a stored tardy when the student's bus arrived within
+// the grace window. A nil Arrivals never excuses.
+func (a *Arrivals) ExcuseLate(ctx context.Context, tardy *entity.Tardy) (bool, error) {
+	if a == nil || tardy == nil || tardy.Id == 0 {
+		return false, nil
+	}
+	routeId, err := a.Routes.FindRouteIdByVisitorId(ctx, tardy.VisitorId)
+	if err != nil || routeId == 0 {
+		return false, err
+	}
+	arrival, err := a.Routes.FindLatestArrival(ctx, routeId, util.StartOfDay(util.In(tardy.TrackedAt, a.Location)))
+	if err != nil || arrival == nil || arrival.ExcusalId == 0 {
+		return false, err
+	}
+	excusal, err := a.Excusals.GetById(ctx, arrival.ExcusalId)
+	if err != nil || excusal == nil || excusal.Revoked() {
+		return false, err
+	}
+	if tardy.TrackedAt.Before(excusal.From) || !tardy.TrackedAt.Before(excusal.To) {
+		return false, nil
+	}
+	if err := a.Excusals.Excuse(ctx, excusal.Id, tardy.Id); err != nil {
+		return false, err
+	}
+	tardy.ExcusalId = excusal.Id
+	tardy.ExcusalReason = excusal.Reason
+	return true, nil
+}
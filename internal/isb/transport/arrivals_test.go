@@ -0,0 +1,245 @@
+package transport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBusRouteRepository struct {
+	mock.Mock
+}
+
+func (m *MockBusRouteRepository) FindVisitorIdsByRoute(ctx context.Context, routeId int64) ([]int32, error) {
+	args := m.Called(routeId)
+	return args.Get(0).([]int32), args.Error(1)
+}
+
+func (m *MockBusRouteRepository) FindAll(ctx context.Context) ([]*entity.BusRoute, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.BusRoute), args.Error(1)
+}
+
+func (m *MockBusRouteRepository) GetById(ctx context.Context, id int64) (*entity.BusRoute, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.BusRoute), args.Error(1)
+}
+
+func (m *MockBusRouteRepository) FindByDeviceId(ctx context.Context, deviceId string) (*entity.BusRoute, error) {
+	args := m.Called(deviceId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.BusRoute), args.Error(1)
+}
+
+func (m *MockBusRouteRepository) Store(ctx context.Context, route *entity.BusRoute) error {
+	return m.Called(route).Error(0)
+}
+
+func (m *MockBusRouteRepository) Delete(ctx context.Context, id int64) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockBusRouteRepository) AssignRider(ctx context.Context, routeId int64, visitorId int32, stopId int64) error {
+	return m.Called(routeId, visitorId, stopId).Error(0)
+}
+
+func (m *MockBusRouteRepository) RemoveRider(ctx context.Context, routeId int64, visitorId int32) error {
+	return m.Called(routeId, visitorId).Error(0)
+}
+
+func (m *MockBusRouteRepository) FindRiders(ctx context.Context, routeId int64) ([]*entity.BusRider, error) {
+	args := m.Called(routeId)
+	return args.Get(0).([]*entity.BusRider), args.Error(1)
+}
+
+func (m *MockBusRouteRepository) FindRidersWithoutTrackSince(ctx context.Context, routeId int64, since time.Time) ([]*entity.BusRider, error) {
+	args := m.Called(routeId, since)
+	return args.Get(0).([]*entity.BusRider), args.Error(1)
+}
+
+func (m *MockBusRouteRepository) FindRouteIdByVisitorId(ctx context.Context, visitorId int32) (int64, error) {
+	args := m.Called(visitorId)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBusRouteRepository) StoreArrival(ctx context.Context, arrival *entity.BusArrival) error {
+	return m.Called(arrival).Error(0)
+}
+
+func (m *MockBusRouteRepository) FindLatestArrival(ctx context.Context, routeId int64, since time.Time) (*entity.BusArrival, error) {
+	args := m.Called(routeId, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.BusArrival), args.Error(1)
+}
+
+type MockExcusalRepository struct {
+	mock.Mock
+}
+
+func (m *MockExcusalRepository) Store(ctx context.Context, excusal *entity.Excusal, tardyIds []int64) error {
+	return m.Called(excusal, tardyIds).Error(0)
+}
+
+func (m *MockExcusalRepository) Excuse(ctx context.Context, id int64, tardyId int64) error {
+	return m.Called(id, tardyId).Error(0)
+}
+
+func (m *MockExcusalRepository) GetById(ctx context.Context, id int64) (*entity.Excusal, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Excusal), args.Error(1)
+}
+
+func (m *MockExcusalRepository) FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.Excusal, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]*entity.Excusal), args.Error(1)
+}
+
+func (m *MockExcusalRepository) Revoke(ctx context.Context, id int64, at time.Time) error {
+	return m.Called(id, at).Error(0)
+}
+
+type MockTardyRepository struct {
+	mock.Mock
+}
+
+func (m *MockTardyRepository) Save(ctx context.Context, tardy *entity.Tardy) error {
+	return m.Called(tardy).Error(0)
+}
+
+func (m *MockTardyRepository) GetByTrackId(ctx context.Context, trackId int64) (*entity.Tardy, error) {
+	args := m.Called(trackId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tardy), args.Error(1)
+}
+
+func (m *MockTardyRepository) FindBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.Tardy, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]*entity.Tardy), args.Error(1)
+}
+
+func (m *MockTardyRepository) CountByVisitorIdSince(ctx context.Context, visitorId int32, since time.Time) (int, error) {
+	args := m.Called(visitorId, since)
+	return args.Int(0), args.Error(1)
+}
+
+var (
+	dayStart  = time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+	arrivedAt = time.Date(2024, 9, 2, 8, 52, 0, 0, time.UTC)
+	north     = &entity.BusRoute{Id: 2, Name: "North"}
+)
+
+func newTestArrivals() (*Arrivals, *MockBusRouteRepository, *MockExcusalRepository, *MockTardyRepository) {
+	routes := new(MockBusRouteRepository)
+	excusals := new(MockExcusalRepository)
+	tardies := new(MockTardyRepository)
+	return &Arrivals{
+		Routes:   routes,
+		Excusals: excusals,
+		Tardies:  tardies,
+		Grace:    15 * time.Minute,
+	}, routes, excusals, tardies
+}
+
+func TestRecord_ExcusesRidersAlreadyLate(t *testing.T) {
+	arrivals, routes, excusals, tardies := newTestArrivals()
+	graceEnd := arrivedAt.Add(15 * time.Minute)
+	routes.On("FindLatestArrival", int64(2), dayStart).Return(nil, nil)
+	routes.On("FindVisitorIdsByRoute", int64(2)).Return([]int32{7, 8}, nil)
+	tardies.On("FindBetween", dayStart, graceEnd).Return([]*entity.Tardy{
+		{Id: 1, VisitorId: 7},
+		{Id: 2, VisitorId: 9},
+		{Id: 3, VisitorId: 8, ExcusalId: 1},
+	}, nil)
+	excusals.On("Store", mock.MatchedBy(func(e *entity.Excusal) bool {
+		return e.Reason == "Bus North arrived at 08:52" && e.RouteId == 2 && e.From.Equal(dayStart) && e.To.Equal(graceEnd)
+	}), []int64{1}).Run(func(args mock.Arguments) {
+		args.Get(0).(*entity.Excusal).Id = 4
+	}).Return(nil)
+	routes.On("StoreArrival", mock.MatchedBy(func(a *entity.BusArrival) bool {
+		return a.RouteId == 2 && a.DeviceId == "bus-1" && a.ExcusalId == 4
+	})).Return(nil)
+
+	arrival, excusal, err := arrivals.Record(context.Background(), north, "bus-1", arrivedAt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, arrivedAt, arrival.ArrivedAt)
+	assert.Equal(t, int64(4), excusal.Id)
+	routes.AssertExpectations(t)
+	excusals.AssertExpectations(t)
+}
+
+func TestRecord_AlreadyArrived(t *testing.T) {
+	arrivals, routes, excusals, _ := newTestArrivals()
+	routes.On("FindLatestArrival", int64(2), dayStart).Return(&entity.BusArrival{Id: 3, RouteId: 2}, nil)
+
+	arrival, _, err := arrivals.Record(context.Background(), north, "", arrivedAt)
+
+	assert.ErrorIs(t, err, ErrAlreadyArrived)
+	assert.Equal(t, int64(3), arrival.Id)
+	excusals.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestExcuseLate_WithinGrace(t *testing.T) {
+	arrivals, routes, excusals, _ := newTestArrivals()
+	routes.On("FindRouteIdByVisitorId", int32(7)).Return(int64(2), nil)
+	routes.On("FindLatestArrival", int64(2), dayStart).Return(&entity.BusArrival{Id: 3, RouteId: 2, ExcusalId: 4}, nil)
+	excusals.On("GetById", int64(4)).Return(&entity.Excusal{Id: 4, Reason: "Bus North arrived at 08:52", From: dayStart, To: arrivedAt.Add(15 * time.Minute)}, nil)
+	excusals.On("Excuse", int64(4), int64(12)).Return(nil)
+
+	tardy := &entity.Tardy{Id: 12, VisitorId: 7, TrackedAt: arrivedAt.Add(5 * time.Minute)}
+	excused, err := arrivals.ExcuseLate(context.Background(), tardy)
+
+	assert.NoError(t, err)
+	assert.True(t, excused)
+	assert.True(t, tardy.Excused())
+	assert.Equal(t, "Bus North arrived at 08:52", tardy.ExcusalReason)
+}
+
+func TestExcuseLate_AfterGrace(t *testing.T) {
+	arrivals, routes, excusals, _ := newTestArrivals()
+	routes.On("FindRouteIdByVisitorId", int32(7)).Return(int64(2), nil)
+	routes.On("FindLatestArrival", int64(2), dayStart).Return(&entity.BusArrival{Id: 3, RouteId: 2, ExcusalId: 4}, nil)
+	excusals.On("GetById", int64(4)).Return(&entity.Excusal{Id: 4, From: dayStart, To: arrivedAt.Add(15 * time.Minute)}, nil)
+
+	excused, err := arrivals.ExcuseLate(context.Background(), &entity.Tardy{Id: 12, VisitorId: 7, TrackedAt: arrivedAt.Add(20 * time.Minute)})
+
+	assert.NoError(t, err)
+	assert.False(t, excused)
+	excusals.AssertNotCalled(t, "Excuse", mock.Anything, mock.Anything)
+}
+
+func TestExcuseLate_NotARider(t *testing.T) {
+	arrivals, routes, _, _ := newTestArrivals()
+	routes.On("FindRouteIdByVisitorId", int32(7)).Return(int64(0), nil)
+
+	excused, err := arrivals.ExcuseLate(context.Background(), &entity.Tardy{Id: 12, VisitorId: 7, TrackedAt: arrivedAt})
+
+	assert.NoError(t, err)
+	assert.False(t, excused)
+	routes.AssertNotCalled(t, "FindLatestArrival", mock.Anything, mock.Anything)
+}
+
+func TestExcuseLate_NilArrivals(t *testing.T) {
+	var arrivals *Arrivals
+
+	excused, err := arrivals.ExcuseLate(context.Background(), &entity.Tardy{Id: 12, VisitorId: 7})
+
+	assert.NoError(t, err)
+	assert.False(t, excused)
+}
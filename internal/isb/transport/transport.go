@@ -0,0 +1,308 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+const AlreadyArrivedCode = "bus_already_arrived"
+
+type StopRequest struct {
+	Name     string `json:"name" binding:"required"`
+	PickupAt string `json:"pickup_at"`
+}
+
+type RouteRequest struct {
+	Name     string        `json:"name" binding:"required"`
+	DeviceID string        `json:"device_id"`
+	Stops    []StopRequest `json:"stops"`
+}
+
+type RiderRequest struct {
+	VisitorID int32 `json:"visitor_id" binding:"required"`
+	StopID    int64 `json:"stop_id"`
+}
+
+type DeviceArrivalRequest struct {
+	DeviceID string `json:"device_id" binding:"required"`
+}
+
+type ArrivalResponse struct {
+	Arrival *entity.BusArrival `json:"arrival"`
+	Excusal *entity.Excusal    `json:"excusal"`
+}
+
+type BusRouteController struct {
+	BusRouteRepository entity.BusRouteRepository `container:"type"`
+	VisitorRepository  entity.VisitorRepository  `container:"type"`
+	Arrivals           *Arrivals                 `container:"type"`
+	Now                func() time.Time
+	Location           *time.Location `container:"type"`
+}
+
+func (bc *BusRouteController) ListHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		routes, err := bc.BusRouteRepository.FindAll(ctx.Request.Context())
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, routes)
+	}
+}
+
+func (bc *BusRouteController) CreateHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request RouteRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		route := &entity.BusRoute{
+			Name:     strings.TrimSpace(request.Name),
+			DeviceId: strings.TrimSpace(request.DeviceID),
+			Stops:    []*entity.BusStop{},
+		}
+		for _, stop := range request.Stops {
+			if stop.PickupAt != "" {
+				if _, err := time.Parse("15:04", stop.PickupAt); err != nil {
+					ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid pickup_at, expected HH:MM"))))
+					return
+				}
+			}
+			route.Stops = append(route.Stops, &entity.BusStop{
+				Name:     strings.TrimSpace(stop.Name),
+				PickupAt: stop.PickupAt,
+			})
+		}
+		if err := bc.BusRouteRepository.Store(ctx.Request.Context(), route); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusCreated, route)
+	}
+}
+
+func (bc *BusRouteController) RouteHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route, ok := bc.routeFromParam(ctx)
+		if !ok {
+			return
+		}
+		ctx.JSON(http.StatusOK, route)
+	}
+}
+
+func (bc *BusRouteController) DeleteHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route, ok := bc.routeFromParam(ctx)
+		if !ok {
+			return
+		}
+		if err := bc.BusRouteRepository.Delete(ctx.Request.Context(), route.Id); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "Route deleted",
+		})
+	}
+}
+
+func (bc *BusRouteController) RidersHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route, ok := bc.routeFromParam(ctx)
+		if !ok {
+			return
+		}
+		riders, err := bc.BusRouteRepository.FindRiders(ctx.Request.Context(), route.Id)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, riders)
+	}
+}
+
+// AssignRiderHandler puts a student on the route, moving them off any
+// other route.
+func (bc *BusRouteController) AssignRiderHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request RiderRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		route, ok := bc.routeFromParam(ctx)
+		if !ok {
+			return
+		}
+		if request.StopID > 0 && !hasStop(route, request.StopID) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Stop is not on this route"))))
+			return
+		}
+		visitor, err := bc.VisitorRepository.FindById(ctx.Request.Context(), request.VisitorID)
+		if errors.Is(err, entity.ErrQueryTimeout) {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		if err != nil || visitor == nil || visitor.Id == 0 {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor not exists"))))
+			return
+		}
+		if err := bc.BusRouteRepository.AssignRider(ctx.Request.Context(), route.Id, visitor.Id, request.StopID); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, &entity.BusRider{
+			Visitor: visitor,
+			RouteId: route.Id,
+			StopId:  request.StopID,
+		})
+	}
+}
+
+func (bc *BusRouteController) RemoveRiderHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		routeId, ok := idFromParam(ctx)
+		if !ok {
+			return
+		}
+		visitorId, err := strconv.ParseInt(ctx.Param("visitorId"), 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid visitor id"))))
+			return
+		}
+		if err := bc.BusRouteRepository.RemoveRider(ctx.Request.Context(), routeId, int32(visitorId)); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "Rider removed",
+		})
+	}
+}
+
+// MissingHandler is the dispatcher view: riders of the route who have not
+// scanned in today, ordered by stop.
+func (bc *BusRouteController) MissingHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route, ok := bc.routeFromParam(ctx)
+		if !ok {
+			return
+		}
+		riders, err := bc.BusRouteRepository.FindRidersWithoutTrackSince(ctx.Request.Context(), route.Id, util.StartOfDay(bc.now()))
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, riders)
+	}
+}
+
+// ArrivedHandler records the arrival of the route from the office.
+func (bc *BusRouteController) ArrivedHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route, ok := bc.routeFromParam(ctx)
+		if !ok {
+			return
+		}
+		bc.arrive(ctx, route, "")
+	}
+}
+
+// DeviceArrivedHandler records the arrival reported by the reader on the bus.
+func (bc *BusRouteController) DeviceArrivedHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request DeviceArrivalRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		route, err := bc.BusRouteRepository.FindByDeviceId(ctx.Request.Context(), request.DeviceID)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		if route == nil {
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Device is not assigned to a bus route"))))
+			return
+		}
+		bc.arrive(ctx, route, request.DeviceID)
+	}
+}
+
+func (bc *BusRouteController) arrive(ctx *gin.Context, route *entity.BusRoute, deviceId string) {
+	arrival, excusal, err := bc.Arrivals.Record(ctx.Request.Context(), route, deviceId, bc.now())
+	if errors.Is(err, ErrAlreadyArrived) {
+		ctx.JSON(http.StatusConflict, util.ExtendedFailureResponse{
+			Code:  AlreadyArrivedCode,
+			Error: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(util.ServerFailure(err))
+		return
+	}
+	logging.FromContext(ctx.Request.Context()).Infow("Bus arrived",
+		"route_id", route.Id,
+		"device_id", deviceId,
+		"excused", excusal.Excused,
+	)
+	ctx.JSON(http.StatusCreated, ArrivalResponse{
+		Arrival: arrival,
+		Excusal: excusal,
+	})
+}
+
+func (bc *BusRouteController) routeFromParam(ctx *gin.Context) (*entity.BusRoute, bool) {
+	id, ok := idFromParam(ctx)
+	if !ok {
+		return nil, false
+	}
+	route, err := bc.BusRouteRepository.GetById(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(util.ServerFailure(err))
+		return nil, false
+	}
+	if route == nil {
+		ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Bus route not exists"))))
+		return nil, false
+	}
+	return route, true
+}
+
+func (bc *BusRouteController) now() time.Time {
+	if bc.Now != nil {
+		return util.In(bc.Now(), bc.Location)
+	}
+	return util.In(time.Now(), bc.Location)
+}
+
+func hasStop(route *entity.BusRoute, stopId int64) bool {
+	for _, stop := range route.Stops {
+		if stop.Id == stopId {
+			return true
+		}
+	}
+	return false
+}
+
+func idFromParam(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid route id"))))
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,150 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVisitorRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorRepository) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Visitor), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
+	return m.Called(visitor, key).Error(0)
+}
+
+func newTestController() (*BusRouteController, *MockBusRouteRepository, *MockVisitorRepository) {
+	arrivals, routes, _, _ := newTestArrivals()
+	visitors := new(MockVisitorRepository)
+	return &BusRouteController{
+		BusRouteRepository: routes,
+		VisitorRepository:  visitors,
+		Arrivals:           arrivals,
+		Now:                func() time.Time { return arrivedAt },
+	}, routes, visitors
+}
+
+func perform(handler gin.HandlerFunc, method string, path string, payload any, params ...gin.Param) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	handler(c)
+	return w
+}
+
+func routeParam() gin.Param {
+	return gin.Param{Key: "id", Value: "2"}
+}
+
+func TestCreateHandler_RejectsInvalidPickupTime(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, routes, _ := newTestController()
+
+	w := perform(controller.CreateHandler(), "POST", "/api/bus-routes", RouteRequest{
+		Name:  "North",
+		Stops: []StopRequest{{Name: "Mill Lane", PickupAt: "7.40am"}},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	routes.AssertNotCalled(t, "Store", mock.Anything)
+}
+
+func TestAssignRiderHandler_StopMustBelongToRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, routes, _ := newTestController()
+	routes.On("GetById", int64(2)).Return(&entity.BusRoute{Id: 2, Stops: []*entity.BusStop{{Id: 5, RouteId: 2}}}, nil)
+
+	w := perform(controller.AssignRiderHandler(), "PUT", "/api/bus-routes/2/riders", RiderRequest{VisitorID: 7, StopID: 6}, routeParam())
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	routes.AssertNotCalled(t, "AssignRider", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAssignRiderHandler_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, routes, visitors := newTestController()
+	routes.On("GetById", int64(2)).Return(&entity.BusRoute{Id: 2, Stops: []*entity.BusStop{{Id: 5, RouteId: 2}}}, nil)
+	visitors.On("FindById", int32(7)).Return(&entity.Visitor{Id: 7, Name: "Tom"}, nil)
+	routes.On("AssignRider", int64(2), int32(7), int64(5)).Return(nil)
+
+	w := perform(controller.AssignRiderHandler(), "PUT", "/api/bus-routes/2/riders", RiderRequest{VisitorID: 7, StopID: 5}, routeParam())
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	routes.AssertExpectations(t)
+}
+
+func TestMissingHandler_ListsRidersNotSignedIn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, routes, _ := newTestController()
+	routes.On("GetById", int64(2)).Return(north, nil)
+	routes.On("FindRidersWithoutTrackSince", int64(2), dayStart).Return([]*entity.BusRider{
+		{Visitor: &entity.Visitor{Id: 8, Name: "Ann"}, RouteId: 2, StopName: "Mill Lane"},
+	}, nil)
+
+	w := perform(controller.MissingHandler(), "GET", "/api/bus-routes/2/missing", nil, routeParam())
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"stop_name":"Mill Lane"`)
+}
+
+func TestDeviceArrivedHandler_UnknownDevice(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, routes, _ := newTestController()
+	routes.On("FindByDeviceId", "bus-9").Return(nil, nil)
+
+	w := perform(controller.DeviceArrivedHandler(), "POST", "/api/bus-routes/arrived", DeviceArrivalRequest{DeviceID: "bus-9"})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestArrivedHandler_AlreadyArrived(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller, routes, _ := newTestController()
+	routes.On("GetById", int64(2)).Return(north, nil)
+	routes.On("FindLatestArrival", int64(2), dayStart).Return(&entity.BusArrival{Id: 3, RouteId: 2}, nil)
+
+	w := perform(controller.ArrivedHandler(), "POST", "/api/bus-routes/2/arrived", nil, routeParam())
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response util.ExtendedFailureResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, AlreadyArrivedCode, response.Code)
+}
@@ -0,0 +1,46 @@
+package visitor
+
+import (
+	"context"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+)
+
+// VisitorService looks up visitors by the keys they scan and manages those
+// keys. The HTTP handlers, the search page and the gRPC lookup share it.
+type VisitorService struct {
+	VisitorRepository entity.VisitorRepository `container:"type"`
+}
+
+// Lookup returns the visitor the key belongs to, tracker.ErrVisitorNotFound for
+// unknown keys.
+func (s *VisitorService) Lookup(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	visit, err := s.VisitorRepository.FindByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if visit == nil || visit.Visitor == nil {
+		return nil, tracker.ErrVisitorNotFound
+	}
+	return visit, nil
+}
+
+// AddKey gives the visitor one more key to scan, tracker.ErrVisitorNotFound rejects
+// unknown visitors.
+func (s *VisitorService) AddKey(ctx context.Context, visitorId int32, key string) error {
+	visitor, err := s.VisitorRepository.FindById(ctx, visitorId)
+	if err != nil {
+		return err
+	}
+	if visitor == nil || visitor.Id == 0 {
+		return tracker.ErrVisitorNotFound
+	}
+	return s.VisitorRepository.AddKeyToVisitor(ctx, visitor, key)
+}
+
+// Roster returns the visitors of the tenant with their keys, the snapshot
+// kiosks cache to greet students while offline.
+func (s *VisitorService) Roster(ctx context.Context) ([]*entity.VisitDetails, error) {
+	return s.VisitorRepository.FindAllWithKeys(ctx)
+}
@@ -0,0 +1,52 @@
+package visitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/infrastructure/repository"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/stretchr/testify/assert"
+)
+
+var jane = &entity.Visitor{Id: 7, Name: "Jane", Surname: "Doe", Grade: 5, SiteId: 3}
+
+func newVisitorService() *VisitorService {
+	memory := repository.NewMemoryStore()
+	scope := repository.Scope{TenantId: 1, SiteId: 3}
+	_ = memory.Seed(scope, repository.MemorySeed{Visitors: []repository.SeedVisitor{{Visitor: *jane}}})
+	return &VisitorService{VisitorRepository: &repository.MemoryVisitor{Memory: memory, Scope: scope}}
+}
+
+func TestVisitorService_AddKeyAndLookup(t *testing.T) {
+	// Setup
+	service := newVisitorService()
+	ctx := context.Background()
+
+	// Execute
+	addErr := service.AddKey(ctx, jane.Id, "KEY123")
+	visit, lookupErr := service.Lookup(ctx, "KEY123")
+	roster, rosterErr := service.Roster(ctx)
+
+	// Assert
+	assert.NoError(t, addErr)
+	assert.NoError(t, lookupErr)
+	assert.Equal(t, &entity.VisitDetails{Visitor: jane, Key: "KEY123"}, visit)
+	assert.NoError(t, rosterErr)
+	assert.Equal(t, []*entity.VisitDetails{{Visitor: jane, Key: "KEY123"}}, roster)
+}
+
+func TestVisitorService_UnknownVisitorAndKey(t *testing.T) {
+	// Setup
+	service := newVisitorService()
+	ctx := context.Background()
+
+	// Execute
+	addErr := service.AddKey(ctx, 99, "KEY123")
+	_, lookupErr := service.Lookup(ctx, "UNKNOWN")
+
+	// Assert
+	assert.ErrorIs(t, addErr, tracker.ErrVisitorNotFound)
+	assert.ErrorIs(t, lookupErr, tracker.ErrVisitorNotFound)
+}
@@ -1,9 +1,16 @@
 package visitor
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
 	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/tracker"
 	"github.com/gin-gonic/gin"
 )
 
@@ -12,8 +19,16 @@ type AddKeyRequest struct {
 	VisitorKey  string `json:"visitor_key"`
 }
 
+// RosterResponse is the snapshot of keys and visitors a kiosk caches to greet
+// students while it cannot reach the server.
+type RosterResponse struct {
+	Visitors []*entity.VisitDetails `json:"visitors"`
+}
+
+// VisitorController serves visitors and their keys over HTTP, see
+// VisitorService.
 type VisitorController struct {
-	VisitorRepository entity.VisitorRepository `container:"type"`
+	Service *VisitorService `container:"type"`
 }
 
 func (vc *VisitorController) AddKeyHandler() gin.HandlerFunc {
@@ -21,33 +36,48 @@ func (vc *VisitorController) AddKeyHandler() gin.HandlerFunc {
 		var Request AddKeyRequest
 		err := ctx.Bind(&Request)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
 			return
 		}
-		visitor, err := vc.VisitorRepository.FindById(Request.VisitorID)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
-			})
+		err = vc.Service.AddKey(ctx.Request.Context(), Request.VisitorID, Request.VisitorKey)
+		switch {
+		case errors.Is(err, tracker.ErrVisitorNotFound):
+			ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(err)))
 			return
-		}
-		if visitor == nil || visitor.Id == 0 {
-			ctx.JSON(http.StatusNotFound, gin.H{
-				"error": "Visitor not exists",
-			})
+		case errors.Is(err, entity.ErrKeyAssigned):
+			ctx.JSON(http.StatusConflict, util.NewFailureResponse(exception.Conflict(err)))
 			return
-		}
-
-		if err = vc.VisitorRepository.AddKeyToVisitor(visitor, Request.VisitorKey); err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
-			})
+		case err != nil:
+			ctx.JSON(util.ServerFailure(err))
 			return
 		}
 		ctx.JSON(http.StatusOK, gin.H{
 			"message": "Key successfully added",
 		})
 	}
-}
\ No newline at end of file
+}
+// RosterHandler returns the roster of the tenant with an ETag, kiosks refresh
+// their copy with If-None-Match and get 304 while nothing changed.
+func (vc *VisitorController) RosterHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		visitors, err := vc.Service.Roster(ctx.Request.Context())
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		body, err := json.Marshal(RosterResponse{Visitors: visitors})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, util.NewFailureResponse(exception.Internal(err)))
+			return
+		}
+		hash := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(hash[:]) + `"`
+		ctx.Header("ETag", etag)
+		ctx.Header("Cache-Control", "no-cache")
+		if ctx.GetHeader("If-None-Match") == etag {
+			ctx.AbortWithStatus(http.StatusNotModified)
+			return
+		}
+		ctx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	}
+}
@@ -2,12 +2,14 @@ package visitor
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/buzyka/imlate/internal/infrastructure/apispec"
 	"github.com/buzyka/imlate/internal/isb/entity"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +21,7 @@ type MockVisitorRepository struct {
 	mock.Mock
 }
 
-func (m *MockVisitorRepository) FindById(id int32) (*entity.Visitor, error) {
+func (m *MockVisitorRepository) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -27,7 +29,7 @@ func (m *MockVisitorRepository) FindById(id int32) (*entity.Visitor, error) {
 	return args.Get(0).(*entity.Visitor), args.Error(1)
 }
 
-func (m *MockVisitorRepository) FindByKey(key string) (*entity.VisitDetails, error) {
+func (m *MockVisitorRepository) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
 	args := m.Called(key)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -35,18 +37,40 @@ func (m *MockVisitorRepository) FindByKey(key string) (*entity.VisitDetails, err
 	return args.Get(0).(*entity.VisitDetails), args.Error(1)
 }
 
-func (m *MockVisitorRepository) AddKeyToVisitor(visitor *entity.Visitor, key string) error {
+func (m *MockVisitorRepository) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
 	args := m.Called(visitor, key)
 	return args.Error(0)
 }
 
+// serve runs the handler and checks the request and the response against
+// api/openapi.yaml.
+func serve(t *testing.T, handler gin.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	validator, err := apispec.NewValidator()
+	assert.NoError(t, err)
+	assert.NoError(t, validator.ValidateRequest(req), "request does not match api/openapi.yaml")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	handler(c)
+	assert.NoError(t, validator.ValidateResponse(req, w.Code, w.Header(), w.Body.Bytes()), "response does not match api/openapi.yaml")
+	return w
+}
+
 func TestAddKeyHandler_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := new(MockVisitorRepository)
-	controller := &VisitorController{
+	controller := &VisitorController{Service: &VisitorService{
 		VisitorRepository: mockRepo,
-	}
+	}}
 
 	visitor := &entity.Visitor{
 		Id:      1,
@@ -64,13 +88,10 @@ func TestAddKeyHandler_Success(t *testing.T) {
 	}
 	body, _ := json.Marshal(request)
 
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = httptest.NewRequest("POST", "/add-key", bytes.NewBuffer(body))
-	c.Request.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest("PATCH", "/api/v1/add-key", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
 
-	handler := controller.AddKeyHandler()
-	handler(c)
+	w := serve(t, controller.AddKeyHandler(), req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -86,13 +107,13 @@ func TestAddKeyHandler_InvalidRequest(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := new(MockVisitorRepository)
-	controller := &VisitorController{
+	controller := &VisitorController{Service: &VisitorService{
 		VisitorRepository: mockRepo,
-	}
+	}}
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
-	c.Request = httptest.NewRequest("POST", "/add-key", bytes.NewBufferString("invalid json"))
+	c.Request = httptest.NewRequest("PATCH", "/api/v1/add-key", bytes.NewBufferString("invalid json"))
 	c.Request.Header.Set("Content-Type", "application/json")
 
 	handler := controller.AddKeyHandler()
@@ -106,9 +127,9 @@ func TestAddKeyHandler_VisitorNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := new(MockVisitorRepository)
-	controller := &VisitorController{
+	controller := &VisitorController{Service: &VisitorService{
 		VisitorRepository: mockRepo,
-	}
+	}}
 
 	mockRepo.On("FindById", int32(999)).Return(&entity.Visitor{}, nil)
 
@@ -118,13 +139,10 @@ func TestAddKeyHandler_VisitorNotFound(t *testing.T) {
 	}
 	body, _ := json.Marshal(request)
 
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = httptest.NewRequest("POST", "/add-key", bytes.NewBuffer(body))
-	c.Request.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest("PATCH", "/api/v1/add-key", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
 
-	handler := controller.AddKeyHandler()
-	handler(c)
+	w := serve(t, controller.AddKeyHandler(), req)
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
 
@@ -140,9 +158,9 @@ func TestAddKeyHandler_FindByIdError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := new(MockVisitorRepository)
-	controller := &VisitorController{
+	controller := &VisitorController{Service: &VisitorService{
 		VisitorRepository: mockRepo,
-	}
+	}}
 
 	mockRepo.On("FindById", int32(1)).Return(nil, errors.New("database error"))
 
@@ -152,13 +170,10 @@ func TestAddKeyHandler_FindByIdError(t *testing.T) {
 	}
 	body, _ := json.Marshal(request)
 
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = httptest.NewRequest("POST", "/add-key", bytes.NewBuffer(body))
-	c.Request.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest("PATCH", "/api/v1/add-key", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
 
-	handler := controller.AddKeyHandler()
-	handler(c)
+	w := serve(t, controller.AddKeyHandler(), req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 
@@ -174,9 +189,9 @@ func TestAddKeyHandler_AddKeyError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := new(MockVisitorRepository)
-	controller := &VisitorController{
+	controller := &VisitorController{Service: &VisitorService{
 		VisitorRepository: mockRepo,
-	}
+	}}
 
 	visitor := &entity.Visitor{
 		Id:      1,
@@ -194,13 +209,10 @@ func TestAddKeyHandler_AddKeyError(t *testing.T) {
 	}
 	body, _ := json.Marshal(request)
 
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = httptest.NewRequest("POST", "/add-key", bytes.NewBuffer(body))
-	c.Request.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest("PATCH", "/api/v1/add-key", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
 
-	handler := controller.AddKeyHandler()
-	handler(c)
+	w := serve(t, controller.AddKeyHandler(), req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 
@@ -211,3 +223,86 @@ func TestAddKeyHandler_AddKeyError(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestAddKeyHandler_KeyAssigned(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := new(MockVisitorRepository)
+	controller := &VisitorController{Service: &VisitorService{
+		VisitorRepository: mockRepo,
+	}}
+
+	visitor := &entity.Visitor{
+		Id:      1,
+		Name:    "John",
+		Surname: "Doe",
+		Grade:   10,
+	}
+
+	mockRepo.On("FindById", int32(1)).Return(visitor, nil)
+	mockRepo.On("AddKeyToVisitor", visitor, "KEY123").Return(entity.ErrKeyAssigned)
+
+	request := AddKeyRequest{
+		VisitorID:  1,
+		VisitorKey: "KEY123",
+	}
+	body, _ := json.Marshal(request)
+
+	req := httptest.NewRequest("PATCH", "/api/v1/add-key", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := serve(t, controller.AddKeyHandler(), req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "conflict", response["code"])
+	assert.Equal(t, "Key already assigned to another visitor", response["error"])
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRosterHandler_ReturnsVisitorsWithETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := new(MockVisitorRepository)
+	controller := &VisitorController{Service: &VisitorService{
+		VisitorRepository: mockRepo,
+	}}
+	mockRepo.On("FindAllWithKeys").Return([]*entity.VisitDetails{
+		{Key: "ABC123", Visitor: &entity.Visitor{Id: 1, Name: "John", Surname: "Doe"}},
+	}, nil)
+
+	w := serve(t, controller.RosterHandler(), httptest.NewRequest("GET", "/api/v1/roster", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+	var response RosterResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Visitors, 1)
+	assert.Equal(t, "ABC123", response.Visitors[0].Key)
+
+	// A kiosk holding the same snapshot is told nothing changed
+	req := httptest.NewRequest("GET", "/api/v1/roster", nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w2 := serve(t, controller.RosterHandler(), req)
+
+	assert.Equal(t, http.StatusNotModified, w2.Code)
+	assert.Empty(t, w2.Body.String())
+}
+
+func TestRosterHandler_RepositoryError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := new(MockVisitorRepository)
+	controller := &VisitorController{Service: &VisitorService{
+		VisitorRepository: mockRepo,
+	}}
+	mockRepo.On("FindAllWithKeys").Return(nil, errors.New("db down"))
+
+	w := serve(t, controller.RosterHandler(), httptest.NewRequest("GET", "/api/v1/roster", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,116 @@
+package watchlist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/logging"
+	"github.com/buzyka/imlate/internal/isb/entity"
+)
+
+const (
+	SourceScan   = "scan"
+	SourceGuest  = "guest"
+	SourcePickup = "pickup"
+
+	// AlertStatus is what kiosks receive on a hit. It deliberately says
+	// nothing about the entry so the screen can stay discreet.
+	AlertStatus = "attention"
+	ClearStatus = "clear"
+)
+
+// Screener checks visitors and free-text identities against the active
+// watchlist, logs every hit and notifies safeguarding staff.
+type Screener struct {
+	Repository entity.WatchlistRepository
+	Notifier   entity.Notifier
+}
+
+// ScreenVisitor matches a scanned visitor against entries linked to them.
+// A nil screener never matches.
+func (s *Screener) ScreenVisitor(ctx context.Context, visitor *entity.Visitor, source string) (*entity.WatchlistEntry, error) {
+	if s == nil || visitor == nil {
+		return nil, nil
+	}
+	entries, err := s.Repository.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.VisitorId > 0 && entry.VisitorId == visitor.Id {
+			return entry, s.report(ctx, entry, &entity.WatchlistHit{
+				EntryId:   entry.Id,
+				VisitorId: visitor.Id,
+				Source:    source,
+				Details:   visitor.Name + " " + visitor.Surname,
+			})
+		}
+	}
+	return nil, nil
+}
+
+// ScreenName matches a free-text identity, e.g. a guest or a collecting
+// adult, against entries by name. Case and extra spaces are ignored.
+func (s *Screener) ScreenName(ctx context.Context, fullName string, source string) (*entity.WatchlistEntry, error) {
+	name := normalizeName(fullName)
+	if s == nil || name == "" {
+		return nil, nil
+	}
+	entries, err := s.Repository.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if normalizeName(entry.Name+" "+entry.Surname) == name {
+			return entry, s.report(ctx, entry, &entity.WatchlistHit{
+				EntryId:   entry.Id,
+				VisitorId: entry.VisitorId,
+				Source:    source,
+				Details:   strings.TrimSpace(fullName),
+			})
+		}
+	}
+	return nil, nil
+}
+
+func (s *Screener) report(ctx context.Context, entry *entity.WatchlistEntry, hit *entity.WatchlistHit) error {
+	logger := logging.FromContext(ctx)
+	logger.Warnw("Watchlist hit",
+		"entry_id", entry.Id,
+		"category", entry.Category,
+		"source", hit.Source,
+		"visitor_id", hit.VisitorId,
+		"details", hit.Details,
+	)
+	if err := s.Repository.StoreHit(ctx, hit); err != nil {
+		return err
+	}
+	if s.Notifier == nil {
+		return nil
+	}
+	err := s.Notifier.Notify(entity.Notification{
+		Type:    "watchlist_hit",
+		Subject: "Safeguarding watchlist match",
+		Message: fmt.Sprintf("%s matched a %s watchlist entry at %s.", hit.Details, entry.Category, hit.Source),
+		Data: map[string]any{
+			"entry_id":   entry.Id,
+			"hit_id":     hit.Id,
+			"category":   entry.Category,
+			"notes":      entry.Notes,
+			"source":     hit.Source,
+			"visitor_id": hit.VisitorId,
+			"details":    hit.Details,
+		},
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		logger.Errorf("Error notifying safeguarding staff about watchlist hit: %s", err.Error())
+	}
+	return nil
+}
+
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
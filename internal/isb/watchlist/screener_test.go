@@ -0,0 +1,131 @@
+package watchlist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWatchlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatchlistRepository) FindActive(ctx context.Context) ([]*entity.WatchlistEntry, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WatchlistEntry), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) Store(ctx context.Context, entry *entity.WatchlistEntry) error {
+	return m.Called(entry).Error(0)
+}
+
+func (m *MockWatchlistRepository) Deactivate(ctx context.Context, id int64) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockWatchlistRepository) StoreHit(ctx context.Context, hit *entity.WatchlistHit) error {
+	return m.Called(hit).Error(0)
+}
+
+func (m *MockWatchlistRepository) FindHitsBetween(ctx context.Context, from time.Time, to time.Time) ([]*entity.WatchlistHit, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WatchlistHit), args.Error(1)
+}
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(notification entity.Notification) error {
+	return m.Called(notification).Error(0)
+}
+
+var testEntries = []*entity.WatchlistEntry{
+	{Id: 1, VisitorId: 7, Name: "Tom", Surname: "Doe", Category: entity.WatchlistNoAdmit},
+	{Id: 2, Name: "John", Surname: "Stranger", Category: entity.WatchlistCustody, Notes: "not allowed to collect Tom"},
+}
+
+func TestScreenVisitor_LinkedEntryIsLoggedAndNotified(t *testing.T) {
+	repo := new(MockWatchlistRepository)
+	notifier := new(MockNotifier)
+	screener := &Screener{Repository: repo, Notifier: notifier}
+
+	repo.On("FindActive").Return(testEntries, nil)
+	repo.On("StoreHit", mock.MatchedBy(func(hit *entity.WatchlistHit) bool {
+		return hit.EntryId == 1 && hit.VisitorId == 7 && hit.Source == SourceScan
+	})).Return(nil)
+	notifier.On("Notify", mock.MatchedBy(func(n entity.Notification) bool {
+		return n.Type == "watchlist_hit" && n.Data["category"] == entity.WatchlistNoAdmit
+	})).Return(nil)
+
+	entry, err := screener.ScreenVisitor(context.Background(), &entity.Visitor{Id: 7, Name: "Tom", Surname: "Doe"}, SourceScan)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), entry.Id)
+	repo.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+}
+
+func TestScreenVisitor_NameOnlyEntriesDoNotMatchScans(t *testing.T) {
+	repo := new(MockWatchlistRepository)
+	screener := &Screener{Repository: repo}
+
+	repo.On("FindActive").Return(testEntries, nil)
+
+	entry, err := screener.ScreenVisitor(context.Background(), &entity.Visitor{Id: 8, Name: "John", Surname: "Stranger"}, SourceScan)
+
+	assert.NoError(t, err)
+	assert.Nil(t, entry)
+	repo.AssertNotCalled(t, "StoreHit", mock.Anything)
+}
+
+func TestScreenName_IgnoresCaseAndSpacing(t *testing.T) {
+	repo := new(MockWatchlistRepository)
+	screener := &Screener{Repository: repo}
+
+	repo.On("FindActive").Return(testEntries, nil)
+	repo.On("StoreHit", mock.MatchedBy(func(hit *entity.WatchlistHit) bool {
+		return hit.EntryId == 2 && hit.Source == SourceGuest && hit.Details == "JOHN   stranger"
+	})).Return(nil)
+
+	entry, err := screener.ScreenName(context.Background(), "  JOHN   stranger ", SourceGuest)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), entry.Id)
+	repo.AssertExpectations(t)
+}
+
+func TestScreenName_NotifierFailureStillReportsHit(t *testing.T) {
+	repo := new(MockWatchlistRepository)
+	notifier := new(MockNotifier)
+	screener := &Screener{Repository: repo, Notifier: notifier}
+
+	repo.On("FindActive").Return(testEntries, nil)
+	repo.On("StoreHit", mock.Anything).Return(nil)
+	notifier.On("Notify", mock.Anything).Return(errors.New("webhook down"))
+
+	entry, err := screener.ScreenName(context.Background(), "John Stranger", SourcePickup)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, entry)
+}
+
+func TestScreener_NilNeverMatches(t *testing.T) {
+	var screener *Screener
+
+	entry, err := screener.ScreenName(context.Background(), "John Stranger", SourceGuest)
+
+	assert.NoError(t, err)
+	assert.Nil(t, entry)
+}
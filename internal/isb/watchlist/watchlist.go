@@ -0,0 +1,151 @@
+package watchlist
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/infrastructure/util/exception"
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type EntryRequest struct {
+	VisitorID int32  `json:"visitor_id"`
+	Name      string `json:"name"`
+	Surname   string `json:"surname"`
+	Category  string `json:"category" binding:"required"`
+	Notes     string `json:"notes"`
+}
+
+type GuestRequest struct {
+	Name    string `json:"name" binding:"required"`
+	Surname string `json:"surname"`
+}
+
+// WatchlistController manages safeguarding entries and screens guests at
+// registration.
+type WatchlistController struct {
+	WatchlistRepository entity.WatchlistRepository `container:"type"`
+	VisitorRepository   entity.VisitorRepository   `container:"type"`
+	Screener            *Screener                  `container:"type"`
+	Location            *time.Location             `container:"type"`
+}
+
+func (wc *WatchlistController) ListHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		entries, err := wc.WatchlistRepository.FindActive(ctx.Request.Context())
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, entries)
+	}
+}
+
+func (wc *WatchlistController) CreateHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request EntryRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		if !slices.Contains(entity.WatchlistCategories, request.Category) {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid category, expected one of: "+strings.Join(entity.WatchlistCategories, ", ")))))
+			return
+		}
+		entry := &entity.WatchlistEntry{
+			Name:     strings.TrimSpace(request.Name),
+			Surname:  strings.TrimSpace(request.Surname),
+			Category: request.Category,
+			Notes:    request.Notes,
+		}
+		if request.VisitorID > 0 {
+			visitor, err := wc.VisitorRepository.FindById(ctx.Request.Context(), request.VisitorID)
+			if err != nil {
+				ctx.JSON(util.ServerFailure(err))
+				return
+			}
+			if visitor == nil || visitor.Id == 0 {
+				ctx.JSON(http.StatusNotFound, util.NewFailureResponse(exception.NotFound(errors.New("Visitor not exists"))))
+				return
+			}
+			entry.VisitorId = visitor.Id
+			if entry.Name == "" && entry.Surname == "" {
+				entry.Name = visitor.Name
+				entry.Surname = visitor.Surname
+			}
+		} else if entry.Name == "" {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Either visitor_id or name is required"))))
+			return
+		}
+		if err := wc.WatchlistRepository.Store(ctx.Request.Context(), entry); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusCreated, entry)
+	}
+}
+
+func (wc *WatchlistController) DeleteHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid watchlist entry id"))))
+			return
+		}
+		if err := wc.WatchlistRepository.Deactivate(ctx.Request.Context(), id); err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "Watchlist entry deactivated",
+		})
+	}
+}
+
+func (wc *WatchlistController) HitsHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		day, err := util.ParseDate(ctx.DefaultQuery("date", util.In(time.Now(), wc.Location).Format(dateLayout)), wc.Location)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(errors.New("Invalid date, expected YYYY-MM-DD"))))
+			return
+		}
+		hits, err := wc.WatchlistRepository.FindHitsBetween(ctx.Request.Context(), day, day.AddDate(0, 0, 1))
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		ctx.JSON(http.StatusOK, hits)
+	}
+}
+
+// GuestHandler screens a guest at registration. The response only carries
+// a status so the kiosk never reveals why a guest was flagged.
+func (wc *WatchlistController) GuestHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request GuestRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, util.NewFailureResponse(exception.Validation(err)))
+			return
+		}
+		entry, err := wc.Screener.ScreenName(ctx.Request.Context(), request.Name+" "+request.Surname, SourceGuest)
+		if err != nil {
+			ctx.JSON(util.ServerFailure(err))
+			return
+		}
+		status := ClearStatus
+		if entry != nil {
+			status = AlertStatus
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"status": status,
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package watchlist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVisitorRepository struct {
+	mock.Mock
+}
+
+func (m *MockVisitorRepository) FindById(ctx context.Context, id int32) (*entity.Visitor, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Visitor), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindByKey(ctx context.Context, key string) (*entity.VisitDetails, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) FindAllWithKeys(ctx context.Context) ([]*entity.VisitDetails, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.VisitDetails), args.Error(1)
+}
+
+func (m *MockVisitorRepository) AddKeyToVisitor(ctx context.Context, visitor *entity.Visitor, key string) error {
+	return m.Called(visitor, key).Error(0)
+}
+
+func performJSON(handler gin.HandlerFunc, path string, payload any) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", path, bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	handler(c)
+	return w
+}
+
+func TestCreateHandler_LinkedVisitorCopiesName(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockWatchlistRepository)
+	visitors := new(MockVisitorRepository)
+	controller := &WatchlistController{WatchlistRepository: repo, VisitorRepository: visitors}
+
+	visitors.On("FindById", int32(7)).Return(&entity.Visitor{Id: 7, Name: "Tom", Surname: "Doe"}, nil)
+	repo.On("Store", mock.MatchedBy(func(entry *entity.WatchlistEntry) bool {
+		return entry.VisitorId == 7 && entry.Name == "Tom" && entry.Category == entity.WatchlistNoAdmit
+	})).Return(nil)
+
+	w := performJSON(controller.CreateHandler(), "/api/watchlist", EntryRequest{VisitorID: 7, Category: entity.WatchlistNoAdmit})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateHandler_InvalidCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockWatchlistRepository)
+	controller := &WatchlistController{WatchlistRepository: repo}
+
+	w := performJSON(controller.CreateHandler(), "/api/watchlist", EntryRequest{Name: "John", Category: "banned"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	repo.AssertNotCalled(t, "Store", mock.Anything)
+}
+
+func TestCreateHandler_RequiresVisitorOrName(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockWatchlistRepository)
+	controller := &WatchlistController{WatchlistRepository: repo}
+
+	w := performJSON(controller.CreateHandler(), "/api/watchlist", EntryRequest{Category: entity.WatchlistCustody})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGuestHandler_MatchReturnsOnlyStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockWatchlistRepository)
+	controller := &WatchlistController{Screener: &Screener{Repository: repo}}
+
+	repo.On("FindActive").Return(testEntries, nil)
+	repo.On("StoreHit", mock.Anything).Return(nil)
+
+	w := performJSON(controller.GuestHandler(), "/api/guests/screen", GuestRequest{Name: "John", Surname: "Stranger"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"attention"}`, w.Body.String())
+}
+
+func TestGuestHandler_NoMatchIsClear(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := new(MockWatchlistRepository)
+	controller := &WatchlistController{Screener: &Screener{Repository: repo}}
+
+	repo.On("FindActive").Return(testEntries, nil)
+
+	w := performJSON(controller.GuestHandler(), "/api/guests/screen", GuestRequest{Name: "Jane", Surname: "Smith"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"clear"}`, w.Body.String())
+}
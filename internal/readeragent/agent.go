@@ -0,0 +1,218 @@
+package readeragent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"go.uber.org/zap"
+)
+
+const (
+	// ScanQueued is played back for scans made while the server is offline.
+	ScanQueued = "queued"
+
+	syncBatchSize       = 100
+	hookTimeout         = 10 * time.Second
+	defaultSyncInterval = 15 * time.Second
+)
+
+// Result is played back for every scan. Status is one of the scan statuses
+// of tracker, tracker.AntiPassbackCode or ScanQueued.
+type Result struct {
+	tracker.Scan
+	Status string                 `json:"status"`
+	Error  string                 `json:"error,omitempty"`
+	Track  *tracker.TrackResponse `json:"track,omitempty"`
+	// Synced is set on the results of queued scans once they reach the server.
+	Synced bool `json:"synced,omitempty"`
+}
+
+// Agent reads card ids from a keyboard-wedge or serial reader and tracks them
+// on the server. Scans made while the server is offline are queued and synced
+// in batches once it is back.
+type Agent struct {
+	Client *Client
+	Queue  *Queue
+	// Output gets every result as a line of JSON.
+	Output io.Writer
+	// Hook is run with the result of every scan made at the reader, results
+	// of synced scans come too late to greet anybody.
+	Hook         string
+	SyncInterval time.Duration
+	Logger       *zap.SugaredLogger
+	Now          func() time.Time
+}
+
+// Run reads one card id per line until the input ends or ctx is done.
+func (a *Agent) Run(ctx context.Context, input io.Reader) error {
+	lines := make(chan string)
+	done := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(input)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+		done <- scanner.Err()
+	}()
+
+	a.sync(ctx)
+	ticker := time.NewTicker(a.syncInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line := <-lines:
+			a.scan(ctx, line)
+		case <-ticker.C:
+			a.sync(ctx)
+		case err := <-done:
+			a.sync(ctx)
+			return err
+		}
+	}
+}
+
+func (a *Agent) scan(ctx context.Context, line string) {
+	visitKey := strings.TrimSpace(line)
+	if visitKey == "" {
+		return
+	}
+	scan := tracker.Scan{Id: newScanId(), VisitKey: visitKey, ScannedAt: a.now().UTC()}
+
+	// Nothing is sent while older scans can not be synced, the server is
+	// most likely still offline.
+	if pending, _ := a.Queue.Len(); pending > 0 && !a.sync(ctx) {
+		a.play(ctx, a.enqueue(scan, ErrOffline))
+		return
+	}
+	result, err := a.Client.Track(ctx, scan)
+	if err != nil {
+		a.Logger.Warnw("Queueing scan", "visit_key", visitKey, "error", err.Error())
+		result = a.enqueue(scan, err)
+	}
+	a.play(ctx, result)
+}
+
+func (a *Agent) enqueue(scan tracker.Scan, reason error) Result {
+	if err := a.Queue.Push(scan); err != nil {
+		a.Logger.Errorf("Error queueing scan: %s", err.Error())
+		return Result{Scan: scan, Status: tracker.ScanFailed, Error: err.Error()}
+	}
+	return Result{Scan: scan, Status: ScanQueued, Error: reason.Error()}
+}
+
+// sync sends the queued scans to the server and reports whether the queue
+// is empty afterwards. Scans the server failed to store stay queued.
+func (a *Agent) sync(ctx context.Context) bool {
+	for {
+		scans, err := a.Queue.Pending(syncBatchSize)
+		if err != nil {
+			a.Logger.Errorf("Error reading queued scans: %s", err.Error())
+			return false
+		}
+		if len(scans) == 0 {
+			return true
+		}
+		results, err := a.Client.Batch(ctx, scans)
+		if err != nil {
+			if !errors.Is(err, ErrOffline) {
+				a.Logger.Errorf("Error syncing queued scans: %s", err.Error())
+			}
+			return false
+		}
+
+		queued := make(map[string]tracker.Scan, len(scans))
+		for _, scan := range scans {
+			queued[scan.Id] = scan
+		}
+		synced := make(map[string]bool, len(results))
+		for _, result := range results {
+			scan, ok := queued[result.Id]
+			if !ok || result.Status == tracker.ScanFailed {
+				continue
+			}
+			synced[result.Id] = true
+			a.play(ctx, Result{Scan: scan, Status: result.Status, Error: result.Error, Track: result.Track, Synced: true})
+		}
+		if err := a.Queue.Remove(synced); err != nil {
+			a.Logger.Errorf("Error removing synced scans: %s", err.Error())
+			return false
+		}
+		if len(synced) < len(scans) {
+			return false
+		}
+	}
+}
+
+func (a *Agent) play(ctx context.Context, result Result) {
+	line, err := json.Marshal(result)
+	if err != nil {
+		a.Logger.Errorf("Error encoding result: %s", err.Error())
+		return
+	}
+	if _, err := a.Output.Write(append(line, '\n')); err != nil {
+		a.Logger.Errorf("Error writing result: %s", err.Error())
+	}
+	if a.Hook == "" || result.Synced {
+		return
+	}
+	if err := a.runHook(ctx, result, line); err != nil {
+		a.Logger.Errorf("Error running hook: %s", err.Error())
+	}
+}
+
+// runHook passes the result as JSON on stdin, the fields a script needs to
+// beep or switch a light are in the environment too.
+func (a *Agent) runHook(ctx context.Context, result Result, line []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, a.Hook)
+	cmd.Stdin = bytes.NewReader(line)
+	cmd.Env = append(os.Environ(),
+		"IMLATE_SCAN_STATUS="+result.Status,
+		"IMLATE_VISIT_KEY="+result.VisitKey,
+	)
+	if result.Track != nil {
+		cmd.Env = append(cmd.Env, "IMLATE_TRACK_TYPE="+result.Track.TrackType)
+		if result.Track.Visitor != nil {
+			cmd.Env = append(cmd.Env, "IMLATE_VISITOR_NAME="+result.Track.Visitor.Name+" "+result.Track.Visitor.Surname)
+		}
+	}
+	return cmd.Run()
+}
+
+func (a *Agent) syncInterval() time.Duration {
+	if a.SyncInterval > 0 {
+		return a.SyncInterval
+	}
+	return defaultSyncInterval
+}
+
+func (a *Agent) now() time.Time {
+	if a.Now != nil {
+		return a.Now()
+	}
+	return time.Now()
+}
+
+func newScanId() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
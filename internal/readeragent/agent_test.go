@@ -0,0 +1,206 @@
+package readeragent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/entity"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/creack/pty"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeServer answers like the tracking API, KEY123 is the only known card.
+type fakeServer struct {
+	mu      sync.Mutex
+	offline bool
+	headers []http.Header
+	batches []tracker.BatchRequest
+}
+
+func (s *fakeServer) setOffline(offline bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offline = offline
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.offline {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	s.headers = append(s.headers, r.Header.Clone())
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/api/v1/find-and-track":
+		var request tracker.Request
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		if request.VisitKey != "KEY123" {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"code": "not_found", "error": "Visitor not exists"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(tracker.TrackResponse{
+			TrackId:   1,
+			Visitor:   &entity.Visitor{Id: 7, Name: "Jane", Surname: "Doe"},
+			TrackType: "sign_in",
+		})
+	case "/api/v1/scans/batch":
+		var request tracker.BatchRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		s.batches = append(s.batches, request)
+		response := tracker.BatchResponse{}
+		for _, scan := range request.Scans {
+			response.Results = append(response.Results, tracker.ScanResult{Id: scan.Id, Status: tracker.ScanTracked})
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+// resultWriter hands every result the agent plays back to the test.
+type resultWriter chan Result
+
+func (w resultWriter) Write(line []byte) (int, error) {
+	var result Result
+	if err := json.Unmarshal(line, &result); err != nil {
+		return 0, err
+	}
+	w <- result
+	return len(line), nil
+}
+
+func (w resultWriter) next(t *testing.T) Result {
+	t.Helper()
+	select {
+	case result := <-w:
+		return result
+	case <-time.After(5 * time.Second):
+		t.Fatal("no result played back")
+		return Result{}
+	}
+}
+
+func newAgent(t *testing.T, server *fakeServer) (*Agent, resultWriter) {
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	results := make(resultWriter, 10)
+	return &Agent{
+		Client:       &Client{BaseURL: ts.URL, Token: "secret", Site: "north", HTTP: ts.Client()},
+		Queue:        &Queue{Path: filepath.Join(t.TempDir(), "queue.jsonl")},
+		Output:       results,
+		SyncInterval: 20 * time.Millisecond,
+		Logger:       zap.NewNop().Sugar(),
+	}, results
+}
+
+// openTerminal returns the reader's end of a pseudo-terminal and the end the
+// test types card ids into, like a keyboard-wedge reader on a tty does.
+func openTerminal(t *testing.T) (*os.File, *os.File) {
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		t.Skipf("pseudo-terminals are not available: %s", err.Error())
+	}
+	t.Cleanup(func() {
+		ptmx.Close()
+		tty.Close()
+	})
+	return tty, ptmx
+}
+
+func TestRun_TracksScansFromTerminal(t *testing.T) {
+	// Setup
+	server := &fakeServer{}
+	agent, results := newAgent(t, server)
+	dir := t.TempDir()
+	agent.Hook = filepath.Join(dir, "hook.sh")
+	script := "#!/bin/sh\ncat > " + filepath.Join(dir, "result.json") + "\necho \"$IMLATE_SCAN_STATUS $IMLATE_VISITOR_NAME\" > " + filepath.Join(dir, "env") + "\n"
+	assert.NoError(t, os.WriteFile(agent.Hook, []byte(script), 0o700))
+	tty, reader := openTerminal(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Execute
+	go agent.Run(ctx, tty)
+	_, err := reader.Write([]byte("KEY123\r"))
+	assert.NoError(t, err)
+	result := results.next(t)
+
+	// Assert
+	assert.Equal(t, "KEY123", result.VisitKey)
+	assert.Equal(t, tracker.ScanTracked, result.Status)
+	assert.Equal(t, "sign_in", result.Track.TrackType)
+	if assert.Len(t, server.headers, 1) {
+		assert.Equal(t, "Bearer secret", server.headers[0].Get("Authorization"))
+		assert.Equal(t, "north", server.headers[0].Get("X-Site"))
+		assert.Equal(t, result.Id, server.headers[0].Get("Idempotency-Key"))
+	}
+	assert.Eventually(t, func() bool {
+		env, err := os.ReadFile(filepath.Join(dir, "env"))
+		return err == nil && string(env) == "tracked Jane Doe\n"
+	}, 5*time.Second, 10*time.Millisecond)
+	played, err := os.ReadFile(filepath.Join(dir, "result.json"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(played), `"visit_key":"KEY123"`)
+}
+
+func TestRun_QueuesWhileOfflineAndSyncs(t *testing.T) {
+	// Setup
+	server := &fakeServer{offline: true}
+	agent, results := newAgent(t, server)
+	tty, reader := openTerminal(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go agent.Run(ctx, tty)
+
+	// Execute
+	_, err := reader.Write([]byte("KEY123\n"))
+	assert.NoError(t, err)
+	queued := results.next(t)
+	pending, err := agent.Queue.Len()
+	assert.NoError(t, err)
+	server.setOffline(false)
+	synced := results.next(t)
+
+	// Assert
+	assert.Equal(t, ScanQueued, queued.Status)
+	assert.Equal(t, 1, pending)
+	assert.Equal(t, queued.Id, synced.Id)
+	assert.Equal(t, tracker.ScanTracked, synced.Status)
+	assert.True(t, synced.Synced)
+	server.mu.Lock()
+	if assert.Len(t, server.batches, 1) {
+		assert.Equal(t, "KEY123", server.batches[0].Scans[0].VisitKey)
+		assert.Equal(t, queued.ScannedAt, server.batches[0].Scans[0].ScannedAt)
+	}
+	server.mu.Unlock()
+	left, err := agent.Queue.Len()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, left)
+}
+
+func TestRun_EndsWithInput(t *testing.T) {
+	// Setup
+	agent, results := newAgent(t, &fakeServer{})
+
+	// Execute
+	err := agent.Run(context.Background(), strings.NewReader("KEY123\n\n  \nUNKNOWN\n"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, tracker.ScanTracked, results.next(t).Status)
+	notFound := results.next(t)
+	assert.Equal(t, tracker.ScanNotFound, notFound.Status)
+	assert.Equal(t, "Visitor not exists", notFound.Error)
+	assert.Empty(t, results)
+}
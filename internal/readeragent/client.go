@@ -0,0 +1,124 @@
+package readeragent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/idempotency"
+	"github.com/buzyka/imlate/internal/isb/site"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+)
+
+// ErrOffline is returned while the server cannot be reached or is
+// unavailable, the scan is queued and synced later.
+var ErrOffline = errors.New("server is offline")
+
+// Client sends scans to the tracking API of the server.
+type Client struct {
+	BaseURL   string
+	Token     string
+	Site      string
+	Direction string
+	HTTP      *http.Client
+}
+
+// Track tracks a scan the way the kiosk does, an error means the scan did
+// not reach the server. The scan id is sent as the Idempotency-Key and the
+// client id of the track, a retried request is tracked once and so is the
+// scan synced after its response was lost.
+func (c *Client) Track(ctx context.Context, scan tracker.Scan) (Result, error) {
+	request := tracker.Request{VisitKey: scan.VisitKey, SignedIn: true, Direction: c.Direction, ClientId: scan.Id}
+	status, body, err := c.post(ctx, "/api/v1/find-and-track", scan.Id, request)
+	if err != nil {
+		return Result{}, err
+	}
+	if status == http.StatusOK {
+		var track tracker.TrackResponse
+		if err := json.Unmarshal(body, &track); err != nil {
+			return Result{Scan: scan, Status: tracker.ScanFailed, Error: err.Error()}, nil
+		}
+		return Result{Scan: scan, Status: tracker.ScanTracked, Track: &track}, nil
+	}
+
+	var failure util.ExtendedFailureResponse
+	_ = json.Unmarshal(body, &failure)
+	result := Result{Scan: scan, Status: tracker.ScanFailed, Error: failure.Error}
+	switch {
+	case status == http.StatusNotFound:
+		result.Status = tracker.ScanNotFound
+	case status == http.StatusConflict && failure.Code == tracker.AntiPassbackCode:
+		result.Status = tracker.AntiPassbackCode
+	case status == http.StatusBadRequest:
+		result.Status = tracker.ScanInvalid
+	}
+	if result.Error == "" {
+		result.Error = http.StatusText(status)
+	}
+	return result, nil
+}
+
+// Batch syncs queued scans, see tracker.TrackerController.BatchHandler.
+func (c *Client) Batch(ctx context.Context, scans []tracker.Scan) ([]tracker.ScanResult, error) {
+	status, body, err := c.post(ctx, "/api/v1/scans/batch", "", tracker.BatchRequest{Scans: scans})
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("syncing scans: %s: %s", http.StatusText(status), strings.TrimSpace(string(body)))
+	}
+	var response tracker.BatchResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
+
+func (c *Client) post(ctx context.Context, path string, idempotencyKey string, payload any) (int, []byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(c.BaseURL, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if c.Site != "" {
+		req.Header.Set(site.Header, c.Site)
+	}
+	if idempotencyKey != "" {
+		req.Header.Set(idempotency.Header, idempotencyKey)
+	}
+
+	resp, err := c.http().Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %s", ErrOffline, err.Error())
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %s", ErrOffline, err.Error())
+	}
+	// Proxies answer 502 to 504 while the server is restarting.
+	if resp.StatusCode >= http.StatusBadGateway {
+		return 0, nil, fmt.Errorf("%w: %s", ErrOffline, http.StatusText(resp.StatusCode))
+	}
+	return resp.StatusCode, body, nil
+}
+
+func (c *Client) http() *http.Client {
+	if c.HTTP != nil {
+		return c.HTTP
+	}
+	return http.DefaultClient
+}
@@ -0,0 +1,68 @@
+package readeragent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/buzyka/imlate/internal/infrastructure/util"
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrack_AntiPassback(t *testing.T) {
+	// Setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(w).Encode(util.ExtendedFailureResponse{Code: tracker.AntiPassbackCode, Error: "Visitor already signed in"})
+	}))
+	defer ts.Close()
+	client := &Client{BaseURL: ts.URL}
+
+	// Execute
+	result, err := client.Track(context.Background(), tracker.Scan{Id: "1", VisitKey: "KEY123"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, tracker.AntiPassbackCode, result.Status)
+	assert.Equal(t, "Visitor already signed in", result.Error)
+}
+
+func TestTrack_SendsScanIdAsClientId(t *testing.T) {
+	// Setup
+	var received tracker.Request
+	var idempotencyKey string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idempotencyKey = r.Header.Get("Idempotency-Key")
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		_ = json.NewEncoder(w).Encode(tracker.TrackResponse{TrackId: 10, TrackType: "sign-in"})
+	}))
+	defer ts.Close()
+	client := &Client{BaseURL: ts.URL}
+
+	// Execute
+	result, err := client.Track(context.Background(), tracker.Scan{Id: "scan-1", VisitKey: "KEY123"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, tracker.ScanTracked, result.Status)
+	assert.Equal(t, "scan-1", received.ClientId)
+	assert.Equal(t, "scan-1", idempotencyKey)
+}
+
+func TestTrack_BadGatewayIsOffline(t *testing.T) {
+	// Setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+	client := &Client{BaseURL: ts.URL}
+
+	// Execute
+	_, err := client.Track(context.Background(), tracker.Scan{Id: "1", VisitKey: "KEY123"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrOffline)
+}
@@ -0,0 +1,24 @@
+package readeragent
+
+import (
+	"github.com/caarlos0/env/v6"
+)
+
+type Config struct {
+	ServerURL           string `env:"READER_AGENT_SERVER_URL" envDefault:"http://localhost:8080"`
+	Token               string `env:"READER_AGENT_TOKEN"`                // API token of the tenant, empty for the default tenant.
+	Site                string `env:"READER_AGENT_SITE"`                 // code of the site, empty for the default site.
+	Input               string `env:"READER_AGENT_INPUT" envDefault:"-"` // serial or tty device the reader writes to, "-" reads stdin.
+	Direction           string `env:"READER_AGENT_DIRECTION"`            // possible values: in, out, empty when the reader is used for both.
+	Hook                string `env:"READER_AGENT_HOOK"`                 // script run with every result, e.g. to beep or switch a light.
+	QueueFile           string `env:"READER_AGENT_QUEUE_FILE" envDefault:"reader-agent-queue.jsonl"`
+	SyncIntervalSeconds int    `env:"READER_AGENT_SYNC_INTERVAL_SECONDS" envDefault:"15"` // queued scans are retried this often while offline.
+	TimeoutSeconds      int    `env:"READER_AGENT_TIMEOUT_SECONDS" envDefault:"5"`
+	Debug               bool   `env:"DEBUG" envDefault:"false"`
+}
+
+func NewFromEnv() (Config, error) {
+	cfg := Config{}
+	err := env.Parse(&cfg)
+	return cfg, err
+}
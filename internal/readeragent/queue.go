@@ -0,0 +1,107 @@
+package readeragent
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/buzyka/imlate/internal/isb/tracker"
+)
+
+// Queue keeps the scans made while the server is offline in a file of JSON
+// lines, they survive a restart of the agent.
+type Queue struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func (q *Queue) Push(scan tracker.Scan) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	line, err := json.Marshal(scan)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(q.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Pending returns up to limit queued scans, oldest first.
+func (q *Queue) Pending(limit int) ([]tracker.Scan, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	scans, err := q.read()
+	if len(scans) > limit {
+		scans = scans[:limit]
+	}
+	return scans, err
+}
+
+func (q *Queue) Len() (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	scans, err := q.read()
+	return len(scans), err
+}
+
+// Remove drops the scans with the given ids, e.g. once they are synced.
+func (q *Queue) Remove(ids map[string]bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	scans, err := q.read()
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, scan := range scans {
+		if ids[scan.Id] {
+			continue
+		}
+		line, err := json.Marshal(scan)
+		if err != nil {
+			return err
+		}
+		buf.Write(append(line, '\n'))
+	}
+	// Replace the file at once, a crash never leaves half of the queue.
+	tmp := q.Path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, q.Path)
+}
+
+// read skips lines which do not decode, the last one may be cut short when
+// the agent was killed while writing it.
+func (q *Queue) read() ([]tracker.Scan, error) {
+	file, err := os.Open(q.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var scans []tracker.Scan
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var scan tracker.Scan
+		if err := json.Unmarshal(scanner.Bytes(), &scan); err != nil || scan.Id == "" {
+			continue
+		}
+		scans = append(scans, scan)
+	}
+	return scans, scanner.Err()
+}
@@ -0,0 +1,60 @@
+package readeragent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/buzyka/imlate/internal/isb/tracker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue_KeepsScansAcrossRestarts(t *testing.T) {
+	// Setup
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	scannedAt := time.Date(2025, 3, 3, 7, 55, 0, 0, time.UTC)
+	queue := &Queue{Path: path}
+	assert.NoError(t, queue.Push(tracker.Scan{Id: "a", VisitKey: "KEY1", ScannedAt: scannedAt}))
+	assert.NoError(t, queue.Push(tracker.Scan{Id: "b", VisitKey: "KEY2", ScannedAt: scannedAt}))
+	assert.NoError(t, queue.Push(tracker.Scan{Id: "c", VisitKey: "KEY3", ScannedAt: scannedAt}))
+
+	// Execute
+	restarted := &Queue{Path: path}
+	first, err := restarted.Pending(2)
+	assert.NoError(t, err)
+	assert.NoError(t, restarted.Remove(map[string]bool{"a": true, "c": true}))
+	left, err := restarted.Pending(10)
+	assert.NoError(t, err)
+
+	// Assert
+	if assert.Len(t, first, 2) {
+		assert.Equal(t, "a", first[0].Id)
+		assert.Equal(t, "b", first[1].Id)
+	}
+	assert.Equal(t, []tracker.Scan{{Id: "b", VisitKey: "KEY2", ScannedAt: scannedAt}}, left)
+}
+
+func TestQueue_SkipsTruncatedLine(t *testing.T) {
+	// Setup
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	content := `{"id":"a","visit_key":"KEY1","scanned_at":"2025-03-03T07:55:00Z"}` + "\n" + `{"id":"b","visit_`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	queue := &Queue{Path: path}
+
+	// Execute
+	count, err := queue.Len()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestQueue_MissingFileIsEmpty(t *testing.T) {
+	queue := &Queue{Path: filepath.Join(t.TempDir(), "queue.jsonl")}
+
+	scans, err := queue.Pending(10)
+
+	assert.NoError(t, err)
+	assert.Empty(t, scans)
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS evacuation_entry;
+DROP TABLE IF EXISTS evacuation_session;
\ No newline at end of file
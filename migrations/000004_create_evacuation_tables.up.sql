@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS evacuation_session (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    note VARCHAR(255) NULL,
+    started_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    ended_at DATETIME NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS evacuation_entry (
+    session_id BIGINT NOT NULL,
+    visitor_id INT NOT NULL,
+    accounted_at DATETIME NULL,
+    assembly_point VARCHAR(255) NULL,
+    PRIMARY KEY (session_id, visitor_id),
+    CONSTRAINT `fk.evacuation_entry.session_id` FOREIGN KEY (session_id) REFERENCES evacuation_session(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS attendance;
+DROP TABLE IF EXISTS lessons;
+DROP TABLE IF EXISTS class_members;
+DROP TABLE IF EXISTS classes;
+DROP TABLE IF EXISTS periods;
+DROP TABLE IF EXISTS devices;
+DROP TABLE IF EXISTS rooms;
\ No newline at end of file
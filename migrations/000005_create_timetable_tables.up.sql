@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS rooms (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS devices (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NULL,
+    room_id BIGINT NULL,
+    CONSTRAINT `fk.devices.room_id` FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS periods (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    starts_at TIME NOT NULL,
+    ends_at TIME NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS classes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    teacher_id INT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS class_members (
+    class_id BIGINT NOT NULL,
+    visitor_id INT NOT NULL,
+    PRIMARY KEY (class_id, visitor_id),
+    CONSTRAINT `fk.class_members.class_id` FOREIGN KEY (class_id) REFERENCES classes(id) ON DELETE CASCADE,
+    CONSTRAINT `fk.class_members.visitor_id` FOREIGN KEY (visitor_id) REFERENCES visitors(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS lessons (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    class_id BIGINT NOT NULL,
+    period_id BIGINT NOT NULL,
+    room_id BIGINT NOT NULL,
+    weekday TINYINT NOT NULL,
+    INDEX idx_room_weekday (room_id, weekday),
+    CONSTRAINT `fk.lessons.class_id` FOREIGN KEY (class_id) REFERENCES classes(id) ON DELETE CASCADE,
+    CONSTRAINT `fk.lessons.period_id` FOREIGN KEY (period_id) REFERENCES periods(id) ON DELETE CASCADE,
+    CONSTRAINT `fk.lessons.room_id` FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS attendance (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    lesson_id BIGINT NOT NULL,
+    visitor_id INT NOT NULL,
+    date DATE NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    scanned_at DATETIME NULL,
+    minutes_late INT DEFAULT 0,
+    note VARCHAR(255) NULL,
+    UNIQUE KEY `uniq.attendance.lesson_visitor_date` (lesson_id, visitor_id, date),
+    CONSTRAINT `fk.attendance.lesson_id` FOREIGN KEY (lesson_id) REFERENCES lessons(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
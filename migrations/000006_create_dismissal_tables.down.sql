@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dismissals;
+DROP TABLE IF EXISTS pickup_persons;
\ No newline at end of file
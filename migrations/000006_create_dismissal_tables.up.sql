@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS pickup_persons (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    visitor_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    relationship VARCHAR(255) NULL,
+    image VARCHAR(255) NULL,
+    key_id VARCHAR(255) NULL,
+    INDEX idx_key_id (key_id),
+    CONSTRAINT `fk.pickup_persons.visitor_id` FOREIGN KEY (visitor_id) REFERENCES visitors(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS dismissals (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    visitor_id INT NOT NULL,
+    pickup_person_id BIGINT NULL,
+    collector_name VARCHAR(255) NULL,
+    reason VARCHAR(255) NULL,
+    status VARCHAR(16) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_createdAt (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS watchlist (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    visitor_id INT NULL,
+    name VARCHAR(255) NULL,
+    surname VARCHAR(255) NULL,
+    category VARCHAR(32) NOT NULL,
+    notes TEXT NULL,
+    active TINYINT(1) DEFAULT 1,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_active (active)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS watchlist_hits (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    entry_id BIGINT NOT NULL,
+    visitor_id INT NULL,
+    source VARCHAR(32) NOT NULL,
+    details VARCHAR(255) NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_createdAt (created_at),
+    CONSTRAINT `fk.watchlist_hits.entry_id` FOREIGN KEY (entry_id) REFERENCES watchlist(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS hall_passes;
\ No newline at end of file
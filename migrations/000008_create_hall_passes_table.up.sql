@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS hall_passes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    visitor_id INT NOT NULL,
+    teacher_id INT NOT NULL,
+    destination VARCHAR(255) NOT NULL,
+    max_minutes INT NOT NULL,
+    issued_at DATETIME NOT NULL,
+    started_at DATETIME NULL,
+    closed_at DATETIME NULL,
+    INDEX idx_visitor_issuedAt (visitor_id, issued_at),
+    INDEX idx_closedAt (closed_at),
+    CONSTRAINT `fk.hall_passes.visitor_id` FOREIGN KEY (visitor_id) REFERENCES visitors(id) ON DELETE CASCADE,
+    CONSTRAINT `fk.hall_passes.teacher_id` FOREIGN KEY (teacher_id) REFERENCES visitors(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
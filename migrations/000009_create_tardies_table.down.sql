@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tardies;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS tardies (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    track_id BIGINT NOT NULL,
+    visitor_id INT NOT NULL,
+    reason VARCHAR(64) NOT NULL,
+    note VARCHAR(255) NULL,
+    minutes_late INT NOT NULL DEFAULT 0,
+    tracked_at DATETIME NOT NULL,
+    UNIQUE KEY `uniq.tardies.track_id` (track_id),
+    INDEX idx_trackedAt (tracked_at),
+    CONSTRAINT `fk.tardies.track_id` FOREIGN KEY (track_id) REFERENCES track(id) ON DELETE CASCADE,
+    CONSTRAINT `fk.tardies.visitor_id` FOREIGN KEY (visitor_id) REFERENCES visitors(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
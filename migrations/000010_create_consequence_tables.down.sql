@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS consequences;
+DROP TABLE IF EXISTS consequence_rules;
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS consequence_rules (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    threshold INT NOT NULL,
+    window_days INT NOT NULL,
+    grades VARCHAR(255) NULL,
+    action VARCHAR(32) NOT NULL,
+    recipient VARCHAR(255) NULL,
+    webhook_url VARCHAR(1024) NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS consequences (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    rule VARCHAR(255) NOT NULL,
+    visitor_id INT NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    late_count INT NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    note VARCHAR(255) NULL,
+    created_at DATETIME NOT NULL,
+    resolved_at DATETIME NULL,
+    INDEX idx_status (status),
+    INDEX idx_rule_visitor_createdAt (rule, visitor_id, created_at),
+    CONSTRAINT `fk.consequences.visitor_id` FOREIGN KEY (visitor_id) REFERENCES visitors(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS detention_assignments;
+DROP TABLE IF EXISTS detention_sessions;
\ No newline at end of file
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS detention_sessions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    room_id BIGINT NOT NULL,
+    supervisor_id INT NOT NULL,
+    capacity INT NOT NULL DEFAULT 0,
+    closed_at DATETIME NULL,
+    INDEX idx_startsAt (starts_at),
+    CONSTRAINT `fk.detention_sessions.room_id` FOREIGN KEY (room_id) REFERENCES rooms(id),
+    CONSTRAINT `fk.detention_sessions.supervisor_id` FOREIGN KEY (supervisor_id) REFERENCES visitors(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS detention_assignments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    session_id BIGINT NOT NULL,
+    visitor_id INT NOT NULL,
+    consequence_id BIGINT NULL,
+    status VARCHAR(32) NOT NULL,
+    attended_at DATETIME NULL,
+    UNIQUE KEY `uniq.detention_assignments.session_visitor` (session_id, visitor_id),
+    UNIQUE KEY `uniq.detention_assignments.consequence_id` (consequence_id),
+    CONSTRAINT `fk.detention_assignments.session_id` FOREIGN KEY (session_id) REFERENCES detention_sessions(id) ON DELETE CASCADE,
+    CONSTRAINT `fk.detention_assignments.visitor_id` FOREIGN KEY (visitor_id) REFERENCES visitors(id) ON DELETE CASCADE,
+    CONSTRAINT `fk.detention_assignments.consequence_id` FOREIGN KEY (consequence_id) REFERENCES consequences(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,5 @@
+ALTER TABLE tardies
+    DROP FOREIGN KEY `fk.tardies.excusal_id`,
+    DROP COLUMN excusal_id;
+
+DROP TABLE IF EXISTS excusals;
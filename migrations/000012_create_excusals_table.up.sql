@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS excusals (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reason VARCHAR(255) NOT NULL,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    route_id BIGINT NULL,
+    grades VARCHAR(64) NULL,
+    visitor_ids TEXT NULL,
+    excused_count INT NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    revoked_at DATETIME NULL,
+    INDEX idx_window (starts_at, ends_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE tardies
+    ADD COLUMN excusal_id BIGINT NULL,
+    ADD CONSTRAINT `fk.tardies.excusal_id` FOREIGN KEY (excusal_id) REFERENCES excusals(id) ON DELETE SET NULL;
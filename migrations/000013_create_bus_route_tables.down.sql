@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bus_arrivals;
+DROP TABLE IF EXISTS bus_riders;
+DROP TABLE IF EXISTS bus_stops;
+DROP TABLE IF EXISTS bus_routes;
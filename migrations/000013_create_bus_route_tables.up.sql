@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS bus_routes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    device_id VARCHAR(64) NULL,
+    UNIQUE KEY `uniq.bus_routes.device_id` (device_id),
+    CONSTRAINT `fk.bus_routes.device_id` FOREIGN KEY (device_id) REFERENCES devices(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS bus_stops (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    route_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    pickup_at VARCHAR(5) NULL,
+    INDEX idx_route_position (route_id, position),
+    CONSTRAINT `fk.bus_stops.route_id` FOREIGN KEY (route_id) REFERENCES bus_routes(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS bus_riders (
+    visitor_id INT PRIMARY KEY,
+    route_id BIGINT NOT NULL,
+    stop_id BIGINT NULL,
+    INDEX idx_route (route_id),
+    CONSTRAINT `fk.bus_riders.visitor_id` FOREIGN KEY (visitor_id) REFERENCES visitors(id) ON DELETE CASCADE,
+    CONSTRAINT `fk.bus_riders.route_id` FOREIGN KEY (route_id) REFERENCES bus_routes(id) ON DELETE CASCADE,
+    CONSTRAINT `fk.bus_riders.stop_id` FOREIGN KEY (stop_id) REFERENCES bus_stops(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS bus_arrivals (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    route_id BIGINT NOT NULL,
+    device_id VARCHAR(64) NULL,
+    arrived_at DATETIME NOT NULL,
+    excusal_id BIGINT NULL,
+    INDEX idx_route_arrivedAt (route_id, arrived_at),
+    CONSTRAINT `fk.bus_arrivals.route_id` FOREIGN KEY (route_id) REFERENCES bus_routes(id) ON DELETE CASCADE,
+    CONSTRAINT `fk.bus_arrivals.excusal_id` FOREIGN KEY (excusal_id) REFERENCES excusals(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,47 @@
+ALTER TABLE bus_routes
+    DROP FOREIGN KEY `fk.bus_routes.site_id`,
+    DROP COLUMN site_id;
+
+ALTER TABLE excusals
+    DROP FOREIGN KEY `fk.excusals.site_id`,
+    DROP COLUMN site_id;
+
+ALTER TABLE detention_sessions
+    DROP FOREIGN KEY `fk.detention_sessions.site_id`,
+    DROP COLUMN site_id;
+
+ALTER TABLE consequence_rules
+    DROP FOREIGN KEY `fk.consequence_rules.site_id`,
+    DROP COLUMN site_id;
+
+ALTER TABLE evacuation_session
+    DROP FOREIGN KEY `fk.evacuation_session.site_id`,
+    DROP COLUMN site_id;
+
+ALTER TABLE track
+    DROP FOREIGN KEY `fk.track.site_id`,
+    DROP INDEX idx_site_createdAt,
+    DROP COLUMN site_id;
+
+ALTER TABLE classes
+    DROP FOREIGN KEY `fk.classes.site_id`,
+    DROP COLUMN site_id;
+
+ALTER TABLE periods
+    DROP FOREIGN KEY `fk.periods.site_id`,
+    DROP COLUMN site_id;
+
+ALTER TABLE rooms
+    DROP FOREIGN KEY `fk.rooms.site_id`,
+    DROP COLUMN site_id;
+
+ALTER TABLE devices
+    DROP FOREIGN KEY `fk.devices.site_id`,
+    DROP COLUMN site_id;
+
+ALTER TABLE visitors
+    DROP FOREIGN KEY `fk.visitors.site_id`,
+    DROP COLUMN site_id;
+
+DROP TABLE IF EXISTS site_closed_days;
+DROP TABLE IF EXISTS sites;
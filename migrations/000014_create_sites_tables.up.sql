@@ -0,0 +1,63 @@
+CREATE TABLE IF NOT EXISTS sites (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    code VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    school_day_starts_at VARCHAR(5) NULL,
+    late_grace_minutes INT NULL,
+    UNIQUE KEY `uniq.sites.code` (code)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO sites (id, code, name) VALUES (1, 'main', 'Main site');
+
+CREATE TABLE IF NOT EXISTS site_closed_days (
+    site_id BIGINT NOT NULL,
+    date DATE NOT NULL,
+    reason VARCHAR(255) NULL,
+    PRIMARY KEY (site_id, date),
+    CONSTRAINT `fk.site_closed_days.site_id` FOREIGN KEY (site_id) REFERENCES sites(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE visitors
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.visitors.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
+
+ALTER TABLE devices
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.devices.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
+
+ALTER TABLE rooms
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.rooms.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
+
+ALTER TABLE periods
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.periods.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
+
+ALTER TABLE classes
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.classes.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
+
+ALTER TABLE track
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD INDEX idx_site_createdAt (site_id, created_at),
+    ADD CONSTRAINT `fk.track.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
+
+ALTER TABLE evacuation_session
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.evacuation_session.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
+
+ALTER TABLE consequence_rules
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.consequence_rules.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
+
+ALTER TABLE detention_sessions
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.detention_sessions.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
+
+ALTER TABLE excusals
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.excusals.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
+
+ALTER TABLE bus_routes
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.bus_routes.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
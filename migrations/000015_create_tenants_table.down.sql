@@ -0,0 +1,121 @@
+ALTER TABLE sites
+    DROP INDEX `uniq.sites.tenant_id.code`,
+    ADD UNIQUE KEY `uniq.sites.code` (code);
+
+ALTER TABLE site_closed_days
+    DROP FOREIGN KEY `fk.site_closed_days.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE sites
+    DROP FOREIGN KEY `fk.sites.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE bus_arrivals
+    DROP FOREIGN KEY `fk.bus_arrivals.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE bus_riders
+    DROP FOREIGN KEY `fk.bus_riders.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE bus_stops
+    DROP FOREIGN KEY `fk.bus_stops.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE bus_routes
+    DROP FOREIGN KEY `fk.bus_routes.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE excusals
+    DROP FOREIGN KEY `fk.excusals.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE detention_assignments
+    DROP FOREIGN KEY `fk.detention_assignments.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE detention_sessions
+    DROP FOREIGN KEY `fk.detention_sessions.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE consequences
+    DROP FOREIGN KEY `fk.consequences.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE consequence_rules
+    DROP FOREIGN KEY `fk.consequence_rules.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE tardies
+    DROP FOREIGN KEY `fk.tardies.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE hall_passes
+    DROP FOREIGN KEY `fk.hall_passes.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE watchlist_hits
+    DROP FOREIGN KEY `fk.watchlist_hits.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE watchlist
+    DROP FOREIGN KEY `fk.watchlist.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE dismissals
+    DROP FOREIGN KEY `fk.dismissals.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE pickup_persons
+    DROP FOREIGN KEY `fk.pickup_persons.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE attendance
+    DROP FOREIGN KEY `fk.attendance.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE lessons
+    DROP FOREIGN KEY `fk.lessons.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE class_members
+    DROP FOREIGN KEY `fk.class_members.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE classes
+    DROP FOREIGN KEY `fk.classes.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE periods
+    DROP FOREIGN KEY `fk.periods.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE devices
+    DROP FOREIGN KEY `fk.devices.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE rooms
+    DROP FOREIGN KEY `fk.rooms.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE evacuation_entry
+    DROP FOREIGN KEY `fk.evacuation_entry.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE evacuation_session
+    DROP FOREIGN KEY `fk.evacuation_session.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE visitor_key
+    DROP FOREIGN KEY `fk.visitor_key.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE track
+    DROP FOREIGN KEY `fk.track.tenant_id`,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE visitors
+    DROP FOREIGN KEY `fk.visitors.tenant_id`,
+    DROP COLUMN tenant_id;
+
+DROP TABLE IF EXISTS tenants;
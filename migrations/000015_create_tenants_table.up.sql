@@ -0,0 +1,134 @@
+CREATE TABLE IF NOT EXISTS tenants (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    code VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    hostname VARCHAR(255) NULL,
+    logo VARCHAR(255) NULL,
+    api_token_hash CHAR(64) NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY `uniq.tenants.code` (code),
+    UNIQUE KEY `uniq.tenants.hostname` (hostname),
+    UNIQUE KEY `uniq.tenants.api_token_hash` (api_token_hash)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO tenants (id, code, name) VALUES (1, 'default', 'Default school');
+
+ALTER TABLE visitors
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.visitors.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE track
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.track.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE visitor_key
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.visitor_key.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE evacuation_session
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.evacuation_session.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE evacuation_entry
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.evacuation_entry.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE rooms
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.rooms.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE devices
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.devices.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE periods
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.periods.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE classes
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.classes.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE class_members
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.class_members.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE lessons
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.lessons.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE attendance
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.attendance.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE pickup_persons
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.pickup_persons.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE dismissals
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.dismissals.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE watchlist
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.watchlist.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE watchlist_hits
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.watchlist_hits.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE hall_passes
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.hall_passes.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE tardies
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.tardies.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE consequence_rules
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.consequence_rules.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE consequences
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.consequences.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE detention_sessions
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.detention_sessions.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE detention_assignments
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.detention_assignments.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE excusals
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.excusals.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE bus_routes
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.bus_routes.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE bus_stops
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.bus_stops.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE bus_riders
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.bus_riders.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE bus_arrivals
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.bus_arrivals.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE sites
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.sites.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE site_closed_days
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT `fk.site_closed_days.tenant_id` FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+
+ALTER TABLE sites
+    DROP INDEX `uniq.sites.code`,
+    ADD UNIQUE KEY `uniq.sites.tenant_id.code` (tenant_id, code);
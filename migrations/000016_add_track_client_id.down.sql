@@ -0,0 +1,3 @@
+ALTER TABLE track
+    DROP INDEX `uniq.track.tenant_id.client_id`,
+    DROP COLUMN client_id;
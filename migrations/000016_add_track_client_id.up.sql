@@ -0,0 +1,3 @@
+ALTER TABLE track
+    ADD COLUMN client_id VARCHAR(64) NULL,
+    ADD UNIQUE KEY `uniq.track.tenant_id.client_id` (tenant_id, client_id);
@@ -0,0 +1,3 @@
+ALTER TABLE visitor_key
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (key_id);
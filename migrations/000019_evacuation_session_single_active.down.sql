@@ -0,0 +1,3 @@
+ALTER TABLE evacuation_session
+    DROP INDEX `uniq.evacuation_session.tenant_id.site_id.active`,
+    DROP COLUMN active;
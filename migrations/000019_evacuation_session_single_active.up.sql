@@ -0,0 +1,10 @@
+UPDATE evacuation_session AS s
+    INNER JOIN (
+        SELECT tenant_id, site_id, MAX(id) AS id FROM evacuation_session WHERE ended_at IS NULL GROUP BY tenant_id, site_id
+    ) AS latest ON latest.tenant_id = s.tenant_id AND latest.site_id = s.site_id
+SET s.ended_at = s.started_at
+WHERE s.ended_at IS NULL AND s.id < latest.id;
+
+ALTER TABLE evacuation_session
+    ADD COLUMN active TINYINT AS (IF(ended_at IS NULL, 1, NULL)) STORED,
+    ADD UNIQUE KEY `uniq.evacuation_session.tenant_id.site_id.active` (tenant_id, site_id, active);
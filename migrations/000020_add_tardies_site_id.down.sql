@@ -0,0 +1,4 @@
+ALTER TABLE tardies
+    DROP FOREIGN KEY `fk.tardies.site_id`,
+    DROP INDEX idx_site_trackedAt,
+    DROP COLUMN site_id;
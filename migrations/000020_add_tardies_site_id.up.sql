@@ -0,0 +1,8 @@
+ALTER TABLE tardies
+    ADD COLUMN site_id BIGINT NOT NULL DEFAULT 1,
+    ADD INDEX idx_site_trackedAt (site_id, tracked_at),
+    ADD CONSTRAINT `fk.tardies.site_id` FOREIGN KEY (site_id) REFERENCES sites(id);
+
+UPDATE tardies AS d
+    INNER JOIN track AS t ON t.id = d.track_id
+SET d.site_id = t.site_id;